@@ -0,0 +1,357 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+)
+
+type snapshotExportChain interface {
+	Genesis() types.Hash
+	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	GetHeaderByHash(types.Hash) (*types.Header, bool)
+	GetTD(types.Hash) (*big.Int, bool)
+}
+
+type snapshotImportChain interface {
+	Genesis() types.Hash
+	Header() *types.Header
+	GetHeaderByHash(types.Hash) (*types.Header, bool)
+	WriteSnapshotBlock(*types.Block, *big.Int, []*types.Header) error
+}
+
+// CreateSnapshot fetches the state snapshot at the given height (the latest block if zero)
+// via gRPC and saves it to given path. It returns the number and the hash of the snapshot block
+func CreateSnapshot(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	number uint64,
+	outPath string,
+) (uint64, types.Hash, error) {
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, types.Hash{}, err
+	}
+
+	resNumber, resHash, err := fetchSnapshot(conn, number, fs)
+	if closeErr := fs.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if removeErr := os.Remove(outPath); removeErr != nil {
+			logger.Error("an error occurred while removing file", "err", removeErr)
+		}
+
+		return 0, types.Hash{}, err
+	}
+
+	logger.Info("Wrote state snapshot", "number", resNumber, "hash", resHash)
+
+	return resNumber, resHash, nil
+}
+
+func fetchSnapshot(conn *grpc.ClientConn, number uint64, writer io.Writer) (uint64, types.Hash, error) {
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		select {
+		case <-signalCh:
+			cancelFn()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := proto.NewSystemClient(conn).ExportSnapshot(ctx, &proto.ExportSnapshotRequest{
+		Number: number,
+	})
+	if err != nil {
+		return 0, types.Hash{}, err
+	}
+
+	var (
+		resNumber uint64
+		resHash   types.Hash
+		received  bool
+	)
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return 0, types.Hash{}, err
+		}
+
+		if _, err := writer.Write(event.Data); err != nil {
+			return 0, types.Hash{}, err
+		}
+
+		resNumber, resHash, received = event.Number, types.StringToHash(event.Hash), true
+	}
+
+	if !received {
+		return 0, types.Hash{}, errors.New("couldn't get the snapshot")
+	}
+
+	return resNumber, resHash, nil
+}
+
+// ExportSnapshot writes the state snapshot at the given header: the metadata, the block,
+// the headers of its ancestors (from the parent down) and every account of the state, with its code and storage
+func ExportSnapshot(writer io.Writer, chain snapshotExportChain, st *itrie.State, header *types.Header) error {
+	block, ok := chain.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return fmt.Errorf("block %d not found", header.Number)
+	}
+
+	td, ok := chain.GetTD(header.Hash)
+	if !ok {
+		return fmt.Errorf("total difficulty of block %d not found", header.Number)
+	}
+
+	metadata := &SnapshotMetadata{
+		Genesis:         chain.Genesis(),
+		Number:          header.Number,
+		Hash:            header.Hash,
+		TotalDifficulty: td,
+	}
+
+	if _, err := writer.Write(metadata.MarshalRLP()); err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(block.MarshalRLP()); err != nil {
+		return err
+	}
+
+	ancestor := header
+	for i := uint64(0); i < blockchain.SnapshotAncestorsOf(header.Number); i++ {
+		if ancestor, ok = chain.GetHeaderByHash(ancestor.ParentHash); !ok {
+			return fmt.Errorf("ancestor header %d not found", header.Number-i-1)
+		}
+
+		if _, err := writer.Write(ancestor.MarshalRLP()); err != nil {
+			return err
+		}
+	}
+
+	return st.ForEachAccount(header.StateRoot, func(key types.Hash, account *state.Account) error {
+		snapAccount := &SnapshotAccount{
+			Key:      key,
+			Nonce:    account.Nonce,
+			Balance:  account.Balance,
+			CodeHash: types.BytesToHash(account.CodeHash),
+		}
+
+		if code, ok := st.GetCode(snapAccount.CodeHash); ok {
+			snapAccount.Code = code
+		}
+
+		if err := st.ForEachStorage(account.Root, func(slot types.Hash, value []byte) error {
+			snapAccount.Storage = append(snapAccount.Storage, &SnapshotStorage{
+				Key:   slot,
+				Value: value,
+			})
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		_, err := writer.Write(snapAccount.MarshalRLP())
+
+		return err
+	})
+}
+
+// ImportSnapshot loads the state snapshot from the file into a chain that only holds the genesis.
+// The state is rebuilt and its root has to match the one of the snapshot block, which then becomes
+// the head of the chain. It returns the header of the block, or nil if the chain already has it
+func ImportSnapshot(chain snapshotImportChain, st *itrie.State, filePath string) (*types.Header, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fp.Close()
+
+	return importSnapshot(chain, st, newBlockStream(fp))
+}
+
+func importSnapshot(chain snapshotImportChain, st *itrie.State, stream *blockStream) (*types.Header, error) {
+	metadata := &SnapshotMetadata{}
+	if ok, err := stream.next(metadata); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("expected metadata in snapshot but doesn't exist")
+	}
+
+	if metadata.Genesis != chain.Genesis() {
+		return nil, fmt.Errorf(
+			"the genesis of the snapshot (%s) does not match blockchain genesis (%s)",
+			metadata.Genesis,
+			chain.Genesis(),
+		)
+	}
+
+	if _, ok := chain.GetHeaderByHash(metadata.Hash); ok {
+		// the snapshot was imported already
+		return nil, nil
+	}
+
+	if chain.Header().Number != 0 {
+		return nil, errors.New("the snapshot can only be imported into an empty chain")
+	}
+
+	block := &types.Block{}
+	if ok, err := stream.next(block); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("expected block in snapshot but doesn't exist")
+	}
+
+	if block.Number() != metadata.Number || block.Hash() != metadata.Hash {
+		return nil, fmt.Errorf(
+			"the snapshot block %d (%s) does not match the metadata %d (%s)",
+			block.Number(),
+			block.Hash(),
+			metadata.Number,
+			metadata.Hash,
+		)
+	}
+
+	ancestors, err := readSnapshotAncestors(chain, stream, block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := st.NewStateBuilder()
+
+	for {
+		account := &SnapshotAccount{}
+		if ok, err := stream.next(account); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+
+		if len(account.Code) != 0 {
+			if hash := types.BytesToHash(crypto.Keccak256(account.Code)); hash != account.CodeHash {
+				return nil, fmt.Errorf("code hash mismatch of account %s: have %s, want %s", account.Key, hash, account.CodeHash)
+			}
+
+			st.SetCode(account.CodeHash, account.Code)
+		}
+
+		storage := make(map[types.Hash][]byte, len(account.Storage))
+		for _, entry := range account.Storage {
+			storage[entry.Key] = entry.Value
+		}
+
+		if err := builder.AddAccount(account.Key, &state.Account{
+			Nonce:    account.Nonce,
+			Balance:  account.Balance,
+			CodeHash: account.CodeHash.Bytes(),
+		}, storage); err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := builder.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if root != block.Header.StateRoot {
+		return nil, fmt.Errorf("state root mismatch: have %s, want %s", root, block.Header.StateRoot)
+	}
+
+	if err := chain.WriteSnapshotBlock(block, metadata.TotalDifficulty, ancestors); err != nil {
+		return nil, err
+	}
+
+	return block.Header, nil
+}
+
+// readSnapshotAncestors reads the ancestor headers of the snapshot block, which the EVM
+// needs for the block hashes, and checks that they link the block to the genesis
+func readSnapshotAncestors(
+	chain snapshotImportChain,
+	stream *blockStream,
+	header *types.Header,
+) ([]*types.Header, error) {
+	count := blockchain.SnapshotAncestorsOf(header.Number)
+	ancestors := make([]*types.Header, 0, count)
+
+	child := header
+	for i := uint64(0); i < count; i++ {
+		ancestor := &types.Header{}
+		if ok, err := stream.next(ancestor); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("expected ancestor header %d in snapshot but doesn't exist", child.Number-1)
+		}
+
+		if ancestor.Number != child.Number-1 || ancestor.Hash != child.ParentHash {
+			return nil, fmt.Errorf(
+				"the ancestor header %d (%s) does not match the parent of block %d (%s)",
+				ancestor.Number,
+				ancestor.Hash,
+				child.Number,
+				child.ParentHash,
+			)
+		}
+
+		ancestors = append(ancestors, ancestor)
+		child = ancestor
+	}
+
+	if child.Number == 1 && child.ParentHash != chain.Genesis() {
+		return nil, fmt.Errorf("the block 1 of the snapshot is not a child of the genesis (%s)", chain.Genesis())
+	}
+
+	return ancestors, nil
+}
+
+type rlpUnmarshaler interface {
+	UnmarshalRLP(input []byte) error
+}
+
+// next consumes the next RLP encoded item from the stream into obj.
+// It returns false if the stream has ended
+func (b *blockStream) next(obj rlpUnmarshaler) (bool, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return false, err
+	}
+
+	if size == 0 {
+		return false, nil
+	}
+
+	if err := obj.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
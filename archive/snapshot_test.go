@@ -0,0 +1,372 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSnapshotChain struct {
+	genesis types.Hash
+	head    *types.Header
+	headers map[types.Hash]*types.Header
+	blocks  map[types.Hash]*types.Block
+	td      map[types.Hash]*big.Int
+}
+
+func newMockSnapshotChain(genesis types.Hash) *mockSnapshotChain {
+	return &mockSnapshotChain{
+		genesis: genesis,
+		head:    &types.Header{Number: 0, Hash: genesis},
+		headers: map[types.Hash]*types.Header{},
+		blocks:  map[types.Hash]*types.Block{},
+		td:      map[types.Hash]*big.Int{},
+	}
+}
+
+func (m *mockSnapshotChain) Genesis() types.Hash {
+	return m.genesis
+}
+
+func (m *mockSnapshotChain) Header() *types.Header {
+	return m.head
+}
+
+func (m *mockSnapshotChain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	b, ok := m.blocks[hash]
+
+	return b, ok
+}
+
+func (m *mockSnapshotChain) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
+	if h, ok := m.headers[hash]; ok {
+		return h, true
+	}
+
+	b, ok := m.blocks[hash]
+	if !ok {
+		return nil, false
+	}
+
+	return b.Header, true
+}
+
+func (m *mockSnapshotChain) GetTD(hash types.Hash) (*big.Int, bool) {
+	td, ok := m.td[hash]
+
+	return td, ok
+}
+
+func (m *mockSnapshotChain) WriteSnapshotBlock(block *types.Block, td *big.Int, ancestors []*types.Header) error {
+	for _, h := range ancestors {
+		m.headers[h.Hash] = h
+	}
+
+	m.blocks[block.Hash()] = block
+	m.td[block.Hash()] = td
+	m.head = block.Header
+
+	return nil
+}
+
+// newSnapshotSource creates a chain whose block at the given height, a descendant
+// of the genesis, holds a state with an account and a contract with storage
+func newSnapshotSource(t *testing.T, genesis types.Hash, number uint64) (*mockSnapshotChain, *itrie.State, *types.Header) {
+	t.Helper()
+
+	code := []byte{0x60, 0x00, 0x54, 0x00}
+	emptyCodeHash := types.BytesToHash(crypto.Keccak256(nil))
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	trie, ok := st.NewSnapshot().(*itrie.Trie)
+	assert.True(t, ok)
+
+	_, root := trie.Commit([]*state.Object{
+		{
+			Address:  types.StringToAddress("1"),
+			Balance:  big.NewInt(100),
+			Nonce:    3,
+			CodeHash: emptyCodeHash,
+			Root:     types.EmptyRootHash,
+		},
+		{
+			Address:   types.StringToAddress("2"),
+			Balance:   big.NewInt(0),
+			Nonce:     1,
+			CodeHash:  types.BytesToHash(crypto.Keccak256(code)),
+			Root:      types.EmptyRootHash,
+			DirtyCode: true,
+			Code:      code,
+			Storage: []*state.StorageObject{
+				{Key: types.StringToHash("1").Bytes(), Val: []byte{0x1}},
+				{Key: types.StringToHash("2").Bytes(), Val: bytes.Repeat([]byte{0x2a}, types.HashLength)},
+			},
+		},
+	})
+
+	chain := newMockSnapshotChain(genesis)
+
+	parentHash := genesis
+
+	for i := uint64(1); i < number; i++ {
+		ancestor := &types.Header{
+			Number:     i,
+			ParentHash: parentHash,
+			Sha3Uncles: types.EmptyUncleHash,
+			TxRoot:     types.EmptyRootHash,
+		}
+		ancestor.ComputeHash()
+
+		chain.headers[ancestor.Hash] = ancestor
+		parentHash = ancestor.Hash
+	}
+
+	header := &types.Header{
+		Number:     number,
+		ParentHash: parentHash,
+		StateRoot:  types.BytesToHash(root),
+		Sha3Uncles: types.EmptyUncleHash,
+		TxRoot:     types.EmptyRootHash,
+	}
+	header.ComputeHash()
+
+	chain.blocks[header.Hash] = &types.Block{Header: header}
+	chain.td[header.Hash] = big.NewInt(int64(number) + 1)
+	chain.head = header
+
+	return chain, st, header
+}
+
+// collectState returns the accounts and the storage values of the state at the root
+func collectState(t *testing.T, st *itrie.State, root types.Hash) map[types.Hash][]interface{} {
+	t.Helper()
+
+	res := map[types.Hash][]interface{}{}
+
+	assert.NoError(t, st.ForEachAccount(root, func(key types.Hash, account *state.Account) error {
+		code, _ := st.GetCode(types.BytesToHash(account.CodeHash))
+		entry := []interface{}{account.Nonce, account.Balance.String(), code}
+
+		assert.NoError(t, st.ForEachStorage(account.Root, func(slot types.Hash, value []byte) error {
+			entry = append(entry, slot, value)
+
+			return nil
+		}))
+
+		res[key] = entry
+
+		return nil
+	}))
+
+	return res
+}
+
+func TestSnapshot_ExportImport(t *testing.T) {
+	genesis := types.StringToHash("0x01")
+	srcChain, srcState, header := newSnapshotSource(t, genesis, 10)
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportSnapshot(&buf, srcChain, srcState, header))
+
+	data := buf.Bytes()
+
+	dstChain := newMockSnapshotChain(genesis)
+	dstState := itrie.NewState(itrie.NewMemoryStorage())
+
+	imported, err := importSnapshot(dstChain, dstState, newBlockStream(bytes.NewReader(data)))
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, imported) {
+		assert.Equal(t, header.Hash, imported.Hash)
+	}
+
+	// the snapshot block is the new head, with the same total difficulty
+	assert.Equal(t, header.Hash, dstChain.Header().Hash)
+	assert.Equal(t, big.NewInt(11), dstChain.td[header.Hash])
+
+	// the ancestors are available down to the genesis
+	assert.Len(t, dstChain.headers, 9)
+
+	for h := header; h.Number > 1; {
+		parent, ok := dstChain.GetHeaderByHash(h.ParentHash)
+		if !assert.True(t, ok) {
+			break
+		}
+
+		h = parent
+	}
+
+	// the state is the same as in the source
+	src := collectState(t, srcState, header.StateRoot)
+	assert.Len(t, src, 2)
+	assert.Equal(t, src, collectState(t, dstState, header.StateRoot))
+
+	// importing the snapshot again is a no-op
+	imported, err = importSnapshot(dstChain, dstState, newBlockStream(bytes.NewReader(data)))
+	assert.NoError(t, err)
+	assert.Nil(t, imported)
+}
+
+func TestSnapshot_ImportIntegrity(t *testing.T) {
+	genesis := types.StringToHash("0x01")
+
+	export := func(chain *mockSnapshotChain, st *itrie.State, header *types.Header) []byte {
+		var buf bytes.Buffer
+		assert.NoError(t, ExportSnapshot(&buf, chain, st, header))
+
+		return buf.Bytes()
+	}
+
+	t.Run("should fail for a different genesis", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, types.StringToHash("0x02"), 10)
+		data := export(chain, st, header)
+
+		_, err := importSnapshot(
+			newMockSnapshotChain(genesis),
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(data)),
+		)
+		assert.ErrorContains(t, err, "does not match blockchain genesis")
+	})
+
+	t.Run("should fail for a chain that is not empty", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 10)
+		data := export(chain, st, header)
+
+		dstChain := newMockSnapshotChain(genesis)
+		dstChain.head = &types.Header{Number: 1}
+
+		_, err := importSnapshot(
+			dstChain,
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(data)),
+		)
+		assert.ErrorContains(t, err, "only be imported into an empty chain")
+	})
+
+	t.Run("should fail for a missing account", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 10)
+		data := export(chain, st, header)
+
+		// drop the last account of the snapshot
+		stream := newBlockStream(bytes.NewReader(data))
+		last := &rawItem{}
+
+		for {
+			ok, err := stream.next(last)
+			assert.NoError(t, err)
+
+			if !ok {
+				break
+			}
+		}
+
+		_, err := importSnapshot(
+			newMockSnapshotChain(genesis),
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(data[:len(data)-last.size])),
+		)
+		assert.ErrorContains(t, err, "state root mismatch")
+	})
+
+	t.Run("should fail for a missing ancestor", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 10)
+		data := export(chain, st, header)
+
+		// drop the header of the parent, which follows the metadata and the block
+		offset := skipItems(t, data, 2)
+		data = append(data[:offset:offset], data[offset+skipItems(t, data[offset:], 1):]...)
+
+		_, err := importSnapshot(
+			newMockSnapshotChain(genesis),
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(data)),
+		)
+		assert.ErrorContains(t, err, "does not match the parent of block 10")
+	})
+
+	t.Run("should fail for ancestors that do not link to the genesis", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 10)
+		data := export(chain, st, header)
+
+		dstChain := newMockSnapshotChain(genesis)
+		dstChain.genesis = types.StringToHash("0x02")
+
+		// the metadata is checked first, so only the ancestors can differ
+		_, err := readSnapshotAncestors(dstChain, newBlockStream(bytes.NewReader(data[skipItems(t, data, 2):])), header)
+		assert.ErrorContains(t, err, "not a child of the genesis")
+	})
+
+	t.Run("should only import the ancestors the EVM can read", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 300)
+		data := export(chain, st, header)
+
+		dstChain := newMockSnapshotChain(genesis)
+
+		_, err := importSnapshot(
+			dstChain,
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(data)),
+		)
+		assert.NoError(t, err)
+		assert.Len(t, dstChain.headers, 256)
+	})
+
+	t.Run("should fail for a tampered code", func(t *testing.T) {
+		chain, st, header := newSnapshotSource(t, genesis, 10)
+		data := export(chain, st, header)
+
+		// the code of the contract is the only place of the PUSH1 0x00 SLOAD sequence
+		tampered := bytes.Replace(data, []byte{0x60, 0x00, 0x54}, []byte{0x60, 0x01, 0x54}, 1)
+		assert.NotEqual(t, data, tampered)
+
+		_, err := importSnapshot(
+			newMockSnapshotChain(genesis),
+			itrie.NewState(itrie.NewMemoryStorage()),
+			newBlockStream(bytes.NewReader(tampered)),
+		)
+		assert.ErrorContains(t, err, "code hash mismatch")
+	})
+}
+
+// rawItem accepts any RLP item of the stream
+type rawItem struct {
+	size int
+}
+
+func (r *rawItem) UnmarshalRLP(input []byte) error {
+	if len(input) == 0 {
+		return errors.New("empty item")
+	}
+
+	r.size = len(input)
+
+	return nil
+}
+
+// skipItems returns the offset of the data after the given number of RLP items
+func skipItems(t *testing.T, data []byte, count int) int {
+	t.Helper()
+
+	stream := newBlockStream(bytes.NewReader(data))
+	offset := 0
+
+	for i := 0; i < count; i++ {
+		item := &rawItem{}
+		ok, err := stream.next(item)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		offset += item.size
+	}
+
+	return offset
+}
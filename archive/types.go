@@ -2,6 +2,7 @@ package archive
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
@@ -59,3 +60,175 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// SnapshotMetadata is the data stored in the beginning of a state snapshot
+type SnapshotMetadata struct {
+	Genesis         types.Hash
+	Number          uint64
+	Hash            types.Hash
+	TotalDifficulty *big.Int
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (m *SnapshotMetadata) MarshalRLP() []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, nil)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *SnapshotMetadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(m.Genesis.Bytes()))
+	vv.Set(arena.NewUint(m.Number))
+	vv.Set(arena.NewBytes(m.Hash.Bytes()))
+	vv.Set(arena.NewBigInt(m.TotalDifficulty))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (m *SnapshotMetadata) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *SnapshotMetadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if num := len(elems); num != 4 {
+		return fmt.Errorf("not enough elements to decode SnapshotMetadata, expected 4 but found %d", num)
+	}
+
+	if err = elems[0].GetHash(m.Genesis[:]); err != nil {
+		return err
+	}
+
+	if m.Number, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(m.Hash[:]); err != nil {
+		return err
+	}
+
+	m.TotalDifficulty = new(big.Int)
+
+	return elems[3].GetBigInt(m.TotalDifficulty)
+}
+
+// SnapshotAccount is an account of a state snapshot, keyed by the hash of its address
+type SnapshotAccount struct {
+	Key      types.Hash
+	Nonce    uint64
+	Balance  *big.Int
+	CodeHash types.Hash
+	Code     []byte
+	Storage  []*SnapshotStorage
+}
+
+// SnapshotStorage is a storage slot of a snapshot account, keyed by the hash of the slot
+type SnapshotStorage struct {
+	Key   types.Hash
+	Value []byte
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (a *SnapshotAccount) MarshalRLP() []byte {
+	return types.MarshalRLPTo(a.MarshalRLPWith, nil)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (a *SnapshotAccount) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(a.Key.Bytes()))
+	vv.Set(arena.NewUint(a.Nonce))
+	vv.Set(arena.NewBigInt(a.Balance))
+	vv.Set(arena.NewBytes(a.CodeHash.Bytes()))
+	vv.Set(arena.NewCopyBytes(a.Code))
+
+	storage := arena.NewArray()
+
+	for _, entry := range a.Storage {
+		slot := arena.NewArray()
+		slot.Set(arena.NewBytes(entry.Key.Bytes()))
+		slot.Set(arena.NewCopyBytes(entry.Value))
+
+		storage.Set(slot)
+	}
+
+	vv.Set(storage)
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (a *SnapshotAccount) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(a.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (a *SnapshotAccount) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if num := len(elems); num != 6 {
+		return fmt.Errorf("not enough elements to decode SnapshotAccount, expected 6 but found %d", num)
+	}
+
+	if err = elems[0].GetHash(a.Key[:]); err != nil {
+		return err
+	}
+
+	if a.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	a.Balance = new(big.Int)
+	if err = elems[2].GetBigInt(a.Balance); err != nil {
+		return err
+	}
+
+	if err = elems[3].GetHash(a.CodeHash[:]); err != nil {
+		return err
+	}
+
+	if a.Code, err = elems[4].GetBytes(nil); err != nil {
+		return err
+	}
+
+	slots, err := elems[5].GetElems()
+	if err != nil {
+		return err
+	}
+
+	a.Storage = make([]*SnapshotStorage, len(slots))
+
+	for i, slot := range slots {
+		entry, err := slot.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if num := len(entry); num != 2 {
+			return fmt.Errorf("not enough elements to decode SnapshotStorage, expected 2 but found %d", num)
+		}
+
+		a.Storage[i] = &SnapshotStorage{}
+
+		if err = entry[0].GetHash(a.Storage[i].Key[:]); err != nil {
+			return err
+		}
+
+		if a.Storage[i].Value, err = entry[1].GetBytes(nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -23,6 +23,18 @@ import (
 
 const (
 	BlockGasTargetDivisor uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
+
+	BaseFeeChangeDenominator uint64 = 8          // The bound divisor of the base fee, used in update calculations (EIP-1559)
+	ElasticityMultiplier     uint64 = 2          // The bound multiplier of the gas target, giving the gas limit (EIP-1559)
+	InitialBaseFee           uint64 = 1000000000 // The base fee of the first London block (EIP-1559)
+
+	SnapshotAncestors uint64 = 256 // The ancestor headers written with a snapshot block, whose hashes the EVM reads (BLOCKHASH)
+)
+
+var (
+	ErrReorgTooDeep   = errors.New("reorg too deep")
+	ErrInvalidBlock   = errors.New("invalid block")
+	ErrReceiptsPruned = errors.New("receipts unavailable, data pruned")
 )
 
 // Blockchain is a blockchain reference
@@ -45,6 +57,10 @@ type Blockchain struct {
 	stream *eventStream // Event subscriptions
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
+
+	maxReorgDepth uint64 // The maximum number of blocks a reorg can revert, 0 if unbounded
+
+	receiptsTail uint64 // The number of the first block whose receipts are kept (atomic)
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -235,6 +251,10 @@ func (b *Blockchain) ComputeGenesis() error {
 		)
 
 		b.setCurrentHeader(header, diff)
+
+		if tail, ok := b.db.ReadReceiptsTail(); ok {
+			atomic.StoreUint64(&b.receiptsTail, tail)
+		}
 	} else {
 		// empty storage, write the genesis
 		if err := b.writeGenesis(b.config.Genesis); err != nil {
@@ -256,6 +276,12 @@ func (b *Blockchain) SetConsensus(c Verifier) {
 	b.consensus = c
 }
 
+// SetMaxReorgDepth sets the maximum number of canonical blocks a reorg can revert.
+// The deeper reorgs are rejected, 0 allows any depth
+func (b *Blockchain) SetMaxReorgDepth(depth uint64) {
+	b.maxReorgDepth = depth
+}
+
 // setCurrentHeader sets the current header
 func (b *Blockchain) setCurrentHeader(h *types.Header, diff *big.Int) {
 	// Update the header (atomic)
@@ -328,6 +354,13 @@ func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	// in either direction per block
 	blockGasTarget := b.Config().BlockGasTarget
 
+	// The gas limit cannot move past the ceiling. A parent above
+	// the ceiling is brought down to it, one step at a time
+	if ceiling := b.Config().BlockGasCeiling; ceiling != 0 &&
+		(blockGasTarget > ceiling || (blockGasTarget == 0 && parentGasLimit > ceiling)) {
+		blockGasTarget = ceiling
+	}
+
 	// Check if the gas limit target has been set
 	if blockGasTarget == 0 {
 		// The gas limit target has not been set,
@@ -353,6 +386,48 @@ func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	return common.Max(blockGasTarget, common.Max(parentGasLimit-delta, 0))
 }
 
+// CalcBaseFee returns the base fee of the next block after parent,
+// following the EIP-1559 formula. It returns 0 before the London fork
+func (b *Blockchain) CalcBaseFee(parent *types.Header) uint64 {
+	forks := b.Config().Forks
+	if forks == nil || !forks.IsLondon(parent.Number+1) {
+		return 0
+	}
+
+	// The first London block (or the block after a
+	// London genesis) starts with the initial base fee
+	if parent.BaseFee == 0 {
+		return InitialBaseFee
+	}
+
+	parentGasTarget := parent.GasLimit / ElasticityMultiplier
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		// The parent block used exactly the gas target,
+		// so the base fee remains the same
+		return parent.BaseFee
+	}
+
+	// delta = parentBaseFee * |parentGasUsed - parentGasTarget| / parentGasTarget / BaseFeeChangeDenominator
+	calcDelta := func(gasDelta uint64) uint64 {
+		delta := new(big.Int).SetUint64(parent.BaseFee)
+		delta.Mul(delta, new(big.Int).SetUint64(gasDelta))
+		delta.Div(delta, new(big.Int).SetUint64(parentGasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(BaseFeeChangeDenominator))
+
+		return delta.Uint64()
+	}
+
+	if parent.GasUsed > parentGasTarget {
+		// The parent block used more gas than its target,
+		// so the base fee increases (by at least 1)
+		return parent.BaseFee + common.Max(calcDelta(parent.GasUsed-parentGasTarget), 1)
+	}
+
+	// The parent block used less gas than its target, so the base fee decreases.
+	// The delta is bounded by parentBaseFee / BaseFeeChangeDenominator
+	return parent.BaseFee - calcDelta(parentGasTarget-parent.GasUsed)
+}
+
 // writeGenesis wrapper for the genesis write function
 func (b *Blockchain) writeGenesis(genesis *chain.Genesis) error {
 	header := genesis.GenesisHeader()
@@ -469,11 +544,61 @@ func (b *Blockchain) advanceHead(newHeader *types.Header) (*big.Int, error) {
 	return newTD, nil
 }
 
-// GetReceiptsByHash returns the receipts by their hash
+// ReceiptsTail returns the number of the first block whose receipts are kept
+func (b *Blockchain) ReceiptsTail() uint64 {
+	return atomic.LoadUint64(&b.receiptsTail)
+}
+
+// GetReceiptsByHash returns the receipts by their hash,
+// or ErrReceiptsPruned if the receipts of the block are pruned
 func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	if tail := atomic.LoadUint64(&b.receiptsTail); tail > 0 {
+		// only the receipts of the canonical blocks are pruned
+		if header, ok := b.readHeader(hash); ok && header.Number < tail {
+			if canonical, ok := b.db.ReadCanonicalHash(header.Number); ok && canonical == hash {
+				return nil, ErrReceiptsPruned
+			}
+		}
+	}
+
 	return b.db.ReadReceipts(hash)
 }
 
+// PruneReceipts deletes the receipts of the canonical blocks below the given tail,
+// returning the number of blocks whose receipts are deleted
+func (b *Blockchain) PruneReceipts(tail uint64) (uint64, error) {
+	from := atomic.LoadUint64(&b.receiptsTail)
+	if tail <= from {
+		return 0, nil
+	}
+
+	// the tail is moved first, so that the receipts
+	// being deleted are already reported as pruned
+	if err := b.db.WriteReceiptsTail(tail); err != nil {
+		return 0, err
+	}
+
+	atomic.StoreUint64(&b.receiptsTail, tail)
+
+	deleted := uint64(0)
+
+	for num := from; num < tail; num++ {
+		// the blocks below a snapshot block are missing
+		hash, ok := b.db.ReadCanonicalHash(num)
+		if !ok {
+			continue
+		}
+
+		if err := b.db.DeleteReceipts(hash); err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
 // GetBodyByHash returns the body by their hash
 func (b *Blockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 	return b.readBody(hash)
@@ -655,13 +780,14 @@ func (b *Blockchain) WriteBlock(block *types.Block) error {
 
 	// Verify the header
 	if err := b.consensus.VerifyHeader(parent, block.Header); err != nil {
-		return fmt.Errorf("failed to verify the header: %w", err)
+		return fmt.Errorf("%w: failed to verify the header: %v", ErrInvalidBlock, err)
 	}
 
 	// Verify body data
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		return fmt.Errorf(
-			"uncle root hash mismatch: have %s, want %s",
+			"%w: uncle root hash mismatch: have %s, want %s",
+			ErrInvalidBlock,
 			hash,
 			block.Header.Sha3Uncles,
 		)
@@ -669,7 +795,8 @@ func (b *Blockchain) WriteBlock(block *types.Block) error {
 
 	if hash := buildroot.CalculateTransactionsRoot(block.Transactions); hash != block.Header.TxRoot {
 		return fmt.Errorf(
-			"transaction root hash mismatch: have %s, want %s",
+			"%w: transaction root hash mismatch: have %s, want %s",
+			ErrInvalidBlock,
 			hash,
 			block.Header.TxRoot,
 		)
@@ -727,6 +854,81 @@ func (b *Blockchain) WriteBlock(block *types.Block) error {
 	return nil
 }
 
+// WriteSnapshotBlock writes the block of an imported state snapshot as the head
+// of a chain that only holds the genesis. The block is trusted as it is, with the
+// given total difficulty. Of the blocks between the genesis and it, only the headers
+// of the ancestors are available (from the parent down), so that the EVM can read their hashes
+func (b *Blockchain) WriteSnapshotBlock(block *types.Block, td *big.Int, ancestors []*types.Header) error {
+	if head := b.Header(); head == nil || head.Number != 0 {
+		return errors.New("the snapshot block can only be written on top of the genesis")
+	}
+
+	header := block.Header
+
+	if expected := SnapshotAncestorsOf(header.Number); uint64(len(ancestors)) != expected {
+		return fmt.Errorf("expected %d ancestor headers of the snapshot block, found %d", expected, len(ancestors))
+	}
+
+	for _, ancestor := range ancestors {
+		if err := b.db.WriteHeader(ancestor); err != nil {
+			return err
+		}
+
+		if err := b.db.WriteCanonicalHash(ancestor.Number, ancestor.Hash); err != nil {
+			return err
+		}
+	}
+
+	if err := b.db.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if err := b.writeBody(block); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteTotalDifficulty(header.Hash, td); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteCanonicalHash(header.Number, header.Hash); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteHeadHash(header.Hash); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteHeadNumber(header.Number); err != nil {
+		return err
+	}
+
+	b.setCurrentHeader(header, td)
+
+	evnt := &Event{Type: EventHead}
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(td)
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("wrote snapshot block", "number", header.Number, "hash", header.Hash)
+
+	return nil
+}
+
+// SnapshotAncestorsOf returns the number of ancestor headers written with the snapshot block
+// of the given number, which are all the ones up to the genesis if there are not enough
+func SnapshotAncestorsOf(number uint64) uint64 {
+	if number == 0 {
+		return 0
+	}
+
+	if number-1 < SnapshotAncestors {
+		return number - 1
+	}
+
+	return SnapshotAncestors
+}
+
 // updateGasPriceAvgWithBlock extracts the gas price information from the
 // block, and updates the average gas price for the chain accordingly
 func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
@@ -738,14 +940,14 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 
 	gasPrices := make([]*big.Int, len(block.Transactions))
 	for i, transaction := range block.Transactions {
-		gasPrices[i] = transaction.GasPrice
+		gasPrices[i] = transaction.GetGasPrice(0)
 	}
 
 	b.updateGasPriceAvg(gasPrices)
 }
 
 // writeBody writes the block body to the DB.
-// Additionally, it also updates the txn lookup, for txnHash -> block lookups
+// Additionally, it also updates the txn lookup, for txnHash -> (block, index) lookups
 func (b *Blockchain) writeBody(block *types.Block) error {
 	body := block.Body()
 
@@ -754,9 +956,15 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 		return err
 	}
 
-	// Write txn lookups (txHash -> block)
-	for _, txn := range block.Transactions {
-		if err := b.db.WriteTxLookup(txn.Hash, block.Hash()); err != nil {
+	// Write txn lookups (txHash -> block, index)
+	for indx, txn := range block.Transactions {
+		lookup := &storage.TxLookup{
+			BlockHash:   block.Hash(),
+			BlockNumber: block.Number(),
+			Index:       uint64(indx),
+		}
+
+		if err := b.db.WriteTxLookup(txn.Hash, lookup); err != nil {
 			return err
 		}
 	}
@@ -766,9 +974,44 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 
 // ReadTxLookup returns the block hash using the transaction hash
 func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
-	v, ok := b.db.ReadTxLookup(hash)
+	lookup, ok := b.db.ReadTxLookup(hash)
+	if !ok {
+		return types.ZeroHash, false
+	}
 
-	return v, ok
+	return lookup.BlockHash, true
+}
+
+// GetTxLookup returns the block hash, the block number and the index
+// of the mined transaction using the transaction hash
+func (b *Blockchain) GetTxLookup(hash types.Hash) (*storage.TxLookup, bool) {
+	lookup, ok := b.db.ReadTxLookup(hash)
+	if !ok {
+		return nil, false
+	}
+
+	if lookup.HasPosition() {
+		return lookup, true
+	}
+
+	// the lookups written by older versions only hold
+	// the block hash, find the transaction in the block
+	block, ok := b.GetBlockByHash(lookup.BlockHash, true)
+	if !ok {
+		return nil, false
+	}
+
+	for indx, txn := range block.Transactions {
+		if txn.Hash == hash {
+			return &storage.TxLookup{
+				BlockHash:   lookup.BlockHash,
+				BlockNumber: block.Number(),
+				Index:       uint64(indx),
+			}, true
+		}
+	}
+
+	return nil, false
 }
 
 // processBlock Processes the block, and does validation
@@ -805,20 +1048,24 @@ func (b *Blockchain) processBlock(block *types.Block) (*BlockResult, error) {
 
 	// Validate the fields
 	if root != header.StateRoot {
-		return nil, fmt.Errorf("invalid merkle root")
+		return nil, fmt.Errorf("%w: invalid merkle root", ErrInvalidBlock)
 	}
 
 	if totalGas != header.GasUsed {
-		return nil, fmt.Errorf("gas used is different")
+		return nil, fmt.Errorf("%w: gas used is different", ErrInvalidBlock)
 	}
 
 	receiptSha := buildroot.CalculateReceiptsRoot(receipts)
 	if receiptSha != header.ReceiptsRoot {
-		return nil, fmt.Errorf("invalid receipts root")
+		return nil, fmt.Errorf("%w: invalid receipts root", ErrInvalidBlock)
 	}
 
 	if gasLimitErr := b.verifyGasLimit(header); gasLimitErr != nil {
-		return nil, fmt.Errorf("invalid gas limit, %w", gasLimitErr)
+		return nil, fmt.Errorf("%w: invalid gas limit, %v", ErrInvalidBlock, gasLimitErr)
+	}
+
+	if expected := b.CalcBaseFee(parent); header.BaseFee != expected {
+		return nil, fmt.Errorf("%w: invalid base fee, expected %d but found %d", ErrInvalidBlock, expected, header.BaseFee)
 	}
 
 	return &BlockResult{
@@ -1009,58 +1256,80 @@ func (b *Blockchain) handleReorg(
 	newChainHead := newHeader
 	oldChainHead := oldHeader
 
+	// The headers leaving and entering the canonical chain,
+	// from the heads down to the common ancestor (excluded)
 	oldChain := []*types.Header{}
 	newChain := []*types.Header{}
 
-	var ok bool
-
-	// Fill up the old headers array
-	for oldHeader.Number > newHeader.Number {
-		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
+	parent := func(header *types.Header) (*types.Header, error) {
+		parentHeader, ok := b.readHeader(header.ParentHash)
 		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
+			return nil, fmt.Errorf("header '%s' not found", header.ParentHash.String())
 		}
 
-		oldChain = append(oldChain, oldHeader)
+		return parentHeader, nil
 	}
 
-	// Fill up the new headers array
-	for newHeader.Number > oldHeader.Number {
-		newHeader, ok = b.readHeader(newHeader.ParentHash)
-		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+	var err error
+
+	// Walk back the longer chain down to the height of the other one
+	for oldHeader.Number > newHeader.Number {
+		oldChain = append(oldChain, oldHeader)
+
+		if oldHeader, err = parent(oldHeader); err != nil {
+			return err
 		}
+	}
 
+	for newHeader.Number > oldHeader.Number {
 		newChain = append(newChain, newHeader)
+
+		if newHeader, err = parent(newHeader); err != nil {
+			return err
+		}
 	}
 
+	// Walk back both chains down to the common ancestor
 	for oldHeader.Hash != newHeader.Hash {
-		oldHeader, ok = b.readHeader(oldHeader.ParentHash)
-		if !ok {
-			return fmt.Errorf("header '%s' not found", oldHeader.ParentHash.String())
-		}
+		oldChain = append(oldChain, oldHeader)
+		newChain = append(newChain, newHeader)
 
-		newHeader, ok = b.readHeader(newHeader.ParentHash)
-		if !ok {
-			return fmt.Errorf("header '%s' not found", newHeader.ParentHash.String())
+		if oldHeader, err = parent(oldHeader); err != nil {
+			return err
 		}
 
-		oldChain = append(oldChain, oldHeader)
+		if newHeader, err = parent(newHeader); err != nil {
+			return err
+		}
 	}
 
-	for _, b := range oldChain[:len(oldChain)-1] {
-		evnt.AddOldHeader(b)
+	// A deep reorg is expensive and unexpected, the current chain is kept
+	if b.maxReorgDepth != 0 && uint64(len(oldChain)) > b.maxReorgDepth {
+		b.logger.Warn(
+			"rejected a reorg deeper than the maximum depth, the peer may be malicious",
+			"depth", len(oldChain),
+			"max", b.maxReorgDepth,
+			"head", oldChainHead.Number,
+			"new head", newChainHead.Number,
+		)
+
+		return fmt.Errorf("%w: %d blocks reverted, the maximum is %d", ErrReorgTooDeep, len(oldChain), b.maxReorgDepth)
 	}
 
-	evnt.AddOldHeader(oldChainHead)
-	evnt.AddNewHeader(newChainHead)
+	// The removed headers are reported from the oldest one,
+	// and the added headers from the new head
+	for i := len(oldChain) - 1; i >= 0; i-- {
+		evnt.AddOldHeader(oldChain[i])
+	}
 
-	for _, b := range newChain {
-		evnt.AddNewHeader(b)
+	for _, h := range newChain {
+		evnt.AddNewHeader(h)
 	}
 
-	if err := b.writeFork(oldChainHead); err != nil {
-		return fmt.Errorf("failed to write the old header as fork: %w", err)
+	if len(oldChain) != 0 {
+		if err := b.writeFork(oldChainHead); err != nil {
+			return fmt.Errorf("failed to write the old header as fork: %w", err)
+		}
 	}
 
 	// Update canonical chain numbers
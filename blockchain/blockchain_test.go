@@ -540,6 +540,149 @@ func TestForkUnkwonParents(t *testing.T) {
 	assert.Error(t, b.WriteHeadersWithBodies([]*types.Header{h1[12]}))
 }
 
+func TestReorgEvents(t *testing.T) {
+	// two nodes share the chain up to block 1, then seal their own blocks
+	common := NewTestHeaderChain(2)
+	nodeAHeaders := NewTestHeaderFromChainWithSeed(common, 2, 1)
+	nodeBHeaders := NewTestHeaderFromChainWithSeed(common, 4, 2)
+
+	nodeA := NewTestBlockchain(t, nodeAHeaders)
+	sub := nodeA.SubscribeEvents()
+
+	hashes := func(headers []*types.Header) []types.Hash {
+		res := make([]types.Hash, len(headers))
+		for i, header := range headers {
+			res[i] = header.Hash
+		}
+
+		return res
+	}
+
+	// node A receives the blocks of node B, which are forks
+	// until their total difficulty exceeds the one of its chain
+	for _, header := range nodeBHeaders[2:4] {
+		assert.NoError(t, nodeA.WriteHeaders([]*types.Header{header}))
+
+		evnt := sub.GetEvent()
+		assert.Equal(t, EventFork, evnt.Type)
+		assert.Equal(t, []types.Hash{header.Hash}, hashes(evnt.OldChain))
+	}
+
+	assert.Equal(t, nodeAHeaders[3].Hash, nodeA.Header().Hash)
+
+	assert.NoError(t, nodeA.WriteHeaders(nodeBHeaders[4:5]))
+
+	// the blocks of node A are removed, from the oldest one,
+	// and all the blocks of node B are added, from the new head
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Equal(t, hashes(nodeAHeaders[2:4]), hashes(evnt.OldChain))
+	assert.Equal(t, []types.Hash{
+		nodeBHeaders[4].Hash,
+		nodeBHeaders[3].Hash,
+		nodeBHeaders[2].Hash,
+	}, hashes(evnt.NewChain))
+
+	// the canonical chain is the one of node B
+	for _, header := range nodeBHeaders[1:5] {
+		canonical, ok := nodeA.GetHeaderByNumber(header.Number)
+		assert.True(t, ok)
+		assert.Equal(t, header.Hash, canonical.Hash)
+	}
+}
+
+func TestMaxReorgDepth(t *testing.T) {
+	// the node has the blocks 2 to 4 on top of the common chain
+	common := NewTestHeaderChain(2)
+	nodeHeaders := NewTestHeaderFromChainWithSeed(common, 3, 1)
+
+	b := NewTestBlockchain(t, nodeHeaders)
+	b.SetMaxReorgDepth(2)
+
+	head := nodeHeaders[4].Hash
+
+	t.Run("should reject a reorg reverting too many blocks", func(t *testing.T) {
+		// the blocks 2 to 5 of the peer revert the blocks 2 to 4 of the node
+		deep := NewTestHeaderFromChainWithSeed(common, 4, 2)
+
+		assert.ErrorIs(t, b.WriteHeaders(deep[2:]), ErrReorgTooDeep)
+		assert.Equal(t, head, b.Header().Hash)
+
+		for _, header := range nodeHeaders[1:] {
+			canonical, ok := b.GetHeaderByNumber(header.Number)
+			assert.True(t, ok)
+			assert.Equal(t, header.Hash, canonical.Hash)
+		}
+	})
+
+	t.Run("should accept a reorg within the maximum depth", func(t *testing.T) {
+		// the blocks 4 and 5 of the peer only revert the block 4 of the node
+		shallow := NewTestHeaderFromChainWithSeed(nodeHeaders[:4], 2, 3)
+
+		assert.NoError(t, b.WriteHeaders(shallow[4:]))
+		assert.Equal(t, shallow[5].Hash, b.Header().Hash)
+	})
+}
+
+func TestPruneReceipts(t *testing.T) {
+	headers := NewTestHeaderChain(5)
+	b := NewTestBlockchain(t, headers)
+
+	for _, header := range headers {
+		receipts := []*types.Receipt{{CumulativeGasUsed: header.Number}}
+		assert.NoError(t, b.db.WriteReceipts(header.Hash, receipts))
+	}
+
+	deleted, err := b.PruneReceipts(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), deleted)
+
+	// the tail is persisted
+	tail, ok := b.db.ReadReceiptsTail()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), tail)
+
+	for _, header := range headers[:3] {
+		_, err := b.db.ReadReceipts(header.Hash)
+		assert.Error(t, err)
+	}
+
+	// the test genesis header isn't stored, so its number is unknown
+	for _, header := range headers[1:3] {
+		_, err := b.GetReceiptsByHash(header.Hash)
+		assert.ErrorIs(t, err, ErrReceiptsPruned)
+	}
+
+	for _, header := range headers[3:] {
+		receipts, err := b.GetReceiptsByHash(header.Hash)
+		assert.NoError(t, err)
+		assert.Len(t, receipts, 1)
+		assert.Equal(t, header.Number, receipts[0].CumulativeGasUsed)
+	}
+
+	assert.Equal(t, uint64(3), b.ReceiptsTail())
+
+	// the receipts of a fork below the tail aren't pruned
+	fork := NewTestHeaderFromChainWithSeed(headers[:2], 3, 1)
+	assert.NoError(t, b.WriteHeaders(fork[2:]))
+	assert.Equal(t, headers[4].Hash, b.Header().Hash)
+
+	forkReceipts := []*types.Receipt{{CumulativeGasUsed: fork[2].Number}}
+	assert.NoError(t, b.db.WriteReceipts(fork[2].Hash, forkReceipts))
+
+	receipts, err := b.GetReceiptsByHash(fork[2].Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, forkReceipts, receipts)
+
+	// the tail doesn't move back
+	deleted, err = b.PruneReceipts(2)
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	tail, _ = b.db.ReadReceiptsTail()
+	assert.Equal(t, uint64(3), tail)
+}
+
 func TestBlockchainWriteBody(t *testing.T) {
 	storage, err := memory.NewMemoryStorage(nil)
 	assert.NoError(t, err)
@@ -564,6 +707,55 @@ func TestBlockchainWriteBody(t *testing.T) {
 	}
 }
 
+func TestBlockchainGetTxLookup(t *testing.T) {
+	storage, err := memory.NewMemoryStorage(nil)
+	assert.NoError(t, err)
+
+	b := &Blockchain{
+		db: storage,
+	}
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number: 5,
+		},
+		Transactions: []*types.Transaction{
+			{
+				Nonce: 0,
+				Value: big.NewInt(10),
+				V:     big.NewInt(1),
+			},
+			{
+				Nonce: 1,
+				Value: big.NewInt(10),
+				V:     big.NewInt(1),
+			},
+		},
+	}
+	block.Header.ComputeHash()
+
+	for _, txn := range block.Transactions {
+		txn.ComputeHash()
+	}
+
+	assert.NoError(t, b.writeBody(block))
+
+	for indx, txn := range block.Transactions {
+		lookup, ok := b.GetTxLookup(txn.Hash)
+		assert.True(t, ok)
+		assert.Equal(t, block.Hash(), lookup.BlockHash)
+		assert.Equal(t, block.Number(), lookup.BlockNumber)
+		assert.Equal(t, uint64(indx), lookup.Index)
+
+		blockHash, ok := b.ReadTxLookup(txn.Hash)
+		assert.True(t, ok)
+		assert.Equal(t, block.Hash(), blockHash)
+	}
+
+	_, ok := b.GetTxLookup(types.StringToHash("1"))
+	assert.False(t, ok)
+}
+
 func TestCalculateGasLimit(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -621,6 +813,181 @@ func TestCalculateGasLimit(t *testing.T) {
 	}
 }
 
+func TestCalculateGasLimit_Drift(t *testing.T) {
+	tests := []struct {
+		name            string
+		genesisGasLimit uint64
+		blockGasTarget  uint64
+		blockGasCeiling uint64
+		expectedLimit   uint64
+	}{
+		{
+			name:            "should grow towards the target",
+			genesisGasLimit: 10000000,
+			blockGasTarget:  10500000,
+			expectedLimit:   10500000,
+		},
+		{
+			name:            "should shrink towards the target",
+			genesisGasLimit: 10500000,
+			blockGasTarget:  10000000,
+			expectedLimit:   10000000,
+		},
+		{
+			name:            "should not grow past the ceiling",
+			genesisGasLimit: 10000000,
+			blockGasTarget:  12000000,
+			blockGasCeiling: 10500000,
+			expectedLimit:   10500000,
+		},
+		{
+			name:            "should shrink to the ceiling without a target",
+			genesisGasLimit: 10500000,
+			blockGasCeiling: 10000000,
+			expectedLimit:   10000000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTestBlockchain(t, nil)
+			assert.NoError(t, b.writeGenesis(&chain.Genesis{
+				GasLimit: tt.genesisGasLimit,
+			}))
+
+			b.config.Params = &chain.Params{
+				Forks:           &chain.Forks{London: chain.NewFork(0)},
+				BlockGasTarget:  tt.blockGasTarget,
+				BlockGasCeiling: tt.blockGasCeiling,
+			}
+
+			parent := b.Header()
+
+			for i := 0; i < 100; i++ {
+				gasLimit, err := b.CalculateGasLimit(parent.Number + 1)
+				assert.NoError(t, err)
+
+				// every block uses exactly its gas target
+				header := &types.Header{
+					ParentHash: parent.Hash,
+					Number:     parent.Number + 1,
+					Difficulty: parent.Number + 1,
+					GasLimit:   gasLimit,
+					GasUsed:    gasLimit / ElasticityMultiplier,
+					BaseFee:    b.CalcBaseFee(parent),
+				}
+				header.ComputeHash()
+
+				// the limit respects the 1/1024 bound
+				assert.NoError(t, b.verifyGasLimit(header))
+				assert.NoError(t, b.WriteHeaders([]*types.Header{header}))
+
+				// the limit moves monotonically towards the expected one
+				if tt.genesisGasLimit < tt.expectedLimit {
+					assert.GreaterOrEqual(t, header.GasLimit, parent.GasLimit)
+					assert.LessOrEqual(t, header.GasLimit, tt.expectedLimit)
+				} else {
+					assert.LessOrEqual(t, header.GasLimit, parent.GasLimit)
+					assert.GreaterOrEqual(t, header.GasLimit, tt.expectedLimit)
+				}
+
+				// the base fee is stable, as the gas target is half the limit
+				assert.Equal(t, InitialBaseFee, header.BaseFee)
+
+				parent = header
+			}
+
+			assert.Equal(t, tt.expectedLimit, parent.GasLimit)
+		})
+	}
+}
+
+func TestCalcBaseFee(t *testing.T) {
+	tests := []struct {
+		name            string
+		forks           *chain.Forks
+		parentBaseFee   uint64
+		parentGasLimit  uint64
+		parentGasUsed   uint64
+		expectedBaseFee uint64
+	}{
+		{
+			name:            "should be zero before the London fork",
+			forks:           &chain.Forks{},
+			parentBaseFee:   0,
+			parentGasLimit:  20000000,
+			parentGasUsed:   10000000,
+			expectedBaseFee: 0,
+		},
+		{
+			name:            "should start at the initial base fee",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   0,
+			parentGasLimit:  20000000,
+			parentGasUsed:   20000000,
+			expectedBaseFee: InitialBaseFee,
+		},
+		{
+			name:            "should not change when the gas target is used",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   InitialBaseFee,
+			parentGasLimit:  20000000,
+			parentGasUsed:   10000000,
+			expectedBaseFee: InitialBaseFee,
+		},
+		{
+			name:            "should increase by 12.5% when the block is full",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   InitialBaseFee,
+			parentGasLimit:  20000000,
+			parentGasUsed:   20000000,
+			expectedBaseFee: InitialBaseFee + InitialBaseFee/8,
+		},
+		{
+			name:            "should increase by at least 1",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   7,
+			parentGasLimit:  20000000,
+			parentGasUsed:   10000001,
+			expectedBaseFee: 8,
+		},
+		{
+			name:            "should decrease by 12.5% when the block is empty",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   InitialBaseFee,
+			parentGasLimit:  20000000,
+			parentGasUsed:   0,
+			expectedBaseFee: InitialBaseFee - InitialBaseFee/8,
+		},
+		{
+			name:            "should decrease proportionally to the unused gas",
+			forks:           &chain.Forks{London: chain.NewFork(0)},
+			parentBaseFee:   InitialBaseFee,
+			parentGasLimit:  20000000,
+			parentGasUsed:   5000000,
+			expectedBaseFee: InitialBaseFee - InitialBaseFee/16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTestBlockchain(t, nil)
+			b.config.Params = &chain.Params{
+				Forks: tt.forks,
+			}
+
+			parent := &types.Header{
+				Number:   1,
+				BaseFee:  tt.parentBaseFee,
+				GasLimit: tt.parentGasLimit,
+				GasUsed:  tt.parentGasUsed,
+			}
+
+			assert.Equal(t, tt.expectedBaseFee, b.CalcBaseFee(parent))
+		})
+	}
+}
+
 // TestGasPriceAverage tests the average gas price of the
 // blockchain
 func TestGasPriceAverage(t *testing.T) {
@@ -681,3 +1048,31 @@ func TestGasPriceAverage(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteSnapshotBlock(t *testing.T) {
+	b := NewTestBlockchain(t, nil)
+
+	headers := NewTestHeaderChainWithSeed(b.Header(), 301, 0)
+	block := &types.Block{Header: headers[300]}
+
+	// the ancestors the EVM can read, from the parent down
+	ancestors := make([]*types.Header, 0, SnapshotAncestors)
+	for i := 299; i >= 300-int(SnapshotAncestors); i-- {
+		ancestors = append(ancestors, headers[i])
+	}
+
+	assert.ErrorContains(t, b.WriteSnapshotBlock(block, big.NewInt(1), ancestors[1:]), "expected 256 ancestor headers")
+	assert.NoError(t, b.WriteSnapshotBlock(block, big.NewInt(1), ancestors))
+	assert.Equal(t, block.Hash(), b.Header().Hash)
+
+	// the hashes of the last 256 blocks are available to the next one
+	getHash := b.GetHashHelper(&types.Header{Number: 301, ParentHash: block.Hash()})
+
+	for i := 301 - int(SnapshotAncestors); i <= 300; i++ {
+		assert.Equal(t, headers[i].Hash, getHash(uint64(i)))
+
+		hash, ok := b.db.ReadCanonicalHash(uint64(i))
+		assert.True(t, ok)
+		assert.Equal(t, headers[i].Hash, hash)
+	}
+}
@@ -46,6 +46,7 @@ var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+	TAIL   = []byte("tail")
 )
 
 // KV is a key value storage interface.
@@ -55,6 +56,7 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	Delete(p []byte) error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -255,33 +257,96 @@ func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error
 	return *receipts, err
 }
 
+// DeleteReceipts deletes the receipts
+func (s *KeyValueStorage) DeleteReceipts(hash types.Hash) error {
+	return s.del(RECEIPTS, hash.Bytes())
+}
+
+// ReadReceiptsTail reads the number of the first block whose receipts are kept
+func (s *KeyValueStorage) ReadReceiptsTail() (uint64, bool) {
+	data, ok := s.get(RECEIPTS, TAIL)
+	if !ok {
+		return 0, false
+	}
+
+	return s.decodeUint(data), true
+}
+
+// WriteReceiptsTail writes the number of the first block whose receipts are kept
+func (s *KeyValueStorage) WriteReceiptsTail(n uint64) error {
+	return s.set(RECEIPTS, TAIL, s.encodeUint(n))
+}
+
 // TX LOOKUP //
 
-// WriteTxLookup maps the transaction hash to the block hash
-func (s *KeyValueStorage) WriteTxLookup(hash types.Hash, blockHash types.Hash) error {
+// TxLookup is the position of a mined transaction in the chain
+type TxLookup struct {
+	BlockHash   types.Hash
+	BlockNumber uint64
+	Index       uint64
+
+	// positioned is false for the lookups written before the block
+	// number and the index were stored, which only hold the block hash
+	positioned bool
+}
+
+// HasPosition checks if the lookup holds the block number and the index of the transaction
+func (l *TxLookup) HasPosition() bool {
+	return l.positioned
+}
+
+// WriteTxLookup maps the transaction hash to its block hash, block number and index
+func (s *KeyValueStorage) WriteTxLookup(hash types.Hash, lookup *TxLookup) error {
 	ar := &fastrlp.Arena{}
-	vr := ar.NewBytes(blockHash.Bytes())
+
+	vr := ar.NewArray()
+	vr.Set(ar.NewBytes(lookup.BlockHash.Bytes()))
+	vr.Set(ar.NewUint(lookup.BlockNumber))
+	vr.Set(ar.NewUint(lookup.Index))
 
 	return s.write2(TX_LOOKUP_PREFIX, hash.Bytes(), vr)
 }
 
-// ReadTxLookup reads the block hash using the transaction hash
-func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
+// ReadTxLookup reads the position of the transaction using the transaction hash
+func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (*TxLookup, bool) {
 	parser := &fastrlp.Parser{}
 
 	v := s.read2(TX_LOOKUP_PREFIX, hash.Bytes(), parser)
 	if v == nil {
-		return types.Hash{}, false
+		return nil, false
 	}
 
-	blockHash := []byte{}
-	blockHash, err := v.GetBytes(blockHash[:0], 32)
+	lookup := &TxLookup{}
 
-	if err != nil {
-		panic(err)
+	// the lookups of older versions are the plain block hash
+	if v.Type() == fastrlp.TypeBytes {
+		if err := v.GetHash(lookup.BlockHash[:]); err != nil {
+			return nil, false
+		}
+
+		return lookup, true
+	}
+
+	elems, err := v.GetElems()
+	if err != nil || len(elems) != 3 {
+		return nil, false
 	}
 
-	return types.BytesToHash(blockHash), true
+	if err := elems[0].GetHash(lookup.BlockHash[:]); err != nil {
+		return nil, false
+	}
+
+	if lookup.BlockNumber, err = elems[1].GetUint64(); err != nil {
+		return nil, false
+	}
+
+	if lookup.Index, err = elems[2].GetUint64(); err != nil {
+		return nil, false
+	}
+
+	lookup.positioned = true
+
+	return lookup, true
 }
 
 // WRITE OPERATIONS //
@@ -363,6 +428,12 @@ func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 	return data, ok
 }
 
+func (s *KeyValueStorage) del(p []byte, k []byte) error {
+	p = append(p, k...)
+
+	return s.db.Delete(p)
+}
+
 // Close closes the connection with the db
 func (s *KeyValueStorage) Close() error {
 	return s.db.Close()
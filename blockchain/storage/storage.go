@@ -36,9 +36,13 @@ type Storage interface {
 
 	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
 	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
+	DeleteReceipts(hash types.Hash) error
 
-	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
-	ReadTxLookup(hash types.Hash) (types.Hash, bool)
+	ReadReceiptsTail() (uint64, bool)
+	WriteReceiptsTail(n uint64) error
+
+	WriteTxLookup(hash types.Hash, lookup *TxLookup) error
+	ReadTxLookup(hash types.Hash) (*TxLookup, bool)
 
 	Close() error
 }
@@ -49,6 +49,9 @@ func TestStorage(t *testing.T, m MockStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testTxLookup(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m MockStorage) {
@@ -370,6 +373,7 @@ func testReceipts(t *testing.T, m MockStorage) {
 		TxHash:            txn.Hash,
 		LogsBloom:         types.Bloom{0x1},
 		GasUsed:           10,
+		EffectiveGasPrice: big.NewInt(20),
 		ContractAddress:   types.Address{0x1},
 		Logs: []*types.Log{
 			{
@@ -392,6 +396,25 @@ func testReceipts(t *testing.T, m MockStorage) {
 	}
 
 	assert.True(t, reflect.DeepEqual(receipts, found))
+
+	// the tail is unset until the receipts are pruned
+	_, ok := s.ReadReceiptsTail()
+	assert.False(t, ok)
+
+	if err := s.WriteReceiptsTail(h.Number + 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteReceipts(h.Hash); err != nil {
+		t.Fatal(err)
+	}
+
+	tail, ok := s.ReadReceiptsTail()
+	assert.True(t, ok)
+	assert.Equal(t, h.Number+1, tail)
+
+	_, err = s.ReadReceipts(h.Hash)
+	assert.Error(t, err)
 }
 
 func testWriteCanonicalHeader(t *testing.T, m MockStorage) {
@@ -451,3 +474,33 @@ func testWriteCanonicalHeader(t *testing.T, m MockStorage) {
 		t.Fatal("canonical hash not correct")
 	}
 }
+
+func testTxLookup(t *testing.T, m MockStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	lookup := &TxLookup{
+		BlockHash:   hash1,
+		BlockNumber: 10,
+		Index:       3,
+	}
+
+	if err := s.WriteTxLookup(hash2, lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	found, ok := s.ReadTxLookup(hash2)
+	if !ok {
+		t.Fatal("not found tx lookup")
+	}
+
+	assert.True(t, found.HasPosition())
+	assert.Equal(t, lookup.BlockHash, found.BlockHash)
+	assert.Equal(t, lookup.BlockNumber, found.BlockNumber)
+	assert.Equal(t, lookup.Index, found.Index)
+
+	_, ok = s.ReadTxLookup(hash1)
+	assert.False(t, ok)
+}
@@ -36,6 +36,7 @@ type Genesis struct {
 	Timestamp  uint64                            `json:"timestamp"`
 	ExtraData  []byte                            `json:"extraData,omitempty"`
 	GasLimit   uint64                            `json:"gasLimit"`
+	BaseFee    uint64                            `json:"baseFeePerGas"`
 	Difficulty uint64                            `json:"difficulty"`
 	Mixhash    types.Hash                        `json:"mixHash"`
 	Coinbase   types.Address                     `json:"coinbase"`
@@ -66,6 +67,7 @@ func (g *Genesis) GenesisHeader() *types.Header {
 		ExtraData:    g.ExtraData,
 		GasLimit:     g.GasLimit,
 		GasUsed:      g.GasUsed,
+		BaseFee:      g.BaseFee,
 		Difficulty:   g.Difficulty,
 		MixHash:      g.Mixhash,
 		Miner:        g.Coinbase,
@@ -102,6 +104,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		Timestamp  *string                     `json:"timestamp,omitempty"`
 		ExtraData  *string                     `json:"extraData,omitempty"`
 		GasLimit   *string                     `json:"gasLimit,omitempty"`
+		BaseFee    *string                     `json:"baseFeePerGas,omitempty"`
 		Difficulty *string                     `json:"difficulty,omitempty"`
 		Mixhash    types.Hash                  `json:"mixHash"`
 		Coinbase   types.Address               `json:"coinbase"`
@@ -120,6 +123,11 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 	enc.GasLimit = types.EncodeUint64(g.GasLimit)
 	enc.Difficulty = types.EncodeUint64(g.Difficulty)
 
+	// the genesis of a chain without a base fee is unchanged
+	if g.BaseFee != 0 {
+		enc.BaseFee = types.EncodeUint64(g.BaseFee)
+	}
+
 	enc.Mixhash = g.Mixhash
 	enc.Coinbase = g.Coinbase
 
@@ -146,6 +154,7 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		Timestamp  *string                    `json:"timestamp"`
 		ExtraData  *string                    `json:"extraData"`
 		GasLimit   *string                    `json:"gasLimit"`
+		BaseFee    *string                    `json:"baseFeePerGas"`
 		Difficulty *string                    `json:"difficulty"`
 		Mixhash    *types.Hash                `json:"mixHash"`
 		Coinbase   *types.Address             `json:"coinbase"`
@@ -194,6 +203,11 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		parseError("gaslimit", subErr)
 	}
 
+	g.BaseFee, subErr = types.ParseUint64orHex(dec.BaseFee)
+	if subErr != nil {
+		parseError("basefeepergas", subErr)
+	}
+
 	g.Difficulty, subErr = types.ParseUint64orHex(dec.Difficulty)
 	if subErr != nil {
 		parseError("difficulty", subErr)
@@ -125,6 +125,18 @@ func TestGenesisX(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `{
+				"difficulty": "0x1",
+				"gasLimit": "0x11",
+				"baseFeePerGas": "0x3b9aca00"
+			}`,
+			output: &Genesis{
+				Difficulty: 1,
+				GasLimit:   17,
+				BaseFee:    1000000000,
+			},
+		},
 	}
 
 	for _, c := range cases {
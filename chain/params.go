@@ -2,6 +2,8 @@ package chain
 
 import (
 	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 // Params are all the set of params for the chain
@@ -10,6 +12,9 @@ type Params struct {
 	ChainID        int                    `json:"chainID"`
 	Engine         map[string]interface{} `json:"engine"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+
+	// BlockGasCeiling is the maximum gas limit of the produced blocks (0 for no ceiling)
+	BlockGasCeiling uint64 `json:"blockGasCeiling,omitempty"`
 }
 
 func (p *Params) GetEngine() string {
@@ -31,6 +36,8 @@ type Forks struct {
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
+	Berlin         *Fork `json:"berlin,omitempty"`
+	London         *Fork `json:"london,omitempty"`
 }
 
 func (f *Forks) active(ff *Fork, block uint64) bool {
@@ -57,6 +64,10 @@ func (f *Forks) IsPetersburg(block uint64) bool {
 	return f.active(f.Petersburg, block)
 }
 
+func (f *Forks) IsIstanbul(block uint64) bool {
+	return f.active(f.Istanbul, block)
+}
+
 func (f *Forks) IsEIP150(block uint64) bool {
 	return f.active(f.EIP150, block)
 }
@@ -69,6 +80,14 @@ func (f *Forks) IsEIP155(block uint64) bool {
 	return f.active(f.EIP155, block)
 }
 
+func (f *Forks) IsBerlin(block uint64) bool {
+	return f.active(f.Berlin, block)
+}
+
+func (f *Forks) IsLondon(block uint64) bool {
+	return f.active(f.London, block)
+}
+
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
 		Homestead:      f.active(f.Homestead, block),
@@ -79,6 +98,8 @@ func (f *Forks) At(block uint64) ForksInTime {
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
+		Berlin:         f.active(f.Berlin, block),
+		London:         f.active(f.London, block),
 	}
 }
 
@@ -106,7 +127,25 @@ type ForksInTime struct {
 	Istanbul,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	Berlin,
+	London bool
+}
+
+// TxTypeEnabled checks if the transactions of the given type are enabled,
+// access list transactions by Berlin (EIP-2930) and dynamic fee
+// transactions by London (EIP-1559)
+func (f ForksInTime) TxTypeEnabled(txType types.TxType) bool {
+	switch txType {
+	case types.LegacyTx:
+		return true
+	case types.AccessListTx:
+		return f.Berlin
+	case types.DynamicFeeTx:
+		return f.London
+	}
+
+	return false
 }
 
 var AllForksEnabled = &Forks{
@@ -118,4 +157,6 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	Berlin:         NewFork(0),
+	London:         NewFork(0),
 }
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 func TestValidateChainID(t *testing.T) {
@@ -51,6 +53,7 @@ func TestParamsForksInTime(t *testing.T) {
 		Byzantium:      NewFork(1000),
 		Constantinople: NewFork(1001),
 		EIP150:         NewFork(2000),
+		Istanbul:       NewFork(1000),
 	}
 
 	ff := f.At(1000)
@@ -65,4 +68,34 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("byzantium", ff.Byzantium, true)
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
+	expect("istanbul", ff.Istanbul, true)
+
+	expect("istanbul before activation", f.IsIstanbul(999), false)
+	expect("istanbul at activation", f.IsIstanbul(1000), true)
+}
+
+func TestForksInTime_TxTypeEnabled(t *testing.T) {
+	f := Forks{
+		Berlin: NewFork(5),
+		London: NewFork(10),
+	}
+
+	cases := []struct {
+		block    uint64
+		txType   types.TxType
+		expected bool
+	}{
+		{0, types.LegacyTx, true},
+		{4, types.AccessListTx, false},
+		{5, types.AccessListTx, true},
+		{9, types.DynamicFeeTx, false},
+		{10, types.DynamicFeeTx, true},
+		{10, types.TxType(0x03), false},
+	}
+
+	for _, c := range cases {
+		if found := f.At(c.block).TxTypeEnabled(c.txType); found != c.expected {
+			t.Fatalf("tx type %d at block %d should be %v but found %v", c.txType, c.block, c.expected, found)
+		}
+	}
 }
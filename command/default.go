@@ -9,8 +9,13 @@ const (
 	DefaultPremineBalance  = "0x3635C9ADC5DEA00000" // 1000 ETH
 	DefaultConsensus       = server.IBFTConsensus
 	DefaultMaxSlots        = 4096
-	DefaultGenesisGasUsed  = 458752  // 0x70000
-	DefaultGenesisGasLimit = 5242880 // 0x500000
+	DefaultMaxAccountSlots = 16
+	DefaultPriceBump       = 10
+	DefaultTxLifetime      = 3 * 60 * 60 // 3h, in seconds
+	DefaultGossipBatch     = 100         // in milliseconds
+	DefaultRejournal       = 60 * 60     // 1h, in seconds
+	DefaultGenesisGasUsed  = 458752      // 0x70000
+	DefaultGenesisGasLimit = 5242880     // 0x500000
 )
 
 const (
@@ -2,6 +2,7 @@ package genesis
 
 import (
 	"fmt"
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
@@ -127,6 +128,32 @@ func setFlags(cmd *cobra.Command) {
 			command.DefaultGenesisGasLimit,
 		),
 	)
+
+	cmd.Flags().Uint64Var(
+		&params.blockGasTarget,
+		blockGasTargetFlag,
+		0,
+		"the target block gas limit for the chain, which the nodes can override. "+
+			"If omitted, the value of the parent block is used",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.isLondon,
+		londonFlag,
+		false,
+		"enable the London fork (EIP-1559 base fee) from the genesis block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.baseFee,
+		baseFeeFlag,
+		0,
+		fmt.Sprintf(
+			"the base fee of the genesis block, if London is enabled. "+
+				"If omitted, the first block starts with a base fee of %d",
+			blockchain.InitialBaseFee,
+		),
+	)
 	cmd.Flags().Uint64Var(
 		&params.minNumValidators,
 		minValidatorCount,
@@ -23,6 +23,9 @@ const (
 	ibftValidatorPrefixFlag = "ibft-validators-prefix-path"
 	epochSizeFlag           = "epoch-size"
 	blockGasLimitFlag       = "block-gas-limit"
+	blockGasTargetFlag      = "block-gas-target"
+	baseFeeFlag             = "base-fee"
+	londonFlag              = "london"
 	posFlag                 = "pos"
 	minValidatorCount       = "min-validator-count"
 	maxValidatorCount       = "max-validator-count"
@@ -44,6 +47,7 @@ var (
 	errUnsupportedConsensus           = errors.New("specified consensusRaw not supported")
 	errMissingBootnode                = errors.New("at least 1 bootnode is required")
 	errInvalidEpochSize               = errors.New("epoch size must be greater than 1")
+	errBaseFeeWithoutLondon           = errors.New("base fee requires the London fork")
 )
 
 type genesisParams struct {
@@ -57,10 +61,13 @@ type genesisParams struct {
 
 	ibftValidatorsRaw []string
 
-	chainID       uint64
-	epochSize     uint64
-	blockGasLimit uint64
-	isPos         bool
+	chainID        uint64
+	epochSize      uint64
+	blockGasLimit  uint64
+	blockGasTarget uint64
+	baseFee        uint64
+	isLondon       bool
+	isPos          bool
 
 	minNumValidators uint64
 	maxNumValidators uint64
@@ -111,6 +118,11 @@ func (p *genesisParams) validateFlags() error {
 		return errInvalidEpochSize
 	}
 
+	// The base fee is only set from the London fork onwards
+	if p.baseFee != 0 && !p.isLondon {
+		return errBaseFeeWithoutLondon
+	}
+
 	// Validate min and max validators number
 	if err := command.ValidateMinMaxValidatorsNumber(p.minNumValidators, p.maxNumValidators); err != nil {
 		return err
@@ -242,6 +254,18 @@ func (p *genesisParams) initIBFTEngineMap(mechanism ibft.MechanismType) {
 	}
 }
 
+// getForks returns the forks of the chain, all enabled from the genesis block.
+// London is opt-in, as its base fee rules out zero gas price transactions
+func (p *genesisParams) getForks() *chain.Forks {
+	forks := *chain.AllForksEnabled
+
+	if !p.isLondon {
+		forks.London = nil
+	}
+
+	return &forks
+}
+
 func (p *genesisParams) generateGenesis() error {
 	if err := p.initGenesisConfig(); err != nil {
 		return err
@@ -262,15 +286,17 @@ func (p *genesisParams) initGenesisConfig() error {
 		Name: p.name,
 		Genesis: &chain.Genesis{
 			GasLimit:   p.blockGasLimit,
+			BaseFee:    p.baseFee,
 			Difficulty: 1,
 			Alloc:      map[types.Address]*chain.GenesisAccount{},
 			ExtraData:  p.extraData,
 			GasUsed:    command.DefaultGenesisGasUsed,
 		},
 		Params: &chain.Params{
-			ChainID: int(p.chainID),
-			Forks:   chain.AllForksEnabled,
-			Engine:  p.consensusEngineConfig,
+			ChainID:        int(p.chainID),
+			Forks:          p.getForks(),
+			Engine:         p.consensusEngineConfig,
+			BlockGasTarget: p.blockGasTarget,
 		},
 		Bootnodes: p.bootnodes,
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/peers"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/snapshot"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/version"
@@ -48,6 +49,7 @@ func (rc *RootCommand) registerSubCommands() {
 		loadbot.GetCommand(),
 		ibft.GetCommand(),
 		backup.GetCommand(),
+		snapshot.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		license.GetCommand(),
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 
 	"github.com/hashicorp/hcl"
@@ -13,20 +15,40 @@ import (
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath       string     `json:"chain_config"`
-	SecretsConfigPath string     `json:"secrets_config"`
-	DataDir           string     `json:"data_dir"`
-	BlockGasTarget    string     `json:"block_gas_target"`
-	GRPCAddr          string     `json:"grpc_addr"`
-	JSONRPCAddr       string     `json:"jsonrpc_addr"`
-	Telemetry         *Telemetry `json:"telemetry"`
-	Network           *Network   `json:"network"`
-	ShouldSeal        bool       `json:"seal"`
-	TxPool            *TxPool    `json:"tx_pool"`
-	LogLevel          string     `json:"log_level"`
-	RestoreFile       string     `json:"restore_file"`
-	BlockTime         uint64     `json:"block_time_s"`
-	Headers           *Headers   `json:"headers"`
+	GenesisPath         string       `json:"chain_config"`
+	SecretsConfigPath   string       `json:"secrets_config"`
+	DataDir             string       `json:"data_dir"`
+	BlockGasTarget      string       `json:"block_gas_target"`
+	BlockGasCeiling     string       `json:"block_gas_ceiling"`
+	GRPCAddr            string       `json:"grpc_addr"`
+	GRPCReflection      bool         `json:"grpc_reflection"`
+	JSONRPCAddr         string       `json:"jsonrpc_addr"`
+	WSAddr              string       `json:"ws_addr"`
+	WSMaxConnections    uint64       `json:"ws_max_connections"`
+	Telemetry           *Telemetry   `json:"telemetry"`
+	Network             *Network     `json:"network"`
+	ShouldSeal          bool         `json:"seal"`
+	TxPool              *TxPool      `json:"tx_pool"`
+	LogLevel            string       `json:"log_level"`
+	JSONLogFormat       bool         `json:"json_log_format"`
+	RestoreFile         string       `json:"restore_file"`
+	SnapshotFile        string       `json:"snapshot_file"`
+	BlockTime           uint64       `json:"block_time_s"`
+	IBFTBaseTimeout     uint64       `json:"ibft_base_timeout_s"`
+	IBFTTimeoutBackoff  uint64       `json:"ibft_timeout_backoff_s"`
+	Headers             *Headers     `json:"headers"`
+	TxRateLimit         *TxRateLimit `json:"tx_rate_limit"`
+	GasOracle           *GasOracle   `json:"gas_price_oracle"`
+	RPCGasCap           uint64       `json:"rpc_gas_cap"`
+	RPCMaxRequestBytes  int64        `json:"rpc_max_request_bytes"`
+	RPCExecutionTimeout uint64       `json:"rpc_execution_timeout_ms"`
+	RPCShutdownTimeout  uint64       `json:"rpc_shutdown_timeout_ms"`
+	RPCNamespaces       []string     `json:"rpc_namespaces"`
+	HealthMaxBlockAge   uint64       `json:"health_max_block_age_s"`
+	RetainBlocks        uint64       `json:"retain_blocks"`
+	RetainReceipts      uint64       `json:"retain_receipts"`
+	MaxReorgDepth       uint64       `json:"max_reorg_depth"`
+	ParallelExecWorkers uint64       `json:"parallel_execution_workers"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -36,19 +58,49 @@ type Telemetry struct {
 
 // Network defines the network configuration params
 type Network struct {
-	NoDiscover       bool   `json:"no_discover"`
-	Libp2pAddr       string `json:"libp2p_addr"`
-	NatAddr          string `json:"nat_addr"`
-	DNSAddr          string `json:"dns_addr"`
-	MaxPeers         int64  `json:"max_peers,omitempty"`
-	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty"`
-	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty"`
+	NoDiscover       bool     `json:"no_discover"`
+	Libp2pAddr       string   `json:"libp2p_addr"`
+	NatAddr          string   `json:"nat_addr"`
+	DNSAddr          string   `json:"dns_addr"`
+	MaxPeers         int64    `json:"max_peers,omitempty"`
+	MaxOutboundPeers int64    `json:"max_outbound_peers,omitempty"`
+	MaxInboundPeers  int64    `json:"max_inbound_peers,omitempty"`
+	ScoreThreshold   int64    `json:"peer_score_threshold"`
+	BanDuration      uint64   `json:"peer_ban_duration_s"`
+	TrustedPeers     []string `json:"trusted_peers"`
+	GossipBandwidth  uint64   `json:"gossip_bandwidth_bps"`
+	DNSDiscovery     string   `json:"dns_discovery_url"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit uint64 `json:"price_limit"`
-	MaxSlots   uint64 `json:"max_slots"`
+	PriceLimit        uint64 `json:"price_limit"`
+	MaxSlots          uint64 `json:"max_slots"`
+	MaxAccountSlots   uint64 `json:"max_account_slots"`
+	PriceBump         uint64 `json:"price_bump"`
+	Lifetime          uint64 `json:"lifetime_s"`
+	GossipBatch       uint64 `json:"gossip_batch_ms"`
+	Journal           bool   `json:"journal"`
+	JournalRemotes    bool   `json:"journal_remotes"`
+	Rejournal         uint64 `json:"rejournal_s"`
+	GasLimitMargin    uint64 `json:"gas_limit_margin"`
+	RejectUnprotected bool   `json:"reject_unprotected_txs"`
+}
+
+// TxRateLimit defines the per sender limit of the transactions
+// submitted through eth_sendRawTransaction
+type TxRateLimit struct {
+	Rate  uint64 `json:"rate"`
+	Burst uint64 `json:"burst"`
+}
+
+// GasOracle defines the gas price suggested by eth_gasPrice,
+// a percentile of the lowest gas prices of the latest blocks
+type GasOracle struct {
+	Blocks     uint64 `json:"blocks"`
+	Percentile uint64 `json:"percentile"`
+	MinPrice   uint64 `json:"min_price"`
+	MaxPrice   uint64 `json:"max_price"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -59,25 +111,56 @@ type Headers struct {
 // minimum block generation time in seconds
 const defaultBlockTime uint64 = 2
 
+// maximum gas of the eth_call and eth_estimateGas executions
+const defaultRPCGasCap uint64 = 50000000
+
+// maximum number of open WS connections
+const defaultWSMaxConnections uint64 = 1000
+
+// maximum size of a JSON-RPC request body
+const defaultRPCMaxRequestBytes int64 = 5 * 1024 * 1024
+
+// maximum duration of a JSON-RPC request in milliseconds
+const defaultRPCExecutionTimeout uint64 = 5000
+
+// maximum duration in milliseconds of the JSON-RPC requests drain on shutdown
+const defaultRPCShutdownTimeout uint64 = 5000
+
+// maximum age of the latest block of a ready node in seconds
+const defaultHealthMaxBlockAge uint64 = 60
+
 // DefaultConfig returns the default server configuration
 func DefaultConfig() *Config {
 	defaultNetworkConfig := network.DefaultConfig()
 
 	return &Config{
-		GenesisPath:    "./genesis.json",
-		DataDir:        "./polygon-edge-chain",
-		BlockGasTarget: "0x0", // Special value signaling the parent gas limit should be applied
+		GenesisPath:     "./genesis.json",
+		DataDir:         "./polygon-edge-chain",
+		BlockGasTarget:  "0x0", // Special value signaling the parent gas limit should be applied
+		BlockGasCeiling: "0x0", // Special value signaling there is no ceiling
+		GRPCReflection:  true,
 		Network: &Network{
 			NoDiscover:       defaultNetworkConfig.NoDiscover,
 			MaxPeers:         defaultNetworkConfig.MaxPeers,
 			MaxOutboundPeers: defaultNetworkConfig.MaxOutboundPeers,
 			MaxInboundPeers:  defaultNetworkConfig.MaxInboundPeers,
+			ScoreThreshold:   defaultNetworkConfig.ScoreThreshold,
+			BanDuration:      uint64(defaultNetworkConfig.BanDuration.Seconds()),
 		},
 		Telemetry:  &Telemetry{},
 		ShouldSeal: false,
 		TxPool: &TxPool{
-			PriceLimit: 0,
-			MaxSlots:   4096,
+			PriceLimit:        0,
+			MaxSlots:          4096,
+			MaxAccountSlots:   16,
+			PriceBump:         10,
+			Lifetime:          3 * 60 * 60,
+			GossipBatch:       100,
+			Journal:           false,
+			JournalRemotes:    false,
+			Rejournal:         60 * 60,
+			GasLimitMargin:    0,
+			RejectUnprotected: false,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -85,6 +168,25 @@ func DefaultConfig() *Config {
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
+		TxRateLimit: &TxRateLimit{
+			Rate:  0,
+			Burst: 0,
+		},
+		GasOracle: &GasOracle{
+			Blocks:     20,
+			Percentile: 60,
+			MinPrice:   1000000000,   // 1 gwei
+			MaxPrice:   500000000000, // 500 gwei
+		},
+		WSMaxConnections:    defaultWSMaxConnections,
+		RPCGasCap:           defaultRPCGasCap,
+		RPCMaxRequestBytes:  defaultRPCMaxRequestBytes,
+		RPCExecutionTimeout: defaultRPCExecutionTimeout,
+		RPCShutdownTimeout:  defaultRPCShutdownTimeout,
+		RPCNamespaces:       jsonrpc.DefaultNamespaces(),
+		HealthMaxBlockAge:   defaultHealthMaxBlockAge,
+		IBFTBaseTimeout:     uint64(ibft.DefaultBaseRoundTimeout.Seconds()),
+		IBFTTimeoutBackoff:  uint64(ibft.DefaultRoundTimeoutBackoff.Seconds()),
 	}
 }
 
@@ -110,7 +212,10 @@ func readConfigFile(path string) (*Config, error) {
 	}
 
 	config := DefaultConfig()
-	config.Network = new(Network)
+	config.Network = &Network{
+		ScoreThreshold: config.Network.ScoreThreshold,
+		BanDuration:    config.Network.BanDuration,
+	}
 	config.Network.MaxPeers = -1
 	config.Network.MaxInboundPeers = -1
 	config.Network.MaxOutboundPeers = -1
@@ -55,6 +55,12 @@ func (p *serverParams) initBlockGasTarget() error {
 		return parseErr
 	}
 
+	if p.blockGasCeiling, parseErr = types.ParseUint64orHex(
+		&p.rawConfig.BlockGasCeiling,
+	); parseErr != nil {
+		return parseErr
+	}
+
 	return nil
 }
 
@@ -83,16 +89,33 @@ func (p *serverParams) initGenesisConfig() error {
 		return parseErr
 	}
 
+	// The gas limit settings of the node take precedence over the genesis ones
+	if p.blockGasTarget != 0 {
+		p.genesisConfig.Params.BlockGasTarget = p.blockGasTarget
+	}
+
+	if p.blockGasCeiling != 0 {
+		p.genesisConfig.Params.BlockGasCeiling = p.blockGasCeiling
+	}
+
 	return nil
 }
 
 func (p *serverParams) initDevMode() {
 	// Dev mode:
 	// - disables peer discovery
-	// - enables all forks
+	// - enables all forks, London only if the chain does
 	p.rawConfig.ShouldSeal = true
 	p.rawConfig.Network.NoDiscover = true
-	p.genesisConfig.Params.Forks = chain.AllForksEnabled
+
+	forks := *chain.AllForksEnabled
+	forks.London = nil
+
+	if p.genesisConfig.Params.Forks != nil {
+		forks.London = p.genesisConfig.Params.Forks.London
+	}
+
+	p.genesisConfig.Params.Forks = &forks
 
 	p.initDevConsensusConfig()
 }
@@ -104,7 +127,9 @@ func (p *serverParams) initDevConsensusConfig() {
 
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
-			"interval": p.devInterval,
+			"interval":  p.devInterval,
+			"mode":      p.devMode,
+			"skipEmpty": p.devSkipEmpty,
 		},
 	}
 }
@@ -184,6 +209,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initWSAddress(); err != nil {
+		return err
+	}
+
 	return p.initGRPCAddress()
 }
 
@@ -260,6 +289,23 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initWSAddress() error {
+	if !p.isWSAddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.wsAddress, parseErr = helper.ResolveAddr(
+		p.rawConfig.WSAddr,
+		helper.AllInterfacesBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
@@ -3,12 +3,14 @@ package server
 import (
 	"errors"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 	"net"
+	"time"
 )
 
 const (
@@ -23,15 +25,55 @@ const (
 	maxPeersFlag          = "max-peers"
 	maxInboundPeersFlag   = "max-inbound-peers"
 	maxOutboundPeersFlag  = "max-outbound-peers"
+	scoreThresholdFlag    = "peer-score-threshold"
+	banDurationFlag       = "peer-ban-duration"
+	trustedPeersFlag      = "trusted-peers"
+	gossipBandwidthFlag   = "gossip-bandwidth"
+	dnsDiscoveryFlag      = "dns-discovery-url"
 	priceLimitFlag        = "price-limit"
 	maxSlotsFlag          = "max-slots"
+	maxAccountSlotsFlag   = "max-account-slots"
+	priceBumpFlag         = "price-bump"
+	txLifetimeFlag        = "tx-lifetime"
+	gossipBatchFlag       = "tx-gossip-batch"
+	txJournalFlag         = "tx-journal"
+	txJournalRemotesFlag  = "tx-journal-remotes"
+	txRejournalFlag       = "tx-rejournal"
+	txGasLimitMarginFlag  = "tx-gas-limit-margin"
+	rejectUnprotectedFlag = "reject-unprotected-txs"
 	blockGasTargetFlag    = "block-gas-target"
+	blockGasCeilingFlag   = "block-gas-ceiling"
 	secretsConfigFlag     = "secrets-config"
 	restoreFlag           = "restore"
+	importSnapshotFlag    = "import-snapshot"
 	blockTimeFlag         = "block-time"
+	ibftBaseTimeoutFlag   = "ibft-base-timeout"
+	ibftBackoffFlag       = "ibft-timeout-backoff"
 	devIntervalFlag       = "dev-interval"
+	devModeFlag           = "dev-mode"
+	devSkipEmptyFlag      = "dev-skip-empty"
 	devFlag               = "dev"
 	corsOriginFlag        = "access-control-allow-origins"
+	txRateLimitFlag       = "json-rpc-tx-rate-limit"
+	txRateBurstFlag       = "json-rpc-tx-rate-burst"
+	rpcGasCapFlag         = "json-rpc-gas-cap"
+	rpcMaxRequestFlag     = "json-rpc-max-request-bytes"
+	rpcTimeoutFlag        = "json-rpc-execution-timeout"
+	rpcShutdownFlag       = "json-rpc-shutdown-timeout"
+	rpcNamespacesFlag     = "json-rpc-namespaces"
+	healthMaxBlockAgeFlag = "health-max-block-age"
+	wsAddressFlag         = "json-rpc-ws"
+	wsMaxConnectionsFlag  = "json-rpc-ws-max-connections"
+	gasPriceBlocksFlag    = "gas-price-blocks"
+	gasPricePercentFlag   = "gas-price-percentile"
+	gasPriceMinFlag       = "gas-price-min"
+	gasPriceMaxFlag       = "gas-price-max"
+	retainBlocksFlag      = "retain-blocks"
+	retainReceiptsFlag    = "retain-receipts"
+	maxReorgDepthFlag     = "max-reorg-depth"
+	parallelExecFlag      = "parallel-execution-workers"
+	grpcReflectionFlag    = "grpc-reflection"
+	jsonLogFormatFlag     = "json-log-format"
 )
 
 const (
@@ -41,9 +83,11 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &Config{
-			Telemetry: &Telemetry{},
-			Network:   &Network{},
-			TxPool:    &TxPool{},
+			Telemetry:   &Telemetry{},
+			Network:     &Network{},
+			TxPool:      &TxPool{},
+			TxRateLimit: &TxRateLimit{},
+			GasOracle:   &GasOracle{},
 		},
 	}
 )
@@ -63,10 +107,14 @@ type serverParams struct {
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	wsAddress         *net.TCPAddr
 
-	blockGasTarget uint64
-	devInterval    uint64
-	isDevMode      bool
+	blockGasTarget  uint64
+	blockGasCeiling uint64
+	devInterval     uint64
+	devMode         string
+	devSkipEmpty    bool
+	isDevMode       bool
 
 	corsAllowedOrigins []string
 
@@ -100,6 +148,10 @@ func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
 
+func (p *serverParams) isWSAddressSet() bool {
+	return p.rawConfig.WSAddr != ""
+}
+
 func (p *serverParams) isNATAddressSet() bool {
 	return p.rawConfig.Network.NatAddr != ""
 }
@@ -133,10 +185,27 @@ func (p *serverParams) generateConfig() *server.Config {
 		Chain: p.genesisConfig,
 		JSONRPC: &server.JSONRPC{
 			JSONRPCAddr:              p.jsonRPCAddress,
+			WSAddr:                   p.wsAddress,
+			WSMaxConnections:         p.rawConfig.WSMaxConnections,
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
+			TxRateLimit:              p.rawConfig.TxRateLimit.Rate,
+			TxRateBurst:              p.rawConfig.TxRateLimit.Burst,
+			GasCap:                   p.rawConfig.RPCGasCap,
+			MaxRequestBytes:          p.rawConfig.RPCMaxRequestBytes,
+			ExecutionTimeout:         time.Duration(p.rawConfig.RPCExecutionTimeout) * time.Millisecond,
+			ShutdownTimeout:          time.Duration(p.rawConfig.RPCShutdownTimeout) * time.Millisecond,
+			Namespaces:               p.rawConfig.RPCNamespaces,
+			HealthMaxBlockAge:        time.Duration(p.rawConfig.HealthMaxBlockAge) * time.Second,
+			GasPriceOracle: &jsonrpc.GasPriceOracleConfig{
+				Blocks:     p.rawConfig.GasOracle.Blocks,
+				Percentile: p.rawConfig.GasOracle.Percentile,
+				MinPrice:   p.rawConfig.GasOracle.MinPrice,
+				MaxPrice:   p.rawConfig.GasOracle.MaxPrice,
+			},
 		},
-		GRPCAddr:   p.grpcAddress,
-		LibP2PAddr: p.libp2pAddress,
+		GRPCAddr:       p.grpcAddress,
+		GRPCReflection: p.rawConfig.GRPCReflection,
+		LibP2PAddr:     p.libp2pAddress,
 		Telemetry: &server.Telemetry{
 			PrometheusAddr: p.prometheusAddress,
 		},
@@ -149,15 +218,37 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxPeers:         p.rawConfig.Network.MaxPeers,
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
+			ScoreThreshold:   p.rawConfig.Network.ScoreThreshold,
+			BanDuration:      time.Duration(p.rawConfig.Network.BanDuration) * time.Second,
+			TrustedPeers:     p.rawConfig.Network.TrustedPeers,
+			GossipBandwidth:  p.rawConfig.Network.GossipBandwidth,
+			DNSDiscoveryURL:  p.rawConfig.Network.DNSDiscovery,
 			Chain:            p.genesisConfig,
 		},
-		DataDir:        p.rawConfig.DataDir,
-		Seal:           p.rawConfig.ShouldSeal,
-		PriceLimit:     p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:       p.rawConfig.TxPool.MaxSlots,
-		SecretsManager: p.secretsConfig,
-		RestoreFile:    p.getRestoreFilePath(),
-		BlockTime:      p.rawConfig.BlockTime,
-		LogLevel:       hclog.LevelFromString(p.rawConfig.LogLevel),
+		DataDir:             p.rawConfig.DataDir,
+		Seal:                p.rawConfig.ShouldSeal,
+		PriceLimit:          p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:            p.rawConfig.TxPool.MaxSlots,
+		MaxAccountSlots:     p.rawConfig.TxPool.MaxAccountSlots,
+		PriceBump:           p.rawConfig.TxPool.PriceBump,
+		TxLifetime:          p.rawConfig.TxPool.Lifetime,
+		TxGossipBatch:       p.rawConfig.TxPool.GossipBatch,
+		TxJournal:           p.rawConfig.TxPool.Journal,
+		TxJournalRemote:     p.rawConfig.TxPool.JournalRemotes,
+		TxRejournal:         p.rawConfig.TxPool.Rejournal,
+		TxGasMargin:         p.rawConfig.TxPool.GasLimitMargin,
+		TxRejectUnprotected: p.rawConfig.TxPool.RejectUnprotected,
+		SecretsManager:      p.secretsConfig,
+		RestoreFile:         p.getRestoreFilePath(),
+		SnapshotFile:        p.rawConfig.SnapshotFile,
+		BlockTime:           p.rawConfig.BlockTime,
+		RoundTimeout:        p.rawConfig.IBFTBaseTimeout,
+		RoundBackoff:        p.rawConfig.IBFTTimeoutBackoff,
+		RetainBlocks:        p.rawConfig.RetainBlocks,
+		RetainReceipts:      p.rawConfig.RetainReceipts,
+		MaxReorgDepth:       p.rawConfig.MaxReorgDepth,
+		ParallelWorkers:     p.rawConfig.ParallelExecWorkers,
+		LogLevel:            hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:       p.rawConfig.JSONLogFormat,
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/dev"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/server"
 )
@@ -39,6 +40,13 @@ func setFlags(cmd *cobra.Command) {
 		"the log level for console output",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONLogFormat,
+		jsonLogFormatFlag,
+		defaultConfig.JSONLogFormat,
+		"format the console output as JSON, one object per log line",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.GenesisPath,
 		genesisPathFlag,
@@ -75,6 +83,28 @@ func setFlags(cmd *cobra.Command) {
 			"If only port is defined (:port) it will bind to 0.0.0.0:port",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.WSAddr,
+		wsAddressFlag,
+		"",
+		"the address and port of the JSON-RPC WS listener (address:port), "+
+			"the WS connections are also served on the /ws path of the JSON-RPC address",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WSMaxConnections,
+		wsMaxConnectionsFlag,
+		defaultConfig.WSMaxConnections,
+		"the maximum number of open JSON-RPC WS connections (0 disables the limit)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.GRPCReflection,
+		grpcReflectionFlag,
+		defaultConfig.GRPCReflection,
+		"register the gRPC reflection service, allowing tools like grpcurl to discover the operator API",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.NatAddr,
 		natFlag,
@@ -96,6 +126,13 @@ func setFlags(cmd *cobra.Command) {
 		"the target block gas limit for the chain. If omitted, the value of the parent block is used",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.BlockGasCeiling,
+		blockGasCeilingFlag,
+		strconv.FormatUint(0, 10),
+		"the maximum gas limit of the produced blocks. If omitted, there is no ceiling",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.SecretsConfigPath,
 		secretsConfigFlag,
@@ -111,6 +148,14 @@ func setFlags(cmd *cobra.Command) {
 		"the path to the archive blockchain data to restore on initialization",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.SnapshotFile,
+		importSnapshotFlag,
+		"",
+		"the path to the state snapshot to import into an empty chain on initialization, "+
+			"the chain then syncs from the snapshot block",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.ShouldSeal,
 		sealFlag,
@@ -152,6 +197,42 @@ func setFlags(cmd *cobra.Command) {
 	// override default usage value
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 
+	cmd.Flags().Int64Var(
+		&params.rawConfig.Network.ScoreThreshold,
+		scoreThresholdFlag,
+		defaultConfig.Network.ScoreThreshold,
+		"the score below which a misbehaving peer is disconnected",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Network.BanDuration,
+		banDurationFlag,
+		defaultConfig.Network.BanDuration,
+		"the duration in seconds a disconnected misbehaving peer is banned for (0 disables the bans)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.TrustedPeers,
+		trustedPeersFlag,
+		defaultConfig.Network.TrustedPeers,
+		"the multiaddrs of the peers always kept connected, regardless of the peer limits",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Network.GossipBandwidth,
+		gossipBandwidthFlag,
+		defaultConfig.Network.GossipBandwidth,
+		"the outbound bandwidth cap in bytes per second for the gossip originated by the node, "+
+			"the gossip relayed for other peers is not capped, consensus messages are sent first (0 disables the cap)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.DNSDiscovery,
+		dnsDiscoveryFlag,
+		"",
+		"the enrtree:// URL of the DNS tree (EIP-1459) the peers are discovered from",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -169,6 +250,70 @@ func setFlags(cmd *cobra.Command) {
 		"maximum slots in the pool",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxAccountSlots,
+		maxAccountSlotsFlag,
+		command.DefaultMaxAccountSlots,
+		"maximum number of enqueued (future nonce) transactions per account",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		command.DefaultPriceBump,
+		"minimum gas price increase (in percent) for replacing a transaction with the same nonce",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.Lifetime,
+		txLifetimeFlag,
+		command.DefaultTxLifetime,
+		"maximum time in seconds an enqueued (future nonce) transaction can stay in the pool (0 disables eviction)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.GossipBatch,
+		gossipBatchFlag,
+		command.DefaultGossipBatch,
+		"time window in milliseconds for coalescing gossiped transactions into one message (0 disables batching)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.Journal,
+		txJournalFlag,
+		false,
+		"persist the transactions of local accounts to the data directory, reloading them on restart",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.JournalRemotes,
+		txJournalRemotesFlag,
+		false,
+		"persist the transactions of all the accounts in the journal, not only the local ones",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.Rejournal,
+		txRejournalFlag,
+		command.DefaultRejournal,
+		"interval in seconds of regenerating the transaction journal (0 only writes it on shutdown)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.GasLimitMargin,
+		txGasLimitMarginFlag,
+		defaultConfig.TxPool.GasLimitMargin,
+		"share (in percent) of the block gas limit a single transaction can't claim, "+
+			"transactions above the remaining limit are rejected",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.RejectUnprotected,
+		rejectUnprotectedFlag,
+		defaultConfig.TxPool.RejectUnprotected,
+		"reject legacy transactions without replay protection (pre EIP-155) from the pool",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -176,6 +321,20 @@ func setFlags(cmd *cobra.Command) {
 		"minimum block time in seconds",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.IBFTBaseTimeout,
+		ibftBaseTimeoutFlag,
+		defaultConfig.IBFTBaseTimeout,
+		"timeout in seconds of the first IBFT round of a block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.IBFTTimeoutBackoff,
+		ibftBackoffFlag,
+		defaultConfig.IBFTTimeoutBackoff,
+		"unit in seconds of the exponential backoff added to the timeout of the following IBFT rounds",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.corsAllowedOrigins,
 		corsOriginFlag,
@@ -183,6 +342,124 @@ func setFlags(cmd *cobra.Command) {
 		"the CORS header indicating whether any JSON-RPC response can be shared with the specified origin",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxRateLimit.Rate,
+		txRateLimitFlag,
+		defaultConfig.TxRateLimit.Rate,
+		"the number of transactions per second a sender can submit through eth_sendRawTransaction (0 disables the limit)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxRateLimit.Burst,
+		txRateBurstFlag,
+		defaultConfig.TxRateLimit.Burst,
+		"the number of transactions a sender can submit at once through eth_sendRawTransaction (defaults to the rate)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.RPCGasCap,
+		rpcGasCapFlag,
+		defaultConfig.RPCGasCap,
+		"the maximum gas of a single eth_call or eth_estimateGas execution (0 disables the cap)",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.rawConfig.RPCMaxRequestBytes,
+		rpcMaxRequestFlag,
+		defaultConfig.RPCMaxRequestBytes,
+		"the maximum size in bytes of a JSON-RPC request body (0 disables the limit)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.RPCExecutionTimeout,
+		rpcTimeoutFlag,
+		defaultConfig.RPCExecutionTimeout,
+		"the maximum duration in milliseconds of a JSON-RPC request (0 disables the timeout)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.RPCShutdownTimeout,
+		rpcShutdownFlag,
+		defaultConfig.RPCShutdownTimeout,
+		"the maximum duration in milliseconds the in-flight JSON-RPC requests are given to complete on shutdown, "+
+			"after which they are cancelled",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasOracle.Blocks,
+		gasPriceBlocksFlag,
+		defaultConfig.GasOracle.Blocks,
+		"the number of latest blocks sampled to suggest the gas price of eth_gasPrice",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasOracle.Percentile,
+		gasPricePercentFlag,
+		defaultConfig.GasOracle.Percentile,
+		"the percentile (0 to 100) of the lowest gas prices of the sampled blocks suggested by eth_gasPrice",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasOracle.MinPrice,
+		gasPriceMinFlag,
+		defaultConfig.GasOracle.MinPrice,
+		"the lowest gas price suggested by eth_gasPrice, also suggested when the sampled blocks are empty",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasOracle.MaxPrice,
+		gasPriceMaxFlag,
+		defaultConfig.GasOracle.MaxPrice,
+		"the highest gas price suggested by eth_gasPrice (0 disables the ceiling)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.RPCNamespaces,
+		rpcNamespacesFlag,
+		defaultConfig.RPCNamespaces,
+		"the namespaces of the served JSON-RPC methods (eth, net, web3, txpool, debug, admin). "+
+			"The debug and admin methods are only served if listed",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HealthMaxBlockAge,
+		healthMaxBlockAgeFlag,
+		defaultConfig.HealthMaxBlockAge,
+		"the maximum age in seconds of the latest block for the /health endpoint to report the node as ready "+
+			"(0 disables the check)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.RetainBlocks,
+		retainBlocksFlag,
+		defaultConfig.RetainBlocks,
+		"the number of latest blocks whose state is kept, older state is pruned (0 keeps the state of all the blocks)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.RetainReceipts,
+		retainReceiptsFlag,
+		defaultConfig.RetainReceipts,
+		"the number of latest blocks whose receipts and logs are kept, older ones are pruned "+
+			"independently of the state (0 keeps the receipts of all the blocks)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.MaxReorgDepth,
+		maxReorgDepthFlag,
+		defaultConfig.MaxReorgDepth,
+		"the maximum number of blocks a reorg can revert, deeper reorgs are rejected (0 allows any depth, "+
+			"1 suits the consensuses with instant finality)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ParallelExecWorkers,
+		parallelExecFlag,
+		defaultConfig.ParallelExecWorkers,
+		"the number of workers executing the transactions of a block optimistically in parallel, "+
+			"the conflicting transactions are executed again serially (0 or 1 executes every transaction serially)",
+	)
+
 	setDevFlags(cmd)
 }
 
@@ -204,6 +481,25 @@ func setDevFlags(cmd *cobra.Command) {
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	cmd.Flags().StringVar(
+		&params.devMode,
+		devModeFlag,
+		string(dev.ModeInterval),
+		"the client's dev block production mode: interval, on-demand (seals the new transactions) "+
+			"or hybrid (seals the new transactions, or an empty block once the interval passes)",
+	)
+
+	_ = cmd.Flags().MarkHidden(devModeFlag)
+
+	cmd.Flags().BoolVar(
+		&params.devSkipEmpty,
+		devSkipEmptyFlag,
+		false,
+		"should the client skip sealing empty blocks once the dev interval passes (default false)",
+	)
+
+	_ = cmd.Flags().MarkHidden(devSkipEmptyFlag)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -0,0 +1,89 @@
+package snapshot
+
+import (
+	"errors"
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	outFlag    = "out"
+	heightFlag = "height"
+)
+
+var (
+	params = &snapshotParams{}
+)
+
+var (
+	errDecodeHeight = errors.New("unable to decode height value")
+)
+
+type snapshotParams struct {
+	out string
+
+	heightRaw string
+	height    uint64
+
+	resNumber uint64
+	resHash   types.Hash
+}
+
+func (p *snapshotParams) validateFlags() error {
+	if p.heightRaw == "" {
+		return nil
+	}
+
+	height, err := types.ParseUint64orHex(&p.heightRaw)
+	if err != nil {
+		return errDecodeHeight
+	}
+
+	p.height = height
+
+	return nil
+}
+
+func (p *snapshotParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *snapshotParams) createSnapshot(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	resNumber, resHash, err := archive.CreateSnapshot(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "snapshot",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		p.height,
+		p.out,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.resNumber = resNumber
+	p.resHash = resHash
+
+	return nil
+}
+
+func (p *snapshotParams) getResult() command.CommandResult {
+	return &SnapshotResult{
+		Number: p.resNumber,
+		Hash:   p.resHash.String(),
+		Out:    p.out,
+	}
+}
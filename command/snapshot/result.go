@@ -0,0 +1,27 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SnapshotResult struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+	Out    string `json:"out"`
+}
+
+func (r *SnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SNAPSHOT]\n")
+	buffer.WriteString("Exported state snapshot file successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Number|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+	}))
+
+	return buffer.String()
+}
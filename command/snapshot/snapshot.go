@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use: "snapshot",
+		Short: "Create state snapshot file of a block by fetching the state from the running node. " +
+			"With IBFT, the block has to be the first block of an epoch",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(snapshotCmd)
+
+	setFlags(snapshotCmd)
+	setRequiredFlags(snapshotCmd)
+
+	return snapshotCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the export path for the snapshot",
+	)
+
+	cmd.Flags().StringVar(
+		&params.heightRaw,
+		heightFlag,
+		"",
+		"the height of the block in snapshot (defaults to the latest block)",
+	)
+}
+
+func setRequiredFlags(cmd *cobra.Command) {
+	for _, requiredFlag := range params.getRequiredFlags() {
+		_ = cmd.MarkFlagRequired(requiredFlag)
+	}
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.createSnapshot(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -8,6 +8,9 @@ import (
 
 type TxPoolStatusResult struct {
 	Transactions uint64 `json:"transactions"`
+	Pending      uint64 `json:"pending"`
+	Queued       uint64 `json:"queued"`
+	Accounts     uint64 `json:"accounts"`
 }
 
 func (r *TxPoolStatusResult) GetOutput() string {
@@ -16,6 +19,9 @@ func (r *TxPoolStatusResult) GetOutput() string {
 	buffer.WriteString("\n[TXPOOL STATUS]\n")
 	buffer.WriteString(helper.FormatKV([]string{
 		fmt.Sprintf("Number of transactions in pool:|%d", r.Transactions),
+		fmt.Sprintf("Pending transactions:|%d", r.Pending),
+		fmt.Sprintf("Queued transactions:|%d", r.Queued),
+		fmt.Sprintf("Sender accounts:|%d", r.Accounts),
 	}))
 	buffer.WriteString("\n")
 
@@ -31,6 +31,9 @@ func runCommand(cmd *cobra.Command, _ []string) {
 
 	outputter.SetCommandResult(&TxPoolStatusResult{
 		Transactions: statusResponse.Length,
+		Pending:      statusResponse.Pending,
+		Queued:       statusResponse.Queued,
+		Accounts:     statusResponse.Accounts,
 	})
 }
 
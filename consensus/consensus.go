@@ -72,6 +72,11 @@ type ConsensusParams struct {
 	Metrics        *Metrics
 	SecretsManager secrets.SecretsManager
 	BlockTime      uint64
+
+	// BaseRoundTimeout is the timeout of the first IBFT round in seconds
+	BaseRoundTimeout uint64
+	// RoundTimeoutBackoff is the unit in seconds of the exponential backoff of the following IBFT rounds
+	RoundTimeoutBackoff uint64
 }
 
 // Factory is the factory function to create a discovery backend
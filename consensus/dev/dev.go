@@ -10,10 +10,27 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool"
+	txpoolProto "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 )
 
+// Mode is the trigger of the dev block production
+type Mode string
+
+const (
+	// ModeInterval seals a block on every interval, even if the pool is empty
+	// (unless empty blocks are skipped)
+	ModeInterval Mode = "interval"
+
+	// ModeOnDemand seals a block as soon as a transaction enters the pool, and idles otherwise
+	ModeOnDemand Mode = "on-demand"
+
+	// ModeHybrid seals a block as soon as a transaction enters the pool,
+	// or once the interval passes without any
+	ModeHybrid Mode = "hybrid"
+)
+
 // Dev consensus protocol seals any new transaction immediately
 type Dev struct {
 	logger hclog.Logger
@@ -21,11 +38,15 @@ type Dev struct {
 	notifyCh chan struct{}
 	closeCh  chan struct{}
 
-	interval uint64
-	txpool   *txpool.TxPool
+	mode      Mode
+	interval  uint64
+	skipEmpty bool // don't seal empty blocks once the interval passes
+	txpool    *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	metrics *consensus.Metrics
 }
 
 // Factory implements the base factory method
@@ -36,11 +57,13 @@ func Factory(
 
 	d := &Dev{
 		logger:     logger,
-		notifyCh:   make(chan struct{}),
+		notifyCh:   make(chan struct{}, 1),
 		closeCh:    make(chan struct{}),
+		mode:       ModeInterval,
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.Txpool,
+		metrics:    params.Metrics,
 	}
 
 	rawInterval, ok := params.Config.Config["interval"]
@@ -53,6 +76,36 @@ func Factory(
 		d.interval = interval
 	}
 
+	rawMode, ok := params.Config.Config["mode"]
+	if ok {
+		mode, ok := rawMode.(string)
+		if !ok {
+			return nil, fmt.Errorf("mode expected string")
+		}
+
+		switch Mode(mode) {
+		case "":
+		case ModeInterval, ModeOnDemand, ModeHybrid:
+			d.mode = Mode(mode)
+		default:
+			return nil, fmt.Errorf("unknown dev mode %s", mode)
+		}
+	}
+
+	rawSkipEmpty, ok := params.Config.Config["skipEmpty"]
+	if ok {
+		skipEmpty, ok := rawSkipEmpty.(bool)
+		if !ok {
+			return nil, fmt.Errorf("skipEmpty expected bool")
+		}
+
+		d.skipEmpty = skipEmpty
+	}
+
+	if d.interval == 0 {
+		d.interval = 1
+	}
+
 	return d, nil
 }
 
@@ -63,39 +116,116 @@ func (d *Dev) Initialize() error {
 
 // Start starts the consensus mechanism
 func (d *Dev) Start() error {
+	if d.mode != ModeInterval {
+		d.watchPromotions()
+	}
+
 	go d.run()
 
 	return nil
 }
 
-func (d *Dev) nextNotify() chan struct{} {
-	if d.interval == 0 {
-		d.interval = 1
-	}
+// watchPromotions notifies the sealing loop whenever a transaction becomes executable.
+// The subscription is renewed if the pool closes it, until the consensus is closed
+func (d *Dev) watchPromotions() {
+	eventCh, cancel := d.txpool.SubscribePoolEvents(txpoolProto.EventType_PROMOTED)
 
 	go func() {
-		<-time.After(time.Duration(d.interval) * time.Second)
-		d.notifyCh <- struct{}{}
+		for d.forwardPromotions(eventCh, cancel) {
+			d.logger.Warn("promotion subscription closed, subscribing again")
+
+			eventCh, cancel = d.txpool.SubscribePoolEvents(txpoolProto.EventType_PROMOTED)
+
+			// the promotions missed in the meantime are sealed right away
+			d.notify()
+		}
 	}()
+}
 
-	return d.notifyCh
+// forwardPromotions notifies the sealing loop of the promotions received on the channel.
+// It returns true if the channel is closed, and false once the consensus is closed
+func (d *Dev) forwardPromotions(eventCh <-chan *txpoolProto.TxPoolEvent, cancel func()) bool {
+	defer cancel()
+
+	for {
+		select {
+		case _, ok := <-eventCh:
+			if !ok {
+				return true
+			}
+
+			d.notify()
+		case <-d.closeCh:
+			return false
+		}
+	}
+}
+
+// notify wakes up the sealing loop, unless a wake up is already pending
+func (d *Dev) notify() {
+	select {
+	case d.notifyCh <- struct{}{}:
+	default:
+	}
 }
 
 func (d *Dev) run() {
-	d.logger.Info("consensus started")
+	d.logger.Info("consensus started", "mode", d.mode, "skip empty", d.skipEmpty)
+
+	interval := time.Duration(d.interval) * time.Second
 
 	for {
-		// wait until there is a new txn
+		// the on-demand mode only waits for new transactions,
+		// the interval mode only for the interval to pass
+		var (
+			notifyCh <-chan struct{}
+			timerCh  <-chan time.Time
+			timer    *time.Timer
+		)
+
+		if d.mode != ModeInterval {
+			notifyCh = d.notifyCh
+		}
+
+		if d.mode != ModeOnDemand {
+			timer = time.NewTimer(interval)
+			timerCh = timer.C
+		}
+
+		notified := false
+
 		select {
-		case <-d.nextNotify():
+		case <-notifyCh:
+			notified = true
+		case <-timerCh:
 		case <-d.closeCh:
 			return
 		}
 
+		if timer != nil {
+			timer.Stop()
+		}
+
+		// the notified transactions might have been sealed in the previous block already,
+		// and the node idles when the interval passes with nothing to seal if it skips empty blocks
+		if (notified || d.skipEmpty) && d.txpool.Length() == 0 {
+			continue
+		}
+
 		// There are new transactions in the pool, try to seal them
 		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
+
+		block, err := d.writeNewBlock(header)
+		if err != nil {
 			d.logger.Error("failed to mine block", "err", err)
+
+			continue
+		}
+
+		// the transactions which didn't fit in the block don't
+		// get promoted again, seal them without waiting for new ones
+		if d.mode != ModeInterval && len(block.Transactions) > 0 && d.txpool.Length() > 0 {
+			d.notify()
 		}
 	}
 }
@@ -105,7 +235,15 @@ type transitionInterface interface {
 }
 
 func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
-	var successful []*types.Transaction
+	var (
+		successful []*types.Transaction
+
+		// time spent executing the transactions,
+		// the rest of the loop is spent selecting them
+		executionTime time.Duration
+	)
+
+	start := time.Now()
 
 	d.txpool.Prepare()
 
@@ -121,7 +259,11 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 			continue
 		}
 
-		if err := transition.Write(tx); err != nil {
+		writeStart := time.Now()
+		err := transition.Write(tx)
+		executionTime += time.Since(writeStart)
+
+		if err != nil {
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { // nolint:errorlint
 				break
 			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { // nolint:errorlint
@@ -139,6 +281,9 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 		successful = append(successful, tx)
 	}
 
+	d.metrics.ExecutionTime.Observe(executionTime.Seconds())
+	d.metrics.TxSelectionTime.Observe((time.Since(start) - executionTime).Seconds())
+
 	d.logger.Info("picked out txns from pool", "num", len(successful), "remaining", d.txpool.Length())
 
 	return successful
@@ -146,7 +291,9 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 
 // writeNewBLock generates a new block based on transactions from the pool,
 // and writes them to the blockchain
-func (d *Dev) writeNewBlock(parent *types.Header) error {
+func (d *Dev) writeNewBlock(parent *types.Header) (*types.Block, error) {
+	start := time.Now()
+
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
@@ -159,26 +306,32 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	// calculate gas limit based on parent header
 	gasLimit, err := d.blockchain.CalculateGasLimit(header.Number)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = d.blockchain.CalcBaseFee(parent)
+
+	// the executables are ordered by their tip over the base fee of the block
+	d.txpool.SetBaseFee(header.BaseFee)
 
 	miner, err := d.GetBlockCreator(header)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	txns := d.writeTransactions(gasLimit, transition)
 
 	// Commit the changes
+	commitStart := time.Now()
 	_, root := transition.Commit()
+	d.metrics.StateRootTime.Observe(time.Since(commitStart).Seconds())
 
 	// Update the header
 	header.StateRoot = root
@@ -194,14 +347,18 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 
 	// Write the block to the blockchain
 	if err := d.blockchain.WriteBlock(block); err != nil {
-		return err
+		return nil, err
 	}
 
+	d.metrics.SealTime.Observe(time.Since(start).Seconds())
+	d.metrics.NumTxs.Set(float64(len(txns)))
+	d.metrics.BlockGasUsed.Set(float64(header.GasUsed))
+
 	// after the block has been written we reset the txpool so that
 	// the old transactions are removed
 	d.txpool.ResetWithHeaders(block.Header)
 
-	return nil
+	return block, nil
 }
 
 // REQUIRED BASE INTERFACE METHODS //
@@ -39,6 +39,11 @@ func istanbulHeaderHash(h *types.Header) types.Hash {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	// the base fee is only set from the London fork onwards
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return types.BytesToHash(buf)
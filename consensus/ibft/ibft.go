@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/consensus"
@@ -39,9 +40,11 @@ type blockchainInterface interface {
 	GetHeaderByNumber(i uint64) (*types.Header, bool)
 	WriteBlock(block *types.Block) error
 	CalculateGasLimit(number uint64) (uint64, error)
+	CalcBaseFee(parent *types.Header) uint64
 }
 
 type txPoolInterface interface {
+	SetBaseFee(baseFee uint64)
 	Prepare()
 	Length() uint64
 	Peek() *types.Transaction
@@ -101,6 +104,21 @@ type Ibft struct {
 	mechanisms []ConsensusMechanism // IBFT ConsensusMechanism used (PoA / PoS)
 
 	blockTime time.Duration // Minimum block generation time in seconds
+
+	baseRoundTimeout    time.Duration // Timeout of the first round of a sequence
+	roundTimeoutBackoff time.Duration // Unit of the exponential backoff added to the following rounds
+
+	status atomic.Value // Last published *consensusStatus, read by the operator without locking
+}
+
+// consensusStatus is a snapshot of the IBFT state, published by the consensus loop
+type consensusStatus struct {
+	sequence    uint64
+	round       uint64
+	state       IbftState
+	proposer    types.Address
+	validators  int
+	isValidator bool
 }
 
 // runHook runs a specified hook if it is present in the hook map
@@ -147,6 +165,11 @@ func Factory(
 		epochSize = uint64(readSize)
 	}
 
+	baseRoundTimeout := DefaultBaseRoundTimeout
+	if params.BaseRoundTimeout != 0 {
+		baseRoundTimeout = time.Duration(params.BaseRoundTimeout) * time.Second
+	}
+
 	p := &Ibft{
 		logger:         params.Logger.Named("ibft"),
 		config:         params.Config,
@@ -162,6 +185,9 @@ func Factory(
 		metrics:        params.Metrics,
 		secretsManager: params.SecretsManager,
 		blockTime:      time.Duration(params.BlockTime) * time.Second,
+
+		baseRoundTimeout:    baseRoundTimeout,
+		roundTimeoutBackoff: time.Duration(params.RoundTimeoutBackoff) * time.Second,
 	}
 
 	// Initialize the mechanism
@@ -301,7 +327,7 @@ func (i *Ibft) setupMechanism() error {
 // setupTransport sets up the gossip transport protocol
 func (i *Ibft) setupTransport() error {
 	// Define a new topic
-	topic, err := i.network.NewTopic(ibftProto, &proto.MessageReq{})
+	topic, err := i.network.NewPriorityTopic(ibftProto, &proto.MessageReq{})
 	if err != nil {
 		return err
 	}
@@ -553,6 +579,8 @@ func (i *Ibft) shouldWriteTransactions(height uint64) bool {
 
 // buildBlock builds the block, based on the passed in snapshot and parent header
 func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, error) {
+	start := time.Now()
+
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     parent.Number + 1,
@@ -573,6 +601,10 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = i.blockchain.CalcBaseFee(parent)
+
+	// the executables are ordered by their tip over the base fee of the block
+	i.txpool.SetBaseFee(header.BaseFee)
 
 	if hookErr := i.runHook(CandidateVoteHook, header.Number, &candidateVoteHookParams{
 		header: header,
@@ -612,7 +644,10 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 		return nil, err
 	}
 
+	commitStart := time.Now()
 	_, root := transition.Commit()
+	i.metrics.StateRootTime.Observe(time.Since(commitStart).Seconds())
+
 	header.StateRoot = root
 	header.GasUsed = transition.TotalGas()
 
@@ -635,6 +670,8 @@ func (i *Ibft) buildBlock(snap *Snapshot, parent *types.Header) (*types.Block, e
 	// is sealed after all the committed seals
 	block.Header.ComputeHash()
 
+	i.metrics.SealTime.Observe(time.Since(start).Seconds())
+
 	i.logger.Info("build block", "number", header.Number, "txns", len(txns))
 
 	return block, nil
@@ -648,7 +685,15 @@ type transitionInterface interface {
 // writeTransactions writes transactions from the txpool to the transition object
 // and returns transactions that were included in the transition (new block)
 func (i *Ibft) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
-	var transactions []*types.Transaction
+	var (
+		transactions []*types.Transaction
+
+		// time spent executing the transactions,
+		// the rest of the loop is spent selecting them
+		executionTime time.Duration
+	)
+
+	start := time.Now()
 
 	successTxCount := 0
 	failedTxCount := 0
@@ -678,7 +723,11 @@ func (i *Ibft) writeTransactions(gasLimit uint64, transition transitionInterface
 			continue
 		}
 
-		if err := transition.Write(tx); err != nil {
+		writeStart := time.Now()
+		err := transition.Write(tx)
+		executionTime += time.Since(writeStart)
+
+		if err != nil {
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { // nolint:errorlint
 				break
 			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { // nolint:errorlint
@@ -699,6 +748,9 @@ func (i *Ibft) writeTransactions(gasLimit uint64, transition transitionInterface
 		transactions = append(transactions, tx)
 	}
 
+	i.metrics.ExecutionTime.Observe(executionTime.Seconds())
+	i.metrics.TxSelectionTime.Observe((time.Since(start) - executionTime).Seconds())
+
 	//nolint:lll
 	i.logger.Info("executed txns", "failed ", failedTxCount, "successful", successTxCount, "remaining in pool", i.txpool.Length())
 
@@ -767,6 +819,8 @@ func (i *Ibft) runAcceptState() { // start new round
 		i.logger.Error(fmt.Sprintf("Unable to run hook %s, %v", CalculateProposerHook, hookErr))
 	}
 
+	i.publishStatus()
+
 	if i.state.proposer == i.validatorKeyAddr {
 		logger.Info("we are the proposer", "block", number)
 
@@ -775,7 +829,7 @@ func (i *Ibft) runAcceptState() { // start new round
 			i.state.block, err = i.buildBlock(snap, parent)
 			if err != nil {
 				i.logger.Error("failed to build block", "err", err)
-				i.setState(RoundChangeState)
+				i.handleStateErr(errFailedToBuildBlock)
 
 				return
 			}
@@ -807,7 +861,7 @@ func (i *Ibft) runAcceptState() { // start new round
 	// we are NOT a proposer for the block. Then, we have to wait
 	// for a pre-prepare message from the proposer
 
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.roundTimeout(i.state.view.Round)
 	for i.getState() == AcceptState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -830,7 +884,7 @@ func (i *Ibft) runAcceptState() { // start new round
 		block := &types.Block{}
 		if err := block.UnmarshalRLP(msg.Proposal.Value); err != nil {
 			i.logger.Error("failed to unmarshal block", "err", err)
-			i.setState(RoundChangeState)
+			i.handleStateErr(errInvalidProposal)
 
 			return
 		}
@@ -891,7 +945,7 @@ func (i *Ibft) runValidateState() {
 		}
 	}
 
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.roundTimeout(i.state.view.Round)
 	for i.getState() == ValidateState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -951,7 +1005,7 @@ func (i *Ibft) runValidateState() {
 }
 
 // updateMetrics will update various metrics based on the given block
-// currently we capture No.of Txs and block interval metrics using this function
+// currently we capture No.of Txs, gas used and block interval metrics using this function
 func (i *Ibft) updateMetrics(block *types.Block) {
 	// get previous header
 	prvHeader, _ := i.blockchain.GetHeaderByNumber(block.Number() - 1)
@@ -967,6 +1021,9 @@ func (i *Ibft) updateMetrics(block *types.Block) {
 
 	//Update the Number of transactions in the block metric
 	i.metrics.NumTxs.Set(float64(len(block.Body().Transactions)))
+
+	//Update the gas used by the block metric
+	i.metrics.BlockGasUsed.Set(float64(block.Header.GasUsed))
 }
 func (i *Ibft) insertBlock(block *types.Block) error {
 	committedSeals := [][]byte{}
@@ -1021,16 +1078,69 @@ var (
 	errIncorrectBlockLocked    = fmt.Errorf("block locked is incorrect")
 	errBlockVerificationFailed = fmt.Errorf("block verification failed")
 	errFailedToInsertBlock     = fmt.Errorf("failed to insert block")
+	errFailedToBuildBlock      = fmt.Errorf("failed to build block")
+	errInvalidProposal         = fmt.Errorf("invalid proposal")
+)
+
+// roundChangeReason describes why the node moved to a new round
+type roundChangeReason string
+
+const (
+	reasonTimeout            roundChangeReason = "timeout"
+	reasonRoundCatchUp       roundChangeReason = "round_catch_up"
+	reasonRoundChangeQuorum  roundChangeReason = "round_change_quorum"
+	reasonIncorrectLocked    roundChangeReason = "incorrect_locked_block"
+	reasonVerificationFailed roundChangeReason = "block_verification_failed"
+	reasonInsertionFailed    roundChangeReason = "block_insertion_failed"
+	reasonBuildFailed        roundChangeReason = "block_build_failed"
+	reasonInvalidProposal    roundChangeReason = "invalid_proposal"
+	reasonStateError         roundChangeReason = "state_error"
 )
 
+// stateErrReason returns the round change reason of a state error
+func stateErrReason(err error) roundChangeReason {
+	switch {
+	case errors.Is(err, errIncorrectBlockLocked):
+		return reasonIncorrectLocked
+	case errors.Is(err, errBlockVerificationFailed):
+		return reasonVerificationFailed
+	case errors.Is(err, errFailedToInsertBlock):
+		return reasonInsertionFailed
+	case errors.Is(err, errFailedToBuildBlock):
+		return reasonBuildFailed
+	case errors.Is(err, errInvalidProposal):
+		return reasonInvalidProposal
+	default:
+		return reasonStateError
+	}
+}
+
+// roundTimeout returns the timeout of the given round
+func (i *Ibft) roundTimeout(round uint64) time.Duration {
+	return exponentialTimeout(i.baseRoundTimeout, i.roundTimeoutBackoff, round)
+}
+
+// reportRoundChange logs and records a local round change along with its reason
+func (i *Ibft) reportRoundChange(round uint64, reason roundChangeReason) {
+	i.logger.Info(
+		"round change",
+		"sequence", i.state.view.Sequence,
+		"round", round,
+		"reason", reason,
+		"timeout", i.roundTimeout(round),
+	)
+
+	i.metrics.RoundChanges.With("reason", string(reason)).Add(1)
+}
+
 func (i *Ibft) handleStateErr(err error) {
 	i.state.err = err
 	i.setState(RoundChangeState)
 }
 
 func (i *Ibft) runRoundChangeState() {
-	sendRoundChange := func(round uint64) {
-		i.logger.Debug("local round change", "round", round+1)
+	sendRoundChange := func(round uint64, reason roundChangeReason) {
+		i.reportRoundChange(round, reason)
 		// set the new round and update the round metric
 		i.state.view.Round = round
 		i.metrics.Rounds.Set(float64(round))
@@ -1039,8 +1149,8 @@ func (i *Ibft) runRoundChangeState() {
 		// send the round change message
 		i.sendRoundChange()
 	}
-	sendNextRoundChange := func() {
-		sendRoundChange(i.state.view.Round + 1)
+	sendNextRoundChange := func(reason roundChangeReason) {
+		sendRoundChange(i.state.view.Round+1, reason)
 	}
 
 	checkTimeout := func() {
@@ -1061,20 +1171,20 @@ func (i *Ibft) runRoundChangeState() {
 
 		// otherwise, it seems that we are in sync
 		// and we should start a new round
-		sendNextRoundChange()
+		sendNextRoundChange(reasonTimeout)
 	}
 
 	// if the round was triggered due to an error, we send our own
 	// next round change
 	if err := i.state.getErr(); err != nil {
 		i.logger.Debug("round change handle err", "err", err)
-		sendNextRoundChange()
+		sendNextRoundChange(stateErrReason(err))
 	} else {
 		// otherwise, it is due to a timeout in any stage
 		// First, we try to sync up with any max round already available
 		if maxRound, ok := i.state.maxRound(); ok {
 			i.logger.Debug("round change set max round", "round", maxRound)
-			sendRoundChange(maxRound)
+			sendRoundChange(maxRound, reasonRoundCatchUp)
 		} else {
 			// otherwise, do your best to sync up
 			checkTimeout()
@@ -1082,7 +1192,7 @@ func (i *Ibft) runRoundChangeState() {
 	}
 
 	// create a timer for the round change
-	timeout := exponentialTimeout(i.state.view.Round)
+	timeout := i.roundTimeout(i.state.view.Round)
 	for i.getState() == RoundChangeState {
 		msg, ok := i.getNextMessage(timeout)
 		if !ok {
@@ -1094,7 +1204,7 @@ func (i *Ibft) runRoundChangeState() {
 			i.logger.Debug("round change timeout")
 			checkTimeout()
 			// update the timeout duration
-			timeout = exponentialTimeout(i.state.view.Round)
+			timeout = i.roundTimeout(i.state.view.Round)
 
 			continue
 		}
@@ -1105,10 +1215,14 @@ func (i *Ibft) runRoundChangeState() {
 		if num == i.state.validators.MaxFaultyNodes()+1 && i.state.view.Round < msg.View.Round {
 			// weak certificate, try to catch up if our round number is smaller
 			// update timer
-			timeout = exponentialTimeout(i.state.view.Round)
-			sendRoundChange(msg.View.Round)
+			timeout = i.roundTimeout(i.state.view.Round)
+			sendRoundChange(msg.View.Round, reasonRoundCatchUp)
 		} else if num == i.state.validators.QuorumSize() {
 			// start a new round immediately
+			if i.state.view.Round != msg.View.Round {
+				i.reportRoundChange(msg.View.Round, reasonRoundChangeQuorum)
+			}
+
 			i.state.view.Round = msg.View.Round
 			i.setState(AcceptState)
 		}
@@ -1192,6 +1306,32 @@ func (i *Ibft) isState(s IbftState) bool {
 func (i *Ibft) setState(s IbftState) {
 	i.logger.Info("state change", "new", s)
 	i.state.setState(s)
+	i.publishStatus()
+}
+
+// publishStatus stores a snapshot of the current state for the operator.
+// It is called from the consensus loop only, so the state is read without locking
+func (i *Ibft) publishStatus() {
+	status := &consensusStatus{
+		state:       i.state.getState(),
+		proposer:    i.state.proposer,
+		validators:  len(i.state.validators),
+		isValidator: i.state.validators.Includes(i.validatorKeyAddr),
+	}
+
+	if i.state.view != nil {
+		status.sequence = i.state.view.Sequence
+		status.round = i.state.view.Round
+	}
+
+	i.status.Store(status)
+}
+
+// getStatus returns the last published snapshot of the state, if any
+func (i *Ibft) getStatus() *consensusStatus {
+	status, _ := i.status.Load().(*consensusStatus)
+
+	return status
 }
 
 // forceTimeout sets the forceTimeoutCh flag to true
@@ -3,10 +3,12 @@ package ibft
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -14,7 +16,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/protocol"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/go-kit/kit/metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	anypb "google.golang.org/protobuf/types/known/anypb"
@@ -465,6 +471,79 @@ func TestTransition_RoundChangeState_MaxRound(t *testing.T) {
 	})
 }
 
+// mockReasonCounter counts the round changes by their reason label
+type mockReasonCounter struct {
+	reason string
+	counts map[string]float64
+}
+
+func (c *mockReasonCounter) With(labelValues ...string) metrics.Counter {
+	return &mockReasonCounter{
+		reason: labelValues[len(labelValues)-1],
+		counts: c.counts,
+	}
+}
+
+func (c *mockReasonCounter) Add(delta float64) {
+	c.counts[c.reason] += delta
+}
+
+func TestTransition_RoundChangeState_Reason(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		maxRound bool
+		reason   roundChangeReason
+		round    uint64
+	}{
+		{"timeout", nil, false, reasonTimeout, 1},
+		{"max round catch-up", nil, true, reasonRoundCatchUp, 10},
+		{"block verification failed", errBlockVerificationFailed, false, reasonVerificationFailed, 1},
+		{"block build failed", errFailedToBuildBlock, false, reasonBuildFailed, 1},
+		{"invalid proposal", errInvalidProposal, false, reasonInvalidProposal, 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockIbft(t, []string{"A", "B", "C"}, "A")
+			m.Close()
+
+			counter := &mockReasonCounter{counts: map[string]float64{}}
+			m.metrics = &consensus.Metrics{
+				Rounds:       consensus.NilMetrics().Rounds,
+				RoundChanges: counter,
+			}
+
+			m.state.err = tt.err
+
+			if tt.maxRound {
+				m.addMessage(&proto.MessageReq{
+					From: "B",
+					Type: proto.MessageReq_RoundChange,
+					View: proto.ViewMsg(1, 10),
+				})
+			}
+
+			m.setState(RoundChangeState)
+			m.runCycle()
+
+			assert.Equal(t, tt.round, m.state.view.Round)
+			assert.Equal(t, map[string]float64{string(tt.reason): 1}, counter.counts)
+		})
+	}
+}
+
+func TestIbft_RoundTimeout(t *testing.T) {
+	i := &Ibft{
+		baseRoundTimeout:    3 * time.Second,
+		roundTimeoutBackoff: 2 * time.Second,
+	}
+
+	assert.Equal(t, 3*time.Second, i.roundTimeout(0))
+	assert.Equal(t, (3+2*2)*time.Second, i.roundTimeout(1))
+	assert.Equal(t, (3+2*8)*time.Second, i.roundTimeout(3))
+}
+
 func TestWriteTransactions(t *testing.T) {
 	type testParams struct {
 		txns                        []*types.Transaction
@@ -609,6 +688,136 @@ func TestWriteTransactions(t *testing.T) {
 	}
 }
 
+// mockHistogram records the observations
+type mockHistogram struct {
+	observations []float64
+}
+
+func (h *mockHistogram) With(labelValues ...string) metrics.Histogram {
+	return h
+}
+
+func (h *mockHistogram) Observe(value float64) {
+	h.observations = append(h.observations, value)
+}
+
+// mockGauge holds the value of the gauge
+type mockGauge struct {
+	value float64
+}
+
+func (g *mockGauge) With(labelValues ...string) metrics.Gauge {
+	return g
+}
+
+func (g *mockGauge) Set(value float64) {
+	g.value = value
+}
+
+func (g *mockGauge) Add(delta float64) {
+	g.value += delta
+}
+
+func TestBuildBlock_Metrics(t *testing.T) {
+	var (
+		sender   = types.StringToAddress("1")
+		receiver = types.StringToAddress("2")
+
+		txSelectionTime = &mockHistogram{}
+		executionTime   = &mockHistogram{}
+		stateRootTime   = &mockHistogram{}
+		sealTime        = &mockHistogram{}
+		blockGasUsed    = &mockGauge{}
+		numTxs          = &mockGauge{}
+	)
+
+	m := newMockIbft(t, []string{"A", "B", "C"}, "A")
+
+	// the blocks need room for the transactions
+	genesis := m.pool.genesis()
+	genesis.GasLimit = chain.GenesisGasLimit
+	m.blockchain = blockchain.TestBlockchain(t, genesis)
+
+	executor := state.NewExecutor(
+		&chain.Params{Forks: chain.AllForksEnabled},
+		itrie.NewState(itrie.NewMemoryStorage()),
+		hclog.NewNullLogger(),
+	)
+	executor.SetRuntime(precompiled.NewPrecompiled())
+	executor.SetRuntime(evm.NewEVM())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	m.executor = executor
+
+	m.metrics = consensus.NilMetrics()
+	m.metrics.TxSelectionTime = txSelectionTime
+	m.metrics.ExecutionTime = executionTime
+	m.metrics.StateRootTime = stateRootTime
+	m.metrics.SealTime = sealTime
+	m.metrics.BlockGasUsed = blockGasUsed
+	m.metrics.NumTxs = numTxs
+
+	snap, err := m.getSnapshot(0)
+	assert.NoError(t, err)
+
+	parent := m.blockchain.Header().Copy()
+	parent.StateRoot = executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000000000)},
+	})
+
+	// produce several blocks, each with a transfer
+	blocks := 3
+
+	for nonce := 0; nonce < blocks; nonce++ {
+		pool := &mockTxPool{
+			transactions: []*types.Transaction{{
+				From:     sender,
+				To:       &receiver,
+				Nonce:    uint64(nonce),
+				Value:    big.NewInt(1),
+				Gas:      state.TxGas,
+				GasPrice: big.NewInt(1),
+			}},
+		}
+		m.txpool = pool
+
+		block, err := m.buildBlock(snap, parent)
+		assert.NoError(t, err)
+
+		// the pool orders the transactions by their tip over the base fee of the block
+		assert.Equal(t, block.Header.BaseFee, pool.baseFee)
+
+		assert.Len(t, block.Transactions, 1)
+		assert.Equal(t, state.TxGas, block.Header.GasUsed)
+
+		assert.NoError(t, m.blockchain.WriteHeaders([]*types.Header{block.Header}))
+		m.updateMetrics(block)
+
+		parent = block.Header
+	}
+
+	for _, histogram := range []*mockHistogram{txSelectionTime, executionTime, stateRootTime, sealTime} {
+		assert.Len(t, histogram.observations, blocks)
+
+		for _, observation := range histogram.observations {
+			assert.GreaterOrEqual(t, observation, float64(0))
+		}
+	}
+
+	// the execution is part of the block building
+	for n := 0; n < blocks; n++ {
+		assert.Greater(t, executionTime.observations[n], float64(0))
+		assert.GreaterOrEqual(t, sealTime.observations[n], executionTime.observations[n])
+	}
+
+	assert.Equal(t, float64(state.TxGas), blockGasUsed.value)
+	assert.Equal(t, float64(1), numTxs.value)
+}
+
 func TestRunSyncState_NewHeadReceivedFromPeer_CallsTxPoolResetWithHeaders(t *testing.T) {
 	m := newMockIbft(t, []string{"A", "B", "C"}, "A")
 	m.setState(SyncState)
@@ -710,6 +919,11 @@ type mockTxPool struct {
 	nonceDecreased        map[*types.Transaction]bool
 	resetWithHeaderCalled bool
 	resetWithHeadersParam []*types.Header
+	baseFee               uint64
+}
+
+func (p *mockTxPool) SetBaseFee(baseFee uint64) {
+	p.baseFee = baseFee
 }
 
 func (p *mockTxPool) Prepare() {
@@ -861,6 +1075,10 @@ func (m *mockIbft) CalculateGasLimit(number uint64) (uint64, error) {
 	return m.blockchain.CalculateGasLimit(number)
 }
 
+func (m *mockIbft) CalcBaseFee(parent *types.Header) uint64 {
+	return m.blockchain.CalcBaseFee(parent)
+}
+
 func newMockIbft(t *testing.T, accounts []string, account string) *mockIbft {
 	t.Helper()
 
@@ -897,6 +1115,9 @@ func newMockIbft(t *testing.T, accounts []string, account string) *mockIbft {
 		state:            newState(),
 		epochSize:        DefaultEpochSize,
 		metrics:          consensus.NilMetrics(),
+
+		baseRoundTimeout:    DefaultBaseRoundTimeout,
+		roundTimeoutBackoff: DefaultRoundTimeoutBackoff,
 	}
 
 	initIbftMechanism(PoA, ibft)
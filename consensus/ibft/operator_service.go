@@ -2,6 +2,7 @@ package ibft
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -10,12 +11,21 @@ import (
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
+// validatorSetSubscriptionBuffer is the number of validator set events
+// a subscriber can fall behind on before it is dropped
+const validatorSetSubscriptionBuffer = 16
+
+var errConsensusNotStarted = errors.New("consensus has not started yet")
+
 type operator struct {
 	ibft *Ibft
 
 	candidatesLock sync.Mutex
 	candidates     []*proto.Candidate
 
+	subscriptionsLock sync.Mutex
+	subscriptions     map[chan *proto.ValidatorSetChanged]struct{}
+
 	proto.UnimplementedIbftOperatorServer
 }
 
@@ -28,6 +38,25 @@ func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.IbftSta
 	return resp, nil
 }
 
+// ConsensusStatus returns the current view, proposer and validator set size,
+// as last published by the consensus loop
+func (o *operator) ConsensusStatus(ctx context.Context, req *empty.Empty) (*proto.ConsensusStatusResp, error) {
+	status := o.ibft.getStatus()
+	if status == nil {
+		return nil, errConsensusNotStarted
+	}
+
+	return &proto.ConsensusStatusResp{
+		Sequence:    status.sequence,
+		Round:       status.round,
+		State:       status.state.String(),
+		Proposer:    status.proposer.String(),
+		Validators:  uint64(status.validators),
+		IsValidator: status.isValidator,
+		IsProposer:  status.isValidator && status.proposer == o.ibft.validatorKeyAddr,
+	}, nil
+}
+
 // getNextCandidate returns a candidate from the snapshot
 func (o *operator) getNextCandidate(snap *Snapshot) *proto.Candidate {
 	o.candidatesLock.Lock()
@@ -159,3 +188,82 @@ func (o *operator) Candidates(ctx context.Context, req *empty.Empty) (*proto.Can
 
 	return resp, nil
 }
+
+// Subscribe streams the validator set changes to the client
+func (o *operator) Subscribe(req *empty.Empty, stream proto.IbftOperator_SubscribeServer) error {
+	eventCh, cancel := o.subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case event, more := <-eventCh:
+			if !more {
+				// the subscriber fell behind and was dropped
+				return nil
+			}
+
+			if err := stream.Send(event); err != nil {
+				return nil
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// subscribe registers a new validator set subscription,
+// and returns its event channel and the function cancelling it
+func (o *operator) subscribe() (<-chan *proto.ValidatorSetChanged, func()) {
+	o.subscriptionsLock.Lock()
+	defer o.subscriptionsLock.Unlock()
+
+	if o.subscriptions == nil {
+		o.subscriptions = make(map[chan *proto.ValidatorSetChanged]struct{})
+	}
+
+	eventCh := make(chan *proto.ValidatorSetChanged, validatorSetSubscriptionBuffer)
+	o.subscriptions[eventCh] = struct{}{}
+
+	cancel := func() {
+		o.subscriptionsLock.Lock()
+		defer o.subscriptionsLock.Unlock()
+
+		if _, ok := o.subscriptions[eventCh]; ok {
+			delete(o.subscriptions, eventCh)
+			close(eventCh)
+		}
+	}
+
+	return eventCh, cancel
+}
+
+// notifyValidatorSetChanged sends the new validator set to the subscribers.
+// Subscribers that can't keep up with the events are dropped
+func (o *operator) notifyValidatorSetChanged(number uint64, set ValidatorSet) {
+	o.subscriptionsLock.Lock()
+	defer o.subscriptionsLock.Unlock()
+
+	if len(o.subscriptions) == 0 {
+		return
+	}
+
+	validators := make([]string, 0, len(set))
+	for _, addr := range set {
+		validators = append(validators, addr.String())
+	}
+
+	event := &proto.ValidatorSetChanged{
+		Number:     number,
+		Validators: validators,
+		Threshold:  uint64(set.QuorumSize()),
+	}
+
+	for eventCh := range o.subscriptions {
+		select {
+		case eventCh <- event:
+		default:
+			delete(o.subscriptions, eventCh)
+			close(eventCh)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestOperator_GetNextCandidate(t *testing.T) {
@@ -125,3 +126,123 @@ func TestOperator_Propose(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func TestOperator_SubscribeValidatorSetChanged(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A")
+
+	genesis := pool.genesis()
+
+	ibft := &Ibft{
+		blockchain: blockchain.TestBlockchain(t, genesis),
+		config:     &consensus.Config{},
+		epochSize:  DefaultEpochSize,
+	}
+	initIbftMechanism(PoA, ibft)
+	assert.NoError(t, ibft.setupSnapshot())
+
+	ibft.operator = &operator{ibft: ibft}
+
+	eventCh, cancel := ibft.operator.subscribe()
+	defer cancel()
+
+	headers := buildHeaders(pool, genesis, []mockHeader{
+		// A votes B in, the validator set changes
+		{action: vote("A", "B", true)},
+		// no vote, the validator set stays the same
+		{action: skipVote("B")},
+	})
+	assert.NoError(t, ibft.processHeaders(headers))
+
+	expectedSet := ValidatorSet{pool.get("A").Address(), pool.get("B").Address()}
+
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, uint64(1), event.Number)
+		assert.Equal(t, []string{expectedSet[0].String(), expectedSet[1].String()}, event.Validators)
+		assert.Equal(t, uint64(expectedSet.QuorumSize()), event.Threshold)
+	default:
+		t.Fatal("validator set change not notified")
+	}
+
+	select {
+	case event := <-eventCh:
+		t.Fatalf("unexpected event for block %d", event.Number)
+	default:
+	}
+}
+
+func TestOperator_SubscribeDropsSlowSubscriber(t *testing.T) {
+	o := &operator{}
+
+	eventCh, cancel := o.subscribe()
+	defer cancel()
+
+	set := ValidatorSet{types.StringToAddress("1")}
+
+	for i := 0; i <= validatorSetSubscriptionBuffer; i++ {
+		o.notifyValidatorSetChanged(uint64(i), set)
+	}
+
+	// the buffered events are still delivered before the channel closes
+	for i := 0; i < validatorSetSubscriptionBuffer; i++ {
+		event, ok := <-eventCh
+		assert.True(t, ok)
+		assert.Equal(t, uint64(i), event.Number)
+	}
+
+	_, ok := <-eventCh
+	assert.False(t, ok)
+	assert.Len(t, o.subscriptions, 0)
+}
+
+func TestOperator_ConsensusStatus(t *testing.T) {
+	pool := newTesterAccountPool()
+	pool.add("A", "B", "C")
+
+	ibft := &Ibft{
+		validatorKeyAddr: pool.get("A").Address(),
+		state:            newState(),
+	}
+	o := &operator{ibft: ibft}
+
+	// nothing is published before the consensus starts
+	_, err := o.ConsensusStatus(context.Background(), &empty.Empty{})
+	assert.ErrorIs(t, err, errConsensusNotStarted)
+
+	ibft.state.validators = pool.ValidatorSet()
+	ibft.state.view = &proto.View{Sequence: 5, Round: 1}
+	ibft.state.proposer = pool.get("B").Address()
+	ibft.state.setState(AcceptState)
+	ibft.publishStatus()
+
+	status, err := o.ConsensusStatus(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &proto.ConsensusStatusResp{
+		Sequence:    5,
+		Round:       1,
+		State:       AcceptState.String(),
+		Proposer:    pool.get("B").Address().String(),
+		Validators:  3,
+		IsValidator: true,
+		IsProposer:  false,
+	}, status)
+
+	// the published snapshot doesn't change with the state,
+	// until the consensus loop publishes it again
+	ibft.state.view.Round = 2
+	ibft.state.proposer = pool.get("A").Address()
+
+	status, err = o.ConsensusStatus(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), status.Round)
+	assert.False(t, status.IsProposer)
+
+	ibft.publishStatus()
+
+	status, err = o.ConsensusStatus(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), status.Round)
+	assert.True(t, status.IsProposer)
+}
@@ -245,6 +245,8 @@ func (pos *PoSMechanism) updateValidators(num uint64) error {
 		} else {
 			pos.ibft.store.replace(newSnap)
 		}
+
+		pos.ibft.validatorSetChanged(header.Number, validators)
 	}
 
 	return nil
@@ -23,6 +23,8 @@ type IbftOperatorClient interface {
 	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error)
 	Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
 	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	ConsensusStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConsensusStatusResp, error)
+	Subscribe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (IbftOperator_SubscribeClient, error)
 }
 
 type ibftOperatorClient struct {
@@ -69,6 +71,47 @@ func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts .
 	return out, nil
 }
 
+func (c *ibftOperatorClient) ConsensusStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ConsensusStatusResp, error) {
+	out := new(ConsensusStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.IbftOperator/ConsensusStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) Subscribe(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (IbftOperator_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IbftOperator_ServiceDesc.Streams[0], "/v1.IbftOperator/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ibftOperatorSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IbftOperator_SubscribeClient interface {
+	Recv() (*ValidatorSetChanged, error)
+	grpc.ClientStream
+}
+
+type ibftOperatorSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *ibftOperatorSubscribeClient) Recv() (*ValidatorSetChanged, error) {
+	m := new(ValidatorSetChanged)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // IbftOperatorServer is the server API for IbftOperator service.
 // All implementations must embed UnimplementedIbftOperatorServer
 // for forward compatibility
@@ -77,6 +120,8 @@ type IbftOperatorServer interface {
 	Propose(context.Context, *Candidate) (*empty.Empty, error)
 	Candidates(context.Context, *empty.Empty) (*CandidatesResp, error)
 	Status(context.Context, *empty.Empty) (*IbftStatusResp, error)
+	ConsensusStatus(context.Context, *empty.Empty) (*ConsensusStatusResp, error)
+	Subscribe(*empty.Empty, IbftOperator_SubscribeServer) error
 	mustEmbedUnimplementedIbftOperatorServer()
 }
 
@@ -96,6 +141,12 @@ func (UnimplementedIbftOperatorServer) Candidates(context.Context, *empty.Empty)
 func (UnimplementedIbftOperatorServer) Status(context.Context, *empty.Empty) (*IbftStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedIbftOperatorServer) ConsensusStatus(context.Context, *empty.Empty) (*ConsensusStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsensusStatus not implemented")
+}
+func (UnimplementedIbftOperatorServer) Subscribe(*empty.Empty, IbftOperator_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedIbftOperatorServer) mustEmbedUnimplementedIbftOperatorServer() {}
 
 // UnsafeIbftOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +232,45 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IbftOperator_ConsensusStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).ConsensusStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.IbftOperator/ConsensusStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).ConsensusStatus(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IbftOperatorServer).Subscribe(m, &ibftOperatorSubscribeServer{stream})
+}
+
+type IbftOperator_SubscribeServer interface {
+	Send(*ValidatorSetChanged) error
+	grpc.ServerStream
+}
+
+type ibftOperatorSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *ibftOperatorSubscribeServer) Send(m *ValidatorSetChanged) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // IbftOperator_ServiceDesc is the grpc.ServiceDesc for IbftOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -204,7 +294,17 @@ var IbftOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _IbftOperator_Status_Handler,
 		},
+		{
+			MethodName: "ConsensusStatus",
+			Handler:    _IbftOperator_ConsensusStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _IbftOperator_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "consensus/ibft/proto/operator.proto",
 }
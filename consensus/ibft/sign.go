@@ -18,13 +18,11 @@ func commitMsg(b []byte) []byte {
 	return crypto.Keccak256(b, []byte{byte(proto.MessageReq_Commit)})
 }
 
-func ecrecoverImpl(sig, msg []byte) (types.Address, error) {
-	pub, err := crypto.RecoverPubkey(sig, crypto.Keccak256(msg))
-	if err != nil {
-		return types.Address{}, err
-	}
+// sealRecoverer caches the signers of the seals, which are verified repeatedly
+var sealRecoverer = crypto.NewPubkeyRecoverer(crypto.DefaultPubkeyCacheSize)
 
-	return crypto.PubKeyToAddress(pub), nil
+func ecrecoverImpl(sig, msg []byte) (types.Address, error) {
+	return sealRecoverer.RecoverAddress(sig, crypto.Keccak256(msg))
 }
 
 func ecrecoverFromHeader(h *types.Header) (types.Address, error) {
@@ -147,6 +145,11 @@ func calculateHeaderHash(h *types.Header) ([]byte, error) {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	// the base fee is only set from the London fork onwards
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return buf, nil
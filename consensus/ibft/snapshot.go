@@ -154,6 +154,10 @@ func (i *Ibft) processHeaders(headers []*types.Header) error {
 		snap.Hash = h.Hash.String()
 		i.store.add(snap)
 
+		if !snap.Set.Equal(&parentSnap.Set) {
+			i.validatorSetChanged(h.Number, snap.Set)
+		}
+
 		// use saved snapshot as new parent and clone it for next
 		parentSnap = snap
 		snap = parentSnap.Copy()
@@ -194,6 +198,13 @@ func (i *Ibft) processHeaders(headers []*types.Header) error {
 	return nil
 }
 
+// validatorSetChanged notifies the operator subscribers of a new validator set
+func (i *Ibft) validatorSetChanged(number uint64, set ValidatorSet) {
+	if i.operator != nil {
+		i.operator.notifyValidatorSetChanged(number, set)
+	}
+}
+
 // getSnapshotMetadata returns the latest snapshot metadata
 func (i *Ibft) getSnapshotMetadata() (*snapshotMetadata, error) {
 	meta := &snapshotMetadata{
@@ -1,28 +1,38 @@
 package ibft
 
 import (
-	"math"
 	"time"
 )
 
 const (
-	baseTimeout = 10 * time.Second
-	maxTimeout  = 300 * time.Second
+	// DefaultBaseRoundTimeout is the default timeout of the first round of a sequence
+	DefaultBaseRoundTimeout = 10 * time.Second
+
+	// DefaultRoundTimeoutBackoff is the default unit of the exponential backoff
+	// added to the timeout of the following rounds
+	DefaultRoundTimeoutBackoff = time.Second
+
+	maxTimeout = 300 * time.Second
 )
 
-// exponentialTimeout calculates the timeout duration in seconds as exponential function
-// where maximum value returned can't exceed 300 seconds
-// t = 10 + 2^exponent	where exponent > 0
-// t = 10				where exponent = 0
-func exponentialTimeout(exponent uint64) time.Duration {
-	if exponent > 8 {
-		return maxTimeout
+// exponentialTimeout calculates the timeout duration as exponential function
+// where maximum value returned can't exceed 300 seconds, unless the base timeout is already greater
+// t = base + backoff * 2^exponent	where exponent > 0
+// t = base							where exponent = 0
+func exponentialTimeout(base, backoff time.Duration, exponent uint64) time.Duration {
+	limit := maxTimeout
+	if base > limit {
+		limit = base
+	}
+
+	if exponent == 0 {
+		return base
 	}
 
-	timeout := baseTimeout
-	if exponent > 0 {
-		timeout += time.Duration(math.Pow(2, float64(exponent))) * time.Second
+	// the backoff would exceed the limit, or overflow
+	if exponent >= 63 || backoff > (limit-base)>>exponent {
+		return limit
 	}
 
-	return timeout
+	return base + backoff<<exponent
 }
@@ -18,11 +18,37 @@ func TestExponentialTimeout(t *testing.T) {
 		{"for exponent 8 returns 256s", 8, (10 + 256) * time.Second},
 		{"for exponent 9 returns 300s", 9, 300 * time.Second},
 		{"for exponent 10 returns 300s", 10, 5 * time.Minute},
+		{"for exponent 64 returns 300s", 64, 5 * time.Minute},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			timeout := exponentialTimeout(test.exponent)
+			timeout := exponentialTimeout(DefaultBaseRoundTimeout, DefaultRoundTimeoutBackoff, test.exponent)
+
+			assert.Equal(t, test.expected, timeout)
+		})
+	}
+}
+
+func TestExponentialTimeout_Custom(t *testing.T) {
+	testCases := []struct {
+		description string
+		base        time.Duration
+		backoff     time.Duration
+		exponent    uint64
+		expected    time.Duration
+	}{
+		{"base is returned for exponent 0", 2 * time.Second, 500 * time.Millisecond, 0, 2 * time.Second},
+		{"backoff is scaled by the exponent", 2 * time.Second, 500 * time.Millisecond, 3, 6 * time.Second},
+		{"no backoff keeps the base", 2 * time.Second, 0, 5, 2 * time.Second},
+		{"backoff is capped at 300s", 2 * time.Second, time.Minute, 4, 5 * time.Minute},
+		{"base above the cap is kept", 10 * time.Minute, time.Second, 0, 10 * time.Minute},
+		{"base above the cap disables the backoff", 10 * time.Minute, time.Second, 2, 10 * time.Minute},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			timeout := exponentialTimeout(test.base, test.backoff, test.exponent)
 
 			assert.Equal(t, test.expected, timeout)
 		})
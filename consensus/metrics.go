@@ -18,6 +18,24 @@ type Metrics struct {
 
 	//Time between current block and the previous block in seconds
 	BlockInterval metrics.Gauge
+
+	// No.of local round changes, labeled by the reason of the change
+	RoundChanges metrics.Counter
+
+	// Gas used by the block
+	BlockGasUsed metrics.Gauge
+
+	// Time spent selecting the transactions of a block from the pool in seconds
+	TxSelectionTime metrics.Histogram
+
+	// Time spent executing the transactions of a block in seconds
+	ExecutionTime metrics.Histogram
+
+	// Time spent computing the state root of a block in seconds
+	StateRootTime metrics.Histogram
+
+	// Total time spent building and sealing a block in seconds
+	SealTime metrics.Histogram
 }
 
 // GetPrometheusMetrics return the consensus metrics instance
@@ -54,6 +72,48 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "block_interval",
 			Help:      "Time between current block and the previous block in seconds.",
 		}, labels).With(labelsWithValues...),
+
+		RoundChanges: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "round_changes",
+			Help:      "Number of local round changes, by reason.",
+		}, append(labels, "reason")).With(labelsWithValues...),
+
+		BlockGasUsed: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "block_gas_used",
+			Help:      "Gas used by the block.",
+		}, labels).With(labelsWithValues...),
+
+		TxSelectionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "tx_selection_seconds",
+			Help:      "Time spent selecting the transactions of a block from the pool in seconds.",
+		}, labels).With(labelsWithValues...),
+
+		ExecutionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "execution_seconds",
+			Help:      "Time spent executing the transactions of a block in seconds.",
+		}, labels).With(labelsWithValues...),
+
+		StateRootTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "state_root_seconds",
+			Help:      "Time spent computing the state root of a block in seconds.",
+		}, labels).With(labelsWithValues...),
+
+		SealTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "consensus",
+			Name:      "seal_seconds",
+			Help:      "Total time spent building and sealing a block in seconds.",
+		}, labels).With(labelsWithValues...),
 	}
 }
 
@@ -64,5 +124,12 @@ func NilMetrics() *Metrics {
 		Rounds:        discard.NewGauge(),
 		NumTxs:        discard.NewGauge(),
 		BlockInterval: discard.NewGauge(),
+		RoundChanges:  discard.NewCounter(),
+
+		BlockGasUsed:    discard.NewGauge(),
+		TxSelectionTime: discard.NewHistogram(),
+		ExecutionTime:   discard.NewHistogram(),
+		StateRootTime:   discard.NewHistogram(),
+		SealTime:        discard.NewHistogram(),
 	}
 }
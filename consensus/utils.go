@@ -29,6 +29,9 @@ func BuildBlock(params BuildBlockParams) *types.Block {
 		header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(params.Receipts)
 	}
 
+	// the logs bloom lets log queries skip blocks without loading receipts
+	header.LogsBloom = types.CreateBloom(params.Receipts)
+
 	// TODO: Compute uncles
 	header.Sha3Uncles = types.EmptyUncleHash
 	header.ComputeHash()
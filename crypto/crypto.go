@@ -30,6 +30,8 @@ var S256 = btcec.S256()
 var (
 	secp256k1N = hex.MustDecodeHex("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
 	one        = []byte{0x01}
+
+	secp256k1halfN = new(big.Int).Rsh(new(big.Int).SetBytes(secp256k1N), 1)
 )
 
 func trimLeftZeros(b []byte) []byte {
@@ -139,14 +141,24 @@ func Ecrecover(hash, sig []byte) ([]byte, error) {
 // secp256k1 curve.
 func RecoverPubkey(signature, hash []byte) (*ecdsa.PublicKey, error) {
 	size := len(signature)
+	if size == 0 || size > signatureLength {
+		return nil, errInvalidSignatureLength
+	}
+
 	term := byte(27)
 
 	if signature[size-1] == 1 {
 		term = 28
 	}
 
-	sig := append([]byte{term}, signature[:size-1]...)
-	pub, _, err := btcec.RecoverCompact(S256, sig, hash)
+	// build the compact signature in a reused buffer
+	sig := compactSigPool.Get().(*[signatureLength]byte) // nolint:forcetypeassert
+	defer compactSigPool.Put(sig)
+
+	sig[0] = term
+	copy(sig[1:], signature[:size-1])
+
+	pub, _, err := btcec.RecoverCompact(S256, sig[:size], hash)
 
 	if err != nil {
 		return nil, err
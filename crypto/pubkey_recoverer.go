@@ -0,0 +1,105 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/btcsuite/btcd/btcec"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// DefaultPubkeyCacheSize is the default number of public keys kept by a PubkeyRecoverer
+	DefaultPubkeyCacheSize = 4096
+
+	// length of a [R || S || V] signature
+	signatureLength = 65
+)
+
+var errInvalidSignatureLength = errors.New("invalid signature length")
+
+// scratch buffers of the compact signatures passed to btcec
+var compactSigPool = sync.Pool{
+	New: func() interface{} {
+		return new([signatureLength]byte)
+	},
+}
+
+// pubkeyCacheKey identifies a recovery: the signed hash followed by the signature
+type pubkeyCacheKey [types.HashLength + signatureLength]byte
+
+// PubkeyRecoverer recovers the public keys of secp256k1 signatures.
+// The recovered keys are kept in an LRU cache, compressed to 33 bytes,
+// so signatures which are verified repeatedly are only recovered once
+type PubkeyRecoverer struct {
+	cache *lru.Cache // nil if disabled
+}
+
+// NewPubkeyRecoverer returns a recoverer caching up to size public keys.
+// A size of 0 disables the cache
+func NewPubkeyRecoverer(size int) *PubkeyRecoverer {
+	r := &PubkeyRecoverer{}
+
+	if size > 0 {
+		// lru.New only fails for non-positive sizes
+		r.cache, _ = lru.New(size)
+	}
+
+	return r
+}
+
+// RecoverPubkey returns the public key that signed the hash,
+// which is only recovered if it is not cached
+func (r *PubkeyRecoverer) RecoverPubkey(signature, hash []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != signatureLength || len(hash) != types.HashLength {
+		return nil, errInvalidSignatureLength
+	}
+
+	if r.cache == nil {
+		return RecoverPubkey(signature, hash)
+	}
+
+	var key pubkeyCacheKey
+
+	copy(key[:], hash)
+	copy(key[types.HashLength:], signature)
+
+	if compressed, ok := r.cache.Get(key); ok {
+		pub, err := btcec.ParsePubKey(compressed.([]byte), S256) // nolint:forcetypeassert
+		if err != nil {
+			return nil, err
+		}
+
+		return pub.ToECDSA(), nil
+	}
+
+	pub, err := RecoverPubkey(signature, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Add(key, (*btcec.PublicKey)(pub).SerializeCompressed())
+
+	return pub, nil
+}
+
+// RecoverAddress returns the address of the key that signed the hash
+func (r *PubkeyRecoverer) RecoverAddress(signature, hash []byte) (types.Address, error) {
+	pub, err := r.RecoverPubkey(signature, hash)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return PubKeyToAddress(pub), nil
+}
+
+// Len returns the number of cached public keys
+func (r *PubkeyRecoverer) Len() int {
+	if r.cache == nil {
+		return 0
+	}
+
+	return r.cache.Len()
+}
@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestHash(t testing.TB, msg string) (sig, hash []byte) {
+	t.Helper()
+
+	priv, err := GenerateKey()
+	assert.NoError(t, err)
+
+	hash = Keccak256([]byte(msg))
+
+	sig, err = Sign(priv, hash)
+	assert.NoError(t, err)
+
+	return sig, hash
+}
+
+func TestPubkeyRecoverer(t *testing.T) {
+	recoverer := NewPubkeyRecoverer(2)
+
+	sig, hash := signTestHash(t, "a")
+
+	expected, err := RecoverPubkey(sig, hash)
+	assert.NoError(t, err)
+
+	// the cached key is the recovered one
+	for i := 0; i < 2; i++ {
+		pub, err := recoverer.RecoverPubkey(sig, hash)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, pub)
+		assert.Equal(t, 1, recoverer.Len())
+	}
+
+	addr, err := recoverer.RecoverAddress(sig, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(expected), addr)
+
+	// the same signature over another hash is a different key
+	otherHash := Keccak256([]byte("b"))
+
+	other, err := recoverer.RecoverPubkey(sig, otherHash)
+	assert.NoError(t, err)
+	assert.NotEqual(t, expected, other)
+	assert.Equal(t, 2, recoverer.Len())
+
+	// the least recently used key is evicted
+	sig2, hash2 := signTestHash(t, "c")
+
+	_, err = recoverer.RecoverPubkey(sig2, hash2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recoverer.Len())
+
+	_, err = recoverer.RecoverPubkey(sig[:64], hash)
+	assert.ErrorIs(t, err, errInvalidSignatureLength)
+}
+
+func TestPubkeyRecoverer_Disabled(t *testing.T) {
+	recoverer := NewPubkeyRecoverer(0)
+
+	sig, hash := signTestHash(t, "a")
+
+	expected, err := RecoverPubkey(sig, hash)
+	assert.NoError(t, err)
+
+	pub, err := recoverer.RecoverPubkey(sig, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pub)
+	assert.Equal(t, 0, recoverer.Len())
+}
+
+func BenchmarkRecoverPubkey(b *testing.B) {
+	sig, hash := signTestHash(b, "a")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := RecoverPubkey(sig, hash); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPubkeyRecoverer_Cached(b *testing.B) {
+	recoverer := NewPubkeyRecoverer(DefaultPubkeyCacheSize)
+	sig, hash := signTestHash(b, "a")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := recoverer.RecoverPubkey(sig, hash); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// DefaultSenderCacheSize is the default number of senders kept in the cache
+const DefaultSenderCacheSize = 4096
+
+// SenderCache is a thread safe LRU cache of recovered transaction senders,
+// keyed by the transaction hash. It can be shared between signers
+// so the sender of a transaction is only recovered once
+type SenderCache struct {
+	cache *lru.Cache
+}
+
+// NewSenderCache returns a new sender cache holding up to size entries
+func NewSenderCache(size int) (*SenderCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SenderCache{cache: cache}, nil
+}
+
+// Get returns the cached sender of the transaction with the given hash
+func (c *SenderCache) Get(hash types.Hash) (types.Address, bool) {
+	sender, ok := c.cache.Get(hash)
+	if !ok {
+		return types.Address{}, false
+	}
+
+	return sender.(types.Address), true // nolint:forcetypeassert
+}
+
+// Add caches the sender of the transaction with the given hash
+func (c *SenderCache) Add(hash types.Hash, sender types.Address) {
+	c.cache.Add(hash, sender)
+}
+
+// Len returns the number of cached senders
+func (c *SenderCache) Len() int {
+	return c.cache.Len()
+}
+
+// Purge clears the cache
+func (c *SenderCache) Purge() {
+	c.cache.Purge()
+}
+
+// CachedSigner wraps a signer, caching the senders it recovers
+type CachedSigner struct {
+	signer TxSigner
+	cache  *SenderCache
+}
+
+// NewCachedSigner returns a signer that caches the senders recovered by the given signer
+func NewCachedSigner(signer TxSigner, cache *SenderCache) *CachedSigner {
+	return &CachedSigner{
+		signer: signer,
+		cache:  cache,
+	}
+}
+
+// Hash returns the hash of the transaction
+func (c *CachedSigner) Hash(tx *types.Transaction) types.Hash {
+	return c.signer.Hash(tx)
+}
+
+// Sender returns the sender of the transaction, recovering it
+// from the signature only if it is not already cached.
+// The transaction hash covers the signature, so it is a safe cache key
+func (c *CachedSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Hash == types.ZeroHash {
+		// the hash was never computed, the tx can't be cached
+		return c.signer.Sender(tx)
+	}
+
+	if sender, ok := c.cache.Get(tx.Hash); ok {
+		return sender, nil
+	}
+
+	sender, err := c.signer.Sender(tx)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	c.cache.Add(tx.Hash, sender)
+
+	return sender, nil
+}
+
+// SignTx signs a transaction
+func (c *CachedSigner) SignTx(tx *types.Transaction, priv *ecdsa.PrivateKey) (*types.Transaction, error) {
+	return c.signer.SignTx(tx, priv)
+}
+
+// CalculateV calculates the V value based on the type of the wrapped signer
+func (c *CachedSigner) CalculateV(parity byte) []byte {
+	return c.signer.CalculateV(parity)
+}
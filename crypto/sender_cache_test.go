@@ -0,0 +1,186 @@
+package crypto
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSigner counts the senders recovered by the wrapped signer
+type countingSigner struct {
+	TxSigner
+
+	lock      sync.Mutex
+	recovered int
+}
+
+func (c *countingSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	c.lock.Lock()
+	c.recovered++
+	c.lock.Unlock()
+
+	return c.TxSigner.Sender(tx)
+}
+
+// generateStressTxs mirrors the txpool stress addition workload,
+// where a number of accounts send a batch of transfers each
+func generateStressTxs(t testing.TB, signer TxSigner, numAccounts, numTxPerAccount int) []*types.Transaction {
+	t.Helper()
+
+	toAddress := types.StringToAddress("1")
+	txs := make([]*types.Transaction, 0, numAccounts*numTxPerAccount)
+
+	for i := 0; i < numAccounts; i++ {
+		key, err := GenerateKey()
+		assert.NoError(t, err)
+
+		for nonce := 0; nonce < numTxPerAccount; nonce++ {
+			tx, err := signer.SignTx(&types.Transaction{
+				Nonce:    uint64(nonce),
+				To:       &toAddress,
+				GasPrice: big.NewInt(10),
+				Gas:      5242880,
+				Value:    big.NewInt(1),
+			}, key)
+			assert.NoError(t, err)
+
+			tx.ComputeHash()
+			txs = append(txs, tx)
+		}
+	}
+
+	return txs
+}
+
+func TestCachedSigner_Sender(t *testing.T) {
+	signer := &countingSigner{TxSigner: NewEIP155Signer(100)}
+
+	cache, err := NewSenderCache(2)
+	assert.NoError(t, err)
+
+	cachedSigner := NewCachedSigner(signer, cache)
+	txs := generateStressTxs(t, signer, 1, 3)
+
+	// the sender is recovered once
+	for i := 0; i < 2; i++ {
+		sender, err := cachedSigner.Sender(txs[0])
+		assert.NoError(t, err)
+
+		expected, err := signer.TxSigner.Sender(txs[0])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, sender)
+	}
+
+	assert.Equal(t, 1, signer.recovered)
+
+	// the cache is bounded
+	for _, tx := range txs {
+		_, err := cachedSigner.Sender(tx)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, 3, signer.recovered)
+
+	// the purged cache recovers the sender again
+	cache.Purge()
+	assert.Equal(t, 0, cache.Len())
+
+	_, err = cachedSigner.Sender(txs[2])
+	assert.NoError(t, err)
+	assert.Equal(t, 4, signer.recovered)
+}
+
+func TestCachedSigner_NoHash(t *testing.T) {
+	signer := &countingSigner{TxSigner: NewEIP155Signer(100)}
+
+	cache, err := NewSenderCache(DefaultSenderCacheSize)
+	assert.NoError(t, err)
+
+	cachedSigner := NewCachedSigner(signer, cache)
+
+	tx := generateStressTxs(t, signer, 1, 1)[0]
+	tx.Hash = types.ZeroHash
+
+	// txs without a hash are never cached
+	for i := 0; i < 2; i++ {
+		_, err := cachedSigner.Sender(tx)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, signer.recovered)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCachedSigner_Concurrent(t *testing.T) {
+	signer := NewEIP155Signer(100)
+
+	cache, err := NewSenderCache(DefaultSenderCacheSize)
+	assert.NoError(t, err)
+
+	cachedSigner := NewCachedSigner(signer, cache)
+	txs := generateStressTxs(t, signer, 4, 10)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for _, tx := range txs {
+				sender, err := cachedSigner.Sender(tx)
+				assert.NoError(t, err)
+
+				expected, err := signer.Sender(tx)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, sender)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, len(txs), cache.Len())
+}
+
+// benchmarkSender recovers the senders of the stress addition
+// workload twice, as done by the txpool and the block execution
+func benchmarkSender(b *testing.B, newSigner func() TxSigner) {
+	b.Helper()
+
+	txs := generateStressTxs(b, NewEIP155Signer(100), 10, 50)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		signer := newSigner()
+
+		for round := 0; round < 2; round++ {
+			for _, tx := range txs {
+				if _, err := signer.Sender(tx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSender_StressAddition(b *testing.B) {
+	benchmarkSender(b, func() TxSigner {
+		return NewEIP155Signer(100)
+	})
+}
+
+func BenchmarkCachedSender_StressAddition(b *testing.B) {
+	benchmarkSender(b, func() TxSigner {
+		cache, _ := NewSenderCache(DefaultSenderCacheSize)
+
+		return NewCachedSigner(NewEIP155Signer(100), cache)
+	})
+}
@@ -2,9 +2,9 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
-	"math/bits"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
@@ -12,6 +12,16 @@ import (
 	"github.com/umbracle/fastrlp"
 )
 
+var (
+	// ErrInvalidChainID is returned when a replay protected transaction
+	// is signed for a chain other than the one of the signer
+	ErrInvalidChainID = errors.New("invalid chain id")
+
+	// ErrUnprotectedTx is returned for transactions without replay protection
+	// (pre EIP155), when the signer is configured to reject them
+	ErrUnprotectedTx = errors.New("transaction is not replay protected")
+)
+
 // TxSigner is a utility interface used to recover data from a transaction
 type TxSigner interface {
 	// Hash returns the hash of the transaction
@@ -27,19 +37,31 @@ type TxSigner interface {
 	CalculateV(parity byte) []byte
 }
 
-// NewSigner creates a new signer object (EIP155 or FrontierSigner)
+// NewSigner creates a new signer object (EIP155, Homestead or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
 
-	if forks.EIP155 {
-		signer = &EIP155Signer{chainID: chainID}
-	} else {
+	switch {
+	case forks.EIP155:
+		eip155Signer := NewEIP155Signer(chainID)
+		eip155Signer.forks = &forks
+
+		signer = eip155Signer
+	case forks.Homestead:
+		signer = &HomesteadSigner{}
+	default:
 		signer = &FrontierSigner{}
 	}
 
 	return signer
 }
 
+// SignerForFork returns the signer matching the fork rules
+// active at the given block height of the chain
+func SignerForFork(config *chain.Params, blockNumber uint64) TxSigner {
+	return NewSigner(config.Forks.At(blockNumber), uint64(config.ChainID))
+}
+
 type FrontierSigner struct {
 }
 
@@ -77,6 +99,64 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	return types.BytesToHash(hash)
 }
 
+// calcAccessListTxHash calculates the signing hash of an EIP-2930 transaction:
+// keccak256(0x01 || rlp([chainId, nonce, gasPrice, gas, to, value, data, accessList]))
+func calcAccessListTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasPrice))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.AccessListTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcDynamicFeeTxHash calculates the signing hash of an EIP-1559 transaction:
+// keccak256(0x02 || rlp([chainId, nonce, maxPriorityFeePerGas, maxFeePerGas,
+// gas, to, value, data, accessList]))
+func calcDynamicFeeTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.MaxPriorityFeePerGas))
+	v.Set(a.NewBigInt(tx.MaxFeePerGas))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.DynamicFeeTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
 // Hash is a wrapper function for the calcTxHash, with chainID 0
 func (f *FrontierSigner) Hash(tx *types.Transaction) types.Hash {
 	return calcTxHash(tx, 0)
@@ -90,6 +170,11 @@ var (
 
 // Sender decodes the signature and returns the sender of the transaction
 func (f *FrontierSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	// typed transactions are signed over a different hash
+	if tx.Type != types.LegacyTx {
+		return types.Address{}, types.ErrTxTypeNotSupported
+	}
+
 	refV := big.NewInt(0)
 	if tx.V != nil {
 		refV.SetBytes(tx.V.Bytes())
@@ -117,6 +202,10 @@ func (f *FrontierSigner) SignTx(
 	tx *types.Transaction,
 	privateKey *ecdsa.PrivateKey,
 ) (*types.Transaction, error) {
+	if tx.Type != types.LegacyTx {
+		return nil, types.ErrTxTypeNotSupported
+	}
+
 	tx = tx.Copy()
 
 	h := f.Hash(tx)
@@ -130,6 +219,9 @@ func (f *FrontierSigner) SignTx(
 	tx.S = new(big.Int).SetBytes(sig[32:64])
 	tx.V = new(big.Int).SetBytes(f.CalculateV(sig[64]))
 
+	// a hash computed before signing is stale
+	tx.ComputeHash()
+
 	return tx, nil
 }
 
@@ -141,44 +233,114 @@ func (f *FrontierSigner) CalculateV(parity byte) []byte {
 	return reference.Bytes()
 }
 
+// HomesteadSigner implements the signing rules introduced in Homestead (EIP-2),
+// which reject signatures with an S value in the upper half of the curve order
+type HomesteadSigner struct {
+	FrontierSigner
+}
+
+// Sender decodes the signature and returns the sender of the transaction
+func (h *HomesteadSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.LegacyTx {
+		return types.Address{}, types.ErrTxTypeNotSupported
+	}
+
+	if !isLowS(tx.S) {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	return h.FrontierSigner.Sender(tx)
+}
+
+// isLowS checks if the S value of a signature is in the lower half of the curve order
+func isLowS(s *big.Int) bool {
+	return s != nil && s.Cmp(secp256k1halfN) <= 0
+}
+
+// IsReplayProtected checks if the transaction is bound to a chain ID.
+// Typed transactions always are, while legacy transactions are if their
+// V value doesn't conform to the standard before EIP155
+func IsReplayProtected(tx *types.Transaction) bool {
+	if tx.Type != types.LegacyTx {
+		return true
+	}
+
+	if tx.V == nil || tx.V.BitLen() > 8 {
+		return true
+	}
+
+	v := tx.V.Uint64()
+
+	return v != 27 && v != 28
+}
+
 // NewEIP155Signer returns a new EIP155Signer object
 func NewEIP155Signer(chainID uint64) *EIP155Signer {
-	return &EIP155Signer{chainID: chainID}
+	return &EIP155Signer{
+		chainID:          chainID,
+		allowUnprotected: true,
+	}
 }
 
 type EIP155Signer struct {
-	chainID uint64
+	chainID          uint64
+	allowUnprotected bool               // flag indicating if pre EIP155 transactions are accepted
+	forks            *chain.ForksInTime // fork rules enabling the typed transactions (all if nil)
+}
+
+// SetAllowUnprotected sets whether transactions without
+// replay protection (pre EIP155) are accepted by Sender
+func (e *EIP155Signer) SetAllowUnprotected(allow bool) {
+	e.allowUnprotected = allow
 }
 
 // Hash is a wrapper function that calls calcTxHash with the EIP155Signer's chainID
 func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
-	return calcTxHash(tx, e.chainID)
+	switch tx.Type {
+	case types.AccessListTx:
+		return calcAccessListTxHash(tx, e.chainID)
+	case types.DynamicFeeTx:
+		return calcDynamicFeeTxHash(tx, e.chainID)
+	default:
+		return calcTxHash(tx, e.chainID)
+	}
 }
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
-	protected := true
+	if tx.Type != types.LegacyTx {
+		if e.forks != nil && !e.forks.TxTypeEnabled(tx.Type) {
+			return types.Address{}, types.ErrTxTypeNotSupported
+		}
+
+		return e.typedTxSender(tx)
+	}
 
-	// Check if v value conforms to an earlier standard (before EIP155)
 	bigV := big.NewInt(0)
 	if tx.V != nil {
 		bigV.SetBytes(tx.V.Bytes())
 	}
 
-	if vv := bigV.Uint64(); bits.Len(uint(vv)) <= 8 {
-		protected = vv != 27 && vv != 28
-	}
+	if !IsReplayProtected(tx) {
+		if !e.allowUnprotected {
+			return types.Address{}, ErrUnprotectedTx
+		}
 
-	if !protected {
 		return (&FrontierSigner{}).Sender(tx)
 	}
 
 	// Reverse the V calculation to find the original V in the range [0, 1]
 	// v = CHAIN_ID * 2 + 35 + {0, 1}
-	mulOperand := big.NewInt(0).Mul(big.NewInt(int64(e.chainID)), big.NewInt(2))
-	bigV.Sub(bigV, mulOperand)
 	bigV.Sub(bigV, big35)
 
+	// the chain id encoded in V must match the one of the signer
+	chainID := new(big.Int).Rsh(bigV, 1)
+	if !chainID.IsUint64() || chainID.Uint64() != e.chainID {
+		return types.Address{}, ErrInvalidChainID
+	}
+
+	bigV.Sub(bigV, new(big.Int).Lsh(chainID, 1))
+
 	sig, err := encodeSignature(tx.R, tx.S, byte(bigV.Int64()))
 	if err != nil {
 		return types.Address{}, err
@@ -194,6 +356,37 @@ func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
 	return types.BytesToAddress(buf), nil
 }
 
+// typedTxSender returns the sender of a typed (EIP-2718) transaction,
+// whose V value is the plain signature parity {0, 1}
+func (e *EIP155Signer) typedTxSender(tx *types.Transaction) (types.Address, error) {
+	if tx.ChainID == nil || !tx.ChainID.IsUint64() || tx.ChainID.Uint64() != e.chainID {
+		return types.Address{}, ErrInvalidChainID
+	}
+
+	parity := big.NewInt(0)
+	if tx.V != nil {
+		parity.Set(tx.V)
+	}
+
+	if !parity.IsUint64() || parity.Uint64() > 1 {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	sig, err := encodeSignature(tx.R, tx.S, byte(parity.Uint64()))
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(e.Hash(tx).Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
 // SignTx signs the transaction using the passed in private key
 func (e *EIP155Signer) SignTx(
 	tx *types.Transaction,
@@ -201,6 +394,10 @@ func (e *EIP155Signer) SignTx(
 ) (*types.Transaction, error) {
 	tx = tx.Copy()
 
+	if tx.Type != types.LegacyTx {
+		tx.ChainID = new(big.Int).SetUint64(e.chainID)
+	}
+
 	h := e.Hash(tx)
 
 	sig, err := Sign(privateKey, h[:])
@@ -210,7 +407,16 @@ func (e *EIP155Signer) SignTx(
 
 	tx.R = new(big.Int).SetBytes(sig[:32])
 	tx.S = new(big.Int).SetBytes(sig[32:64])
-	tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+
+	if tx.Type != types.LegacyTx {
+		// typed transactions carry the raw parity instead of an EIP155 V
+		tx.V = new(big.Int).SetUint64(uint64(sig[64]))
+	} else {
+		tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+	}
+
+	// a hash computed before signing is stale
+	tx.ComputeHash()
 
 	return tx, nil
 }
@@ -240,3 +446,60 @@ func encodeSignature(R, S *big.Int, V byte) ([]byte, error) {
 
 	return sig, nil
 }
+
+// ForkSigner is a signer which follows the fork rules active
+// at the block height returned by the given callback
+type ForkSigner struct {
+	config           *chain.Params
+	blockNumber      func() uint64
+	allowUnprotected bool // flag indicating if pre EIP155 transactions are accepted
+}
+
+// NewForkSigner returns a new ForkSigner object
+func NewForkSigner(config *chain.Params, blockNumber func() uint64) *ForkSigner {
+	return &ForkSigner{
+		config:           config,
+		blockNumber:      blockNumber,
+		allowUnprotected: true,
+	}
+}
+
+// SetAllowUnprotected sets whether transactions without replay
+// protection (pre EIP155) are accepted once EIP155 is active
+func (f *ForkSigner) SetAllowUnprotected(allow bool) {
+	f.allowUnprotected = allow
+}
+
+// signer returns the signer for the current block height
+func (f *ForkSigner) signer() TxSigner {
+	signer := SignerForFork(f.config, f.blockNumber())
+
+	if eip155Signer, ok := signer.(*EIP155Signer); ok {
+		eip155Signer.SetAllowUnprotected(f.allowUnprotected)
+	}
+
+	return signer
+}
+
+// Hash returns the signing hash of the transaction
+func (f *ForkSigner) Hash(tx *types.Transaction) types.Hash {
+	return f.signer().Hash(tx)
+}
+
+// Sender returns the transaction sender
+func (f *ForkSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	return f.signer().Sender(tx)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (f *ForkSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	return f.signer().SignTx(tx, privateKey)
+}
+
+// CalculateV calculates the V value based on the signer for the current block height
+func (f *ForkSigner) CalculateV(parity byte) []byte {
+	return f.signer().CalculateV(parity)
+}
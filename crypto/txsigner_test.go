@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -133,8 +134,350 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 				assert.Equal(t, recoveredSender.String(), PubKeyToAddress(&key.PublicKey).String())
 			} else {
 				// There should be an error for mismatched chain IDs
-				assert.Error(t, recoverErr)
+				assert.ErrorIs(t, recoverErr, ErrInvalidChainID)
 			}
 		}
 	}
 }
+
+func TestEIP155Signer_UnprotectedTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	// pre EIP155 transaction, without the chain ID in V
+	signedTx, err := (&FrontierSigner{}).SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(0),
+	}, key)
+	assert.NoError(t, err)
+
+	signer := NewEIP155Signer(100)
+
+	// unprotected transactions are accepted by default
+	recoveredSender, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+	signer.SetAllowUnprotected(false)
+
+	_, err = signer.Sender(signedTx)
+	assert.ErrorIs(t, err, ErrUnprotectedTx)
+
+	// replay protected transactions are still accepted
+	protectedTx, err := signer.SignTx(signedTx, key)
+	assert.NoError(t, err)
+
+	recoveredSender, err = signer.Sender(protectedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+}
+
+func TestEIP155Signer_InvalidV(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	signer := NewEIP155Signer(100)
+
+	signedTx, err := signer.SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(0),
+	}, key)
+	assert.NoError(t, err)
+
+	// V values that can't encode a chain ID
+	for _, v := range []int64{0, 30, 34} {
+		signedTx.V = big.NewInt(v)
+
+		_, err = signer.Sender(signedTx)
+		assert.ErrorIs(t, err, ErrInvalidChainID)
+	}
+}
+
+func TestEIP155Signer_DynamicFeeTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:                 types.DynamicFeeTx,
+		To:                   &toAddress,
+		Value:                big.NewInt(1),
+		MaxFeePerGas:         big.NewInt(10),
+		MaxPriorityFeePerGas: big.NewInt(1),
+	}
+
+	signer := NewEIP155Signer(100)
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+
+	// typed transactions carry the chain ID and the raw signature parity
+	assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+	assert.True(t, signedTx.V.Uint64() <= 1)
+
+	// the sender is recovered after a round trip through the envelope encoding
+	decodedTx := new(types.Transaction)
+	assert.NoError(t, decodedTx.UnmarshalRLP(signedTx.MarshalRLP()))
+
+	recoveredSender, err := signer.Sender(decodedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+	// the signing hash differs from the legacy one
+	legacyTx := signedTx.Copy()
+	legacyTx.Type = types.LegacyTx
+	assert.NotEqual(t, signer.Hash(legacyTx), signer.Hash(signedTx))
+}
+
+func TestEIP155Signer_AccessListTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:     types.AccessListTx,
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(10),
+		AccessList: types.AccessList{
+			{
+				Address:     toAddress,
+				StorageKeys: []types.Hash{types.StringToHash("1")},
+			},
+		},
+	}
+
+	signer := NewEIP155Signer(100)
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+	assert.True(t, signedTx.V.Uint64() <= 1)
+
+	// the sender is recovered after a round trip through the envelope encoding
+	decodedTx := new(types.Transaction)
+	assert.NoError(t, decodedTx.UnmarshalRLP(signedTx.MarshalRLP()))
+
+	recoveredSender, err := signer.Sender(decodedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+	// the access list is covered by the signature
+	tamperedTx := decodedTx.Copy()
+	tamperedTx.AccessList[0].StorageKeys[0] = types.StringToHash("2")
+
+	tamperedSender, err := signer.Sender(tamperedTx)
+	if err == nil {
+		assert.NotEqual(t, recoveredSender, tamperedSender)
+	}
+
+	// the signature is bound to the chain ID
+	_, err = NewEIP155Signer(1).Sender(decodedTx)
+	assert.Error(t, err)
+}
+
+func TestHomesteadSigner_HighS(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	signedTx, err := (&FrontierSigner{}).SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(0),
+	}, key)
+	assert.NoError(t, err)
+
+	from, err := (&HomesteadSigner{}).Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// flip the signature into its malleable (high S) form
+	malleableTx := signedTx.Copy()
+	malleableTx.S = new(big.Int).Sub(new(big.Int).SetBytes(secp256k1N), signedTx.S)
+
+	if signedTx.V.Uint64() == 27 {
+		malleableTx.V = big.NewInt(28)
+	} else {
+		malleableTx.V = big.NewInt(27)
+	}
+
+	// frontier accepts the malleable signature, homestead doesn't
+	from, err = (&FrontierSigner{}).Sender(malleableTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	_, err = (&HomesteadSigner{}).Sender(malleableTx)
+	assert.Error(t, err)
+}
+
+func TestHomesteadSigner_TypedTx(t *testing.T) {
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	for _, txType := range []types.TxType{types.AccessListTx, types.DynamicFeeTx} {
+		tx := &types.Transaction{
+			Type:                 txType,
+			To:                   &toAddress,
+			Value:                big.NewInt(1),
+			GasPrice:             big.NewInt(1),
+			MaxFeePerGas:         big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1),
+		}
+
+		// the signers before EIP155 don't know the typed transactions
+		_, err := (&FrontierSigner{}).SignTx(tx, key)
+		assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+
+		signedTx, err := NewEIP155Signer(100).SignTx(tx, key)
+		assert.NoError(t, err)
+
+		_, err = (&FrontierSigner{}).Sender(signedTx)
+		assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+
+		_, err = (&HomesteadSigner{}).Sender(signedTx)
+		assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+	}
+}
+
+func TestSignerForFork(t *testing.T) {
+	config := &chain.Params{
+		ChainID: 100,
+		Forks: &chain.Forks{
+			Homestead: chain.NewFork(5),
+			EIP155:    chain.NewFork(10),
+		},
+	}
+
+	testCases := []struct {
+		blockNumber uint64
+		expected    TxSigner
+	}{
+		{0, &FrontierSigner{}},
+		{4, &FrontierSigner{}},
+		{5, &HomesteadSigner{}},
+		{9, &HomesteadSigner{}},
+		{10, &EIP155Signer{
+			chainID:          100,
+			allowUnprotected: true,
+			forks:            &chain.ForksInTime{Homestead: true, EIP155: true},
+		}},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, SignerForFork(config, test.blockNumber))
+	}
+}
+
+func TestSignerForFork_TypedTx(t *testing.T) {
+	config := &chain.Params{
+		ChainID: 100,
+		Forks: &chain.Forks{
+			EIP155: chain.NewFork(0),
+			Berlin: chain.NewFork(5),
+			London: chain.NewFork(10),
+		},
+	}
+
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		txType      types.TxType
+		activeBlock uint64
+	}{
+		{types.AccessListTx, 5},
+		{types.DynamicFeeTx, 10},
+	}
+
+	for _, test := range testCases {
+		signedTx, err := NewEIP155Signer(100).SignTx(&types.Transaction{
+			Type:                 test.txType,
+			To:                   &toAddress,
+			Value:                big.NewInt(1),
+			GasPrice:             big.NewInt(1),
+			MaxFeePerGas:         big.NewInt(1),
+			MaxPriorityFeePerGas: big.NewInt(1),
+		}, key)
+		assert.NoError(t, err)
+
+		// the typed transaction is recovered once its fork is active
+		_, err = SignerForFork(config, test.activeBlock-1).Sender(signedTx)
+		assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+
+		from, err := SignerForFork(config, test.activeBlock).Sender(signedTx)
+		assert.NoError(t, err)
+		assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+	}
+}
+
+func TestForkSigner(t *testing.T) {
+	config := &chain.Params{
+		ChainID: 100,
+		Forks: &chain.Forks{
+			EIP155: chain.NewFork(10),
+		},
+	}
+
+	toAddress := types.StringToAddress("1")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	blockNumber := uint64(1)
+	signer := NewForkSigner(config, func() uint64 {
+		return blockNumber
+	})
+
+	// pre EIP155 the transaction is signed without replay protection
+	signedTx, err := signer.SignTx(&types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(0),
+	}, key)
+	assert.NoError(t, err)
+	assert.True(t, signedTx.V.Uint64() == 27 || signedTx.V.Uint64() == 28)
+
+	blockNumber = 10
+
+	// the EIP155 signer accepts the legacy transaction
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// and signs new transactions for the chain
+	protectedTx, err := signer.SignTx(signedTx, key)
+	assert.NoError(t, err)
+	assert.True(t, protectedTx.V.Uint64() == 235 || protectedTx.V.Uint64() == 236)
+
+	blockNumber = 1
+
+	// replay protected transactions can't be recovered before EIP155
+	_, err = signer.Sender(protectedTx)
+	assert.Error(t, err)
+
+	blockNumber = 10
+	signer.SetAllowUnprotected(false)
+
+	// the EIP155 signer rejects the legacy transaction, if set
+	_, err = signer.Sender(signedTx)
+	assert.ErrorIs(t, err, ErrUnprotectedTx)
+
+	from, err = signer.Sender(protectedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+}
@@ -0,0 +1,402 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// type of the EIP-712 domain, which every typed data defines
+const typedDataDomainType = "EIP712Domain"
+
+var (
+	errTypedDataInvalidSignature = errors.New("invalid typed data signature length")
+
+	// matches the array types, i.e. Person[] or uint256[2]
+	typedDataArrayRegexp = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+)
+
+// TypedDataField is a member of an EIP-712 struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the EIP-712 structured data, in the format of eth_signTypedData_v4
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// Hash returns the EIP-712 hash of the typed data, which is signed:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func (td *TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := td.HashStruct(typedDataDomainType, td.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the domain: %w", err)
+	}
+
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the message: %w", err)
+	}
+
+	return Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// HashStruct returns the hash of the struct of the given type:
+// keccak256(typeHash ‖ encodeData(data))
+func (td *TypedData) HashStruct(typeName string, data map[string]interface{}) ([]byte, error) {
+	encoded, err := td.encodeData(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256(encoded), nil
+}
+
+// TypeHash returns the hash of the encoded type
+func (td *TypedData) TypeHash(typeName string) ([]byte, error) {
+	encodedType, err := td.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return Keccak256([]byte(encodedType)), nil
+}
+
+// EncodeType returns the encoding of the type, followed by
+// the encodings of the struct types it references, sorted by name
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	deps := make(map[string]struct{})
+	if err := td.dependencies(typeName, deps); err != nil {
+		return "", err
+	}
+
+	delete(deps, typeName)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+
+	sort.Strings(sorted)
+
+	var buf strings.Builder
+
+	for _, name := range append([]string{typeName}, sorted...) {
+		fields := make([]string, len(td.Types[name]))
+		for i, field := range td.Types[name] {
+			fields[i] = field.Type + " " + field.Name
+		}
+
+		buf.WriteString(name + "(" + strings.Join(fields, ",") + ")")
+	}
+
+	return buf.String(), nil
+}
+
+// dependencies collects the struct types referenced by the type, including itself
+func (td *TypedData) dependencies(typeName string, deps map[string]struct{}) error {
+	if _, ok := deps[typeName]; ok {
+		return nil
+	}
+
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return fmt.Errorf("unknown type %s", typeName)
+	}
+
+	deps[typeName] = struct{}{}
+
+	for _, field := range fields {
+		if fieldType := baseType(field.Type); td.isStruct(fieldType) {
+			if err := td.dependencies(fieldType, deps); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// baseType strips the array dimensions of the type
+func baseType(typeName string) string {
+	for {
+		match := typedDataArrayRegexp.FindStringSubmatch(typeName)
+		if match == nil {
+			return typeName
+		}
+
+		typeName = match[1]
+	}
+}
+
+func (td *TypedData) isStruct(typeName string) bool {
+	_, ok := td.Types[typeName]
+
+	return ok
+}
+
+// encodeData encodes the struct as the type hash followed by its encoded members
+func (td *TypedData) encodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(typeHash)
+
+	for _, field := range td.Types[typeName] {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing field %s of %s", field.Name, typeName)
+		}
+
+		encoded, err := td.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field %s of %s: %w", field.Name, typeName, err)
+		}
+
+		buf.Write(encoded)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeValue encodes a member of a struct as 32 bytes
+func (td *TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	// arrays are the hash of their concatenated encoded items
+	if match := typedDataArrayRegexp.FindStringSubmatch(typeName); match != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an array for %s", typeName)
+		}
+
+		if match[2] != "" {
+			if size, _ := strconv.Atoi(match[2]); size != len(items) {
+				return nil, fmt.Errorf("expected %d items for %s, got %d", size, typeName, len(items))
+			}
+		}
+
+		var buf bytes.Buffer
+
+		for _, item := range items {
+			encoded, err := td.encodeValue(match[1], item)
+			if err != nil {
+				return nil, err
+			}
+
+			buf.Write(encoded)
+		}
+
+		return Keccak256(buf.Bytes()), nil
+	}
+
+	// structs are encoded as their hash
+	if td.isStruct(typeName) {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object for %s", typeName)
+		}
+
+		return td.HashStruct(typeName, data)
+	}
+
+	return encodeAtomicValue(typeName, value)
+}
+
+// encodeAtomicValue encodes a value of an elementary type
+func encodeAtomicValue(typeName string, value interface{}) ([]byte, error) {
+	switch {
+	case typeName == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string")
+		}
+
+		return Keccak256([]byte(str)), nil
+
+	case typeName == "bytes":
+		buf, err := parseTypedBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return Keccak256(buf), nil
+
+	case strings.HasPrefix(typeName, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typeName, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type %s", typeName)
+		}
+
+		buf, err := parseTypedBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(buf) != size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", size, len(buf))
+		}
+
+		return rightPad32(buf), nil
+
+	case typeName == "address":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an address")
+		}
+
+		addr := types.Address{}
+		if err := addr.UnmarshalText([]byte(str)); err != nil {
+			return nil, err
+		}
+
+		return leftPad32(addr.Bytes()), nil
+
+	case typeName == "bool":
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool")
+		}
+
+		if flag {
+			return leftPad32([]byte{1}), nil
+		}
+
+		return leftPad32(nil), nil
+
+	case strings.HasPrefix(typeName, "uint"), strings.HasPrefix(typeName, "int"):
+		num, err := parseTypedInteger(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if num.Sign() < 0 {
+			if strings.HasPrefix(typeName, "uint") {
+				return nil, fmt.Errorf("negative value for %s", typeName)
+			}
+
+			// two's complement
+			num = new(big.Int).Add(num, new(big.Int).Lsh(big1, 256))
+		}
+
+		if num.BitLen() > 256 {
+			return nil, fmt.Errorf("value overflows %s", typeName)
+		}
+
+		return leftPad32(num.Bytes()), nil
+	}
+
+	return nil, fmt.Errorf("unknown type %s", typeName)
+}
+
+// parseTypedBytes parses a hex encoded byte string
+func parseTypedBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return hex.DecodeHex(v)
+	default:
+		return nil, fmt.Errorf("expected hex encoded bytes")
+	}
+}
+
+// parseTypedInteger parses a JSON number or a decimal or hex encoded string
+func parseTypedInteger(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, nil
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer %v", v)
+		}
+
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return parseTypedInteger(v.String())
+	case string:
+		num, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", v)
+		}
+
+		return num, nil
+	default:
+		return nil, fmt.Errorf("expected an integer")
+	}
+}
+
+func leftPad32(buf []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded[32-len(buf):], buf)
+
+	return padded
+}
+
+func rightPad32(buf []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded, buf)
+
+	return padded
+}
+
+// SignTypedData signs the EIP-712 hash of the typed data,
+// returning the signature in the [R || S || V] format where V is 0 or 1
+func SignTypedData(priv *ecdsa.PrivateKey, td *TypedData) ([]byte, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return Sign(priv, hash)
+}
+
+// RecoverTypedDataSigner returns the address which signed the typed data.
+// V can be 0 or 1, or 27 or 28 as produced by the wallets
+func RecoverTypedDataSigner(td *TypedData, sig []byte) (types.Address, error) {
+	if len(sig) != 65 {
+		return types.ZeroAddress, errTypedDataInvalidSignature
+	}
+
+	hash, err := td.Hash()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	sig = append([]byte{}, sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	pub, err := SigToPub(hash, sig)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return PubKeyToAddress(pub), nil
+}
+
+// VerifyTypedData checks if the typed data was signed by the given address
+func VerifyTypedData(td *TypedData, sig []byte, signer types.Address) (bool, error) {
+	recovered, err := RecoverTypedDataSigner(td, sig)
+	if err != nil {
+		return false, err
+	}
+
+	return recovered == signer, nil
+}
@@ -0,0 +1,170 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// the example of the EIP-712 specification
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {
+			"name": "Cow",
+			"wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+		},
+		"to": {
+			"name": "Bob",
+			"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"
+		},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func newMailTypedData(t *testing.T) *TypedData {
+	t.Helper()
+
+	td := &TypedData{}
+	assert.NoError(t, json.Unmarshal([]byte(mailTypedData), td))
+
+	return td
+}
+
+func TestTypedData_Hash(t *testing.T) {
+	td := newMailTypedData(t)
+
+	encodedType, err := td.EncodeType("Mail")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encodedType)
+
+	typeHash, err := td.TypeHash("Mail")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2", hex.EncodeToHex(typeHash))
+
+	messageHash, err := td.HashStruct("Mail", td.Message)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToHex(messageHash))
+
+	domainSeparator, err := td.HashStruct("EIP712Domain", td.Domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToHex(domainSeparator))
+
+	hash, err := td.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToHex(hash))
+}
+
+func TestTypedData_Signature(t *testing.T) {
+	td := newMailTypedData(t)
+
+	// the signer of the specification example is keccak256("cow")
+	priv, err := ParsePrivateKey(Keccak256([]byte("cow")))
+	assert.NoError(t, err)
+
+	signer := types.StringToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+	assert.Equal(t, signer, PubKeyToAddress(&priv.PublicKey))
+
+	sig, err := SignTypedData(priv, td)
+	assert.NoError(t, err)
+
+	// r, s and v (28) of the specification example
+	expected := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "01"
+	assert.Equal(t, expected, hex.EncodeToHex(sig))
+
+	// the wallet encoding of v is accepted as well
+	walletSig := append(append([]byte{}, sig[:64]...), 28)
+
+	for _, s := range [][]byte{sig, walletSig} {
+		valid, err := VerifyTypedData(td, s, signer)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// a modified message doesn't match the signature
+	td.Message["contents"] = "Hello, Alice!"
+
+	valid, err := VerifyTypedData(td, sig, signer)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = RecoverTypedDataSigner(td, sig[:64])
+	assert.ErrorIs(t, err, errTypedDataInvalidSignature)
+}
+
+func TestTypedData_Arrays(t *testing.T) {
+	td := newMailTypedData(t)
+
+	td.Types["Group"] = []TypedDataField{
+		{Name: "members", Type: "Person[]"},
+		{Name: "ids", Type: "uint8[2]"},
+	}
+
+	from, ok := td.Message["from"].(map[string]interface{})
+	assert.True(t, ok)
+
+	to, ok := td.Message["to"].(map[string]interface{})
+	assert.True(t, ok)
+
+	group := map[string]interface{}{
+		"members": []interface{}{from, to},
+		"ids":     []interface{}{float64(1), "0x2"},
+	}
+
+	encodedType, err := td.EncodeType("Group")
+	assert.NoError(t, err)
+	assert.Equal(t, "Group(Person[] members,uint8[2] ids)Person(string name,address wallet)", encodedType)
+
+	// arrays are encoded as the hash of their concatenated encoded items
+	fromHash, err := td.HashStruct("Person", from)
+	assert.NoError(t, err)
+
+	toHash, err := td.HashStruct("Person", to)
+	assert.NoError(t, err)
+
+	typeHash, err := td.TypeHash("Group")
+	assert.NoError(t, err)
+
+	expected := Keccak256(
+		typeHash,
+		Keccak256(fromHash, toHash),
+		Keccak256(leftPad32([]byte{1}), leftPad32([]byte{2})),
+	)
+
+	groupHash, err := td.HashStruct("Group", group)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, groupHash)
+
+	// fixed size arrays are checked
+	group["ids"] = []interface{}{float64(1)}
+
+	_, err = td.HashStruct("Group", group)
+	assert.Error(t, err)
+}
@@ -77,7 +77,7 @@ func TestBroadcast(t *testing.T) {
 				To:       &receiverAddr,
 				Value:    framework.EthToWei(1),
 				Gas:      1000000,
-				GasPrice: big.NewInt(10000),
+				GasPrice: big.NewInt(framework.DefaultGasPrice),
 				Input:    []byte{},
 			}, senderKey)
 			if err != nil {
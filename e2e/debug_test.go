@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebug_TraceTransaction(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	contractAddr := types.StringToAddress("0x1000")
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetRPCNamespaces(append(jsonrpc.DefaultNamespaces(), jsonrpc.NamespaceDebug)...)
+		config.Premine(senderAddr, framework.EthToWei(10))
+		config.PremineContract(contractAddr, []byte{
+			0x60, 0x2a, 0x60, 0x01, 0x55, // PUSH1 42 PUSH1 1 SSTORE
+			0x00, // STOP
+		}, nil)
+	})
+	srv := srvs[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     senderAddr,
+		To:       &contractAddr,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      framework.DefaultGasLimit,
+	}, senderKey)
+	assert.NoError(t, err)
+
+	if receipt == nil {
+		t.FailNow()
+	}
+
+	var trace tracer.StructLogResult
+
+	assert.NoError(t, srv.JSONRPC().Call(
+		"debug_traceTransaction",
+		&trace,
+		receipt.TransactionHash,
+		map[string]interface{}{"disableMemory": true},
+	))
+
+	assert.False(t, trace.Failed)
+	assert.Equal(t, receipt.GasUsed, trace.Gas)
+
+	ops := make([]string, len(trace.StructLogs))
+	for i, log := range trace.StructLogs {
+		ops[i] = log.Op
+	}
+
+	assert.Equal(t, []string{"PUSH1", "PUSH1", "SSTORE", "STOP"}, ops)
+
+	if len(trace.StructLogs) == len(ops) {
+		sstore := trace.StructLogs[2]
+		assert.Equal(t, []string{"0x2a", "0x1"}, sstore.Stack)
+		assert.Nil(t, sstore.Memory)
+		assert.Equal(t, map[string]string{fmt.Sprintf("%064x", 1): fmt.Sprintf("%064x", 42)}, sstore.Storage)
+	}
+}
@@ -0,0 +1,279 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/dev"
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/go-web3"
+)
+
+func TestDev_OnDemandMode(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetDevMode(dev.ModeOnDemand)
+		config.Premine(senderAddr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	assertBlockNumber := func(expected uint64) {
+		t.Helper()
+
+		number, err := client.Eth().BlockNumber()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, number)
+	}
+
+	// no blocks are sealed while the pool is empty
+	time.Sleep(3 * time.Second)
+	assertBlockNumber(0)
+
+	signedTx, err := signer.SignTx(&types.Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      framework.DefaultGasLimit,
+		To:       &receiverAddr,
+		Value:    oneEth,
+	}, senderKey)
+	assert.NoError(t, err)
+
+	start := time.Now()
+
+	response, err := srv.TxnPoolOperator().AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+		Raw: &any.Any{
+			Value: signedTx.MarshalRLP(),
+		},
+		From: types.ZeroAddress.String(),
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the transaction is sealed right away, well before the default interval
+	receipt, err := tests.WaitForReceipt(ctx, client.Eth(), web3.Hash(types.StringToHash(response.TxHash)))
+	assert.NoError(t, err)
+
+	if receipt == nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, uint64(1), receipt.BlockNumber)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	// and the node idles again afterwards
+	time.Sleep(3 * time.Second)
+	assertBlockNumber(1)
+}
+
+func TestDev_OnDemandMode_PromotionBurst(t *testing.T) {
+	// more promotions than a lagging pool event subscription can buffer
+	numTxs := 1100
+
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetDevMode(dev.ModeOnDemand)
+		config.Premine(senderAddr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	addTxn := func(nonce uint64) types.Hash {
+		t.Helper()
+
+		signedTx, err := signer.SignTx(&types.Transaction{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      21000,
+			To:       &receiverAddr,
+			Value:    big.NewInt(1),
+		}, senderKey)
+		assert.NoError(t, err)
+
+		response, err := srv.TxnPoolOperator().AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+			Raw: &any.Any{
+				Value: signedTx.MarshalRLP(),
+			},
+			From: types.ZeroAddress.String(),
+		})
+		assert.NoError(t, err)
+
+		return types.StringToHash(response.TxHash)
+	}
+
+	waitForReceipt := func(hash types.Hash) uint64 {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		receipt, err := tests.WaitForReceipt(ctx, client.Eth(), web3.Hash(hash))
+		assert.NoError(t, err)
+
+		if receipt == nil {
+			t.FailNow()
+		}
+
+		return receipt.BlockNumber
+	}
+
+	var lastHash types.Hash
+	for nonce := 0; nonce < numTxs; nonce++ {
+		lastHash = addTxn(uint64(nonce))
+	}
+
+	// the whole burst is sealed
+	burstBlock := waitForReceipt(lastHash)
+
+	// and the blocks are still sealed on demand afterwards
+	assert.Greater(t, waitForReceipt(addTxn(uint64(numTxs))), burstBlock)
+}
+
+func TestDev_HybridMode(t *testing.T) {
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetDevMode(dev.ModeHybrid)
+		config.SetDevInterval(1)
+	})[0]
+
+	// empty blocks are sealed once the idle interval passes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		number, err := srv.JSONRPC().Eth().BlockNumber()
+
+		return nil, err != nil || number < 2
+	})
+	assert.NoError(t, err)
+}
+
+func TestDev_SkipEmptyBlocks(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetDevMode(dev.ModeInterval)
+		config.SetDevInterval(1)
+		config.SetDevSkipEmpty(true)
+		config.Premine(senderAddr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	assertBlockNumber := func(expected uint64) {
+		t.Helper()
+
+		number, err := client.Eth().BlockNumber()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, number)
+	}
+
+	// the interval passes a few times without any block
+	time.Sleep(3 * time.Second)
+	assertBlockNumber(0)
+
+	signedTx, err := signer.SignTx(&types.Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      framework.DefaultGasLimit,
+		To:       &receiverAddr,
+		Value:    oneEth,
+	}, senderKey)
+	assert.NoError(t, err)
+
+	response, err := srv.TxnPoolOperator().AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+		Raw: &any.Any{
+			Value: signedTx.MarshalRLP(),
+		},
+		From: types.ZeroAddress.String(),
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the transaction is sealed on the next interval
+	receipt, err := tests.WaitForReceipt(ctx, client.Eth(), web3.Hash(types.StringToHash(response.TxHash)))
+	assert.NoError(t, err)
+
+	if receipt == nil {
+		t.FailNow()
+	}
+
+	assert.Equal(t, uint64(1), receipt.BlockNumber)
+
+	// and the node idles again afterwards
+	time.Sleep(3 * time.Second)
+	assertBlockNumber(1)
+}
+
+func TestDev_InclusionDeadline(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetDevMode(dev.ModeHybrid)
+		config.SetDevInterval(1)
+		config.Premine(senderAddr, framework.EthToWei(10))
+	})[0]
+
+	// the nonce gap keeps the tx from being included, the chain stalls with empty blocks
+	signedTx, err := signer.SignTx(&types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      framework.DefaultGasLimit,
+		To:       &receiverAddr,
+		Value:    oneEth,
+	}, senderKey)
+	assert.NoError(t, err)
+
+	head, err := srv.JSONRPC().Eth().BlockNumber()
+	assert.NoError(t, err)
+
+	response, err := srv.TxnPoolOperator().AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+		Raw: &any.Any{
+			Value: signedTx.MarshalRLP(),
+		},
+		From:      types.ZeroAddress.String(),
+		TtlBlocks: 2,
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// the tx is dropped once the chain advances past the block window
+	_, err = tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		status, err := srv.TxnPoolOperator().TxStatus(ctx, &txpoolOp.TxStatusReq{Hash: response.TxHash})
+
+		return nil, err != nil || status.Known
+	})
+	assert.NoError(t, err)
+
+	number, err := srv.JSONRPC().Eth().BlockNumber()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, number, head+2)
+}
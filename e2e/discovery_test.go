@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/stretchr/testify/assert"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -106,3 +108,49 @@ func TestDiscovery(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscovery_RPCPeers(t *testing.T) {
+	srvs := framework.NewTestServers(t, 2, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDummy)
+		config.SetRPCNamespaces(append(jsonrpc.DefaultNamespaces(), jsonrpc.NamespaceAdmin)...)
+	})
+
+	status, err := srvs[1].Operator().GetStatus(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := srvs[0].Operator().PeersAdd(ctx, &proto.PeersAddRequest{
+		Id: strings.Split(status.P2PAddr, ",")[0],
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, srv := range srvs {
+		if _, err := framework.WaitUntilPeerConnects(ctx, srv, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		var peerCount string
+
+		assert.NoError(t, srv.JSONRPC().Call("net_peerCount", &peerCount))
+		assert.Equal(t, "1", peerCount)
+
+		var peers []*jsonrpc.PeerInfo
+
+		assert.NoError(t, srv.JSONRPC().Call("admin_peers", &peers))
+		assert.Len(t, peers, 1)
+
+		// the first node dialed the second one
+		expectedDirection := "inbound"
+		if i == 0 {
+			expectedDirection = "outbound"
+		}
+
+		assert.Contains(t, peers[0].Directions, expectedDirection)
+		assert.NotEmpty(t, peers[0].Addrs)
+	}
+}
@@ -0,0 +1,92 @@
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ClusterTopology int
+
+const (
+	// ClusterFullMesh connects every node to every other node
+	ClusterFullMesh ClusterTopology = iota
+	// ClusterRing connects every node to its successor, the last one to the first
+	ClusterRing
+)
+
+// TestCluster is a set of test servers connected to each other
+type TestCluster struct {
+	Servers []*TestServer
+}
+
+// NewTestCluster starts num servers, connects them using the given topology
+// and waits until every node reports the expected number of peers.
+// The servers are stopped and their data directories removed when the test finishes
+func NewTestCluster(
+	t *testing.T,
+	num int,
+	topology ClusterTopology,
+	conf func(*TestServerConfig),
+) *TestCluster {
+	t.Helper()
+
+	cluster := &TestCluster{
+		Servers: NewTestServers(t, num, conf),
+	}
+
+	if num < 2 {
+		return cluster
+	}
+
+	var (
+		dials         []*TestServer
+		requiredPeers int
+	)
+
+	switch topology {
+	case ClusterFullMesh:
+		for i := 0; i < num; i++ {
+			for j := i + 1; j < num; j++ {
+				dials = append(dials, cluster.Servers[i], cluster.Servers[j])
+			}
+		}
+
+		requiredPeers = num - 1
+	case ClusterRing:
+		for i := 0; i < num; i++ {
+			dials = append(dials, cluster.Servers[i], cluster.Servers[(i+1)%num])
+		}
+
+		// a ring of two nodes is a single connection
+		requiredPeers = 2
+		if num == 2 {
+			requiredPeers = 1
+			dials = dials[:2]
+		}
+	default:
+		t.Fatalf("unknown cluster topology %d", topology)
+	}
+
+	MultiJoin(t, dials...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for i, srv := range cluster.Servers {
+		if _, err := WaitUntilPeerConnects(ctx, srv, requiredPeers); err != nil {
+			t.Fatalf("node %d failed to connect to %d peers, %v", i, requiredPeers, err)
+		}
+	}
+
+	return cluster
+}
+
+// GetServer returns the i-th server of the cluster, or nil if it doesn't exist
+func (c *TestCluster) GetServer(i int) *TestServer {
+	if i >= len(c.Servers) {
+		return nil
+	}
+
+	return c.Servers[i]
+}
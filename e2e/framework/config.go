@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"path/filepath"
 
+	"github.com/0xPolygon/polygon-edge/consensus/dev"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -23,6 +24,13 @@ type SrvAccount struct {
 	Balance *big.Int
 }
 
+// SrvContract is a contract deployed at genesis
+type SrvContract struct {
+	Addr    types.Address
+	Code    []byte
+	Storage map[types.Hash]types.Hash
+}
+
 // TestServerConfig for the test server
 type TestServerConfig struct {
 	ReservedPorts           []ReservedPort
@@ -34,20 +42,34 @@ type TestServerConfig struct {
 	IBFTDirPrefix           string               // The prefix of data directory for IBFT
 	IBFTDir                 string               // The name of data directory for IBFT
 	PremineAccts            []*SrvAccount        // Accounts with existing balances (genesis accounts)
+	PremineContracts        []*SrvContract       // Contracts with existing code and storage (genesis accounts)
 	GenesisValidatorBalance *big.Int             // Genesis the balance for the validators
 	DevStakers              []types.Address      // List of initial staking addresses for the staking SC with dev consensus
 	Consensus               ConsensusType        // Consensus MechanismType
 	Bootnodes               []string             // Bootnode Addresses
 	PriceLimit              *uint64              // Minimum gas price limit to enforce for acceptance into the pool
+	MaxSlots                uint64               // Maximum number of slots in the pool
+	MaxAccountSlots         uint64               // Maximum number of slots a single account can occupy in the pool
 	DevInterval             int                  // Dev consensus update interval [s]
+	DevMode                 dev.Mode             // Dev consensus block production mode
+	DevSkipEmpty            bool                 // Flag specifying if the dev consensus skips empty blocks
 	EpochSize               uint64               // The epoch size in blocks for the IBFT layer
+	IBFTBaseTimeout         uint64               // Timeout of the first IBFT round [s]
 	BlockGasLimit           uint64               // Block gas limit
 	BlockGasTarget          uint64               // Gas target for new blocks
+	GenesisBlockGasTarget   uint64               // Gas target written into the genesis file
+	InitialBaseFee          uint64               // Base fee of the genesis block
+	London                  bool                 // Flag specifying if the London fork is enabled
 	ShowsLog                bool                 // Flag specifying if logs are shown
 	IsPos                   bool                 // Specifies the mechanism used for IBFT (PoA / PoS)
 	Signer                  *crypto.EIP155Signer // Signer used for transactions
 	MinValidatorCount       uint64               // Min validator count
 	MaxValidatorCount       uint64               // Max validator count
+	RPCGasCap               uint64               // Maximum gas of an eth_call or eth_estimateGas execution
+	RetainBlocks            uint64               // Number of latest blocks whose state is kept
+	RetainReceipts          uint64               // Number of latest blocks whose receipts are kept
+	SnapshotFile            string               // Path of the state snapshot to import on start
+	RPCNamespaces           []string             // Namespaces of the served JSON-RPC methods (the default ones if empty)
 }
 
 // DataDir returns path of data directory server uses
@@ -83,6 +105,15 @@ func (t *TestServerConfig) Premine(addr types.Address, amount *big.Int) {
 	})
 }
 
+// PremineContract callback specifies a contract with its code and storage slots
+func (t *TestServerConfig) PremineContract(addr types.Address, code []byte, storage map[types.Hash]types.Hash) {
+	t.PremineContracts = append(t.PremineContracts, &SrvContract{
+		Addr:    addr,
+		Code:    code,
+		Storage: storage,
+	})
+}
+
 // PremineValidatorBalance callback sets the genesis balance of the validator the server manages (in WEI)
 func (t *TestServerConfig) PremineValidatorBalance(balance *big.Int) {
 	t.GenesisValidatorBalance = balance
@@ -93,6 +124,22 @@ func (t *TestServerConfig) SetBlockGasTarget(target uint64) {
 	t.BlockGasTarget = target
 }
 
+// SetGenesisBlockGasTarget sets the gas target written into the genesis file,
+// independently of the target the server is started with
+func (t *TestServerConfig) SetGenesisBlockGasTarget(target uint64) {
+	t.GenesisBlockGasTarget = target
+}
+
+// SetInitialBaseFee sets the base fee of the genesis block
+func (t *TestServerConfig) SetInitialBaseFee(baseFee uint64) {
+	t.InitialBaseFee = baseFee
+}
+
+// SetLondon enables the London fork (EIP-1559 base fee) from the genesis block
+func (t *TestServerConfig) SetLondon(london bool) {
+	t.London = london
+}
+
 // SetConsensus callback sets consensus
 func (t *TestServerConfig) SetConsensus(c ConsensusType) {
 	t.Consensus = c
@@ -103,6 +150,18 @@ func (t *TestServerConfig) SetDevInterval(interval int) {
 	t.DevInterval = interval
 }
 
+// SetDevMode sets the block production mode for the dev consensus.
+// In the hybrid mode, the dev interval is the maximum idle interval
+func (t *TestServerConfig) SetDevMode(mode dev.Mode) {
+	t.DevMode = mode
+}
+
+// SetDevSkipEmpty sets whether the dev consensus skips sealing
+// empty blocks, idling while the pool is empty
+func (t *TestServerConfig) SetDevSkipEmpty(skip bool) {
+	t.DevSkipEmpty = skip
+}
+
 // SetDevStakingAddresses sets the Staking smart contract staker addresses for the dev mode.
 // These addresses should be passed into the `ibft-validator` flag in genesis generation.
 // Since invoking the dev consensus will not generate the ibft base folders, this is the only way
@@ -126,6 +185,11 @@ func (t *TestServerConfig) SetIBFTDir(ibftDir string) {
 	t.IBFTDir = ibftDir
 }
 
+// SetIBFTBaseTimeout sets the timeout of the first IBFT round in seconds
+func (t *TestServerConfig) SetIBFTBaseTimeout(timeout uint64) {
+	t.IBFTBaseTimeout = timeout
+}
+
 // SetSeal callback toggles the seal mode
 func (t *TestServerConfig) SetSeal(state bool) {
 	t.Seal = state
@@ -141,6 +205,16 @@ func (t *TestServerConfig) SetPriceLimit(priceLimit *uint64) {
 	t.PriceLimit = priceLimit
 }
 
+// SetMaxSlots sets the maximum number of slots in the pool
+func (t *TestServerConfig) SetMaxSlots(maxSlots uint64) {
+	t.MaxSlots = maxSlots
+}
+
+// SetMaxAccountSlots sets the maximum number of slots a single account can occupy in the pool
+func (t *TestServerConfig) SetMaxAccountSlots(maxAccountSlots uint64) {
+	t.MaxAccountSlots = maxAccountSlots
+}
+
 // SetBlockLimit sets the block gas limit
 func (t *TestServerConfig) SetBlockLimit(limit uint64) {
 	t.BlockGasLimit = limit
@@ -157,6 +231,31 @@ func (t *TestServerConfig) SetEpochSize(epochSize uint64) {
 	t.EpochSize = epochSize
 }
 
+// SetRPCGasCap sets the maximum gas of an eth_call or eth_estimateGas execution
+func (t *TestServerConfig) SetRPCGasCap(gasCap uint64) {
+	t.RPCGasCap = gasCap
+}
+
+// SetRetainBlocks sets the number of latest blocks whose state is kept
+func (t *TestServerConfig) SetRetainBlocks(retain uint64) {
+	t.RetainBlocks = retain
+}
+
+// SetRetainReceipts sets the number of latest blocks whose receipts are kept
+func (t *TestServerConfig) SetRetainReceipts(retain uint64) {
+	t.RetainReceipts = retain
+}
+
+// SetSnapshotFile sets the path of the state snapshot to import on start
+func (t *TestServerConfig) SetSnapshotFile(path string) {
+	t.SnapshotFile = path
+}
+
+// SetRPCNamespaces sets the namespaces of the served JSON-RPC methods
+func (t *TestServerConfig) SetRPCNamespaces(namespaces ...string) {
+	t.RPCNamespaces = namespaces
+}
+
 // SetMinValidatorCount sets the min validator count
 func (t *TestServerConfig) SetMinValidatorCount(val uint64) {
 	t.MinValidatorCount = val
@@ -26,6 +26,7 @@ import (
 	"github.com/umbracle/go-web3"
 	"github.com/umbracle/go-web3/jsonrpc"
 	"golang.org/x/crypto/sha3"
+	any "google.golang.org/protobuf/types/known/anypb"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -53,6 +54,23 @@ func GetAccountBalance(t *testing.T, address types.Address, rpcClient *jsonrpc.C
 	return accountBalance
 }
 
+// GetBlockBaseFee returns the base fee of the block with the given hash
+func GetBlockBaseFee(t *testing.T, hash web3.Hash, rpcClient *jsonrpc.Client) *big.Int {
+	t.Helper()
+
+	// the base fee is not part of the web3 block
+	var block struct {
+		BaseFee string `json:"baseFeePerGas"`
+	}
+
+	assert.NoError(t, rpcClient.Call("eth_getBlockByHash", &block, hash, false))
+
+	baseFee, err := types.ParseUint256orHex(&block.BaseFee)
+	assert.NoError(t, err)
+
+	return baseFee
+}
+
 // GetValidatorSet returns the validator set from the SC
 func GetValidatorSet(from types.Address, rpcClient *jsonrpc.Client) ([]types.Address, error) {
 	validatorsMethod, ok := abis.StakingABI.Methods["validators"]
@@ -64,11 +82,10 @@ func GetValidatorSet(from types.Address, rpcClient *jsonrpc.Client) ([]types.Add
 	selector := validatorsMethod.ID()
 	response, err := rpcClient.Eth().Call(
 		&web3.CallMsg{
-			From:     web3.Address(from),
-			To:       &toAddress,
-			Data:     selector,
-			GasPrice: 100000000,
-			Value:    big.NewInt(0),
+			From:  web3.Address(from),
+			To:    &toAddress,
+			Data:  selector,
+			Value: big.NewInt(0),
 		},
 		web3.Latest,
 	)
@@ -96,7 +113,7 @@ func StakeAmount(
 	txn := &PreparedTransaction{
 		From:     from,
 		To:       &staking.AddrStakingContract,
-		GasPrice: big.NewInt(10000),
+		GasPrice: big.NewInt(DefaultGasPrice),
 		Gas:      1000000,
 		Value:    amount,
 		Input:    MethodSig("stake"),
@@ -153,11 +170,10 @@ func GetStakedAmount(from types.Address, rpcClient *jsonrpc.Client) (*big.Int, e
 	selector := stakedAmountMethod.ID()
 	response, err := rpcClient.Eth().Call(
 		&web3.CallMsg{
-			From:     web3.Address(from),
-			To:       &toAddress,
-			Data:     selector,
-			GasPrice: 100000000,
-			Value:    big.NewInt(0),
+			From:  web3.Address(from),
+			To:    &toAddress,
+			Data:  selector,
+			Value: big.NewInt(0),
 		},
 		web3.Latest,
 	)
@@ -299,6 +315,99 @@ func WaitUntilTxPoolFilled(
 	return status, nil
 }
 
+// WaitForTxInPool waits until the transaction with the given hash is held by the node's txpool,
+// otherwise returns timeout
+func WaitForTxInPool(
+	ctx context.Context,
+	srv *TestServer,
+	hash types.Hash,
+) (*txpoolProto.TxStatusResp, error) {
+	clt := srv.TxnPoolOperator()
+	res, err := tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		subCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		res, _ := clt.TxStatus(subCtx, &txpoolProto.TxStatusReq{Hash: hash.String()})
+		if res != nil && res.Known {
+			return res, false
+		}
+
+		return nil, true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := res.(*txpoolProto.TxStatusResp)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	return status, nil
+}
+
+// WaitForTxGossip submits the signed transaction to the src node
+// and waits until it is propagated to the txpool of the dst node
+func WaitForTxGossip(
+	ctx context.Context,
+	src, dst *TestServer,
+	tx *types.Transaction,
+) error {
+	if _, err := src.TxnPoolOperator().AddTxn(ctx, &txpoolProto.AddTxnReq{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+		From: tx.From.String(),
+	}); err != nil {
+		return fmt.Errorf("unable to add transaction, %w", err)
+	}
+
+	if _, err := WaitForTxInPool(ctx, dst, tx.Hash); err != nil {
+		return fmt.Errorf("transaction not gossiped, %w", err)
+	}
+
+	return nil
+}
+
+// WaitForTxDrop waits until the transaction with the given hash leaves the txpool
+// without being included in a block, and returns the reason reported by the pool.
+// Only drops happening after the subscription is established are observed,
+// so it should be called before the action that is expected to evict the transaction
+func WaitForTxDrop(
+	ctx context.Context,
+	operator txpoolProto.TxnPoolOperatorClient,
+	hash types.Hash,
+) (string, error) {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := operator.Subscribe(subCtx, &txpoolProto.SubscribeRequest{
+		Types: []txpoolProto.EventType{
+			txpoolProto.EventType_DROPPED,
+			txpoolProto.EventType_REPLACED,
+			txpoolProto.EventType_PRUNED_ENQUEUED,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to subscribe to txpool events, %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return "", ctxErr
+			}
+
+			return "", fmt.Errorf("txpool event stream closed, %w", err)
+		}
+
+		if event.TxHash == hash.String() {
+			return event.Reason, nil
+		}
+	}
+}
+
 // WaitUntilBlockMined waits until server mined block with bigger height than given height
 // otherwise returns timeout
 func WaitUntilBlockMined(ctx context.Context, srv *TestServer, desiredHeight uint64) (uint64, error) {
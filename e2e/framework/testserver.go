@@ -16,8 +16,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
+	"github.com/0xPolygon/polygon-edge/command/helper"
 	ibftSwitch "github.com/0xPolygon/polygon-edge/command/ibft/switch"
 	initCmd "github.com/0xPolygon/polygon-edge/command/secrets/init"
 	"github.com/0xPolygon/polygon-edge/command/server"
@@ -304,10 +306,59 @@ func (t *TestServer) GenerateGenesis() error {
 	blockGasLimit := strconv.FormatUint(t.Config.BlockGasLimit, 10)
 	args = append(args, "--block-gas-limit", blockGasLimit)
 
+	if t.Config.GenesisBlockGasTarget != 0 {
+		args = append(args, "--block-gas-target", strconv.FormatUint(t.Config.GenesisBlockGasTarget, 10))
+	}
+
+	if t.Config.London {
+		args = append(args, "--london")
+	}
+
+	if t.Config.InitialBaseFee != 0 {
+		args = append(args, "--base-fee", strconv.FormatUint(t.Config.InitialBaseFee, 10))
+	}
+
 	cmd := exec.Command(binaryName, args...)
 	cmd.Dir = t.Config.RootDir
 
-	return cmd.Run()
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	return t.premineContracts()
+}
+
+// premineContracts writes the code and storage of the premined contracts
+// into the allocation of the generated genesis file
+func (t *TestServer) premineContracts() error {
+	if len(t.Config.PremineContracts) == 0 {
+		return nil
+	}
+
+	genesisPath := filepath.Join(t.Config.RootDir, "genesis.json")
+
+	chainConfig, err := chain.ImportFromFile(genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis: %w", err)
+	}
+
+	if chainConfig.Genesis.Alloc == nil {
+		chainConfig.Genesis.Alloc = map[types.Address]*chain.GenesisAccount{}
+	}
+
+	for _, contract := range t.Config.PremineContracts {
+		// a premined balance of the same address is kept
+		account, ok := chainConfig.Genesis.Alloc[contract.Addr]
+		if !ok {
+			account = &chain.GenesisAccount{}
+			chainConfig.Genesis.Alloc[contract.Addr] = account
+		}
+
+		account.Code = contract.Code
+		account.Storage = contract.Storage
+	}
+
+	return helper.WriteGenesisConfigToDisk(chainConfig, genesisPath)
 }
 
 func (t *TestServer) Start(ctx context.Context) error {
@@ -327,6 +378,10 @@ func (t *TestServer) Start(ctx context.Context) error {
 	switch t.Config.Consensus {
 	case ConsensusIBFT:
 		args = append(args, "--data-dir", filepath.Join(t.Config.RootDir, t.Config.IBFTDir))
+
+		if t.Config.IBFTBaseTimeout != 0 {
+			args = append(args, "--ibft-base-timeout", strconv.FormatUint(t.Config.IBFTBaseTimeout, 10))
+		}
 	case ConsensusDev:
 		args = append(args, "--data-dir", t.Config.RootDir)
 		args = append(args, "--dev")
@@ -334,6 +389,14 @@ func (t *TestServer) Start(ctx context.Context) error {
 		if t.Config.DevInterval != 0 {
 			args = append(args, "--dev-interval", strconv.Itoa(t.Config.DevInterval))
 		}
+
+		if t.Config.DevMode != "" {
+			args = append(args, "--dev-mode", string(t.Config.DevMode))
+		}
+
+		if t.Config.DevSkipEmpty {
+			args = append(args, "--dev-skip-empty")
+		}
 	case ConsensusDummy:
 		args = append(args, "--data-dir", t.Config.RootDir)
 	}
@@ -346,6 +409,14 @@ func (t *TestServer) Start(ctx context.Context) error {
 		args = append(args, "--price-limit", strconv.FormatUint(*t.Config.PriceLimit, 10))
 	}
 
+	if t.Config.MaxSlots != 0 {
+		args = append(args, "--max-slots", strconv.FormatUint(t.Config.MaxSlots, 10))
+	}
+
+	if t.Config.MaxAccountSlots != 0 {
+		args = append(args, "--max-account-slots", strconv.FormatUint(t.Config.MaxAccountSlots, 10))
+	}
+
 	if t.Config.ShowsLog {
 		args = append(args, "--log-level", "debug")
 	}
@@ -355,6 +426,26 @@ func (t *TestServer) Start(ctx context.Context) error {
 		args = append(args, "--block-gas-target", *types.EncodeUint64(t.Config.BlockGasTarget))
 	}
 
+	if t.Config.RPCGasCap != 0 {
+		args = append(args, "--json-rpc-gas-cap", strconv.FormatUint(t.Config.RPCGasCap, 10))
+	}
+
+	if t.Config.RetainBlocks != 0 {
+		args = append(args, "--retain-blocks", strconv.FormatUint(t.Config.RetainBlocks, 10))
+	}
+
+	if t.Config.RetainReceipts != 0 {
+		args = append(args, "--retain-receipts", strconv.FormatUint(t.Config.RetainReceipts, 10))
+	}
+
+	if t.Config.SnapshotFile != "" {
+		args = append(args, "--import-snapshot", t.Config.SnapshotFile)
+	}
+
+	if len(t.Config.RPCNamespaces) != 0 {
+		args = append(args, "--json-rpc-namespaces", strings.Join(t.Config.RPCNamespaces, ","))
+	}
+
 	t.ReleaseReservedPorts()
 
 	// Start the server
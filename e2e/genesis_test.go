@@ -1,6 +1,7 @@
 package e2e
 
 import (
+	"bytes"
 	"context"
 	"github.com/0xPolygon/polygon-edge/command"
 	"math/big"
@@ -9,11 +10,13 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/go-web3"
 )
 
 // Test if the custom block gas limit is properly set
@@ -59,6 +62,29 @@ func TestGenesisDefaultBlockGasLimit(t *testing.T) {
 	}
 }
 
+// Test if the custom base fee is set on the genesis block
+func TestGenesisCustomBaseFee(t *testing.T) {
+	var baseFee uint64 = 2000000000
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetLondon(true)
+		config.SetInitialBaseFee(baseFee)
+	})
+	srv := srvs[0]
+
+	// the base fee is not part of the web3 block
+	var block struct {
+		BaseFee string `json:"baseFeePerGas"`
+	}
+
+	if err := srv.JSONRPC().Call("eth_getBlockByNumber", &block, "0x0", false); err != nil {
+		t.Fatalf("failed to retrieve block: %v", err)
+	}
+
+	assert.Equal(t, *types.EncodeUint64(baseFee), block.BaseFee)
+}
+
 // Test if the custom block gas limit is propagated to the subsequent blocks
 func TestCustomBlockGasLimitPropagation(t *testing.T) {
 	var blockGasLimit uint64 = 5000000000
@@ -124,3 +150,46 @@ func TestCustomBlockGasLimitPropagation(t *testing.T) {
 		t.Fatalf("invalid block gas limit, expected [%d] but got [%d]", blockGasLimit, block.GasLimit)
 	}
 }
+
+// Test if the code and storage of the premined contracts are set at genesis
+func TestGenesisPremineContract(t *testing.T) {
+	contractAddr := types.StringToAddress("0x1000")
+	value := types.BytesToHash(bytes.Repeat([]byte{0x2a}, types.HashLength))
+
+	// returns the value of the storage slot 0
+	code := []byte{
+		0x60, 0x00, // PUSH1 0
+		0x54,       // SLOAD
+		0x60, 0x00, // PUSH1 0
+		0x52,       // MSTORE
+		0x60, 0x20, // PUSH1 32
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.PremineContract(contractAddr, code, map[types.Hash]types.Hash{
+			types.ZeroHash: value,
+		})
+	})
+
+	client := srvs[0].JSONRPC()
+
+	deployedCode, err := client.Eth().GetCode(web3.Address(contractAddr), web3.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToHex(code), deployedCode)
+
+	storedValue, err := client.Eth().GetStorageAt(web3.Address(contractAddr), web3.Hash(types.ZeroHash), web3.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, web3.Hash(value), storedValue)
+
+	to := web3.Address(contractAddr)
+
+	response, err := client.Eth().Call(&web3.CallMsg{
+		To:    &to,
+		Value: big.NewInt(0),
+	}, web3.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, value.String(), response)
+}
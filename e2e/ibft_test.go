@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestIbft_Transfer(t *testing.T) {
@@ -38,7 +39,7 @@ func TestIbft_Transfer(t *testing.T) {
 		txn := &framework.PreparedTransaction{
 			From:     senderAddr,
 			To:       &receiverAddr,
-			GasPrice: big.NewInt(10000),
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
 			Gas:      1000000,
 			Value:    framework.EthToWei(1),
 		}
@@ -84,6 +85,7 @@ func TestIbft_TransactionFeeRecipient(t *testing.T) {
 				func(i int, config *framework.TestServerConfig) {
 					config.Premine(senderAddr, framework.EthToWei(10))
 					config.SetSeal(true)
+					config.SetLondon(true)
 				})
 
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
@@ -96,7 +98,7 @@ func TestIbft_TransactionFeeRecipient(t *testing.T) {
 			txn := &framework.PreparedTransaction{
 				From:     senderAddr,
 				To:       &receiverAddr,
-				GasPrice: big.NewInt(10000),
+				GasPrice: big.NewInt(framework.DefaultGasPrice),
 				Gas:      1000000,
 				Value:    tc.txAmount,
 			}
@@ -105,7 +107,7 @@ func TestIbft_TransactionFeeRecipient(t *testing.T) {
 				// Deploy contract
 				deployTx := &framework.PreparedTransaction{
 					From:     senderAddr,
-					GasPrice: big.NewInt(10),
+					GasPrice: big.NewInt(framework.DefaultGasPrice),
 					Gas:      1000000,
 					Value:    big.NewInt(0),
 					Input:    framework.MethodSig("setA1"),
@@ -139,12 +141,132 @@ func TestIbft_TransactionFeeRecipient(t *testing.T) {
 			proposerAddr, err := framework.EcrecoverFromBlockhash(types.Hash(block.Hash), extraData.Seal)
 			assert.NoError(t, err)
 
-			// Given that this is the first transaction on the blockchain, proposer's balance should be equal to the tx fee
+			// Given that this is the first transaction on the blockchain, proposer's balance should be equal to the tx fee,
+			// without the base fee which is burned
 			balanceProposer, err := clt.Eth().GetBalance(web3.Address(proposerAddr), web3.Latest)
 			assert.NoError(t, err)
 
-			txFee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), txn.GasPrice)
+			tip := new(big.Int).Sub(txn.GasPrice, framework.GetBlockBaseFee(t, receipt.BlockHash, clt))
+			txFee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tip)
 			assert.Equalf(t, txFee, balanceProposer, "Proposer didn't get appropriate transaction fee")
 		})
 	}
 }
+
+func TestIbft_RoundChangeTimeout(t *testing.T) {
+	// shorter than the default timeout, so that the round changes are told apart from it
+	const baseTimeout = 4
+
+	ibftManager := framework.NewIBFTServersManager(
+		t,
+		IBFTMinNodes,
+		IBFTDirPrefix,
+		func(i int, config *framework.TestServerConfig) {
+			config.SetSeal(true)
+			config.SetIBFTBaseTimeout(baseTimeout)
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ibftManager.StartServers(ctx)
+
+	srv := ibftManager.GetServer(0)
+
+	_, err := framework.WaitUntilBlockMined(ctx, srv, 2)
+	assert.NoError(t, err)
+
+	// take a validator offline, the blocks it is due to propose
+	// are only sealed after the others time out and change the round
+	ibftManager.GetServer(IBFTMinNodes - 1).Stop()
+
+	start, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+
+	end := start + 2*IBFTMinNodes
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer waitCancel()
+
+	_, err = framework.WaitUntilBlockMined(waitCtx, srv, end)
+	assert.NoError(t, err)
+
+	clt := srv.JSONRPC().Eth()
+
+	parent, err := clt.GetBlockByNumber(web3.BlockNumber(start), false)
+	assert.NoError(t, err)
+
+	roundChanges := 0
+
+	for number := start + 1; number <= end; number++ {
+		block, err := clt.GetBlockByNumber(web3.BlockNumber(number), false)
+		assert.NoError(t, err)
+
+		if interval := block.Timestamp - parent.Timestamp; interval >= baseTimeout {
+			roundChanges++
+
+			// the configured timeout is applied instead of the default one
+			assert.Less(t, interval, uint64(ibft.DefaultBaseRoundTimeout.Seconds()))
+		}
+
+		parent = block
+	}
+
+	assert.GreaterOrEqual(t, roundChanges, 1)
+}
+
+func TestIbft_ConsensusStatus(t *testing.T) {
+	ibftManager := framework.NewIBFTServersManager(
+		t,
+		IBFTMinNodes,
+		IBFTDirPrefix,
+		func(i int, config *framework.TestServerConfig) {
+			config.SetSeal(true)
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ibftManager.StartServers(ctx)
+
+	// the validator keys of the cluster
+	validators := make(map[string]bool)
+
+	for i := 0; i < IBFTMinNodes; i++ {
+		status, err := ibftManager.GetServer(i).IBFTOperator().Status(ctx, &empty.Empty{})
+		assert.NoError(t, err)
+
+		validators[status.Key] = true
+	}
+
+	srv := ibftManager.GetServer(0)
+
+	selfStatus, err := srv.IBFTOperator().Status(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+
+	// the proposers reported for each sequence
+	proposers := make(map[uint64]string)
+	distinct := make(map[string]bool)
+
+	for len(distinct) < 2 {
+		status, err := srv.IBFTOperator().ConsensusStatus(ctx, &empty.Empty{})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, uint64(IBFTMinNodes), status.Validators)
+		assert.True(t, status.IsValidator)
+		assert.Equal(t, status.Proposer == selfStatus.Key, status.IsProposer)
+
+		if status.State != ibft.SyncState.String() {
+			assert.True(t, validators[status.Proposer], "unknown proposer %s", status.Proposer)
+
+			proposers[status.Sequence] = status.Proposer
+			distinct[status.Proposer] = true
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("proposer didn't rotate, reported proposers %v", proposers)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
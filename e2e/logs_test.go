@@ -70,7 +70,7 @@ func TestNewFilter_Block(t *testing.T) {
 		_, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
 			From:     from,
 			To:       &to,
-			GasPrice: big.NewInt(10000),
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
 			Gas:      1000000,
 			Value:    big.NewInt(10000),
 		}, fromKey)
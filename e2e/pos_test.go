@@ -785,7 +785,7 @@ func TestSnapshotUpdating(t *testing.T) {
 		&framework.PreparedTransaction{
 			From:     faucetAddr,
 			To:       &firstNonValidatorAddr,
-			GasPrice: big.NewInt(10000),
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
 			Gas:      1000000,
 			Value:    framework.EthToWei(300),
 		}, faucetKey)
@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/dev"
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/go-web3"
+)
+
+// Test that the state of the blocks out of the retention window is pruned,
+// while the state of the latest blocks is still served
+func TestPruning_RetainBlocks(t *testing.T) {
+	const retainBlocks = 2
+
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.Premine(senderAddr, framework.EthToWei(10))
+		config.SetRetainBlocks(retainBlocks)
+	})
+	srv := srvs[0]
+	client := srv.JSONRPC()
+
+	// every transfer changes the state, so that the blocks have different state roots
+	var firstBlock uint64
+
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+			From:     senderAddr,
+			To:       &receiverAddr,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      1000000,
+			Value:    big.NewInt(10000),
+		}, senderKey)
+
+		cancel()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, receipt)
+
+		if i == 0 {
+			firstBlock = receipt.BlockNumber
+		}
+	}
+
+	// mine past the window until the pruning catches up with the first transfer
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		_, err := client.Eth().GetBalance(web3.Address(senderAddr), web3.BlockNumber(firstBlock))
+		if err == nil {
+			return nil, true
+		}
+
+		return err, false
+	})
+	assert.NoError(t, err)
+
+	if pruneErr, ok := res.(error); assert.True(t, ok) {
+		assert.Contains(t, pruneErr.Error(), "state unavailable, node is pruned")
+	}
+
+	// the latest state is retained
+	balance, err := client.Eth().GetBalance(web3.Address(receiverAddr), web3.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(40000), balance)
+}
+
+// Test that the receipts of the blocks out of the retention window are pruned,
+// while the receipts of the latest blocks are still served
+func TestPruning_RetainReceipts(t *testing.T) {
+	const retainReceipts = 2
+
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.Premine(senderAddr, framework.EthToWei(10))
+		config.SetRetainReceipts(retainReceipts)
+		// the blocks are only sealed for the transfers, so that the head stays put afterwards
+		config.SetDevMode(dev.ModeOnDemand)
+	})
+	srv := srvs[0]
+	client := srv.JSONRPC()
+
+	var first, last *web3.Receipt
+
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+			From:     senderAddr,
+			To:       &receiverAddr,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      1000000,
+			Value:    big.NewInt(10000),
+		}, senderKey)
+
+		cancel()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, receipt)
+
+		if i == 0 {
+			first = receipt
+		}
+
+		last = receipt
+	}
+
+	// the transfers mine past the window, wait for the pruning to catch up with the first one
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		_, err := client.Eth().GetTransactionReceipt(first.TransactionHash)
+		if err == nil {
+			return nil, true
+		}
+
+		return err, false
+	})
+	assert.NoError(t, err)
+
+	if pruneErr, ok := res.(error); assert.True(t, ok) {
+		assert.Contains(t, pruneErr.Error(), "data pruned")
+	}
+
+	// the logs of the pruned blocks are unavailable too
+	_, err = client.Eth().GetLogs(&web3.LogFilter{BlockHash: &first.BlockHash})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "data pruned")
+	}
+
+	// the state isn't pruned along with the receipts
+	_, err = client.Eth().GetBalance(web3.Address(senderAddr), web3.BlockNumber(first.BlockNumber))
+	assert.NoError(t, err)
+
+	// the receipts of the latest blocks are retained
+	receipt, err := client.Eth().GetTransactionReceipt(last.TransactionHash)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, receipt) {
+		assert.Equal(t, last.TransactionHash, receipt.TransactionHash)
+	}
+}
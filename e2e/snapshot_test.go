@@ -0,0 +1,118 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/go-web3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Test that a node bootstrapped from the state snapshot of another node
+// has the same state and keeps syncing from the snapshot block
+func TestSnapshot_ImportAndSync(t *testing.T) {
+	const (
+		epochSize    = 5
+		nonValidator = IBFTMinNodes
+	)
+
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot")
+
+	ibftManager := framework.NewIBFTServersManager(
+		t,
+		IBFTMinNodes+1,
+		IBFTDirPrefix,
+		func(i int, config *framework.TestServerConfig) {
+			config.Premine(senderAddr, framework.EthToWei(10))
+			config.SetEpochSize(epochSize)
+			config.SetSeal(i < IBFTMinNodes)
+
+			if i == nonValidator {
+				dirPrefix := "polygon-edge-non-validator-"
+				config.SetIBFTDirPrefix(dirPrefix)
+				config.SetIBFTDir(fmt.Sprintf("%s%d", dirPrefix, i))
+				config.SetSnapshotFile(snapshotPath)
+			}
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// start the validators only, the other node needs the snapshot first
+	for i := 0; i < IBFTMinNodes; i++ {
+		assert.NoError(t, ibftManager.GetServer(i).Start(ctx))
+	}
+
+	srv := ibftManager.GetServer(0)
+	assert.NoError(t, srv.WaitForReady(ctx))
+
+	receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     senderAddr,
+		To:       &receiverAddr,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      framework.DefaultGasLimit,
+		Value:    framework.EthToWei(1),
+	}, senderKey)
+	assert.NoError(t, err)
+
+	if receipt == nil {
+		t.FailNow()
+	}
+
+	// with IBFT, the snapshot block has to be the first block of an epoch
+	snapshotHeight := (receipt.BlockNumber/epochSize + 1) * epochSize
+
+	_, err = framework.WaitUntilBlockMined(ctx, srv, snapshotHeight)
+	assert.NoError(t, err)
+
+	conn, err := grpc.Dial(srv.GrpcAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	number, hash, err := archive.CreateSnapshot(conn, hclog.NewNullLogger(), snapshotHeight, snapshotPath)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotHeight, number)
+
+	// bootstrap the other node from the snapshot
+	node := ibftManager.GetServer(nonValidator)
+	assert.NoError(t, node.Start(ctx))
+	assert.NoError(t, node.WaitForReady(ctx))
+
+	_, err = framework.WaitUntilBlockMined(ctx, node, snapshotHeight+epochSize)
+	assert.NoError(t, err)
+
+	client := node.JSONRPC().Eth()
+
+	block, err := client.GetBlockByNumber(web3.BlockNumber(snapshotHeight), false)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, block) {
+		assert.Equal(t, web3.Hash(hash), block.Hash)
+	}
+
+	// the blocks below the snapshot are not available
+	block, err = client.GetBlockByNumber(web3.BlockNumber(receipt.BlockNumber), false)
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+
+	// the state of the snapshot and of the synced blocks is available
+	for _, num := range []web3.BlockNumber{web3.BlockNumber(snapshotHeight), web3.Latest} {
+		balance, err := client.GetBalance(web3.Address(receiverAddr), num)
+		assert.NoError(t, err)
+		assert.Equal(t, framework.EthToWei(1), balance)
+	}
+}
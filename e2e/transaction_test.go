@@ -16,6 +16,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/e2e/framework"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/state"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -54,7 +55,7 @@ func TestSignedTransaction(t *testing.T) {
 		txn := &framework.PreparedTransaction{
 			From:     senderAddr,
 			To:       &receiverAddr,
-			GasPrice: big.NewInt(10000),
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
 			Gas:      1000000,
 			Value:    big.NewInt(10000),
 		}
@@ -213,7 +214,7 @@ func TestEthTransfer(t *testing.T) {
 			txn := &framework.PreparedTransaction{
 				From:     testCase.sender,
 				To:       &testCase.recipient,
-				GasPrice: big.NewInt(1048576),
+				GasPrice: big.NewInt(framework.DefaultGasPrice),
 				Gas:      1000000,
 				Value:    testCase.amount,
 			}
@@ -287,11 +288,10 @@ func getCount(
 	selector := stressTestMethod.ID()
 	response, err := rpcClient.Eth().Call(
 		&web3.CallMsg{
-			From:     web3.Address(from),
-			To:       &contractAddress,
-			Data:     selector,
-			GasPrice: 100000000,
-			Value:    big.NewInt(0),
+			From:  web3.Address(from),
+			To:    &contractAddress,
+			Data:  selector,
+			Value: big.NewInt(0),
 		},
 		web3.Latest,
 	)
@@ -618,3 +618,324 @@ func Test_TransactionIBFTLoop(t *testing.T) {
 	// Check that the count is correct
 	assert.Equalf(t, strconv.Itoa(numTransactions), count.String(), "Count doesn't match")
 }
+
+// Test if the calls are bounded by the gas cap of the JSON-RPC endpoints
+func TestCall_GasCap(t *testing.T) {
+	var (
+		gasCap       uint64 = 100000
+		cheapAddr           = types.StringToAddress("0x1000")
+		infiniteAddr        = types.StringToAddress("0x2000")
+	)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetRPCGasCap(gasCap)
+		config.PremineContract(cheapAddr, []byte{0x00}, nil) // STOP
+		config.PremineContract(infiniteAddr, []byte{
+			0x5b,       // JUMPDEST
+			0x60, 0x00, // PUSH1 0
+			0x56, // JUMP
+		}, nil)
+	})
+
+	client := srvs[0].JSONRPC()
+
+	call := func(to types.Address) error {
+		toAddr := web3.Address(to)
+
+		_, err := client.Eth().Call(&web3.CallMsg{
+			To:    &toAddr,
+			Value: big.NewInt(0),
+		}, web3.Latest)
+
+		return err
+	}
+
+	assert.NoError(t, call(cheapAddr))
+
+	// the infinite loop runs out of the capped gas
+	err := call(infiniteAddr)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "out of gas")
+
+	estimate, err := client.Eth().EstimateGas(&web3.CallMsg{
+		To:    (*web3.Address)(&cheapAddr),
+		Value: big.NewInt(0),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), estimate)
+
+	_, err = client.Eth().EstimateGas(&web3.CallMsg{
+		To:    (*web3.Address)(&infiniteAddr),
+		Value: big.NewInt(0),
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("highest gas limit %d", gasCap))
+}
+
+func TestReceipt_StatusAndEffectiveGasPrice(t *testing.T) {
+	key, from := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetLondon(true)
+		config.Premine(from, framework.EthToWei(10))
+	})
+	srv := srvs[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	contractAddr, err := srv.DeployContract(ctx, sampleByteCode, key)
+	assert.NoError(t, err)
+
+	contract := types.Address(contractAddr)
+
+	call := func(input []byte) *web3.Receipt {
+		t.Helper()
+
+		receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+			From:     from,
+			To:       &contract,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      framework.DefaultGasLimit,
+			Input:    input,
+		}, key)
+		assert.NoError(t, err)
+
+		if receipt == nil {
+			t.FailNow()
+		}
+
+		return receipt
+	}
+
+	// the sample contract has no fallback, a call without a method selector reverts
+	reverted := call(nil)
+	assert.Equal(t, uint64(types.ReceiptFailed), reverted.Status)
+
+	succeeded := call(framework.MethodSig("setA1"))
+	assert.Equal(t, uint64(types.ReceiptSuccess), succeeded.Status)
+
+	// the effective gas price is not part of the web3 receipt
+	var raw struct {
+		EffectiveGasPrice string `json:"effectiveGasPrice"`
+	}
+
+	assert.NoError(t, srv.JSONRPC().Call("eth_getTransactionReceipt", &raw, reverted.TransactionHash))
+	assert.Equal(t, fmt.Sprintf("0x%x", framework.DefaultGasPrice), raw.EffectiveGasPrice)
+
+	// dynamic fee transactions pay min(maxFeePerGas, baseFee + maxPriorityFeePerGas)
+	dynamicFeeCases := []struct {
+		name   string
+		maxFee *big.Int
+		maxTip *big.Int
+	}{
+		{"under the fee cap", big.NewInt(2 * framework.DefaultGasPrice), big.NewInt(1000)},
+		{"at the fee cap", big.NewInt(framework.DefaultGasPrice), big.NewInt(framework.DefaultGasPrice)},
+	}
+
+	for _, tc := range dynamicFeeCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nonce, err := srv.JSONRPC().Eth().GetNonce(web3.Address(from), web3.Latest)
+			assert.NoError(t, err)
+
+			txn, err := srv.SignTx(&types.Transaction{
+				Type:                 types.DynamicFeeTx,
+				Nonce:                nonce,
+				From:                 from,
+				To:                   &contract,
+				MaxFeePerGas:         tc.maxFee,
+				MaxPriorityFeePerGas: tc.maxTip,
+				Gas:                  framework.DefaultGasLimit,
+				Value:                big.NewInt(0),
+				Input:                framework.MethodSig("setA1"),
+			}, key)
+			assert.NoError(t, err)
+
+			hash, err := srv.JSONRPC().Eth().SendRawTransaction(txn.MarshalRLP())
+			assert.NoError(t, err)
+
+			receipt, err := srv.WaitForReceipt(ctx, hash)
+			assert.NoError(t, err)
+
+			if receipt == nil {
+				t.FailNow()
+			}
+
+			expected := framework.GetBlockBaseFee(t, receipt.BlockHash, srv.JSONRPC())
+			expected.Add(expected, tc.maxTip)
+
+			if expected.Cmp(tc.maxFee) > 0 {
+				expected.Set(tc.maxFee)
+			}
+
+			assert.NoError(t, srv.JSONRPC().Call("eth_getTransactionReceipt", &raw, hash))
+			assert.Equal(t, fmt.Sprintf("0x%x", expected), raw.EffectiveGasPrice)
+		})
+	}
+}
+
+func TestCall_ChainID(t *testing.T) {
+	key, from := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.Premine(from, framework.EthToWei(10))
+	})
+	srv := srvs[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the deployed code returns block.chainid:
+	// CHAINID PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	contractAddr, err := srv.DeployContract(ctx, "6009600c60003960096000f3"+"4660005260206000f3", key)
+	assert.NoError(t, err)
+
+	client := srv.JSONRPC()
+
+	response, err := client.Eth().Call(&web3.CallMsg{
+		To:    &contractAddr,
+		Value: big.NewInt(0),
+	}, web3.Latest)
+	assert.NoError(t, err)
+
+	chainID, err := client.Eth().ChainID()
+	assert.NoError(t, err)
+
+	// the chain id of the EVM is the one the deployment transaction is signed with
+	expected := types.BytesToHash(big.NewInt(100).Bytes())
+	assert.Equal(t, expected.String(), response)
+	assert.Equal(t, uint64(100), chainID.Uint64())
+}
+
+func TestGetBalance_Pending(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+	// the dummy consensus doesn't mine, the transfer stays in the pool
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDummy)
+		config.SetSeal(true)
+		config.Premine(senderAddr, framework.EthToWei(10))
+	})
+	srv := srvs[0]
+	client := srv.JSONRPC()
+
+	tx, err := signer.SignTx(&types.Transaction{
+		Nonce:    0,
+		From:     senderAddr,
+		To:       &receiverAddr,
+		Value:    oneEth,
+		Gas:      framework.DefaultGasLimit,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+	}, senderKey)
+	assert.NoError(t, err)
+
+	_, err = client.Eth().SendRawTransaction(tx.MarshalRLP())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the transaction is added to the pool asynchronously
+	_, err = tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+		nonce, err := client.Eth().GetNonce(web3.Address(senderAddr), web3.Pending)
+
+		return nil, err != nil || nonce != 1
+	})
+	assert.NoError(t, err)
+
+	latest, err := client.Eth().GetBalance(web3.Address(receiverAddr), web3.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, latest.Sign())
+
+	pending, err := client.Eth().GetBalance(web3.Address(receiverAddr), web3.Pending)
+	assert.NoError(t, err)
+	assert.Equal(t, oneEth, pending)
+
+	// the sender pays for the transfer and the gas in the pending block
+	senderPending, err := client.Eth().GetBalance(web3.Address(senderAddr), web3.Pending)
+	assert.NoError(t, err)
+
+	fee := new(big.Int).Mul(big.NewInt(int64(state.TxGas)), big.NewInt(framework.DefaultGasPrice))
+	expected := new(big.Int).Sub(framework.EthToWei(10), oneEth)
+	assert.Equal(t, expected.Sub(expected, fee), senderPending)
+
+	// nothing is mined
+	blockNumber, err := client.Eth().BlockNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), blockNumber)
+}
+
+func TestGetStorageAt_Historical(t *testing.T) {
+	key, from := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.Premine(from, framework.EthToWei(10))
+	})
+	srv := srvs[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the deployed code stores the first calldata word in the slot 0:
+	// PUSH1 0 CALLDATALOAD PUSH1 0 SSTORE STOP
+	contractAddr, err := srv.DeployContract(ctx, "6007600c60003960076000f3"+"60003560005500", key)
+	assert.NoError(t, err)
+
+	deployBlock, err := srv.JSONRPC().Eth().BlockNumber()
+	assert.NoError(t, err)
+
+	contract := types.Address(contractAddr)
+
+	store := func(value int64) uint64 {
+		t.Helper()
+
+		receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+			From:     from,
+			To:       &contract,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      framework.DefaultGasLimit,
+			Input:    types.BytesToHash(big.NewInt(value).Bytes()).Bytes(),
+		}, key)
+		assert.NoError(t, err)
+
+		if receipt == nil {
+			t.FailNow()
+		}
+
+		assert.Equal(t, uint64(types.ReceiptSuccess), receipt.Status)
+
+		return receipt.BlockNumber
+	}
+
+	// the slot changes across two blocks
+	firstBlock := store(5)
+	secondBlock := store(7)
+
+	getStorageAt := func(block string) string {
+		t.Helper()
+
+		var value string
+
+		assert.NoError(t, srv.JSONRPC().Call("eth_getStorageAt", &value, contractAddr, "0x0", block))
+
+		return value
+	}
+
+	word := func(value int64) string {
+		return types.BytesToHash(big.NewInt(value).Bytes()).String()
+	}
+
+	// the slot is unset when the contract is deployed
+	assert.Equal(t, word(0), getStorageAt(fmt.Sprintf("0x%x", deployBlock)))
+	assert.Equal(t, word(5), getStorageAt(fmt.Sprintf("0x%x", firstBlock)))
+	assert.Equal(t, word(7), getStorageAt(fmt.Sprintf("0x%x", secondBlock)))
+	assert.Equal(t, word(7), getStorageAt("latest"))
+}
@@ -23,20 +23,28 @@ import (
 	"github.com/umbracle/go-web3"
 )
 
+// waitForBlockTimeout is the time waitForBlock waits for a block event
+const waitForBlockTimeout = 30 * time.Second
+
 var (
 	oneEth = framework.EthToWei(1)
 	signer = crypto.NewEIP155Signer(100)
 )
 
+// waitForBlock waits for the next block event of the server, and returns the number
+// of the block at the given index of the event. The event is not necessarily
+// the one sealing the transactions submitted before: a dev server on the interval
+// mode might seal an empty block first. Servers skipping empty blocks emit
+// no events while the pool is empty, so the wait fails after a timeout
 func waitForBlock(t *testing.T, srv *framework.TestServer, expectedBlocks int, index int) int64 {
 	t.Helper()
 
 	systemClient := srv.Operator()
-	ctx, cancelFn := context.WithCancel(context.Background())
-	stream, err := systemClient.Subscribe(ctx, &empty.Empty{})
+	ctx, cancelFn := context.WithTimeout(context.Background(), waitForBlockTimeout)
+	defer cancelFn()
 
+	stream, err := systemClient.Subscribe(ctx, &empty.Empty{})
 	if err != nil {
-		cancelFn()
 		t.Fatalf("Unable to subscribe to blockchain events")
 	}
 
@@ -53,8 +61,6 @@ func waitForBlock(t *testing.T, srv *framework.TestServer, expectedBlocks int, i
 		t.Fatalf("Invalid number of blocks added")
 	}
 
-	cancelFn()
-
 	return evnt.Added[index].Number
 }
 
@@ -98,7 +104,7 @@ func generateReq(params generateTxReqParams) *txpoolOp.AddTxnReq {
 }
 
 func TestTxPool_ErrorCodes(t *testing.T) {
-	gasPrice := big.NewInt(10000)
+	gasPrice := big.NewInt(framework.DefaultGasPrice)
 	devInterval := 5
 
 	testTable := []struct {
@@ -182,8 +188,7 @@ func TestTxPool_ErrorCodes(t *testing.T) {
 				_, addErr = clt.AddTxn(context.Background(), addReq)
 			}
 
-			assert.NotNil(t, addErr)
-			assert.Contains(t, addErr.Error(), testCase.expectedError.Error())
+			assert.ErrorIs(t, txpool.FromStatusError(addErr), testCase.expectedError)
 		})
 	}
 }
@@ -197,7 +202,7 @@ func TestTxPool_TransactionCoalescing(t *testing.T) {
 	// Add tx with nonce 1
 	// -> check if both tx with nonce 1 and tx with nonce 2 are parsed
 	// Predefined values
-	gasPrice := big.NewInt(10000)
+	gasPrice := big.NewInt(framework.DefaultGasPrice)
 
 	referenceKey, referenceAddr := tests.GenerateKeyAndAddr(t)
 	defaultBalance := framework.EthToWei(10)
@@ -349,7 +354,7 @@ func TestTxPool_StressAddition(t *testing.T) {
 			Nonce:    nonce,
 			From:     account.address,
 			To:       &toAddress,
-			GasPrice: big.NewInt(10),
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
 			Gas:      framework.DefaultGasLimit,
 			Value:    defaultValue,
 			V:        big.NewInt(27), // it is necessary to encode in rlp
@@ -658,7 +663,7 @@ func TestTxPool_GetPendingTx(t *testing.T) {
 	// Construct the transaction
 	signedTx, err := signer.SignTx(&types.Transaction{
 		Nonce:    0,
-		GasPrice: big.NewInt(0),
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
 		Gas:      framework.DefaultGasLimit - 1,
 		To:       &receiverAddress,
 		Value:    oneEth,
@@ -707,3 +712,83 @@ func TestTxPool_GetPendingTx(t *testing.T) {
 	assert.Equal(t, receipt.BlockNumber, tx.BlockNumber)
 	assert.Equal(t, receipt.BlockHash, tx.BlockHash)
 }
+
+func TestTxPool_PriorityOrdering(t *testing.T) {
+	// Test scenario:
+	// A low priced and a high priced transaction are sent from different accounts,
+	// in that order. The blocks only fit one transaction, and the high priced
+	// one should be sealed first
+	lowKey, lowAddress := tests.GenerateKeyAndAddr(t)
+	highKey, highAddress := tests.GenerateKeyAndAddr(t)
+	_, receiverAddress := tests.GenerateKeyAndAddr(t)
+
+	server := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetSeal(true)
+		config.SetLondon(true)
+		config.SetBlockLimit(1.5 * 21000)
+		config.SetDevInterval(2)
+		config.Premine(lowAddress, framework.EthToWei(10))
+		config.Premine(highAddress, framework.EthToWei(10))
+	})[0]
+
+	client := server.JSONRPC()
+	operator := server.TxnPoolOperator()
+
+	sendTx := func(tx *types.Transaction, key *ecdsa.PrivateKey) web3.Hash {
+		signedTx, err := signer.SignTx(tx, key)
+		assert.NoError(t, err)
+
+		response, err := operator.AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+			Raw: &any.Any{
+				Value: signedTx.MarshalRLP(),
+			},
+			From: types.ZeroAddress.String(),
+		})
+		assert.NoError(t, err, "Unable to send transaction, %v", err)
+
+		if response == nil {
+			t.FailNow()
+		}
+
+		return web3.Hash(types.StringToHash(response.TxHash))
+	}
+
+	lowHash := sendTx(&types.Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Gas:      21000,
+		To:       &receiverAddress,
+		Value:    oneEth,
+		V:        big.NewInt(27),
+		From:     lowAddress,
+	}, lowKey)
+
+	// the tip of the dynamic fee transaction is capped by its max fee
+	highHash := sendTx(&types.Transaction{
+		Type:                 types.DynamicFeeTx,
+		Nonce:                0,
+		MaxFeePerGas:         big.NewInt(2 * framework.DefaultGasPrice),
+		MaxPriorityFeePerGas: big.NewInt(2 * framework.DefaultGasPrice),
+		Gas:                  21000,
+		To:                   &receiverAddress,
+		Value:                oneEth,
+		V:                    big.NewInt(27),
+		From:                 highAddress,
+	}, highKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	lowReceipt, err := tests.WaitForReceipt(ctx, client.Eth(), lowHash)
+	assert.NoError(t, err)
+
+	highReceipt, err := tests.WaitForReceipt(ctx, client.Eth(), highHash)
+	assert.NoError(t, err)
+
+	if lowReceipt == nil || highReceipt == nil {
+		t.FailNow()
+	}
+
+	assert.Less(t, highReceipt.BlockNumber, lowReceipt.BlockNumber)
+}
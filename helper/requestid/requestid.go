@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// LogKey is the key of the request ID in the log lines
+const LogKey = "request_id"
+
+// contextKey is the key of the request ID in the context
+type contextKey struct{}
+
+// Generate returns a new random request ID
+func Generate() string {
+	buf := make([]byte, 8)
+
+	// the ID only correlates log lines, an all-zero ID is still usable
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+// WithID returns a copy of the context carrying the request ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by the context, if any
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+// Logger returns the logger annotated with the request ID carried by the context,
+// so the log lines of a single request can be correlated
+func Logger(ctx context.Context, logger hclog.Logger) hclog.Logger {
+	if id, ok := FromContext(ctx); ok {
+		return logger.With(LogKey, id)
+	}
+
+	return logger
+}
@@ -0,0 +1,25 @@
+package jsonrpc
+
+// adminStore provides methods needed for Admin endpoint
+type adminStore interface {
+	// GetPeersInfo returns the information about the connected peers
+	GetPeersInfo() []*PeerInfo
+}
+
+// PeerInfo is the information about a connected peer
+type PeerInfo struct {
+	ID         string   `json:"id"`
+	Addrs      []string `json:"addrs"`
+	Directions []string `json:"directions"`
+	Score      int64    `json:"score"`
+}
+
+// Admin is the admin jsonrpc endpoint
+type Admin struct {
+	store adminStore
+}
+
+// Peers returns the ID, addresses, connection directions and misbehavior scores of the connected peers
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.GetPeersInfo(), nil
+}
@@ -0,0 +1,46 @@
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAdminStore struct {
+	*mockStore
+
+	peers []*PeerInfo
+}
+
+func (m *mockAdminStore) GetPeersInfo() []*PeerInfo {
+	return m.peers
+}
+
+func TestAdminEndpointPeers(t *testing.T) {
+	peer := &PeerInfo{
+		ID:         "16Uiu2HAmJxxH1tScDX2rLGSU9exnuvZKNM9SoK3v315azp68DLPW",
+		Addrs:      []string{"/ip4/127.0.0.1/tcp/1478"},
+		Directions: []string{"outbound"},
+		Score:      -20,
+	}
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		&mockAdminStore{newMockStore(), []*PeerInfo{peer}},
+		0,
+		[]string{NamespaceAdmin},
+	)
+
+	resp, err := dispatcher.Handle(context.Background(), []byte(`{
+		"method": "admin_peers",
+		"params": []
+	}`))
+	assert.NoError(t, err)
+
+	var res []*PeerInfo
+
+	assert.NoError(t, expectJSONResult(resp, &res))
+	assert.Equal(t, []*PeerInfo{peer}, res)
+}
@@ -94,9 +94,11 @@ func (e *ObjectError) Error() string {
 }
 
 const (
-	PendingBlockNumber  = BlockNumber(-3)
-	LatestBlockNumber   = BlockNumber(-2)
-	EarliestBlockNumber = BlockNumber(-1)
+	SafeBlockNumber      = BlockNumber(-5)
+	FinalizedBlockNumber = BlockNumber(-4)
+	PendingBlockNumber   = BlockNumber(-3)
+	LatestBlockNumber    = BlockNumber(-2)
+	EarliestBlockNumber  = BlockNumber(-1)
 )
 
 type BlockNumber int64
@@ -109,7 +111,8 @@ type BlockNumberOrHash struct {
 // UnmarshalJSON will try to extract the filter's data.
 // Here are the possible input formats :
 //
-// 1 - "latest", "pending" or "earliest"	- self-explaining keywords
+// 1 - "latest", "pending", "earliest",
+//     "safe" or "finalized"				- self-explaining keywords
 // 2 - "0x2"								- block number #2 (EIP-1898 backward compatible)
 // 3 - {blockNumber:	"0x2"}				- EIP-1898 compliant block number #2
 // 4 - {blockHash:		"0xe0e..."}			- EIP-1898 compliant block hash 0xe0e...
@@ -154,11 +157,18 @@ func stringToBlockNumber(str string) (BlockNumber, error) {
 		return LatestBlockNumber, nil
 	case "earliest":
 		return EarliestBlockNumber, nil
+	case "safe":
+		return SafeBlockNumber, nil
+	case "finalized":
+		return FinalizedBlockNumber, nil
 	}
 
 	n, err := types.ParseUint64orHex(&str)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf(
+			"invalid block number %q, expected a number or one of the latest, pending, earliest, safe and finalized tags",
+			str,
+		)
 	}
 
 	return BlockNumber(n), nil
@@ -13,6 +13,8 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 
 	blockNumberZero := BlockNumber(0x0)
 	blockNumberLatest := LatestBlockNumber
+	blockNumberSafe := SafeBlockNumber
+	blockNumberFinalized := FinalizedBlockNumber
 
 	tests := []struct {
 		name        string
@@ -55,6 +57,28 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 				BlockNumber: &blockNumberLatest,
 			},
 		},
+		{
+			"should unmarshal safe block number properly",
+			`"safe"`,
+			false,
+			BlockNumberOrHash{
+				BlockNumber: &blockNumberSafe,
+			},
+		},
+		{
+			"should unmarshal finalized block number properly",
+			`{"blockNumber": "finalized"}`,
+			false,
+			BlockNumberOrHash{
+				BlockNumber: &blockNumberFinalized,
+			},
+		},
+		{
+			"should return an error for unknown block tags",
+			`"final"`,
+			true,
+			BlockNumberOrHash{},
+		},
 		{
 			"should unmarshal block number 0 properly #1",
 			`{"blockNumber": "0x0"}`,
@@ -0,0 +1,85 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// debugStore provides the methods needed by the debug endpoint
+type debugStore interface {
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// TraceTxn re-executes the transaction of the block over the state it was applied on,
+	// reporting the executed opcodes to the tracer. The execution stops once the context is done
+	TraceTxn(
+		ctx context.Context,
+		block *types.Block,
+		txHash types.Hash,
+		tracer runtime.Tracer,
+	) (*runtime.ExecutionResult, error)
+}
+
+// defaultTraceMaxBytes is the maximum size of the struct logs of a traced transaction
+const defaultTraceMaxBytes = 64 * 1024 * 1024
+
+// Debug is the debug jsonrpc endpoint
+type Debug struct {
+	store debugStore
+
+	// traceMaxBytes is the maximum size of the struct logs of a traced transaction
+	traceMaxBytes int
+}
+
+// traceConfig selects the tracer of a transaction and bounds its output
+type traceConfig struct {
+	Tracer         string `json:"tracer"`
+	DisableStack   bool   `json:"disableStack"`
+	DisableMemory  bool   `json:"disableMemory"`
+	DisableStorage bool   `json:"disableStorage"`
+}
+
+var ErrTraceTxNotFound = errors.New("transaction not found")
+
+// TraceTransaction re-executes the sealed transaction and returns its trace,
+// made by the struct logger unless another tracer is set in the config
+func (d *Debug) TraceTransaction(ctx context.Context, hash types.Hash, config *traceConfig) (interface{}, error) {
+	if config == nil {
+		config = &traceConfig{}
+	}
+
+	blockHash, ok := d.store.ReadTxLookup(hash)
+	if !ok {
+		return nil, ErrTraceTxNotFound
+	}
+
+	block, ok := d.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, fmt.Errorf("block %s not found", blockHash)
+	}
+
+	txTracer, err := tracer.New(config.Tracer, tracer.Config{
+		DisableStack:   config.DisableStack,
+		DisableMemory:  config.DisableMemory,
+		DisableStorage: config.DisableStorage,
+		MaxBytes:       d.traceMaxBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.store.TraceTxn(ctx, block, hash, txTracer)
+	if err != nil {
+		return nil, err
+	}
+
+	return txTracer.GetResult(result)
+}
@@ -0,0 +1,174 @@
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDebugStore struct {
+	block *types.Block
+}
+
+func (m *mockDebugStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
+	for _, tx := range m.block.Transactions {
+		if tx.Hash == txnHash {
+			return m.block.Hash(), true
+		}
+	}
+
+	return types.ZeroHash, false
+}
+
+func (m *mockDebugStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	if hash != m.block.Hash() {
+		return nil, false
+	}
+
+	return m.block, true
+}
+
+func (m *mockDebugStore) TraceTxn(
+	ctx context.Context,
+	block *types.Block,
+	txHash types.Hash,
+	txTracer runtime.Tracer,
+) (*runtime.ExecutionResult, error) {
+	// a single frame with a single step, PUSH1 1
+	txTracer.CaptureEnter(&runtime.Contract{
+		Type:    runtime.Call,
+		Caller:  addr0,
+		Address: addr1,
+		Gas:     100,
+	})
+
+	step := &runtime.Step{
+		Op:     0x60,
+		OpName: "PUSH1",
+		Gas:    100,
+		Depth:  1,
+	}
+
+	txTracer.CaptureState(nil, step)
+
+	step.GasCost = 3
+	txTracer.CaptureStateEnd(nil, step)
+
+	result := &runtime.ExecutionResult{
+		ReturnValue: []byte{0x1},
+		GasLeft:     97,
+	}
+
+	txTracer.CaptureExit(result)
+
+	result.GasUsed = 21003
+
+	return result, nil
+}
+
+func TestDebug_TraceTransaction(t *testing.T) {
+	txn := newTestTransaction(1, addr0)
+	block := newTestBlock(1, hash1)
+	block.Transactions = []*types.Transaction{txn}
+
+	debug := &Debug{&mockDebugStore{block: block}, defaultTraceMaxBytes}
+
+	t.Run("should trace with the struct logger by default", func(t *testing.T) {
+		res, err := debug.TraceTransaction(context.Background(), txn.Hash, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &tracer.StructLogResult{
+			Gas:         21003,
+			Failed:      false,
+			ReturnValue: "01",
+			StructLogs: []*tracer.StructLog{
+				{
+					Op:      "PUSH1",
+					Gas:     100,
+					GasCost: 3,
+					Depth:   1,
+					Stack:   []string{},
+					Memory:  []string{},
+				},
+			},
+		}, res)
+	})
+
+	t.Run("should trace with the call tracer", func(t *testing.T) {
+		res, err := debug.TraceTransaction(context.Background(), txn.Hash, &traceConfig{Tracer: "callTracer"})
+		assert.NoError(t, err)
+
+		frame, ok := res.(*tracer.CallFrame)
+		assert.True(t, ok)
+
+		assert.Equal(t, "CALL", frame.Type)
+		assert.Equal(t, addr0, frame.From)
+		assert.Equal(t, addr1, frame.To)
+		assert.Equal(t, "0x64", frame.Gas)
+		assert.Equal(t, "0x520b", frame.GasUsed)
+		assert.Equal(t, "0x01", frame.Output)
+	})
+
+	t.Run("should fail for an unknown transaction", func(t *testing.T) {
+		_, err := debug.TraceTransaction(context.Background(), hash2, nil)
+		assert.ErrorIs(t, err, ErrTraceTxNotFound)
+	})
+
+	t.Run("should fail for struct logs above the size limit", func(t *testing.T) {
+		limited := &Debug{&mockDebugStore{block: block}, 32}
+
+		_, err := limited.TraceTransaction(context.Background(), txn.Hash, nil)
+		assert.ErrorIs(t, err, tracer.ErrTraceTooLarge)
+
+		// the call tracer isn't bounded
+		_, err = limited.TraceTransaction(context.Background(), txn.Hash, &traceConfig{Tracer: "callTracer"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail for an unknown tracer", func(t *testing.T) {
+		_, err := debug.TraceTransaction(context.Background(), txn.Hash, &traceConfig{Tracer: "unknown"})
+		assert.ErrorContains(t, err, "tracer unknown not found")
+	})
+}
+
+func TestDebug_DisabledNamespace(t *testing.T) {
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, []string{NamespaceEth})
+
+	_, err := dispatcher.handleReq(context.Background(), Request{
+		Method: "debug_traceTransaction",
+		Params: []byte(`["` + hash1.String() + `"]`),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, -32601, err.ErrorCode())
+
+	// the enabled namespaces are still served
+	_, err = dispatcher.handleReq(context.Background(), Request{
+		Method: "eth_chainId",
+	})
+	assert.NoError(t, err)
+}
+
+func TestDebug_DefaultNamespaces(t *testing.T) {
+	// the debug and admin methods are opt-in
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
+
+	for _, method := range []string{"debug_traceTransaction", "admin_peers"} {
+		_, err := dispatcher.handleReq(context.Background(), Request{
+			Method: method,
+			Params: []byte(`["` + hash1.String() + `"]`),
+		})
+		if assert.Error(t, err) {
+			assert.Equal(t, -32601, err.ErrorCode())
+		}
+	}
+
+	_, err := dispatcher.handleReq(context.Background(), Request{
+		Method: "eth_chainId",
+	})
+	assert.NoError(t, err)
+}
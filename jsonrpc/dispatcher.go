@@ -2,14 +2,18 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"runtime/debug"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/0xPolygon/polygon-edge/helper/requestid"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -23,10 +27,44 @@ type funcData struct {
 	reqt  []reflect.Type
 	fv    reflect.Value
 	isDyn bool
+
+	// hasCtx is set when the first argument of the function is a context.Context
+	hasCtx bool
+}
+
+// numArgs returns the number of arguments before the params of the request
+func (f *funcData) numArgs() int {
+	if f.hasCtx {
+		return 2
+	}
+
+	return 1
 }
 
 func (f *funcData) numParams() int {
-	return f.inNum - 1
+	return f.inNum - f.numArgs()
+}
+
+// Namespaces of the JSON-RPC methods
+const (
+	NamespaceEth    = "eth"
+	NamespaceNet    = "net"
+	NamespaceWeb3   = "web3"
+	NamespaceTxPool = "txpool"
+	NamespaceDebug  = "debug"
+	NamespaceAdmin  = "admin"
+)
+
+// AllNamespaces returns the namespaces of all the JSON-RPC methods
+func AllNamespaces() []string {
+	return []string{NamespaceEth, NamespaceNet, NamespaceWeb3, NamespaceTxPool, NamespaceDebug, NamespaceAdmin}
+}
+
+// DefaultNamespaces returns the namespaces served unless configured otherwise.
+// The debug and admin methods are opt-in, as they run expensive traces
+// and expose the peers of the node
+func DefaultNamespaces() []string {
+	return []string{NamespaceEth, NamespaceNet, NamespaceWeb3, NamespaceTxPool}
 }
 
 type endpoints struct {
@@ -34,6 +72,8 @@ type endpoints struct {
 	Web3   *Web3
 	Net    *Net
 	TxPool *TxPool
+	Debug  *Debug
+	Admin  *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -44,12 +84,26 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 	chainID       uint64
+
+	// executionTimeout is the maximum duration of a request. 0 disables the timeout
+	executionTimeout time.Duration
+
+	// running holds a slot for each method call running in the background of a request
+	running chan struct{}
 }
 
-func newDispatcher(logger hclog.Logger, store JSONRPCStore, chainID uint64) *Dispatcher {
+// maxRunningCalls is the maximum number of method calls running at once when
+// the execution timeout is enabled. A call which doesn't stop along with its
+// timed out request keeps its slot until it returns, so such calls can't pile up
+const maxRunningCalls = 256
+
+// newDispatcher creates a dispatcher serving the methods of the given namespaces,
+// or of the default ones if none is given
+func newDispatcher(logger hclog.Logger, store JSONRPCStore, chainID uint64, namespaces []string) *Dispatcher {
 	d := &Dispatcher{
 		logger:  logger.Named("dispatcher"),
 		chainID: chainID,
+		running: make(chan struct{}, maxRunningCalls),
 	}
 
 	if store != nil {
@@ -57,21 +111,46 @@ func newDispatcher(logger hclog.Logger, store JSONRPCStore, chainID uint64) *Dis
 		go d.filterManager.Run()
 	}
 
-	d.registerEndpoints(store)
+	if len(namespaces) == 0 {
+		namespaces = DefaultNamespaces()
+	}
+
+	d.registerEndpoints(store, namespaces)
 
 	return d
 }
 
-func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
-	d.endpoints.Eth = &Eth{d.logger, store, d.chainID, d.filterManager}
+func (d *Dispatcher) registerEndpoints(store JSONRPCStore, namespaces []string) {
+	d.endpoints.Eth = &Eth{d.logger, store, d.chainID, d.filterManager, nil, 0, nil}
 	d.endpoints.Net = &Net{store, d.chainID}
 	d.endpoints.Web3 = &Web3{}
 	d.endpoints.TxPool = &TxPool{store}
+	d.endpoints.Debug = &Debug{store, defaultTraceMaxBytes}
+	d.endpoints.Admin = &Admin{store}
+
+	services := map[string]interface{}{
+		NamespaceEth:    d.endpoints.Eth,
+		NamespaceNet:    d.endpoints.Net,
+		NamespaceWeb3:   d.endpoints.Web3,
+		NamespaceTxPool: d.endpoints.TxPool,
+		NamespaceDebug:  d.endpoints.Debug,
+		NamespaceAdmin:  d.endpoints.Admin,
+	}
+
+	// the methods of the disabled namespaces are not registered,
+	// so they are not found
+	for _, namespace := range namespaces {
+		if service, ok := services[namespace]; ok {
+			d.registerService(namespace, service)
+		}
+	}
+}
+
+// isEnabled returns true if the methods of the namespace are served
+func (d *Dispatcher) isEnabled(namespace string) bool {
+	_, ok := d.serviceMap[namespace]
 
-	d.registerService("eth", d.endpoints.Eth)
-	d.registerService("net", d.endpoints.Net)
-	d.registerService("web3", d.endpoints.Web3)
-	d.registerService("txpool", d.endpoints.TxPool)
+	return ok
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -137,10 +216,16 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	if subscribeMethod == "newHeads" {
 		filterID = d.filterManager.NewBlockFilter(conn)
 	} else if subscribeMethod == "logs" {
-		logQuery, err := decodeLogQueryFromInterface(params[1])
-		if err != nil {
-			return "", NewInternalError(err.Error())
+		// without a filter object, the subscription matches all logs
+		logQuery := &LogQuery{}
+
+		if len(params) > 1 {
+			var err error
+			if logQuery, err = decodeLogQueryFromInterface(params[1]); err != nil {
+				return "", NewInternalError(err.Error())
+			}
 		}
+
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
@@ -149,7 +234,7 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	return filterID, nil
 }
 
-func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
+func (d *Dispatcher) handleUnsubscribe(req Request, conn wsConn) (bool, Error) {
 	var params []interface{}
 	if err := json.Unmarshal(req.Params, &params); err != nil {
 		return false, NewInvalidRequestError("Invalid json request")
@@ -164,10 +249,23 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 		return false, NewSubscriptionNotFoundError(filterID)
 	}
 
-	return d.filterManager.Uninstall(filterID), nil
+	// a connection can only cancel its own subscriptions
+	return d.filterManager.UninstallWs(filterID, conn), nil
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+// RemoveFilterByWs removes all the subscriptions of the
+// given connection. It's called when the connection closes
+func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
+	if d.filterManager == nil {
+		return
+	}
+
+	if removed := d.filterManager.RemoveFilterByWs(conn); removed > 0 {
+		d.logger.Debug("removed subscriptions of closed connection", "num", removed)
+	}
+}
+
+func (d *Dispatcher) HandleWs(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -175,7 +273,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
-	if req.Method == "eth_subscribe" {
+	if req.Method == "eth_subscribe" && d.isEnabled(NamespaceEth) {
 		filterID, err := d.handleSubscribe(req, conn)
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
@@ -190,8 +288,8 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return []byte(resp), nil
 	}
 
-	if req.Method == "eth_unsubscribe" {
-		ok, err := d.handleUnsubscribe(req)
+	if req.Method == "eth_unsubscribe" && d.isEnabled(NamespaceEth) {
+		ok, err := d.handleUnsubscribe(req, conn)
 		if err != nil {
 			return nil, err
 		}
@@ -210,7 +308,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	}
 
 	// its a normal query that we handle with the dispatcher
-	resp, err := d.handleReq(req)
+	resp, err := d.handleReq(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +316,9 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+// Handle handles the request (or the batch of requests) of the body.
+// The context carries the ID of the request, if any
+func (d *Dispatcher) Handle(ctx context.Context, reqBody []byte) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -234,7 +334,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleReq(ctx, req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -248,7 +348,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleReq(ctx, req)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
 			responses = append(responses, errorResponse)
@@ -268,23 +368,34 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
-func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
-	d.logger.Debug("request", "method", req.Method, "id", req.ID)
+func (d *Dispatcher) handleReq(ctx context.Context, req Request) ([]byte, Error) {
+	requestid.Logger(ctx, d.logger).Debug("request", "method", req.Method, "id", req.ID)
 
 	service, fd, ferr := d.getFnHandler(req)
 	if ferr != nil {
 		return nil, ferr
 	}
 
+	if d.executionTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, d.executionTimeout)
+		defer cancel()
+	}
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
+	if fd.hasCtx {
+		inArgs[1] = reflect.ValueOf(ctx)
+	}
+
 	inputs := make([]interface{}, fd.numParams())
 
-	for i := 0; i < fd.inNum-1; i++ {
-		val := reflect.New(fd.reqt[i+1])
+	for i := 0; i < fd.numParams(); i++ {
+		val := reflect.New(fd.reqt[i+fd.numArgs()])
 		inputs[i] = val.Interface()
-		inArgs[i+1] = val.Elem()
+		inArgs[i+fd.numArgs()] = val.Elem()
 	}
 
 	if fd.numParams() > 0 {
@@ -293,9 +404,29 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		}
 	}
 
-	output := fd.fv.Call(inArgs)
+	output, callErr := d.call(ctx, fd, inArgs)
+	if callErr != nil {
+		d.logInternalError(ctx, req.Method, callErr)
+
+		if errors.Is(callErr, errMethodPanicked) {
+			return nil, NewInternalError("Internal error")
+		}
+
+		return nil, d.contextError(callErr)
+	}
+
 	if err := getError(output[1]); err != nil {
-		d.logInternalError(req.Method, err)
+		d.logInternalError(ctx, req.Method, err)
+
+		// keep the error code of typed endpoint errors
+		var rpcErr Error
+		if errors.As(err, &rpcErr) {
+			return nil, rpcErr
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, d.contextError(err)
+		}
 
 		return nil, NewInvalidRequestError(err.Error())
 	}
@@ -308,7 +439,7 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	if res := output[0].Interface(); res != nil {
 		data, err = json.Marshal(res)
 		if err != nil {
-			d.logInternalError(req.Method, err)
+			d.logInternalError(ctx, req.Method, err)
 
 			return nil, NewInternalError("Internal error")
 		}
@@ -317,8 +448,63 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	return data, nil
 }
 
-func (d *Dispatcher) logInternalError(method string, err error) {
-	d.logger.Error("failed to dispatch", "method", method, "err", err)
+// call runs the function of the request. Once the context expires, the caller gets
+// back a timeout. The functions taking the context stop along with it, while the others
+// run to completion in the background, holding their slot of the running calls.
+// A panic of a function run in the background is returned as errMethodPanicked
+func (d *Dispatcher) call(ctx context.Context, fd *funcData, inArgs []reflect.Value) ([]reflect.Value, error) {
+	if d.executionTimeout == 0 {
+		return fd.fv.Call(inArgs), nil
+	}
+
+	select {
+	case d.running <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	type callResult struct {
+		output []reflect.Value
+		err    error
+	}
+
+	resultCh := make(chan callResult, 1)
+
+	go func() {
+		defer func() {
+			// a panic is not recovered by the HTTP server outside of its handler
+			if r := recover(); r != nil {
+				requestid.Logger(ctx, d.logger).Error("method panicked", "panic", r, "stack", string(debug.Stack()))
+
+				resultCh <- callResult{err: fmt.Errorf("%w: %v", errMethodPanicked, r)}
+			}
+
+			<-d.running
+		}()
+
+		resultCh <- callResult{output: fd.fv.Call(inArgs)}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.output, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// contextError returns the error of a request whose context is done, either
+// timed out or cancelled (by the shutdown of the server or the caller leaving)
+func (d *Dispatcher) contextError(err error) Error {
+	if errors.Is(err, context.Canceled) {
+		return NewInternalError("request cancelled")
+	}
+
+	return NewTimeoutError(d.executionTimeout)
+}
+
+func (d *Dispatcher) logInternalError(ctx context.Context, method string, err error) {
+	requestid.Logger(ctx, d.logger).Error("failed to dispatch", "method", method, "err", err)
 }
 
 func (d *Dispatcher) registerService(serviceName string, service interface{}) {
@@ -355,9 +541,12 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) {
 		if fd.inNum, fd.reqt, err = validateFunc(funcName, fd.fv, true); err != nil {
 			panic(fmt.Sprintf("jsonrpc: %s", err))
 		}
+
+		fd.hasCtx = fd.inNum > 1 && fd.reqt[1] == contextType
+
 		// check if last item is a pointer
 		if fd.numParams() != 0 {
-			last := fd.reqt[fd.numParams()]
+			last := fd.reqt[fd.inNum-1]
 			if last.Kind() == reflect.Ptr {
 				fd.isDyn = true
 			}
@@ -413,7 +602,12 @@ func validateFunc(funcName string, fv reflect.Value, isMethod bool) (inNum int,
 	return
 }
 
-var errt = reflect.TypeOf((*error)(nil)).Elem()
+var errMethodPanicked = errors.New("method panicked")
+
+var (
+	errt        = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
 
 func isErrorType(t reflect.Type) bool {
 	return t.Implements(errt)
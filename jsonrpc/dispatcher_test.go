@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 	"reflect"
@@ -58,7 +59,7 @@ func expectBatchJSONResult(data []byte, v interface{}) error {
 func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 	t.Run("clients should be able to receive \"newHeads\" event thru eth_subscribe", func(t *testing.T) {
 		store := newMockStore()
-		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, nil)
 
 		mockConnection := &mockWsConn{
 			msgCh: make(chan []byte, 1),
@@ -68,7 +69,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(context.Background(), req, mockConnection); err != nil {
 			t.Fatal(err)
 		}
 
@@ -90,9 +91,48 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 	})
 }
 
+func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
+	store := newMockStore()
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, nil)
+
+	conn1 := &mockWsConn{msgCh: make(chan []byte, 1)}
+	conn2 := &mockWsConn{msgCh: make(chan []byte, 1)}
+
+	// logs subscriptions don't require a filter object
+	var filterID string
+
+	data, err := dispatcher.HandleWs(context.Background(), []byte(`{
+		"method": "eth_subscribe",
+		"params": ["logs"],
+		"id": 1
+	}`), conn1)
+	assert.NoError(t, err)
+	assert.NoError(t, expectJSONResult(data, &filterID))
+	assert.True(t, dispatcher.filterManager.Exists(filterID))
+
+	unsubscribe := func(conn wsConn) bool {
+		var ok string
+
+		data, err := dispatcher.HandleWs(context.Background(), []byte(`{
+			"method": "eth_unsubscribe",
+			"params": ["`+filterID+`"],
+			"id": 2
+		}`), conn)
+		assert.NoError(t, err)
+		assert.NoError(t, expectJSONResult(data, &ok))
+
+		return ok == "true"
+	}
+
+	// only the connection owning the subscription can cancel it
+	assert.False(t, unsubscribe(conn2))
+	assert.True(t, unsubscribe(conn1))
+	assert.False(t, dispatcher.filterManager.Exists(filterID))
+}
+
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 	store := newMockStore()
-	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0)
+	dispatcher := newDispatcher(hclog.NewNullLogger(), store, 0, nil)
 
 	mockConnection := &mockWsConn{
 		msgCh: make(chan []byte, 1),
@@ -143,7 +183,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(context.Background(), c.msg, mockConnection)
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -162,7 +202,8 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 }
 
 type mockService struct {
-	msgCh chan interface{}
+	msgCh     chan interface{}
+	releaseCh chan struct{}
 }
 
 func (m *mockService) Block(f BlockNumber) (interface{}, error) {
@@ -171,6 +212,29 @@ func (m *mockService) Block(f BlockNumber) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) CtxBlock(ctx context.Context, f BlockNumber) (interface{}, error) {
+	m.msgCh <- f
+
+	return nil, nil
+}
+
+func (m *mockService) Wait(ctx context.Context) (interface{}, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// Stuck ignores the context and returns once released
+func (m *mockService) Stuck() (interface{}, error) {
+	<-m.releaseCh
+
+	return nil, nil
+}
+
+func (m *mockService) Panic() (interface{}, error) {
+	panic("mock panic")
+}
+
 func (m *mockService) Type(addr types.Address) (interface{}, error) {
 	m.msgCh <- addr
 
@@ -196,11 +260,11 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 func TestDispatcherFuncDecode(t *testing.T) {
 	srv := &mockService{msgCh: make(chan interface{}, 10)}
 
-	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
 	dispatcher.registerService("mock", srv)
 
 	handleReq := func(typ string, msg string) interface{} {
-		_, err := dispatcher.handleReq(Request{
+		_, err := dispatcher.handleReq(context.Background(), Request{
 			Method: "mock_" + typ,
 			Params: []byte(msg),
 		})
@@ -231,6 +295,11 @@ func TestDispatcherFuncDecode(t *testing.T) {
 			`["0x1"]`,
 			BlockNumber(1),
 		},
+		{
+			"ctxBlock",
+			`["0x1"]`,
+			BlockNumber(1),
+		},
 		{
 			"type",
 			`["` + addr1.String() + `"]`,
@@ -261,12 +330,114 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_ExecutionTimeout(t *testing.T) {
+	srv := &mockService{msgCh: make(chan interface{}, 10)}
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
+	dispatcher.registerService("mock", srv)
+	dispatcher.executionTimeout = 50 * time.Millisecond
+
+	t.Run("should time out a request running for too long", func(t *testing.T) {
+		_, err := dispatcher.handleReq(context.Background(), Request{
+			Method: "mock_wait",
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, -32002, err.ErrorCode())
+	})
+
+	t.Run("should pass the context along with the params", func(t *testing.T) {
+		_, err := dispatcher.handleReq(context.Background(), Request{
+			Method: "mock_ctxBlock",
+			Params: []byte(`["latest"]`),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, LatestBlockNumber, <-srv.msgCh)
+	})
+}
+
+func TestDispatcher_RunningCalls(t *testing.T) {
+	srv := &mockService{
+		msgCh:     make(chan interface{}, 10),
+		releaseCh: make(chan struct{}),
+	}
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
+	dispatcher.registerService("mock", srv)
+	dispatcher.executionTimeout = 50 * time.Millisecond
+	dispatcher.running = make(chan struct{}, 1)
+
+	call := func(method string, params string) Error {
+		_, err := dispatcher.handleReq(context.Background(), Request{
+			Method: method,
+			Params: []byte(params),
+		})
+
+		return err
+	}
+
+	// the call ignoring the context outlives its request
+	err := call("mock_stuck", "[]")
+	if assert.Error(t, err) {
+		assert.Equal(t, -32002, err.ErrorCode())
+	}
+
+	// and holds the only slot, so the next call doesn't start
+	err = call("mock_ctxBlock", `["latest"]`)
+	if assert.Error(t, err) {
+		assert.Equal(t, -32002, err.ErrorCode())
+	}
+
+	assert.Len(t, srv.msgCh, 0)
+
+	// the slot is freed once it returns
+	close(srv.releaseCh)
+
+	assert.Eventually(t, func() bool {
+		return len(dispatcher.running) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, call("mock_ctxBlock", `["latest"]`))
+	assert.Equal(t, LatestBlockNumber, <-srv.msgCh)
+}
+
+func TestDispatcher_PanickingCall(t *testing.T) {
+	srv := &mockService{msgCh: make(chan interface{}, 10)}
+
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
+	dispatcher.registerService("mock", srv)
+	dispatcher.executionTimeout = time.Second
+	dispatcher.running = make(chan struct{}, 1)
+
+	// the panic is returned as an internal error
+	_, err := dispatcher.handleReq(context.Background(), Request{
+		Method: "mock_panic",
+		Params: []byte("[]"),
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, -32603, err.ErrorCode())
+	}
+
+	// and the slot is freed
+	assert.Eventually(t, func() bool {
+		return len(dispatcher.running) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = dispatcher.handleReq(context.Background(), Request{
+		Method: "mock_ctxBlock",
+		Params: []byte(`["latest"]`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, LatestBlockNumber, <-srv.msgCh)
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
-	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0)
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
 
 	// test with leading whitespace ("  \t\n\n\r")
 	leftBytes := []byte{0x20, 0x20, 0x09, 0x0A, 0x0A, 0x0D}
-	resp, err := dispatcher.Handle(append(leftBytes, []byte(`[
+	resp, err := dispatcher.Handle(context.Background(), append(leftBytes, []byte(`[
     {"id":1,"jsonrpc":"2.0","method":"eth_getBalance","params":["0x1", true]},
     {"id":2,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x2", true]},
     {"id":3,"jsonrpc":"2.0","method":"eth_getBlockByNumber","params":["0x3", true]},
@@ -3,6 +3,8 @@ package jsonrpc
 import (
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/umbracle/go-web3/abi"
 )
@@ -75,6 +77,30 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type rateLimitError struct {
+	err string
+}
+
+func (e *rateLimitError) Error() string {
+	return e.err
+}
+
+func (e *rateLimitError) ErrorCode() int {
+	return -32005
+}
+
+type timeoutError struct {
+	err string
+}
+
+func (e *timeoutError) Error() string {
+	return e.err
+}
+
+func (e *timeoutError) ErrorCode() int {
+	return -32002
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -85,6 +111,14 @@ func NewInvalidParamsError(msg string) *invalidParamsError {
 	return &invalidParamsError{msg}
 }
 
+func NewRateLimitError(msg string) *rateLimitError {
+	return &rateLimitError{msg}
+}
+
+func NewTimeoutError(timeout time.Duration) *timeoutError {
+	return &timeoutError{fmt.Sprintf("request timed out after %s", timeout)}
+}
+
 func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
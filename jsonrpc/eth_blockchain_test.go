@@ -1,12 +1,16 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -51,6 +55,42 @@ func TestEth_Block_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestEth_Block_GetBlockByNumber_Tags(t *testing.T) {
+	store := &mockBlockStore{}
+	for i := 0; i < 10; i++ {
+		store.add(newTestBlock(uint64(i), hash1))
+	}
+
+	// the finality of the store lags behind the head
+	store.finalized = 5
+	store.safe = 7
+
+	eth := newTestEthEndpoint(store)
+
+	cases := []struct {
+		blockNum BlockNumber
+		expected uint64
+	}{
+		{LatestBlockNumber, 9},
+		{SafeBlockNumber, 7},
+		{FinalizedBlockNumber, 5},
+		{EarliestBlockNumber, 0},
+	}
+
+	for _, c := range cases {
+		res, err := eth.GetBlockByNumber(c.blockNum, false)
+		assert.NoError(t, err)
+
+		b, ok := res.(*block)
+		assert.True(t, ok)
+		assert.Equal(t, argUint64(c.expected), b.Number)
+
+		header, err := eth.getBlockHeader(c.blockNum)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, header.Number)
+	}
+}
+
 func TestEth_Block_GetBlockByHash(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(newTestBlock(1, hash1))
@@ -188,6 +228,170 @@ func TestEth_Block_GetLogs(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("Range below the receipts tail", func(t *testing.T) {
+		store.receiptsTail = 3
+		defer func() { store.receiptsTail = 0 }()
+
+		foundLogs, err := eth.GetLogs(&LogQuery{
+			fromBlock: 2,
+			toBlock:   4,
+			Topics:    topics,
+		})
+		assert.ErrorIs(t, err, blockchain.ErrReceiptsPruned)
+		assert.Nil(t, foundLogs)
+
+		// the range starting at the tail is served
+		foundLogs, err = eth.GetLogs(&LogQuery{
+			fromBlock: 3,
+			toBlock:   4,
+			Topics:    topics,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, foundLogs, 1)
+	})
+}
+
+// logsStore is a block store indexed by number, keeping the receipts
+// RLP encoded (decoded on read, like the storage does)
+type logsStore struct {
+	ethStore
+	blocks   []*types.Block
+	receipts map[types.Hash][]byte
+}
+
+// newLogsStore returns a store where each block holds a log with a common topic,
+// apart from every rareEvery-th block, which holds a log with the rare topic instead
+func newLogsStore(numBlocks, rareEvery int, withBloom bool, commonTopic, rareTopic types.Hash) *logsStore {
+	store := &logsStore{
+		blocks:   make([]*types.Block, numBlocks),
+		receipts: make(map[types.Hash][]byte),
+	}
+
+	for i := 0; i < numBlocks; i++ {
+		topic := commonTopic
+		if i%rareEvery == 0 {
+			topic = rareTopic
+		}
+
+		receipts := types.Receipts{
+			{
+				Logs: []*types.Log{
+					{Address: addr0, Topics: []types.Hash{topic}},
+				},
+			},
+		}
+
+		header := &types.Header{
+			Number: uint64(i),
+			Hash:   types.StringToHash(strconv.Itoa(i)),
+		}
+
+		if withBloom {
+			header.LogsBloom = types.CreateBloom(receipts)
+		}
+
+		store.blocks[i] = &types.Block{
+			Header:       header,
+			Transactions: []*types.Transaction{{Nonce: uint64(i)}},
+		}
+		store.receipts[header.Hash] = receipts.MarshalRLPTo(nil)
+	}
+
+	return store
+}
+
+func (s *logsStore) Header() *types.Header {
+	return s.blocks[len(s.blocks)-1].Header
+}
+
+func (s *logsStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
+	if num >= uint64(len(s.blocks)) {
+		return nil, false
+	}
+
+	return s.blocks[num].Header, true
+}
+
+func (s *logsStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool) {
+	if num >= uint64(len(s.blocks)) {
+		return nil, false
+	}
+
+	return s.blocks[num], true
+}
+
+func (s *logsStore) ReceiptsTail() uint64 {
+	return 0
+}
+
+func (s *logsStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalRLP(s.receipts[hash]); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+func TestEth_Block_GetLogs_Bloom(t *testing.T) {
+	commonTopic := types.StringToHash("100")
+	rareTopic := types.StringToHash("101")
+
+	testTable := []struct {
+		name  string
+		query *LogQuery
+	}{
+		{"rare topic", &LogQuery{Topics: [][]types.Hash{{rareTopic}}}},
+		{"common topic", &LogQuery{Topics: [][]types.Hash{{commonTopic}}}},
+		{"any of the topics", &LogQuery{Topics: [][]types.Hash{{rareTopic, commonTopic}}}},
+		{"unknown topic", &LogQuery{Topics: [][]types.Hash{{hash3}}}},
+		{"wildcard topic", &LogQuery{Topics: [][]types.Hash{{}}}},
+		{"address", &LogQuery{Addresses: []types.Address{addr0}}},
+		{"unknown address", &LogQuery{Addresses: []types.Address{addr1}}},
+	}
+
+	// the bloom prefiltering must not change the results
+	naive := newTestEthEndpoint(newLogsStore(100, 10, false, commonTopic, rareTopic))
+	prefiltered := newTestEthEndpoint(newLogsStore(100, 10, true, commonTopic, rareTopic))
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.query.fromBlock = EarliestBlockNumber
+			testCase.query.toBlock = LatestBlockNumber
+
+			expected, err := naive.GetLogs(testCase.query)
+			assert.NoError(t, err)
+
+			found, err := prefiltered.GetLogs(testCase.query)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, found)
+		})
+	}
+}
+
+func BenchmarkEth_GetLogs_RareTopic(b *testing.B) {
+	commonTopic := types.StringToHash("100")
+	rareTopic := types.StringToHash("101")
+
+	query := &LogQuery{
+		fromBlock: EarliestBlockNumber,
+		toBlock:   LatestBlockNumber,
+		Topics:    [][]types.Hash{{rareTopic}},
+	}
+
+	for _, withBloom := range []bool{false, true} {
+		eth := newTestEthEndpoint(newLogsStore(10000, 1000, withBloom, commonTopic, rareTopic))
+
+		b.Run(fmt.Sprintf("bloom=%t", withBloom), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := eth.GetLogs(query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
 
 func TestEth_GetTransactionByHash(t *testing.T) {
@@ -250,6 +454,62 @@ func TestEth_GetTransactionByHash(t *testing.T) {
 	})
 }
 
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	store := &mockBlockStore{}
+	eth := newTestEthEndpoint(store)
+	block := newTestBlock(1, hash1)
+	store.add(block)
+
+	for i := 0; i < 3; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	lookups := map[string]func(index argUint64) (interface{}, error){
+		"by number": func(index argUint64) (interface{}, error) {
+			return eth.GetTransactionByBlockNumberAndIndex(BlockNumber(block.Number()), index)
+		},
+		"by hash": func(index argUint64) (interface{}, error) {
+			return eth.GetTransactionByBlockHashAndIndex(block.Hash(), index)
+		},
+	}
+
+	for name, lookup := range lookups {
+		t.Run(name, func(t *testing.T) {
+			for i, txn := range block.Transactions {
+				res, err := lookup(argUint64(i))
+				assert.NoError(t, err)
+
+				// the same as the transaction looked up by hash
+				byHash, err := eth.GetTransactionByHash(txn.Hash)
+				assert.NoError(t, err)
+				assert.Equal(t, byHash, res)
+
+				// nolint:forcetypeassert
+				foundTxn := res.(*transaction)
+				assert.Equal(t, txn.Hash, foundTxn.Hash)
+				assert.Equal(t, argUint64(block.Number()), *foundTxn.BlockNumber)
+				assert.Equal(t, block.Hash(), *foundTxn.BlockHash)
+				assert.Equal(t, argUint64(i), *foundTxn.TxIndex)
+			}
+
+			// out of range
+			res, err := lookup(argUint64(len(block.Transactions)))
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+		})
+	}
+
+	t.Run("unknown block", func(t *testing.T) {
+		res, err := eth.GetTransactionByBlockNumberAndIndex(BlockNumber(2), 0)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+
+		res, err = eth.GetTransactionByBlockHashAndIndex(hash2, 0)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
 func TestEth_GetTransactionReceipt(t *testing.T) {
 	t.Run("returns nil if transaction with same hash not found", func(t *testing.T) {
 		store := &mockBlockStore{}
@@ -290,6 +550,164 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Equal(t, txn.Hash, response.TxHash)
 		assert.Equal(t, block.Hash(), response.BlockHash)
 		assert.NotNil(t, response.Logs)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), response.Status)
+		// the receipt lacks the effective gas price, it falls back to the one of the transaction
+		assert.Equal(t, argBig(*big.NewInt(1)), response.EffectiveGasPrice)
+	})
+
+	t.Run("falls back to the effective gas price of a dynamic fee transaction", func(t *testing.T) {
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		block.Header.BaseFee = 10
+		store.add(block)
+
+		// the tip is capped by the max fee
+		capped := newTestTransaction(uint64(0), addr0)
+		capped.Type = types.DynamicFeeTx
+		capped.MaxFeePerGas = big.NewInt(12)
+		capped.MaxPriorityFeePerGas = big.NewInt(5)
+		capped.ComputeHash()
+
+		tipped := newTestTransaction(uint64(1), addr0)
+		tipped.Type = types.DynamicFeeTx
+		tipped.MaxFeePerGas = big.NewInt(20)
+		tipped.MaxPriorityFeePerGas = big.NewInt(5)
+		tipped.ComputeHash()
+
+		block.Transactions = append(block.Transactions, capped, tipped)
+
+		for range block.Transactions {
+			rec := &types.Receipt{}
+			rec.SetStatus(types.ReceiptSuccess)
+			store.receipts[hash4] = append(store.receipts[hash4], rec)
+		}
+
+		for txn, expected := range map[*types.Transaction]int64{capped: 12, tipped: 15} {
+			res, err := eth.GetTransactionReceipt(txn.Hash)
+			assert.NoError(t, err)
+
+			// nolint:forcetypeassert
+			response := res.(*receipt)
+			assert.Equal(t, argBig(*big.NewInt(expected)), response.EffectiveGasPrice)
+		}
+	})
+
+	t.Run("returns the effective gas price of the receipt", func(t *testing.T) {
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+		txn := newTestTransaction(uint64(0), addr0)
+		block.Transactions = append(block.Transactions, txn)
+		rec := &types.Receipt{
+			EffectiveGasPrice: big.NewInt(7),
+		}
+		rec.SetStatus(types.ReceiptFailed)
+		store.receipts[hash4] = []*types.Receipt{rec}
+
+		res, err := eth.GetTransactionReceipt(txn.Hash)
+		assert.NoError(t, err)
+
+		// nolint:forcetypeassert
+		response := res.(*receipt)
+		assert.Equal(t, argUint64(types.ReceiptFailed), response.Status)
+		assert.Equal(t, argBig(*big.NewInt(7)), response.EffectiveGasPrice)
+	})
+
+	t.Run("returns an error if the receipt is pruned", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.receiptsTail = 2
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+		txn := newTestTransaction(uint64(0), addr0)
+		block.Transactions = append(block.Transactions, txn)
+		store.receipts[hash4] = []*types.Receipt{{}}
+
+		res, err := eth.GetTransactionReceipt(txn.Hash)
+		assert.ErrorIs(t, err, blockchain.ErrReceiptsPruned)
+		assert.Nil(t, res)
+	})
+}
+
+func TestEth_GetBlockReceipts(t *testing.T) {
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	store.add(newTestBlock(0, hash1), block)
+
+	receipts := make([]*types.Receipt, 3)
+	for i := range receipts {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+
+		receipts[i] = &types.Receipt{
+			CumulativeGasUsed: uint64(i+1) * 21000,
+			GasUsed:           21000,
+			EffectiveGasPrice: big.NewInt(int64(i + 1)),
+			Logs: []*types.Log{
+				{
+					Address: addr1,
+					Topics: []types.Hash{
+						hash2,
+					},
+				},
+			},
+		}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	receipts[1].SetStatus(types.ReceiptFailed)
+	store.receipts[hash4] = receipts
+
+	blockNumber := BlockNumber(1)
+	blockHash := hash4
+
+	filters := map[string]BlockNumberOrHash{
+		"by number": {BlockNumber: &blockNumber},
+		"by hash":   {BlockHash: &blockHash},
+	}
+
+	for name, filter := range filters {
+		filter := filter
+
+		t.Run(name, func(t *testing.T) {
+			res, err := eth.GetBlockReceipts(filter)
+			assert.NoError(t, err)
+
+			// nolint:forcetypeassert
+			response := res.([]*receipt)
+			assert.Len(t, response, len(block.Transactions))
+
+			for indx, txn := range block.Transactions {
+				single, err := eth.GetTransactionReceipt(txn.Hash)
+				assert.NoError(t, err)
+
+				assert.Equal(t, single, response[indx])
+				assert.Equal(t, txn.Hash, response[indx].TxHash)
+				assert.Equal(t, argUint64(indx), response[indx].TxIndex)
+			}
+
+			assert.Equal(t, argUint64(types.ReceiptFailed), response[1].Status)
+			assert.Equal(t, argUint64(3*21000), response[2].CumulativeGasUsed)
+			assert.Equal(t, argBig(*big.NewInt(3)), response[2].EffectiveGasPrice)
+		})
+	}
+
+	t.Run("returns an empty list for a block without transactions", func(t *testing.T) {
+		genesisNumber := BlockNumber(0)
+
+		res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockNumber: &genesisNumber})
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("returns an error for an unknown block", func(t *testing.T) {
+		unknownHash := hash3
+
+		_, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &unknownHash})
+		assert.Error(t, err)
 	})
 }
 
@@ -338,6 +756,150 @@ func TestEth_GasPrice(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("0x%x", store.averageGasPrice), response)
 }
 
+func TestEth_FeeHistory(t *testing.T) {
+	// returns a block with the given gas usage
+	newFeeBlock := func(number, gasUsed, gasLimit uint64) *types.Block {
+		block := newTestBlock(number, types.StringToHash(strconv.FormatUint(number, 10)))
+		block.Header.GasUsed = gasUsed
+		block.Header.GasLimit = gasLimit
+
+		return block
+	}
+
+	toBigs := func(values ...int64) []argBig {
+		res := make([]argBig, len(values))
+		for i, v := range values {
+			res[i] = argBig(*big.NewInt(v))
+		}
+
+		return res
+	}
+
+	t.Run("empty block", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newFeeBlock(0, 0, 100))
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.FeeHistory(1, LatestBlockNumber, []float64{10, 90})
+		assert.NoError(t, err)
+
+		history, ok := res.(*feeHistory)
+		assert.True(t, ok)
+
+		assert.Equal(t, argUint64(0), history.OldestBlock)
+		assert.Equal(t, toBigs(0, 0), history.BaseFeePerGas)
+		assert.Equal(t, []float64{0}, history.GasUsedRatio)
+		assert.Equal(t, [][]argBig{toBigs(0, 0)}, history.Reward)
+	})
+
+	t.Run("full block", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newFeeBlock(0, 0, 100))
+
+		block := newFeeBlock(1, 100, 100)
+		block.Transactions = []*types.Transaction{
+			{Nonce: 0, From: addr0, GasPrice: big.NewInt(20)},
+			{Nonce: 1, From: addr0, GasPrice: big.NewInt(10)},
+			{
+				Nonce:                2,
+				From:                 addr0,
+				Type:                 types.DynamicFeeTx,
+				MaxFeePerGas:         big.NewInt(40),
+				MaxPriorityFeePerGas: big.NewInt(30),
+			},
+		}
+		store.add(block)
+		store.receipts[block.Hash()] = []*types.Receipt{
+			{GasUsed: 30},
+			{GasUsed: 20},
+			{GasUsed: 50},
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.FeeHistory(1, BlockNumber(1), []float64{0, 25, 50, 100})
+		assert.NoError(t, err)
+
+		history, ok := res.(*feeHistory)
+		assert.True(t, ok)
+
+		assert.Equal(t, argUint64(1), history.OldestBlock)
+		assert.Equal(t, []float64{1}, history.GasUsedRatio)
+
+		// rewards are weighted by the gas used of each tx
+		assert.Equal(t, [][]argBig{toBigs(10, 20, 20, 30)}, history.Reward)
+	})
+
+	t.Run("range crossing genesis", func(t *testing.T) {
+		store := newMockBlockStore()
+		for i := uint64(0); i < 3; i++ {
+			store.add(newFeeBlock(i, 50, 100))
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.FeeHistory(10, LatestBlockNumber, nil)
+		assert.NoError(t, err)
+
+		history, ok := res.(*feeHistory)
+		assert.True(t, ok)
+
+		// the range is capped at genesis
+		assert.Equal(t, argUint64(0), history.OldestBlock)
+		assert.Equal(t, toBigs(0, 0, 0, 0), history.BaseFeePerGas)
+		assert.Equal(t, []float64{0.5, 0.5, 0.5}, history.GasUsedRatio)
+		assert.Nil(t, history.Reward)
+	})
+
+	t.Run("london blocks", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newFeeBlock(0, 0, 100))
+
+		block := newFeeBlock(1, 100, 100)
+		block.Header.BaseFee = 10
+		block.Transactions = []*types.Transaction{
+			{Nonce: 0, From: addr0, GasPrice: big.NewInt(15)},
+			{
+				Nonce:                1,
+				From:                 addr0,
+				Type:                 types.DynamicFeeTx,
+				MaxFeePerGas:         big.NewInt(40),
+				MaxPriorityFeePerGas: big.NewInt(30),
+			},
+		}
+		store.add(block)
+		store.receipts[block.Hash()] = []*types.Receipt{
+			{GasUsed: 50},
+			{GasUsed: 50},
+		}
+
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.FeeHistory(1, BlockNumber(1), []float64{0, 100})
+		assert.NoError(t, err)
+
+		history, ok := res.(*feeHistory)
+		assert.True(t, ok)
+
+		// the base fee is subtracted from the rewards
+		assert.Equal(t, toBigs(10, 10), history.BaseFeePerGas)
+		assert.Equal(t, [][]argBig{toBigs(5, 30)}, history.Reward)
+	})
+
+	t.Run("invalid percentiles", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newFeeBlock(0, 0, 100))
+
+		eth := newTestEthEndpoint(store)
+
+		for _, percentiles := range [][]float64{{-1}, {101}, {50, 10}} {
+			_, err := eth.FeeHistory(1, LatestBlockNumber, percentiles)
+			assert.ErrorIs(t, err, ErrInvalidPercentile)
+		}
+	})
+}
+
 func TestEth_Call(t *testing.T) {
 	t.Run("returns error if transaction execution fails", func(t *testing.T) {
 		store := newMockBlockStore()
@@ -354,7 +916,7 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), store.ethCallError.Error())
@@ -376,10 +938,50 @@ func TestEth_Call(t *testing.T) {
 			Nonce:    argUintPtr(0),
 		}
 
-		res, err := eth.Call(contractCall, BlockNumberOrHash{})
+		res, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
+		assert.Nil(t, store.callOverride)
+	})
+
+	t.Run("passes the state override to the execution", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		eth := newTestEthEndpoint(store)
+		contractCall := &txnArgs{
+			From:  &addr0,
+			To:    &addr1,
+			Nonce: argUintPtr(0),
+		}
+
+		override := stateOverride{}
+		assert.NoError(t, json.Unmarshal([]byte(`{
+			"0x0000000000000000000000000000000000000001": {
+				"nonce": "0x2",
+				"balance": "0x64",
+				"code": "0x6000",
+				"stateDiff": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+				}
+			}
+		}`), &override))
+
+		_, err := eth.Call(context.Background(), contractCall, BlockNumberOrHash{}, &override)
+		assert.NoError(t, err)
+
+		nonce := uint64(2)
+
+		assert.Equal(t, types.StateOverride{
+			addr1: {
+				Nonce:   &nonce,
+				Balance: big.NewInt(100),
+				Code:    []byte{0x60, 0x00},
+				StateDiff: map[types.Hash]types.Hash{
+					types.StringToHash("0x1"): types.StringToHash("0x2"),
+				},
+			},
+		}, store.callOverride)
 	})
 }
 
@@ -392,6 +994,12 @@ type mockBlockStore struct {
 	isSyncing       bool
 	averageGasPrice int64
 	ethCallError    error
+	callOverride    types.StateOverride
+	finalized       uint64
+	safe            uint64
+
+	// the receipts of the blocks below it are pruned
+	receiptsTail uint64
 }
 
 func newMockBlockStore() *mockBlockStore {
@@ -475,7 +1083,15 @@ func (m *mockBlockStore) setupLogs() {
 	}
 }
 
+func (m *mockBlockStore) ReceiptsTail() uint64 {
+	return m.receiptsTail
+}
+
 func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	if b, ok := m.GetBlockByHash(hash, false); ok && b.Number() < m.receiptsTail {
+		return nil, blockchain.ErrReceiptsPruned
+	}
+
 	receipts, ok := m.receipts[hash]
 	if !ok {
 		return nil, nil
@@ -517,16 +1133,32 @@ func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
-func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
+func (m *mockBlockStore) FinalizedHeader() *types.Header {
+	header, _ := m.GetHeaderByNumber(m.finalized)
+
+	return header
+}
+
+func (m *mockBlockStore) SafeHeader() *types.Header {
+	header, _ := m.GetHeaderByNumber(m.safe)
+
+	return header
+}
+
+func (m *mockBlockStore) GetTxLookup(txnHash types.Hash) (*storage.TxLookup, bool) {
 	for _, block := range m.blocks {
-		for _, txn := range block.Transactions {
+		for indx, txn := range block.Transactions {
 			if txn.Hash == txnHash {
-				return block.Hash(), true
+				return &storage.TxLookup{
+					BlockHash:   block.Hash(),
+					BlockNumber: block.Number(),
+					Index:       uint64(indx),
+				}, true
 			}
 		}
 	}
 
-	return types.ZeroHash, false
+	return nil, false
 }
 
 func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
@@ -556,7 +1188,19 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) CalcBaseFee(parent *types.Header) uint64 {
+	// keep the base fee constant
+	return parent.BaseFee
+}
+
+func (m *mockBlockStore) ApplyTxn(
+	ctx context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+) (*runtime.ExecutionResult, error) {
+	m.callOverride = override
+
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
 
@@ -1,11 +1,16 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -19,11 +24,15 @@ type ethTxPoolStore interface {
 	// GetNonce returns the next nonce for this address
 	GetNonce(addr types.Address) uint64
 
-	// AddTx adds a new transaction to the tx pool
-	AddTx(tx *types.Transaction) error
+	// AddTx adds a new transaction to the tx pool.
+	// The context carries the ID of the request, if any
+	AddTx(ctx context.Context, tx *types.Transaction) error
 
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// GetSender recovers the sender of the transaction
+	GetSender(tx *types.Transaction) (types.Address, error)
 }
 
 type ethStateStore interface {
@@ -31,12 +40,26 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(hash types.Hash) ([]byte, error)
+
+	// GetProof returns the Merkle proof of the key (an address or a storage slot)
+	// in the trie with the given root
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
 }
 
 type ethBlockchainStore interface {
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
+	// FinalizedHeader returns the header of the latest finalized block
+	FinalizedHeader() *types.Header
+
+	// SafeHeader returns the header of the latest block which is safe from reorganizations
+	SafeHeader() *types.Header
+
+	// PendingHeader returns the header of the pending block,
+	// assembled from the executable transactions of the pool
+	PendingHeader() (*types.Header, error)
+
 	// GetHeaderByNumber returns the header by number
 	GetHeaderByNumber(block uint64) (*types.Header, bool)
 
@@ -46,17 +69,46 @@ type ethBlockchainStore interface {
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 
-	// ReadTxLookup returns a block hash in which a given txn was mined
-	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+	// GetTxLookup returns the block hash, block number and index of a mined txn
+	GetTxLookup(txnHash types.Hash) (*storage.TxLookup, bool)
 
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
+	// ReceiptsTail returns the number of the first block whose receipts are kept
+	ReceiptsTail() uint64
+
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
-	// ApplyTxn applies a transaction object to the blockchain
-	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// CalcBaseFee returns the base fee of the block following parent
+	CalcBaseFee(parent *types.Header) uint64
+
+	// ApplyTxn applies a transaction object to the blockchain,
+	// over the state with the given accounts overridden.
+	// The execution stops once the context is done
+	ApplyTxn(
+		ctx context.Context,
+		header *types.Header,
+		txn *types.Transaction,
+		override types.StateOverride,
+	) (*runtime.ExecutionResult, error)
+
+	// CreateAccessList applies a transaction object to the blockchain, returning the access list
+	// of the addresses and storage slots it touches. The execution stops once the context is done
+	CreateAccessList(
+		ctx context.Context,
+		header *types.Header,
+		txn *types.Transaction,
+	) (types.AccessList, *runtime.ExecutionResult, error)
+
+	// SimulateBundle applies the transactions in order over the state of the given block
+	// without committing them, returning the receipt and the result of each one
+	SimulateBundle(
+		ctx context.Context,
+		header *types.Header,
+		txns []*types.Transaction,
+	) ([]*types.Receipt, []*runtime.ExecutionResult, error)
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
@@ -75,13 +127,29 @@ type Eth struct {
 	store         ethStore
 	chainID       uint64
 	filterManager *FilterManager
+
+	// limits the submitted transactions per sender (nil if disabled)
+	txRateLimiter *senderRateLimiter
+
+	// maximum gas of the call executions (0 if unlimited)
+	gasCap uint64
+
+	// suggests the gas price (nil if the average gas price is suggested)
+	gasPriceOracle *gasPriceOracle
 }
 
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
 	ErrGasCapOverflow    = errors.New("unable to apply transaction for the highest gas limit")
+	ErrInvalidPercentile = errors.New("invalid reward percentile")
 )
 
+// maximum number of blocks returned by eth_feeHistory
+const maxFeeHistoryBlocks = 1024
+
+// code hash of the accounts without code
+var emptyCodeHash = types.BytesToHash(crypto.Keccak256(nil))
+
 // ChainId returns the chain id of the client
 //nolint:stylecheck
 func (e *Eth) ChainId() (interface{}, error) {
@@ -131,6 +199,12 @@ func GetNumericBlockNumber(number BlockNumber, e *Eth) (uint64, error) {
 	case LatestBlockNumber:
 		return e.store.Header().Number, nil
 
+	case FinalizedBlockNumber:
+		return e.store.FinalizedHeader().Number, nil
+
+	case SafeBlockNumber:
+		return e.store.SafeHeader().Number, nil
+
 	case EarliestBlockNumber:
 		return 0, nil
 
@@ -187,6 +261,50 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	return len(block.Transactions), nil
 }
 
+// GetTransactionByBlockNumberAndIndex returns the transaction
+// at the given index of the block with the given number
+func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return blockTransaction(block, index), nil
+}
+
+// GetTransactionByBlockHashAndIndex returns the transaction
+// at the given index of the block with the given hash
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return blockTransaction(block, index), nil
+}
+
+// blockTransaction returns the transaction at the given index of the block,
+// or nil if the block has fewer transactions
+func blockTransaction(block *types.Block, index argUint64) interface{} {
+	if uint64(index) >= uint64(len(block.Transactions)) {
+		return nil
+	}
+
+	idx := int(index)
+
+	return toTransaction(
+		block.Transactions[idx],
+		argUintPtr(block.Number()),
+		argHashPtr(block.Hash()),
+		&idx,
+	)
+}
+
 // BlockNumber returns current block number
 func (e *Eth) BlockNumber() (interface{}, error) {
 	h := e.store.Header()
@@ -198,21 +316,79 @@ func (e *Eth) BlockNumber() (interface{}, error) {
 }
 
 // SendRawTransaction sends a raw transaction
-func (e *Eth) SendRawTransaction(input string) (interface{}, error) {
-	buf := hex.MustDecodeHex(input)
+func (e *Eth) SendRawTransaction(ctx context.Context, input string) (interface{}, error) {
+	buf, err := hex.DecodeHex(input)
+	if err != nil {
+		return nil, NewInvalidParamsError(fmt.Sprintf("invalid raw transaction hex: %v", err))
+	}
+
+	tx, err := e.addRawTransaction(ctx, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendRawTransactions sends a batch of raw transactions.
+// The results are returned in the order of the inputs,
+// holding either the hash or the error of each transaction
+func (e *Eth) SendRawTransactions(ctx context.Context, inputs []string) (interface{}, error) {
+	results := make([]*sendRawTxResult, len(inputs))
+
+	for i, input := range inputs {
+		buf, err := hex.DecodeHex(input)
+		if err != nil {
+			results[i] = &sendRawTxResult{Error: err.Error()}
+
+			continue
+		}
+
+		tx, err := e.addRawTransaction(ctx, buf)
+		if err != nil {
+			results[i] = &sendRawTxResult{Error: err.Error()}
+
+			continue
+		}
+
+		results[i] = &sendRawTxResult{Hash: argHashPtr(tx.Hash)}
+	}
+
+	return results, nil
+}
 
+// addRawTransaction decodes the RLP encoded transaction and adds it to the pool
+func (e *Eth) addRawTransaction(ctx context.Context, buf []byte) (*types.Transaction, error) {
 	tx := &types.Transaction{}
 	if err := tx.UnmarshalRLP(buf); err != nil {
-		return nil, err
+		return nil, NewInvalidParamsError(fmt.Sprintf("invalid raw transaction: %v", err))
+	}
+
+	// the sender whose token is refunded if the pool rejects the tx
+	var limitedSender *types.Address
+
+	if e.txRateLimiter != nil {
+		// txs with an invalid signature are rejected by the pool
+		if sender, err := e.store.GetSender(tx); err == nil {
+			if !e.txRateLimiter.allow(sender) {
+				return nil, NewRateLimitError(
+					fmt.Sprintf("too many transactions from %s, the limit is %d per second", sender, e.txRateLimiter.rate),
+				)
+			}
+
+			limitedSender = &sender
+		}
 	}
 
-	tx.ComputeHash()
+	if err := e.store.AddTx(ctx, tx); err != nil {
+		if limitedSender != nil {
+			e.txRateLimiter.refund(*limitedSender)
+		}
 
-	if err := e.store.AddTx(tx); err != nil {
 		return nil, err
 	}
 
-	return tx.Hash.String(), nil
+	return tx, nil
 }
 
 // Reject eth_sendTransaction json-rpc call as we don't support wallet management
@@ -229,32 +405,31 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	// for the transaction with the provided hash
 	findSealedTx := func() *transaction {
 		// Check the chain state for the transaction
-		blockHash, ok := e.store.ReadTxLookup(hash)
+		lookup, ok := e.store.GetTxLookup(hash)
 		if !ok {
 			// Block not found in storage
 			return nil
 		}
 
-		block, ok := e.store.GetBlockByHash(blockHash, true)
+		block, ok := e.store.GetBlockByHash(lookup.BlockHash, true)
 
 		if !ok {
 			// Block receipts not found in storage
 			return nil
 		}
 
-		// Find the transaction within the block
-		for idx, txn := range block.Transactions {
-			if txn.Hash == hash {
-				return toTransaction(
-					txn,
-					argUintPtr(block.Number()),
-					argHashPtr(block.Hash()),
-					&idx,
-				)
-			}
+		// The lookup holds the position of the transaction within the block
+		idx := int(lookup.Index)
+		if idx >= len(block.Transactions) || block.Transactions[idx].Hash != hash {
+			return nil
 		}
 
-		return nil
+		return toTransaction(
+			block.Transactions[idx],
+			argUintPtr(block.Number()),
+			argHashPtr(block.Hash()),
+			&idx,
+		)
 	}
 
 	// findPendingTx is a helper method for checking the TxPool
@@ -289,12 +464,14 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 
 // GetTransactionReceipt returns a transaction receipt by his hash
 func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
-	blockHash, ok := e.store.ReadTxLookup(hash)
+	lookup, ok := e.store.GetTxLookup(hash)
 	if !ok {
 		// txn not found
 		return nil, nil
 	}
 
+	blockHash := lookup.BlockHash
+
 	block, ok := e.store.GetBlockByHash(blockHash, true)
 	if !ok {
 		// block not found
@@ -306,6 +483,10 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	}
 
 	receipts, err := e.store.GetReceiptsByHash(blockHash)
+	if errors.Is(err, blockchain.ErrReceiptsPruned) {
+		return nil, err
+	}
+
 	if err != nil {
 		// block receipts not found
 		e.logger.Warn(
@@ -323,54 +504,50 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 
 		return nil, nil
 	}
-	// find the transaction in the body
-	indx := -1
+	// the lookup holds the position of the transaction in the body
+	indx := int(lookup.Index)
+	if indx >= len(block.Transactions) || indx >= len(receipts) || block.Transactions[indx].Hash != hash {
+		// txn not found
+		return nil, nil
+	}
 
-	for i, txn := range block.Transactions {
-		if txn.Hash == hash {
-			indx = i
+	return toReceipt(receipts[indx], block.Transactions[indx], indx, block), nil
+}
 
-			break
-		}
+// GetBlockReceipts returns the receipts of all the transactions in the block, in transaction order
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, err
 	}
 
-	if indx == -1 {
-		// txn not found
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		// block not found
 		return nil, nil
 	}
 
-	txn := block.Transactions[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
-		}
-	}
-
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            txn.Hash,
-		TxIndex:           argUint64(indx),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	res := make([]*receipt, 0, len(block.Transactions))
+	if len(block.Transactions) == 0 {
+		return res, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the receipts of block %s: %w", block.Hash(), err)
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf(
+			"block %s has %d transactions but %d receipts",
+			block.Hash(),
+			len(block.Transactions),
+			len(receipts),
+		)
+	}
+
+	for indx, txn := range block.Transactions {
+		res = append(res, toReceipt(receipts[indx], txn, indx, block))
 	}
 
 	return res, nil
@@ -400,7 +577,7 @@ func (e *Eth) GetStorageAt(
 	// Get the storage for the passed in location
 	result, err := e.store.GetStorage(header.StateRoot, address, index)
 	if err != nil {
-		if errors.As(err, &ErrStateNotFound) {
+		if errors.Is(err, ErrStateNotFound) {
 			return argBytesPtr(types.ZeroHash[:]), nil
 		}
 
@@ -420,19 +597,188 @@ func (e *Eth) GetStorageAt(
 		return argBytesPtr(types.ZeroHash[:]), nil
 	}
 
-	return argBytesPtr(data), nil
+	// the leading zeros of the values are trimmed in the trie,
+	// the slot is returned as a full 32 bytes word
+	return argBytesPtr(types.BytesToHash(data).Bytes()), nil
 }
 
 // GasPrice returns the average gas price based on the last x blocks
 func (e *Eth) GasPrice() (interface{}, error) {
+	if e.gasPriceOracle != nil {
+		price, err := e.gasPriceOracle.SuggestGasPrice()
+		if err != nil {
+			return nil, err
+		}
+
+		return hex.EncodeBig(price), nil
+	}
+
 	// Grab the average gas price and convert it to a hex value
 	avgGasPrice := hex.EncodeBig(e.store.GetAvgGasPrice())
 
 	return avgGasPrice, nil
 }
 
-// Call executes a smart contract call using the transaction object data
-func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+// MaxPriorityFeePerGas returns the priority fee (tip) suggested for
+// the dynamic fee transactions, based on the tips of the latest blocks
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	if e.gasPriceOracle == nil {
+		return hex.EncodeUint64(defaultPriorityFee), nil
+	}
+
+	tip, err := e.gasPriceOracle.SuggestTipCap()
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.EncodeBig(tip), nil
+}
+
+// FeeHistory returns the base fees, gas used ratios and the effective priority fee
+// percentiles (rewards) of the blockCount blocks ending with newestBlock
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	for i, percentile := range rewardPercentiles {
+		if percentile < 0 || percentile > 100 ||
+			(i > 0 && percentile < rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("%w: %f", ErrInvalidPercentile, percentile)
+		}
+	}
+
+	newest, err := GetNumericBlockNumber(newestBlock, e)
+	if err != nil {
+		return nil, err
+	}
+
+	count := uint64(blockCount)
+	if count > maxFeeHistoryBlocks {
+		count = maxFeeHistoryBlocks
+	}
+
+	// the range can't go past genesis
+	if count > newest+1 {
+		count = newest + 1
+	}
+
+	history := &feeHistory{
+		OldestBlock:   argUint64(newest + 1 - count),
+		BaseFeePerGas: make([]argBig, 0, count+1),
+		GasUsedRatio:  make([]float64, 0, count),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		history.Reward = make([][]argBig, 0, count)
+	}
+
+	var block *types.Block
+
+	for num := newest + 1 - count; num <= newest; num++ {
+		var ok bool
+		if block, ok = e.store.GetBlockByNumber(num, true); !ok {
+			return nil, fmt.Errorf("unable to fetch block %d", num)
+		}
+
+		// blocks before the London fork have no base fee (0)
+		baseFee := new(big.Int).SetUint64(block.Header.BaseFee)
+		history.BaseFeePerGas = append(history.BaseFeePerGas, argBig(*baseFee))
+
+		gasUsedRatio := float64(0)
+		if block.Header.GasLimit > 0 {
+			gasUsedRatio = float64(block.Header.GasUsed) / float64(block.Header.GasLimit)
+		}
+
+		history.GasUsedRatio = append(history.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+
+		rewards, err := e.getBlockRewards(block, baseFee, rewardPercentiles)
+		if err != nil {
+			return nil, err
+		}
+
+		history.Reward = append(history.Reward, rewards)
+	}
+
+	// the base fee of the block following the newest one
+	if block != nil {
+		nextBaseFee := new(big.Int).SetUint64(e.store.CalcBaseFee(block.Header))
+		history.BaseFeePerGas = append(history.BaseFeePerGas, argBig(*nextBaseFee))
+	}
+
+	return history, nil
+}
+
+// getBlockRewards samples the effective priority fees of the block's
+// transactions at the given percentiles, weighted by the gas they used
+func (e *Eth) getBlockRewards(
+	block *types.Block,
+	baseFee *big.Int,
+	percentiles []float64,
+) ([]argBig, error) {
+	rewards := make([]argBig, len(percentiles))
+	if len(block.Transactions) == 0 {
+		for i := range rewards {
+			rewards[i] = argBig(*big.NewInt(0))
+		}
+
+		return rewards, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("unable to fetch receipts of block %d", block.Number())
+	}
+
+	type txReward struct {
+		gasUsed uint64
+		reward  *big.Int
+	}
+
+	sorted := make([]txReward, len(block.Transactions))
+
+	for i, tx := range block.Transactions {
+		reward := new(big.Int).Sub(tx.GetGasPrice(baseFee.Uint64()), baseFee)
+		if reward.Sign() < 0 {
+			reward.SetUint64(0)
+		}
+
+		sorted[i] = txReward{gasUsed: receipts[i].GasUsed, reward: reward}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].reward.Cmp(sorted[j].reward) < 0
+	})
+
+	var (
+		txIndex = 0
+		sumGas  = sorted[0].gasUsed
+	)
+
+	for i, percentile := range percentiles {
+		threshold := uint64(float64(block.Header.GasUsed) * percentile / 100)
+		for sumGas < threshold && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGas += sorted[txIndex].gasUsed
+		}
+
+		rewards[i] = argBig(*sorted[txIndex].reward)
+	}
+
+	return rewards, nil
+}
+
+// Call executes a smart contract call using the transaction object data.
+// The optional override replaces the fields of the given accounts for the call only
+func (e *Eth) Call(ctx context.Context, arg *txnArgs, filter BlockNumberOrHash, override *stateOverride) (interface{}, error) {
 	var (
 		header *types.Header
 		err    error
@@ -455,11 +801,18 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	}
 	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
 	if transaction.Gas == 0 {
-		transaction.Gas = header.GasLimit
+		transaction.SetGas(header.GasLimit)
+	}
+
+	transaction.SetGas(e.capGas(transaction.Gas))
+
+	var stateOverride types.StateOverride
+	if override != nil {
+		stateOverride = override.toStateOverride()
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction)
+	result, err := e.store.ApplyTxn(ctx, header, transaction, stateOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -476,8 +829,103 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+// CreateAccessList returns the access list of the addresses and storage slots
+// touched by the transaction, along with the gas it uses with that access list
+func (e *Eth) CreateAccessList(ctx context.Context, arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	transaction, err := e.decodeTxn(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.SetGas(header.GasLimit)
+	}
+
+	transaction.SetGas(e.capGas(transaction.Gas))
+
+	accessList, result, err := e.store.CreateAccessList(ctx, header, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &accessListResult{
+		AccessList: accessList,
+		GasUsed:    argUint64(result.GasUsed),
+	}
+
+	if res.AccessList == nil {
+		res.AccessList = types.AccessList{}
+	}
+
+	if result.Reverted() {
+		res.Error = constructErrorFromRevert(result).Error()
+	} else if result.Failed() {
+		res.Error = result.Err.Error()
+	}
+
+	return res, nil
+}
+
+// SimulateBundle executes the raw transactions in order over the state of the given block,
+// without committing them. Each transaction executes over the changes of the previous ones
+func (e *Eth) SimulateBundle(ctx context.Context, inputs []string, filter BlockNumberOrHash) (interface{}, error) {
+	if len(inputs) == 0 {
+		return nil, NewInvalidParamsError("empty bundle")
+	}
+
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	txns := make([]*types.Transaction, len(inputs))
+
+	for i, input := range inputs {
+		buf, err := hex.DecodeHex(input)
+		if err != nil {
+			return nil, NewInvalidParamsError(fmt.Sprintf("invalid raw transaction %d hex: %v", i, err))
+		}
+
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(buf); err != nil {
+			return nil, NewInvalidParamsError(fmt.Sprintf("invalid raw transaction %d: %v", i, err))
+		}
+
+		txns[i] = tx
+	}
+
+	receipts, results, err := e.store.SimulateBundle(ctx, header, txns)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*bundleTxResult, len(txns))
+
+	for i, txn := range txns {
+		res[i] = toBundleTxResult(receipts[i], results[i], txn, i, header)
+	}
+
+	return res, nil
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+func (e *Eth) EstimateGas(ctx context.Context, arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
 	transaction, err := e.decodeTxn(arg)
 	if err != nil {
 		return nil, err
@@ -494,7 +942,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		return nil, err
 	}
 
-	forksInTime := e.store.GetForksInTime(uint64(number))
+	forksInTime := e.store.GetForksInTime(header.Number)
 
 	var standardGas uint64
 	if transaction.IsContractCreation() && forksInTime.Homestead {
@@ -516,6 +964,8 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = header.GasLimit
 	}
 
+	highEnd = e.capGas(highEnd)
+
 	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
 	valueInt := new(big.Int).Set(transaction.Value)
 
@@ -530,7 +980,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		accountBalance := big.NewInt(0)
 		acc, err := e.store.GetAccount(header.StateRoot, transaction.From)
 
-		if err != nil && !errors.As(err, &ErrStateNotFound) {
+		if err != nil && !errors.Is(err, ErrStateNotFound) {
 			// An unrelated error occurred, return it
 			return nil, err
 		} else if err == nil {
@@ -591,9 +1041,9 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	testTransaction := func(gas uint64, shouldOmitErr bool) (bool, error) {
 		// Create a dummy transaction with the new gas
 		txn := transaction.Copy()
-		txn.Gas = gas
+		txn.SetGas(gas)
 
-		result, applyErr := e.store.ApplyTxn(header, txn)
+		result, applyErr := e.store.ApplyTxn(ctx, header, txn, nil)
 
 		if applyErr != nil {
 			// Check the application error.
@@ -664,6 +1114,15 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	return hex.EncodeUint64(highEnd), nil
 }
 
+// capGas bounds the gas of a call execution to the gas cap, if set
+func (e *Eth) capGas(gas uint64) uint64 {
+	if e.gasCap != 0 && gas > e.gasCap {
+		return e.gasCap
+	}
+
+	return gas
+}
+
 // GetLogs returns an array of logs matching the filter options
 func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 	result := make([]*Log, 0)
@@ -714,16 +1173,15 @@ func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 	head := e.store.Header().Number
 
 	resolveNum := func(num BlockNumber) uint64 {
-		if num == PendingBlockNumber {
-			num = LatestBlockNumber
-		}
-
-		if num == EarliestBlockNumber {
-			num = 0
-		}
-
-		if num == LatestBlockNumber {
+		switch num {
+		case PendingBlockNumber, LatestBlockNumber:
 			return head
+		case FinalizedBlockNumber:
+			return e.store.FinalizedHeader().Number
+		case SafeBlockNumber:
+			return e.store.SafeHeader().Number
+		case EarliestBlockNumber:
+			return 0
 		}
 
 		return uint64(num)
@@ -736,14 +1194,37 @@ func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 		return nil, fmt.Errorf("incorrect range")
 	}
 
+	// the logs of the blocks below the tail are gone, the genesis has none
+	if tail := e.store.ReceiptsTail(); from < tail && tail > 1 {
+		return nil, blockchain.ErrReceiptsPruned
+	}
+
+	bloomQuery := newLogBloomQuery(query)
+
 	for i := from; i <= to; i++ {
+		header, ok := e.store.GetHeaderByNumber(i)
+		if !ok {
+			break
+		}
+
+		if header.Number == 0 {
+			// do not check logs in genesis
+			continue
+		}
+
+		// skip the block if its logs bloom rules out any match,
+		// blocks sealed without a logs bloom (empty) are scanned
+		if !header.LogsBloom.IsEmpty() && !bloomQuery.match(&header.LogsBloom) {
+			continue
+		}
+
 		block, ok := e.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
 		}
 
-		if block.Header.Number == 0 || len(block.Transactions) == 0 {
-			// do not check logs in genesis and skip if no txs
+		if len(block.Transactions) == 0 {
+			// skip if no txs
 			continue
 		}
 
@@ -774,7 +1255,7 @@ func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (inter
 
 	// Extract the account balance
 	acc, err := e.store.GetAccount(header.StateRoot, address)
-	if errors.As(err, &ErrStateNotFound) {
+	if errors.Is(err, ErrStateNotFound) {
 		// Account not found, return an empty account
 		return argUintPtr(0), nil
 	} else if err != nil {
@@ -840,7 +1321,7 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	emptySlice := []byte{}
 	acc, err := e.store.GetAccount(header.StateRoot, address)
 
-	if errors.As(err, &ErrStateNotFound) {
+	if errors.Is(err, ErrStateNotFound) {
 		// If the account doesn't exist / is not initialized yet,
 		// return the default value
 		return "0x", nil
@@ -857,6 +1338,96 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the Merkle proofs of the account and of the given storage slots
+// at the referenced block (EIP-1186).
+// The account proof chains up to the state root of the block header
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	var (
+		header *types.Header
+		err    error
+	)
+
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err = e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// a missing account is proven with its absence from the state trie
+	result := &accountProof{
+		Address:      address,
+		AccountProof: toArgBytesList(proof),
+		Balance:      *argBigPtr(big.NewInt(0)),
+		CodeHash:     emptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]storageProof, 0, len(storageKeys)),
+	}
+
+	acc, err := e.store.GetAccount(header.StateRoot, address)
+	if err == nil {
+		result.Balance = *argBigPtr(acc.Balance)
+		result.Nonce = argUint64(acc.Nonce)
+		result.CodeHash = types.BytesToHash(acc.CodeHash)
+		result.StorageHash = acc.Root
+	} else if !errors.Is(err, ErrStateNotFound) {
+		return nil, err
+	}
+
+	for _, key := range storageKeys {
+		proof, err := e.store.GetProof(result.StorageHash, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		value := big.NewInt(0)
+
+		if acc != nil {
+			obj, err := e.store.GetStorage(header.StateRoot, address, key)
+			if err != nil && !errors.Is(err, ErrStateNotFound) {
+				return nil, err
+			}
+
+			if err == nil {
+				// the values in the storage trie are RLP encoded
+				p := &fastrlp.Parser{}
+
+				v, err := p.Parse(obj)
+				if err != nil {
+					return nil, err
+				}
+
+				data, err := v.Bytes()
+				if err != nil {
+					return nil, err
+				}
+
+				value.SetBytes(data)
+			}
+		}
+
+		result.StorageProof = append(result.StorageProof, storageProof{
+			Key:   key,
+			Value: *argBigPtr(value),
+			Proof: toArgBytesList(proof),
+		})
+	}
+
+	return result, nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -891,6 +1462,12 @@ func (e *Eth) getBlockHeader(number BlockNumber) (*types.Header, error) {
 	case LatestBlockNumber:
 		return e.store.Header(), nil
 
+	case FinalizedBlockNumber:
+		return e.store.FinalizedHeader(), nil
+
+	case SafeBlockNumber:
+		return e.store.SafeHeader(), nil
+
 	case EarliestBlockNumber:
 		header, ok := e.store.GetHeaderByNumber(uint64(0))
 		if !ok {
@@ -900,7 +1477,7 @@ func (e *Eth) getBlockHeader(number BlockNumber) (*types.Header, error) {
 		return header, nil
 
 	case PendingBlockNumber:
-		return nil, fmt.Errorf("fetching the pending header is not supported")
+		return e.store.PendingHeader()
 
 	default:
 		// Convert the block number from hex to uint64
@@ -932,7 +1509,7 @@ func (e *Eth) getNextNonce(address types.Address, number BlockNumber) (uint64, e
 
 	acc, err := e.store.GetAccount(header.StateRoot, address)
 
-	if errors.As(err, &ErrStateNotFound) {
+	if errors.Is(err, ErrStateNotFound) {
 		// If the account doesn't exist / isn't initialized,
 		// return a nonce value of 0
 		return 0, nil
@@ -998,6 +1575,11 @@ func (e *Eth) decodeTxn(arg *txnArgs) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	if arg.AccessList != nil {
+		txn.Type = types.AccessListTx
+		txn.AccessList = *arg.AccessList
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
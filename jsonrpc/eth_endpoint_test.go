@@ -223,5 +223,5 @@ func TestEth_GetNextNonce(t *testing.T) {
 }
 
 func newTestEthEndpoint(store ethStore) *Eth {
-	return &Eth{hclog.NewNullLogger(), store, 100, nil}
+	return &Eth{hclog.NewNullLogger(), store, 100, nil, nil, 0, nil}
 }
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -176,6 +179,7 @@ func TestEth_State_GetTransactionCount(t *testing.T) {
 	eth := newTestEthEndpoint(store)
 	blockNumberEarliest := EarliestBlockNumber
 	blockNumberLatest := LatestBlockNumber
+	blockNumberPending := PendingBlockNumber
 	blockNumberZero := BlockNumber(0x0)
 	blockNumberInvalid := BlockNumber(0x1)
 
@@ -219,6 +223,14 @@ func TestEth_State_GetTransactionCount(t *testing.T) {
 			false,
 			100,
 		},
+		{
+			"should return the pool nonce for pending block number",
+			addr0,
+			&blockNumberPending,
+			nil,
+			false,
+			1,
+		},
 		{
 			"should return an error for non-existing block",
 			addr0,
@@ -529,6 +541,20 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 			succeeded:    false,
 			expectedData: nil,
 		},
+		{
+			name: "should return the value left padded to 32 bytes",
+			initialStorage: map[types.Address]map[types.Hash]types.Hash{
+				addr0: {
+					hash1: types.StringToHash("0x05"),
+				},
+			},
+			address:      addr0,
+			index:        hash1,
+			blockNumber:  &blockNumberLatest,
+			blockHash:    nil,
+			succeeded:    true,
+			expectedData: argBytesPtr(types.StringToHash("0x05").Bytes()),
+		},
 		{
 			name: "should return data using earliest block number",
 			initialStorage: map[types.Address]map[types.Hash]types.Hash{
@@ -558,8 +584,9 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 				}
 				account := store.account
 				for index, data := range storage {
+					// the trie stores the values without the leading zeros
 					a := &fastrlp.Arena{}
-					value := a.NewBytes(data.Bytes())
+					value := a.NewBytes(bytes.TrimLeft(data.Bytes(), "\x00"))
 					newData := value.MarshalTo(nil)
 					account.Storage(index, newData)
 				}
@@ -681,7 +708,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(context.Background(), testCase.transaction, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -730,6 +757,7 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 
 	// Run the estimation
 	estimate, estimateErr := ethEndpoint.EstimateGas(
+		context.Background(),
 		constructMockTx(nil, nil),
 		nil,
 	)
@@ -756,6 +784,7 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 
 	// Run the estimation
 	estimate, estimateErr := ethEndpoint.EstimateGas(
+		context.Background(),
 		mockTx,
 		nil,
 	)
@@ -766,12 +795,507 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_GasCap(t *testing.T) {
+	const (
+		gasCap  = 100000
+		callGas = 30000
+	)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+	ethEndpoint.gasCap = gasCap
+
+	var maxGas uint64
+
+	// the cheap call uses a fixed amount of gas,
+	// while the infinite loop runs out of any gas
+	applyHook := func(infiniteLoop bool) func(*types.Header, *types.Transaction) (*runtime.ExecutionResult, error) {
+		return func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+			if txn.Gas > maxGas {
+				maxGas = txn.Gas
+			}
+
+			if infiniteLoop || txn.Gas < callGas {
+				return &runtime.ExecutionResult{GasLeft: 0, Err: runtime.ErrOutOfGas}, nil
+			}
+
+			return &runtime.ExecutionResult{GasLeft: txn.Gas - callGas}, nil
+		}
+	}
+
+	store.createAccessListHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (types.AccessList, *runtime.ExecutionResult, error) {
+		result, err := store.applyTxnHook(header, txn)
+
+		return nil, result, err
+	}
+
+	t.Run("cheap call", func(t *testing.T) {
+		maxGas = 0
+		store.applyTxnHook = applyHook(false)
+
+		_, err := ethEndpoint.Call(context.Background(), constructMockTx(nil, nil), BlockNumberOrHash{}, nil)
+		assert.NoError(t, err)
+
+		// the gas defaults to the block gas limit, which is capped
+		assert.Equal(t, uint64(gasCap), maxGas)
+
+		estimate, err := ethEndpoint.EstimateGas(context.Background(), constructMockTx(nil, nil), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("0x%x", callGas), estimate)
+		assert.Equal(t, uint64(gasCap), maxGas)
+
+		maxGas = 0
+		_, err = ethEndpoint.CreateAccessList(context.Background(), constructMockTx(nil, nil), BlockNumberOrHash{})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(gasCap), maxGas)
+	})
+
+	t.Run("infinite loop", func(t *testing.T) {
+		maxGas = 0
+		store.applyTxnHook = applyHook(true)
+
+		// the gas of the transaction is capped as well
+		_, err := ethEndpoint.Call(context.Background(), constructMockTx(argUintPtr(gasCap*10), nil), BlockNumberOrHash{}, nil)
+		assert.ErrorIs(t, err, runtime.ErrOutOfGas)
+		assert.Equal(t, uint64(gasCap), maxGas)
+
+		_, err = ethEndpoint.EstimateGas(context.Background(), constructMockTx(argUintPtr(gasCap*10), nil), nil)
+		assert.ErrorIs(t, err, runtime.ErrOutOfGas)
+		assert.Equal(t, uint64(gasCap), maxGas)
+
+		maxGas = 0
+		_, err = ethEndpoint.CreateAccessList(
+			context.Background(),
+			constructMockTx(argUintPtr(gasCap*10), nil),
+			BlockNumberOrHash{},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(gasCap), maxGas)
+	})
+}
+
+func TestEth_CreateAccessList(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	accessList := types.AccessList{
+		{
+			Address:     addr1,
+			StorageKeys: []types.Hash{hash1, hash2},
+		},
+	}
+
+	var applied *types.Transaction
+
+	store.createAccessListHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (types.AccessList, *runtime.ExecutionResult, error) {
+		applied = txn
+
+		return accessList, &runtime.ExecutionResult{GasUsed: 30000}, nil
+	}
+
+	// the given access list is passed on as the starting point
+	arg := constructMockTx(nil, nil)
+	arg.AccessList = &types.AccessList{{Address: addr1}}
+
+	res, err := ethEndpoint.CreateAccessList(context.Background(), arg, BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &accessListResult{
+		AccessList: accessList,
+		GasUsed:    argUint64(30000),
+	}, res)
+
+	assert.Equal(t, types.AccessListTx, applied.Type)
+	assert.Equal(t, *arg.AccessList, applied.AccessList)
+	assert.Equal(t, store.block.Header.GasLimit, applied.Gas)
+}
+
+func TestEth_CreateAccessList_Reverts(t *testing.T) {
+	// Example revert data that has the string "revert reason" as the revert reason
+	exampleReturnData := "08c379a000000000000000000000000000000000000000000000000000000000000000" +
+		"20000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e" +
+		"00000000000000000000000000000000000000"
+	rawReturnData, err := hex.DecodeHex(exampleReturnData)
+	assert.NoError(t, err)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	store.createAccessListHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (types.AccessList, *runtime.ExecutionResult, error) {
+		return nil, &runtime.ExecutionResult{
+			ReturnValue: rawReturnData,
+			GasUsed:     25000,
+			Err:         runtime.ErrExecutionReverted,
+		}, nil
+	}
+
+	res, err := ethEndpoint.CreateAccessList(context.Background(), constructMockTx(nil, nil), BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	// the list of a failed execution is still returned, along with the error
+	result, ok := res.(*accessListResult)
+	assert.True(t, ok)
+	assert.Equal(t, types.AccessList{}, result.AccessList)
+	assert.Equal(t, argUint64(25000), result.GasUsed)
+	assert.Contains(t, result.Error, "revert reason")
+}
+
+func TestEth_State_PendingBlock(t *testing.T) {
+	store := getExampleStore()
+	store.pendingHeader = &types.Header{
+		Number:    1,
+		GasLimit:  store.block.Header.GasLimit,
+		StateRoot: types.StringToHash("pending"),
+	}
+	// the pending block holds a transfer to the account
+	store.pendingAccount = &state.Account{
+		Balance: big.NewInt(150),
+	}
+
+	ethEndpoint := newTestEthEndpoint(store)
+
+	latest := LatestBlockNumber
+	pending := PendingBlockNumber
+
+	balance, err := ethEndpoint.GetBalance(addr0, BlockNumberOrHash{BlockNumber: &latest})
+	assert.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(100)), balance)
+
+	pendingFilter := BlockNumberOrHash{BlockNumber: &pending}
+
+	balance, err = ethEndpoint.GetBalance(addr0, pendingFilter)
+	assert.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(150)), balance)
+
+	// the calls are applied on top of the pending state
+	var applied []*types.Header
+
+	store.applyTxnHook = func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+		applied = append(applied, header)
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	_, err = ethEndpoint.Call(context.Background(), constructMockTx(nil, nil), pendingFilter, nil)
+	assert.NoError(t, err)
+
+	_, err = ethEndpoint.EstimateGas(context.Background(), constructMockTx(nil, nil), &pending)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, applied)
+
+	for _, header := range applied {
+		assert.Equal(t, store.pendingHeader, header)
+	}
+}
+
+func TestEth_State_GetProof(t *testing.T) {
+	slot0 := types.StringToHash("1")
+	missingSlot := types.StringToHash("2")
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	txn := state.NewTxn(st, st.NewSnapshot())
+
+	txn.CreateAccount(addr0)
+	txn.SetBalance(addr0, big.NewInt(100))
+	txn.SetNonce(addr0, 5)
+	txn.SetCode(addr0, code0)
+	txn.SetState(addr0, slot0, types.StringToHash("0xabcd"))
+
+	for i := 1; i < 20; i++ {
+		acc := types.BytesToAddress([]byte{0x10, byte(i)})
+
+		txn.CreateAccount(acc)
+		txn.SetBalance(acc, big.NewInt(int64(i)))
+	}
+
+	_, root := txn.Commit(false)
+
+	store := &mockProofStore{
+		state: st,
+		header: &types.Header{
+			Number:    1,
+			StateRoot: types.BytesToHash(root),
+		},
+	}
+	eth := newTestEthEndpoint(store)
+
+	// verifyStorage checks the storage proofs against the storage root
+	verifyStorage := func(t *testing.T, res *accountProof, values map[types.Hash]*big.Int) {
+		t.Helper()
+
+		assert.Len(t, res.StorageProof, len(values))
+
+		for _, proof := range res.StorageProof {
+			nodes := make([][]byte, 0, len(proof.Proof))
+			for _, node := range proof.Proof {
+				nodes = append(nodes, node)
+			}
+
+			value, err := itrie.VerifyProof(res.StorageHash, keccak.Keccak256(nil, proof.Key.Bytes()), nodes)
+			assert.NoError(t, err)
+
+			expected := big.NewInt(0)
+
+			if value != nil {
+				p := &fastrlp.Parser{}
+				v, err := p.Parse(value)
+				assert.NoError(t, err)
+
+				data, err := v.Bytes()
+				assert.NoError(t, err)
+
+				expected.SetBytes(data)
+			}
+
+			assert.Equal(t, values[proof.Key], expected)
+			assert.Equal(t, values[proof.Key], (*big.Int)(&proof.Value))
+		}
+	}
+
+	// verifyAccount checks the account proof against the state root of the header
+	verifyAccount := func(t *testing.T, res *accountProof) []byte {
+		t.Helper()
+
+		nodes := make([][]byte, 0, len(res.AccountProof))
+		for _, node := range res.AccountProof {
+			nodes = append(nodes, node)
+		}
+
+		value, err := itrie.VerifyProof(store.header.StateRoot, keccak.Keccak256(nil, res.Address.Bytes()), nodes)
+		assert.NoError(t, err)
+
+		return value
+	}
+
+	t.Run("existing account", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{slot0, missingSlot}, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProof)
+		assert.True(t, ok)
+
+		value := verifyAccount(t, proof)
+		assert.NotNil(t, value)
+
+		var account state.Account
+		assert.NoError(t, account.UnmarshalRlp(value))
+
+		assert.Equal(t, addr0, proof.Address)
+		assert.Equal(t, big.NewInt(100), (*big.Int)(&proof.Balance))
+		assert.Equal(t, argUint64(5), proof.Nonce)
+		assert.Equal(t, types.BytesToHash(keccak.Keccak256(nil, code0)), proof.CodeHash)
+		assert.Equal(t, account.Root, proof.StorageHash)
+		assert.Equal(t, account.Balance, (*big.Int)(&proof.Balance))
+
+		verifyStorage(t, proof, map[types.Hash]*big.Int{
+			slot0:       big.NewInt(0xabcd),
+			missingSlot: big.NewInt(0),
+		})
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		res, err := eth.GetProof(uninitializedAddress, []types.Hash{slot0}, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProof)
+		assert.True(t, ok)
+
+		// the proof shows the account is absent
+		assert.NotEmpty(t, proof.AccountProof)
+		assert.Nil(t, verifyAccount(t, proof))
+
+		assert.Equal(t, big.NewInt(0), (*big.Int)(&proof.Balance))
+		assert.Equal(t, argUint64(0), proof.Nonce)
+		assert.Equal(t, emptyCodeHash, proof.CodeHash)
+		assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+
+		verifyStorage(t, proof, map[types.Hash]*big.Int{
+			slot0: big.NewInt(0),
+		})
+	})
+}
+
+type mockProofStore struct {
+	ethStore
+	header *types.Header
+	state  *itrie.State
+}
+
+func (m *mockProofStore) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockProofStore) getState(root types.Hash, key []byte) ([]byte, error) {
+	snap, err := m.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := snap.Get(keccak.Keccak256(nil, key))
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+
+	return obj, nil
+}
+
+func (m *mockProofStore) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
+	obj, err := m.getState(root, addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(obj); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (m *mockProofStore) GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
+	account, err := m.GetAccount(root, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.getState(account.Root, slot.Bytes())
+}
+
+func (m *mockProofStore) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return m.state.Prove(root, keccak.Keccak256(nil, key))
+}
+
+func TestEth_SimulateBundle(t *testing.T) {
+	// Example revert data that has the string "revert reason" as the revert reason
+	exampleReturnData := "08c379a000000000000000000000000000000000000000000000000000000000000000" +
+		"20000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e" +
+		"00000000000000000000000000000000000000"
+	rawReturnData, err := hex.DecodeHex(exampleReturnData)
+	assert.NoError(t, err)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// the second transaction depends on the first one
+	first := &types.Transaction{Nonce: 0, To: &addr1, Value: big.NewInt(10), V: big.NewInt(1)}
+	second := &types.Transaction{Nonce: 1, To: &addr1, Value: big.NewInt(10), V: big.NewInt(1)}
+
+	inputs := []string{
+		hex.EncodeToHex(first.MarshalRLP()),
+		hex.EncodeToHex(second.MarshalRLP()),
+	}
+
+	first.ComputeHash()
+	second.ComputeHash()
+
+	var (
+		simulatedHeader *types.Header
+		simulated       []*types.Transaction
+	)
+
+	store.simulateBundleHook = func(
+		header *types.Header,
+		txns []*types.Transaction,
+	) ([]*types.Receipt, []*runtime.ExecutionResult, error) {
+		simulatedHeader = header
+		simulated = txns
+
+		receipts := []*types.Receipt{
+			{
+				Logs: []*types.Log{
+					{Address: addr1, Topics: []types.Hash{hash1}, Data: []byte{0x1}},
+				},
+			},
+			{},
+		}
+
+		results := []*runtime.ExecutionResult{
+			{GasUsed: 21000, ReturnValue: []byte{0x2}},
+			{GasUsed: 25000, ReturnValue: rawReturnData, Err: runtime.ErrExecutionReverted},
+		}
+
+		return receipts, results, nil
+	}
+
+	t.Run("should simulate the transactions in order", func(t *testing.T) {
+		res, err := ethEndpoint.SimulateBundle(context.Background(), inputs, BlockNumberOrHash{})
+		assert.NoError(t, err)
+
+		// the latest block is used by default
+		assert.Equal(t, store.block.Header, simulatedHeader)
+
+		assert.Len(t, simulated, 2)
+		assert.Equal(t, first.Hash, simulated[0].Hash)
+		assert.Equal(t, second.Hash, simulated[1].Hash)
+
+		results, ok := res.([]*bundleTxResult)
+		assert.True(t, ok)
+		assert.Len(t, results, 2)
+
+		assert.Equal(t, &bundleTxResult{
+			TxHash:     first.Hash,
+			GasUsed:    argUint64(21000),
+			Status:     argUint64(types.ReceiptSuccess),
+			ReturnData: argBytes{0x2},
+			Logs: []*Log{
+				{
+					Address:     addr1,
+					Topics:      []types.Hash{hash1},
+					Data:        argBytes{0x1},
+					BlockHash:   store.block.Header.Hash,
+					BlockNumber: argUint64(store.block.Header.Number),
+					TxHash:      first.Hash,
+				},
+			},
+		}, results[0])
+
+		assert.Equal(t, second.Hash, results[1].TxHash)
+		assert.Equal(t, argUint64(25000), results[1].GasUsed)
+		assert.Equal(t, argUint64(types.ReceiptFailed), results[1].Status)
+		assert.Empty(t, results[1].Logs)
+		assert.Contains(t, results[1].Error, "revert reason")
+	})
+
+	t.Run("should reject an invalid bundle", func(t *testing.T) {
+		_, err := ethEndpoint.SimulateBundle(context.Background(), nil, BlockNumberOrHash{})
+		assert.Error(t, err)
+
+		_, err = ethEndpoint.SimulateBundle(context.Background(), []string{inputs[0], "0x01"}, BlockNumberOrHash{})
+		assert.ErrorContains(t, err, "invalid raw transaction 1")
+	})
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
 
+	// the header and the account state of the pending block, if any
+	pendingHeader  *types.Header
+	pendingAccount *state.Account
+
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+
+	createAccessListHook func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (types.AccessList, *runtime.ExecutionResult, error)
+
+	simulateBundleHook func(
+		header *types.Header,
+		txns []*types.Transaction,
+	) ([]*types.Receipt, []*runtime.ExecutionResult, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -787,6 +1311,10 @@ func (m *mockSpecialStore) GetAccount(root types.Hash, addr types.Address) (*sta
 		return nil, ErrStateNotFound
 	}
 
+	if m.pendingHeader != nil && m.pendingHeader.StateRoot == root {
+		return m.pendingAccount, nil
+	}
+
 	return m.account.account, nil
 }
 
@@ -802,6 +1330,14 @@ func (m *mockSpecialStore) Header() *types.Header {
 	return m.block.Header
 }
 
+func (m *mockSpecialStore) PendingHeader() (*types.Header, error) {
+	if m.pendingHeader == nil {
+		return nil, fmt.Errorf("no pending block")
+	}
+
+	return m.pendingHeader, nil
+}
+
 func (m *mockSpecialStore) GetNonce(addr types.Address) uint64 {
 	return 1
 }
@@ -833,10 +1369,39 @@ func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime
 	return chain.ForksInTime{}
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(
+	ctx context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+) (*runtime.ExecutionResult, error) {
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+func (m *mockSpecialStore) CreateAccessList(
+	ctx context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+) (types.AccessList, *runtime.ExecutionResult, error) {
+	if m.createAccessListHook != nil {
+		return m.createAccessListHook(header, txn)
+	}
+
+	return nil, &runtime.ExecutionResult{}, nil
+}
+
+func (m *mockSpecialStore) SimulateBundle(
+	ctx context.Context,
+	header *types.Header,
+	txns []*types.Transaction,
+) ([]*types.Receipt, []*runtime.ExecutionResult, error) {
+	if m.simulateBundleHook != nil {
+		return m.simulateBundleHook(header, txns)
+	}
+
+	return nil, nil, nil
+}
@@ -1,12 +1,16 @@
 package jsonrpc
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +25,7 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	txn.ComputeHash()
 
 	data := txn.MarshalRLP()
-	_, err := eth.SendRawTransaction(hex.EncodeToHex(data))
+	_, err := eth.SendRawTransaction(context.Background(), hex.EncodeToHex(data))
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 
@@ -31,6 +35,81 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	}
 }
 
+func TestEth_TxnPool_SendRawTransaction_Malformed(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	valid := txn.MarshalRLP()
+
+	inputs := []string{
+		"0xzz",                                // invalid hex
+		"0x",                                  // empty
+		hex.EncodeToHex(valid[:len(valid)-2]), // truncated
+		hex.EncodeToHex(append(valid, 0x01)),  // trailing bytes
+		hex.EncodeToHex([]byte{0xf8, 0xff, 0x01}), // truncated length prefix
+	}
+
+	for _, input := range inputs {
+		_, err := eth.SendRawTransaction(context.Background(), input)
+
+		// malformed inputs are reported as invalid params
+		rpcErr, ok := err.(Error) // nolint:errorlint
+		assert.True(t, ok)
+		assert.Equal(t, -32602, rpcErr.ErrorCode())
+	}
+
+	assert.Nil(t, store.txn)
+
+	// the error code is preserved by the dispatcher
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil)
+
+	_, err := dispatcher.handleReq(context.Background(), Request{
+		Method: "eth_sendRawTransaction",
+		Params: []byte(`["0xzz"]`),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, -32602, err.ErrorCode())
+}
+
+func TestEth_TxnPool_SendRawTransactions(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	inputs := []string{
+		"0xzz",                            // invalid hex
+		hex.EncodeToHex(txn.MarshalRLP()), // valid tx
+		"0x01",                            // invalid rlp
+	}
+
+	res, err := eth.SendRawTransactions(context.Background(), inputs)
+	assert.NoError(t, err)
+
+	results, ok := res.([]*sendRawTxResult)
+	assert.True(t, ok)
+	assert.Len(t, results, len(inputs))
+
+	// results preserve the order of the inputs
+	assert.Nil(t, results[0].Hash)
+	assert.NotEmpty(t, results[0].Error)
+
+	assert.Equal(t, txn.Hash, *results[1].Hash)
+	assert.Empty(t, results[1].Error)
+	assert.Equal(t, txn.Hash, store.txn.Hash)
+
+	assert.Nil(t, results[2].Hash)
+	assert.NotEmpty(t, results[2].Error)
+}
+
 func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	store := &mockStoreTxn{}
 	store.AddAccount(addr0)
@@ -43,18 +122,86 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 		GasPrice: big.NewInt(int64(1)),
 	}
 
-	_, err := eth.SendRawTransaction(hex.EncodeToHex(txToSend.MarshalRLP()))
+	_, err := eth.SendRawTransaction(context.Background(), hex.EncodeToHex(txToSend.MarshalRLP()))
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendRawTransaction_RateLimit(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	now := time.Now()
+	eth.txRateLimiter = newSenderRateLimiter(1, 2)
+	eth.txRateLimiter.now = func() time.Time {
+		return now
+	}
+
+	send := func(sender types.Address, nonce uint64) error {
+		store.sender = sender
+
+		txn := &types.Transaction{
+			Nonce: nonce,
+			V:     big.NewInt(1),
+		}
+
+		_, err := eth.SendRawTransaction(context.Background(), hex.EncodeToHex(txn.MarshalRLP()))
+
+		return err
+	}
+
+	// the burst is allowed
+	assert.NoError(t, send(addr0, 0))
+	assert.NoError(t, send(addr0, 1))
+
+	// excess submissions are rejected
+	err := send(addr0, 2)
+	assert.Error(t, err)
+
+	var rpcErr Error
+
+	assert.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32005, rpcErr.ErrorCode())
+
+	// other senders are not affected
+	assert.NoError(t, send(addr1, 0))
+
+	// the bucket refills over time
+	now = now.Add(time.Second)
+
+	assert.NoError(t, send(addr0, 2))
+	assert.Error(t, send(addr0, 3))
+
+	// the txs rejected by the pool don't take a token
+	store.addErr = errors.New("rejected")
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, send(addr2, uint64(i)), store.addErr)
+	}
+
+	store.addErr = nil
+
+	assert.NoError(t, send(addr2, 0))
+	assert.NoError(t, send(addr2, 1))
+}
+
 type mockStoreTxn struct {
 	ethStore
 	accounts map[types.Address]*mockAccount
 	txn      *types.Transaction
+	sender   types.Address
+	addErr   error
 }
 
-func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
+func (m *mockStoreTxn) GetSender(tx *types.Transaction) (types.Address, error) {
+	return m.sender, nil
+}
+
+func (m *mockStoreTxn) AddTx(ctx context.Context, tx *types.Transaction) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+
 	m.txn = tx
 
 	return nil
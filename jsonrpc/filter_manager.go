@@ -371,6 +371,37 @@ func (f *FilterManager) Uninstall(id string) bool {
 	return f.removeFilterByID(id)
 }
 
+// UninstallWs removes the filter with given ID from list,
+// only if the filter belongs to the given web socket connection
+func (f *FilterManager) UninstallWs(id string, ws wsConn) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	filter, ok := f.filters[id]
+	if !ok || filter.getFilterBase().ws != ws {
+		return false
+	}
+
+	return f.removeFilterByID(id)
+}
+
+// RemoveFilterByWs removes all the filters bound to the given web socket
+// connection, so that subscriptions are cleaned up on disconnect
+func (f *FilterManager) RemoveFilterByWs(ws wsConn) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	removed := 0
+
+	for id, filter := range f.filters {
+		if filter.getFilterBase().ws == ws && f.removeFilterByID(id) {
+			removed++
+		}
+	}
+
+	return removed
+}
+
 // removeFilterByID removes the filter with given ID, unsafe against race condition
 func (f *FilterManager) removeFilterByID(id string) bool {
 	filter, ok := f.filters[id]
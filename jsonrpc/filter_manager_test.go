@@ -180,6 +180,33 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterWebsocket_RemoveByWs(t *testing.T) {
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store)
+	go m.Run()
+
+	conn1 := &mockWsConn{msgCh: make(chan []byte, 1)}
+	conn2 := &mockWsConn{msgCh: make(chan []byte, 1)}
+
+	blockID := m.NewBlockFilter(conn1)
+	logID := m.NewLogFilter(&LogQuery{}, conn1)
+	otherID := m.NewBlockFilter(conn2)
+
+	// a connection can't remove the filters of another one
+	assert.False(t, m.UninstallWs(otherID, conn1))
+	assert.True(t, m.Exists(otherID))
+
+	// closing the connection removes all of its filters
+	assert.Equal(t, 2, m.RemoveFilterByWs(conn1))
+	assert.False(t, m.Exists(blockID))
+	assert.False(t, m.Exists(logID))
+	assert.True(t, m.Exists(otherID))
+
+	assert.True(t, m.UninstallWs(otherID, conn2))
+	assert.False(t, m.Exists(otherID))
+}
+
 type mockWsConn struct {
 	msgCh chan []byte
 }
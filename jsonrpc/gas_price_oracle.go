@@ -0,0 +1,204 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// GasPriceOracleConfig configures the gas price suggested by eth_gasPrice
+type GasPriceOracleConfig struct {
+	// Blocks is the number of latest blocks sampled
+	Blocks uint64
+	// Percentile (0 to 100) of the lowest prices of the sampled blocks
+	Percentile uint64
+	// MinPrice is the lowest suggested price, also suggested if no transaction is sampled
+	MinPrice uint64
+	// MaxPrice is the highest suggested price. 0 disables the ceiling
+	MaxPrice uint64
+}
+
+type gasPriceOracleStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetBlockByNumber returns a block using the provided number
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+}
+
+// defaultPriorityFee is the priority fee suggested if no dynamic fee block is sampled
+const defaultPriorityFee uint64 = 1000000000 // 1 gwei
+
+// gasPriceOracle suggests a gas price (or priority fee) out of the lowest
+// effective gas prices (or priority fees) of the transactions in the latest blocks
+type gasPriceOracle struct {
+	sync.Mutex
+
+	config GasPriceOracleConfig
+	store  gasPriceOracleStore
+
+	// suggestions for the head, reused until a new block is written
+	lastHead  types.Hash
+	lastPrice *big.Int
+	lastTip   *big.Int
+}
+
+func newGasPriceOracle(config GasPriceOracleConfig, store gasPriceOracleStore) *gasPriceOracle {
+	if config.Percentile > 100 {
+		config.Percentile = 100
+	}
+
+	return &gasPriceOracle{
+		config: config,
+		store:  store,
+	}
+}
+
+// SuggestGasPrice returns the gas price suggested for the next block [thread-safe]
+func (o *gasPriceOracle) SuggestGasPrice() (*big.Int, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	head := o.store.Header()
+	if o.lastPrice != nil && head.Hash == o.lastHead {
+		return new(big.Int).Set(o.lastPrice), nil
+	}
+
+	prices, err := o.sample(head, lowestGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	price := new(big.Int).SetUint64(o.config.MinPrice)
+
+	if sampled := o.percentile(prices); sampled != nil && sampled.Cmp(price) > 0 {
+		price = sampled
+	}
+
+	price = o.capPrice(price)
+
+	o.setHead(head)
+	o.lastPrice = price
+
+	return new(big.Int).Set(price), nil
+}
+
+// SuggestTipCap returns the priority fee suggested for the dynamic fee
+// transactions of the next block. The blocks without a base fee
+// (before the London fork) are not sampled [thread-safe]
+func (o *gasPriceOracle) SuggestTipCap() (*big.Int, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	head := o.store.Header()
+	if o.lastTip != nil && head.Hash == o.lastHead {
+		return new(big.Int).Set(o.lastTip), nil
+	}
+
+	tips, err := o.sample(head, lowestTip)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := o.percentile(tips)
+	if tip == nil {
+		tip = new(big.Int).SetUint64(defaultPriorityFee)
+	}
+
+	tip = o.capPrice(tip)
+
+	o.setHead(head)
+	o.lastTip = tip
+
+	return new(big.Int).Set(tip), nil
+}
+
+// sample returns the lowest values of the latest blocks up to the head,
+// skipping the blocks without one
+func (o *gasPriceOracle) sample(head *types.Header, lowest func(*types.Block) *big.Int) ([]*big.Int, error) {
+	values := make([]*big.Int, 0, o.config.Blocks)
+
+	for i := uint64(0); i < o.config.Blocks && i <= head.Number; i++ {
+		block, ok := o.store.GetBlockByNumber(head.Number-i, true)
+		if !ok {
+			return nil, fmt.Errorf("unable to fetch block %d", head.Number-i)
+		}
+
+		if value := lowest(block); value != nil {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// percentile returns the configured percentile of the values, nil if there are none
+func (o *gasPriceOracle) percentile(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+
+	return values[uint64(len(values)-1)*o.config.Percentile/100]
+}
+
+// capPrice bounds the suggestion by the maximum price (if any)
+func (o *gasPriceOracle) capPrice(price *big.Int) *big.Int {
+	if o.config.MaxPrice != 0 {
+		if maxPrice := new(big.Int).SetUint64(o.config.MaxPrice); price.Cmp(maxPrice) > 0 {
+			return maxPrice
+		}
+	}
+
+	return price
+}
+
+// setHead drops the suggestions of the previous head once a new block is written
+func (o *gasPriceOracle) setHead(head *types.Header) {
+	if head.Hash != o.lastHead {
+		o.lastHead = head.Hash
+		o.lastPrice = nil
+		o.lastTip = nil
+	}
+}
+
+// lowestGasPrice returns the lowest effective gas price
+// of the transactions of the block, nil if it has none
+func lowestGasPrice(block *types.Block) *big.Int {
+	var lowest *big.Int
+
+	for _, txn := range block.Transactions {
+		if price := txn.GetGasPrice(block.Header.BaseFee); lowest == nil || price.Cmp(lowest) < 0 {
+			lowest = price
+		}
+	}
+
+	return lowest
+}
+
+// lowestTip returns the lowest effective priority fee of the transactions
+// of the block, nil if it has none or the block has no base fee
+func lowestTip(block *types.Block) *big.Int {
+	baseFee := block.Header.BaseFee
+	if baseFee == 0 {
+		return nil
+	}
+
+	lowest := lowestGasPrice(block)
+	if lowest == nil {
+		return nil
+	}
+
+	tip := new(big.Int).Sub(lowest, new(big.Int).SetUint64(baseFee))
+	if tip.Sign() < 0 {
+		tip.SetUint64(0)
+	}
+
+	return tip
+}
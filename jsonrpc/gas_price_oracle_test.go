@@ -0,0 +1,237 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasPriceOracle_SuggestGasPrice(t *testing.T) {
+	// returns a block with transactions of the given gas prices
+	newPriceBlock := func(number uint64, prices ...int64) *types.Block {
+		block := newTestBlock(number, types.StringToHash(strconv.FormatUint(number, 10)))
+
+		for i, price := range prices {
+			block.Transactions = append(block.Transactions, &types.Transaction{
+				Nonce:    uint64(i),
+				GasPrice: big.NewInt(price),
+			})
+		}
+
+		return block
+	}
+
+	// the lowest prices of the blocks 1 to 5 are 10 to 50
+	newStore := func() *mockBlockStore {
+		store := newMockBlockStore()
+		store.add(
+			newPriceBlock(0),
+			newPriceBlock(1, 100, 10),
+			newPriceBlock(2, 20),
+			newPriceBlock(3, 30, 300),
+			newPriceBlock(4, 40, 45),
+			newPriceBlock(5, 50),
+		)
+
+		return store
+	}
+
+	suggest := func(store *mockBlockStore, config GasPriceOracleConfig) uint64 {
+		t.Helper()
+
+		price, err := newGasPriceOracle(config, store).SuggestGasPrice()
+		assert.NoError(t, err)
+
+		return price.Uint64()
+	}
+
+	t.Run("should suggest the percentile of the lowest prices", func(t *testing.T) {
+		store := newStore()
+
+		assert.Equal(t, uint64(30), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60}))
+		assert.Equal(t, uint64(10), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 0}))
+		assert.Equal(t, uint64(50), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 100}))
+
+		// only the blocks 4 and 5 are sampled
+		assert.Equal(t, uint64(40), suggest(store, GasPriceOracleConfig{Blocks: 2, Percentile: 0}))
+	})
+
+	t.Run("should bound the suggested price", func(t *testing.T) {
+		store := newStore()
+
+		assert.Equal(t, uint64(35), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60, MinPrice: 35}))
+		assert.Equal(t, uint64(25), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60, MaxPrice: 25}))
+	})
+
+	t.Run("should suggest the minimum price for empty blocks", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newPriceBlock(0), newPriceBlock(1), newPriceBlock(2))
+
+		assert.Equal(t, uint64(7), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60, MinPrice: 7}))
+	})
+
+	t.Run("should sample the effective price of the dynamic fee transactions", func(t *testing.T) {
+		store := newStore()
+
+		block := newPriceBlock(6)
+		block.Header.BaseFee = 100
+		block.Transactions = []*types.Transaction{
+			{
+				Type:                 types.DynamicFeeTx,
+				MaxPriorityFeePerGas: big.NewInt(5),
+				MaxFeePerGas:         big.NewInt(200),
+			},
+		}
+
+		store.add(block)
+
+		assert.Equal(t, uint64(105), suggest(store, GasPriceOracleConfig{Blocks: 1, Percentile: 60}))
+	})
+
+	t.Run("should update the suggestion once a block is written", func(t *testing.T) {
+		store := newStore()
+		oracle := newGasPriceOracle(GasPriceOracleConfig{Blocks: 1, Percentile: 60}, store)
+
+		price, err := oracle.SuggestGasPrice()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(50), price.Uint64())
+
+		store.add(newPriceBlock(6, 60))
+
+		price, err = oracle.SuggestGasPrice()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(60), price.Uint64())
+	})
+}
+
+func TestEth_GasPrice_Oracle(t *testing.T) {
+	store := newMockBlockStore()
+	store.add(newTestBlock(0, hash1))
+
+	eth := newTestEthEndpoint(store)
+	eth.gasPriceOracle = newGasPriceOracle(GasPriceOracleConfig{Blocks: 20, Percentile: 60, MinPrice: 1000}, store)
+
+	res, err := eth.GasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3e8", res)
+}
+
+func TestGasPriceOracle_SuggestTipCap(t *testing.T) {
+	// returns a London block with dynamic fee transactions of the given tips
+	newTipBlock := func(number, baseFee uint64, tips ...int64) *types.Block {
+		block := newTestBlock(number, types.StringToHash(strconv.FormatUint(number, 10)))
+		block.Header.BaseFee = baseFee
+
+		for i, tip := range tips {
+			block.Transactions = append(block.Transactions, &types.Transaction{
+				Type:                 types.DynamicFeeTx,
+				Nonce:                uint64(i),
+				MaxPriorityFeePerGas: big.NewInt(tip),
+				MaxFeePerGas:         big.NewInt(int64(baseFee) + tip),
+			})
+		}
+
+		return block
+	}
+
+	suggest := func(store *mockBlockStore, config GasPriceOracleConfig) uint64 {
+		t.Helper()
+
+		tip, err := newGasPriceOracle(config, store).SuggestTipCap()
+		assert.NoError(t, err)
+
+		return tip.Uint64()
+	}
+
+	t.Run("should suggest the percentile of the lowest tips", func(t *testing.T) {
+		// the lowest tips of the blocks 1 to 5 are 1 to 5
+		store := newMockBlockStore()
+		store.add(
+			newTipBlock(0, 0),
+			newTipBlock(1, 100, 10, 1),
+			newTipBlock(2, 200, 2),
+			newTipBlock(3, 100, 3, 30),
+			newTipBlock(4, 50, 4, 5),
+			newTipBlock(5, 100, 5),
+		)
+
+		assert.Equal(t, uint64(3), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60}))
+		assert.Equal(t, uint64(1), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 0}))
+		assert.Equal(t, uint64(4), suggest(store, GasPriceOracleConfig{Blocks: 2, Percentile: 0}))
+
+		// the tip is bounded by the maximum price
+		assert.Equal(t, uint64(2), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60, MaxPrice: 2}))
+	})
+
+	t.Run("should not sample the blocks without a base fee", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newTipBlock(0, 0), newTipBlock(1, 100, 7))
+
+		// the legacy block has a high gas price, but no tip
+		legacy := newTestBlock(2, types.StringToHash("2"))
+		legacy.Transactions = []*types.Transaction{
+			{GasPrice: big.NewInt(1000)},
+		}
+
+		store.add(legacy)
+
+		assert.Equal(t, uint64(7), suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60}))
+	})
+
+	t.Run("should suggest the default tip without samples", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newTipBlock(0, 0), newTipBlock(1, 100), newTipBlock(2, 0))
+
+		assert.Equal(t, defaultPriorityFee, suggest(store, GasPriceOracleConfig{Blocks: 5, Percentile: 60}))
+	})
+
+	t.Run("should update the suggestion once a block is written", func(t *testing.T) {
+		store := newMockBlockStore()
+		store.add(newTipBlock(0, 0), newTipBlock(1, 100, 5))
+
+		oracle := newGasPriceOracle(GasPriceOracleConfig{Blocks: 1, Percentile: 60}, store)
+
+		tip, err := oracle.SuggestTipCap()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), tip.Uint64())
+
+		store.add(newTipBlock(2, 100, 8))
+
+		tip, err = oracle.SuggestTipCap()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(8), tip.Uint64())
+	})
+}
+
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	store := newMockBlockStore()
+	store.add(newTestBlock(0, hash1))
+
+	eth := newTestEthEndpoint(store)
+
+	// the default tip is suggested without an oracle
+	res, err := eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3b9aca00", res)
+
+	block := newTestBlock(1, hash2)
+	block.Header.BaseFee = 100
+	block.Transactions = []*types.Transaction{
+		{
+			Type:                 types.DynamicFeeTx,
+			MaxPriorityFeePerGas: big.NewInt(10),
+			MaxFeePerGas:         big.NewInt(200),
+		},
+	}
+
+	store.add(block)
+
+	eth.gasPriceOracle = newGasPriceOracle(GasPriceOracleConfig{Blocks: 20, Percentile: 60}, store)
+
+	res, err = eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa", res)
+}
@@ -0,0 +1,66 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// healthStatus is the response of the health endpoint
+type healthStatus struct {
+	// Ready is true once the node is synced
+	Ready bool `json:"ready"`
+
+	// Syncing is true while the node bulk syncs
+	Syncing bool `json:"syncing"`
+
+	// Peers is the number of connected peers
+	Peers int `json:"peers"`
+
+	// BlockNumber is the number of the latest block
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// BlockAge is the number of seconds since the latest block
+	BlockAge uint64 `json:"blockAge"`
+}
+
+// health returns the health status of the node. The node is ready once it has sealed or synced
+// a block past the genesis, is not bulk syncing and its latest block is recent enough
+func (j *JSONRPC) health(now time.Time) *healthStatus {
+	header := j.config.Store.Header()
+
+	status := &healthStatus{
+		Syncing:     j.config.Store.GetSyncProgression() != nil,
+		Peers:       j.config.Store.GetPeers(),
+		BlockNumber: header.Number,
+	}
+
+	if timestamp := uint64(now.Unix()); timestamp > header.Timestamp {
+		status.BlockAge = timestamp - header.Timestamp
+	}
+
+	status.Ready = !status.Syncing && header.Number > 0
+
+	if maxAge := j.config.HealthMaxBlockAge; maxAge > 0 && time.Duration(status.BlockAge)*time.Second > maxAge {
+		status.Ready = false
+	}
+
+	return status
+}
+
+// handleHealth serves the health status of the node. It answers with
+// 503 Service Unavailable until the node is ready, so it can be used
+// as the readiness probe of the node
+func (j *JSONRPC) handleHealth(w http.ResponseWriter, req *http.Request) {
+	status := j.health(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !status.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		j.logger.Error("unable to write the health status", "err", err)
+	}
+}
@@ -0,0 +1,112 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockHealthStore struct {
+	JSONRPCStore
+
+	header  *types.Header
+	peers   int
+	syncing bool
+}
+
+func (m *mockHealthStore) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockHealthStore) GetPeers() int {
+	return m.peers
+}
+
+func (m *mockHealthStore) GetSyncProgression() *progress.Progression {
+	if m.syncing {
+		return &progress.Progression{SyncType: progress.ChainSyncBulk}
+	}
+
+	return nil
+}
+
+func TestHealth(t *testing.T) {
+	store := &mockHealthStore{
+		header: &types.Header{Number: 0},
+		peers:  3,
+	}
+
+	srv := &JSONRPC{
+		logger: hclog.NewNullLogger(),
+		config: &Config{
+			Store:             store,
+			HealthMaxBlockAge: time.Minute,
+		},
+	}
+
+	check := func(t *testing.T, expectedCode int) *healthStatus {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		srv.handleHealth(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Equal(t, expectedCode, rec.Code)
+
+		status := &healthStatus{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), status))
+
+		return status
+	}
+
+	t.Run("should not be ready before the first block", func(t *testing.T) {
+		status := check(t, http.StatusServiceUnavailable)
+
+		assert.False(t, status.Ready)
+		assert.Equal(t, uint64(0), status.BlockNumber)
+		assert.Equal(t, 3, status.Peers)
+	})
+
+	t.Run("should be ready once the first block is sealed", func(t *testing.T) {
+		store.header = &types.Header{
+			Number:    1,
+			Timestamp: uint64(time.Now().Unix()),
+		}
+
+		status := check(t, http.StatusOK)
+
+		assert.True(t, status.Ready)
+		assert.Equal(t, uint64(1), status.BlockNumber)
+	})
+
+	t.Run("should not be ready while bulk syncing", func(t *testing.T) {
+		store.syncing = true
+		defer func() {
+			store.syncing = false
+		}()
+
+		status := check(t, http.StatusServiceUnavailable)
+
+		assert.False(t, status.Ready)
+		assert.True(t, status.Syncing)
+	})
+
+	t.Run("should not be ready once the latest block is too old", func(t *testing.T) {
+		store.header = &types.Header{
+			Number:    1,
+			Timestamp: uint64(time.Now().Add(-2 * time.Minute).Unix()),
+		}
+
+		status := check(t, http.StatusServiceUnavailable)
+
+		assert.False(t, status.Ready)
+		assert.GreaterOrEqual(t, status.BlockAge, uint64(120))
+	})
+}
@@ -1,12 +1,18 @@
 package jsonrpc
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/requestid"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 )
@@ -32,16 +38,53 @@ func (s serverType) String() string {
 	}
 }
 
+const (
+	// wsWriteWait is the time allowed to write a message to the WS peer
+	wsWriteWait = 10 * time.Second
+
+	// wsPongWait is the time allowed to read the next pong from the WS peer
+	wsPongWait = 60 * time.Second
+
+	// wsPingPeriod is the period of the pings sent to the WS peer, lower than wsPongWait
+	wsPingPeriod = wsPongWait * 9 / 10
+)
+
+const (
+	// requestIDHeader is the HTTP header of the request ID,
+	// given by the caller or generated and returned by the server
+	requestIDHeader = "X-Request-Id"
+
+	// maxRequestIDLength is the maximum length of a request ID given by the caller
+	maxRequestIDLength = 64
+)
+
 // JSONRPC is an API backend
 type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// number of open WS connections, accessed atomically
+	wsConnections int64
+
+	// servers are the started HTTP and WS servers
+	servers []*http.Server
+	// wsHandlers tracks the WS connections, which the servers don't drain
+	wsHandlers sync.WaitGroup
+
+	// ctx is the parent context of the requests, cancelled once
+	// the in-flight requests exceed the shutdown deadline
+	ctx    context.Context
+	cancel context.CancelFunc
+	// closeCh is closed on shutdown, stopping the WS connections from reading new messages
+	closeCh   chan struct{}
+	closeOnce sync.Once
 }
 
 type dispatcher interface {
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(ctx context.Context, reqBody []byte, conn wsConn) ([]byte, error)
+	Handle(ctx context.Context, reqBody []byte) ([]byte, error)
+	RemoveFilterByWs(conn wsConn)
 }
 
 // JSONRPCStore defines all the methods required
@@ -51,6 +94,8 @@ type JSONRPCStore interface {
 	networkStore
 	txPoolStore
 	filterManagerStore
+	debugStore
+	adminStore
 }
 
 type Config struct {
@@ -58,14 +103,75 @@ type Config struct {
 	Addr                     *net.TCPAddr
 	ChainID                  uint64
 	AccessControlAllowOrigin []string
+
+	// WSAddr is the address of the WS listener, served along with
+	// the /ws path of the HTTP server. Not started if nil
+	WSAddr *net.TCPAddr
+	// WSMaxConnections is the maximum number of open WS connections. 0 disables the limit
+	WSMaxConnections uint64
+
+	// TxRateLimit is the number of transactions per second a single sender
+	// can submit through eth_sendRawTransaction. 0 disables the limit
+	TxRateLimit uint64
+	// TxRateBurst is the number of transactions a sender can submit at once
+	TxRateBurst uint64
+
+	// GasCap is the maximum gas of a single eth_call or eth_estimateGas
+	// execution. 0 disables the cap
+	GasCap uint64
+
+	// MaxRequestBytes is the maximum size of a request body. 0 disables the limit
+	MaxRequestBytes int64
+	// ExecutionTimeout is the maximum duration of a request, after which
+	// its execution is cancelled. It also bounds the calls running at once. 0 disables the timeout
+	ExecutionTimeout time.Duration
+
+	// GasPriceOracle configures the price suggested by eth_gasPrice.
+	// The average gas price is suggested if nil
+	GasPriceOracle *GasPriceOracleConfig
+
+	// Namespaces are the namespaces of the served methods. The default ones are served if empty
+	Namespaces []string
+
+	// HealthMaxBlockAge is the maximum age of the latest block for the /health
+	// endpoint to report the node as ready. 0 disables the check
+	HealthMaxBlockAge time.Duration
+
+	// ShutdownTimeout is the time the in-flight requests are given to complete
+	// on shutdown, after which they are cancelled. 0 cancels them right away
+	ShutdownTimeout time.Duration
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	for _, namespace := range config.Namespaces {
+		if !isKnownNamespace(namespace) {
+			return nil, fmt.Errorf("unknown JSON-RPC namespace %s", namespace)
+		}
+	}
+
+	d := newDispatcher(logger, config.Store, config.ChainID, config.Namespaces)
+
+	if config.TxRateLimit > 0 {
+		d.endpoints.Eth.txRateLimiter = newSenderRateLimiter(config.TxRateLimit, config.TxRateBurst)
+	}
+
+	d.endpoints.Eth.gasCap = config.GasCap
+	d.executionTimeout = config.ExecutionTimeout
+
+	if config.GasPriceOracle != nil {
+		d.endpoints.Eth.gasPriceOracle = newGasPriceOracle(*config.GasPriceOracle, config.Store)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	srv := &JSONRPC{
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
-		dispatcher: newDispatcher(logger, config.Store, config.ChainID),
+		dispatcher: d,
+		ctx:        ctx,
+		cancel:     cancel,
+		closeCh:    make(chan struct{}),
 	}
 
 	// start http server
@@ -73,9 +179,93 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	if config.WSAddr != nil {
+		if err := srv.setupWS(); err != nil {
+			//nolint
+			srv.Close()
+
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
+// Close stops the servers from accepting new requests and waits for the
+// in-flight ones to complete. The requests still running once ShutdownTimeout
+// elapses are cancelled, and their connections closed
+func (j *JSONRPC) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.config.ShutdownTimeout)
+	defer cancel()
+
+	j.closeOnce.Do(func() {
+		close(j.closeCh)
+	})
+
+	var shutdownErr error
+
+	for _, srv := range j.servers {
+		if err := srv.Shutdown(ctx); err != nil && shutdownErr == nil {
+			shutdownErr = err
+		}
+	}
+
+	wsDone := make(chan struct{})
+
+	go func() {
+		j.wsHandlers.Wait()
+		close(wsDone)
+	}()
+
+	select {
+	case <-wsDone:
+	case <-ctx.Done():
+		if shutdownErr == nil {
+			shutdownErr = ctx.Err()
+		}
+	}
+
+	// cancels the executions of the requests past the deadline
+	j.cancel()
+
+	if shutdownErr == nil {
+		return nil
+	}
+
+	j.logger.Warn("in-flight requests cancelled on shutdown", "timeout", j.config.ShutdownTimeout)
+
+	for _, srv := range j.servers {
+		//nolint
+		srv.Close()
+	}
+
+	return shutdownErr
+}
+
+// newServer returns an HTTP server whose requests are cancelled along with the JSONRPC context
+func (j *JSONRPC) newServer(handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Handler: handler,
+		BaseContext: func(net.Listener) context.Context {
+			return j.ctx
+		},
+	}
+
+	j.servers = append(j.servers, srv)
+
+	return srv
+}
+
+func isKnownNamespace(namespace string) bool {
+	for _, known := range AllNamespaces() {
+		if namespace == known {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server started", "addr", j.config.Addr.String())
 
@@ -84,20 +274,19 @@ func (j *JSONRPC) setupHTTP() error {
 		return err
 	}
 
-	mux := http.DefaultServeMux
+	mux := http.NewServeMux()
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
 	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
 
 	mux.HandleFunc("/ws", j.handleWs)
+	mux.HandleFunc("/health", j.handleHealth)
 
-	srv := http.Server{
-		Handler: mux,
-	}
+	srv := j.newServer(mux)
 
 	go func() {
-		if err := srv.Serve(lis); err != nil {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			j.logger.Error("closed http connection", "err", err)
 		}
 	}()
@@ -105,6 +294,26 @@ func (j *JSONRPC) setupHTTP() error {
 	return nil
 }
 
+// setupWS starts the WS listener, serving the WS connections on any path
+func (j *JSONRPC) setupWS() error {
+	j.logger.Info("ws server started", "addr", j.config.WSAddr.String())
+
+	lis, err := net.Listen("tcp", j.config.WSAddr.String())
+	if err != nil {
+		return err
+	}
+
+	srv := j.newServer(http.HandlerFunc(j.handleWs))
+
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			j.logger.Error("closed ws connection", "err", err)
+		}
+	}()
+
+	return nil
+}
+
 // The middlewareFactory builds a middleware which enables CORS using the provided config.
 func middlewareFactory(config *Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -150,6 +359,11 @@ type wsWrapper struct {
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
 	w.writeLock.Lock()
 	defer w.writeLock.Unlock()
+
+	if err := w.ws.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return err
+	}
+
 	writeErr := w.ws.WriteMessage(messageType, data)
 
 	if writeErr != nil {
@@ -167,10 +381,45 @@ func isSupportedWSType(messageType int) bool {
 		messageType == websocket.BinaryMessage
 }
 
+// keepAlive pings the WS peer until the done channel is closed
+func (w *wsWrapper) keepAlive(done <-chan struct{}) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// control messages can be written concurrently with the other messages
+			if err := w.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				w.logger.Debug("unable to ping the WS peer", "err", err)
+
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
+	// the connection is drained on shutdown
+	j.wsHandlers.Add(1)
+	defer j.wsHandlers.Done()
+
+	// the connection is counted until it closes
+	connections := atomic.AddInt64(&j.wsConnections, 1)
+	defer atomic.AddInt64(&j.wsConnections, -1)
+
+	if j.config.WSMaxConnections > 0 && uint64(connections) > j.config.WSMaxConnections {
+		j.logger.Warn("rejected a WS connection, too many open connections", "max", j.config.WSMaxConnections)
+		http.Error(w, "too many WS connections", http.StatusServiceUnavailable)
+
+		return
+	}
+
 	// Upgrade the connection to a WS one
 	ws, err := wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
@@ -189,13 +438,58 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
+	// Messages over the limit close the connection
+	if j.config.MaxRequestBytes > 0 {
+		ws.SetReadLimit(j.config.MaxRequestBytes)
+	}
+
+	// the peer has to answer the pings to keep the connection open
+	_ = ws.SetReadDeadline(time.Now().Add(wsPongWait))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
 
+	done := make(chan struct{})
+	defer close(done)
+
+	go wrapConn.keepAlive(done)
+
+	// Remove the subscriptions of the connection once it closes
+	defer j.dispatcher.RemoveFilterByWs(wrapConn)
+
+	// the in-flight messages are answered before the connection closes
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
+	// unblocks the read of the next message on shutdown
+	go func() {
+		select {
+		case <-j.closeCh:
+			_ = ws.SetReadDeadline(time.Now())
+
+			// the connection is closed once the requests are cancelled
+			select {
+			case <-req.Context().Done():
+				_ = ws.Close()
+			case <-done:
+			}
+		case <-done:
+		}
+	}()
+
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
 	for {
 		// Read the incoming message
 		msgType, message, err := ws.ReadMessage()
+		if j.isClosing() {
+			j.logger.Info("Closing WS connection, the server is shutting down")
+
+			break
+		}
+
 		if err != nil {
 			if websocket.IsCloseError(err,
 				websocket.CloseGoingAway,
@@ -212,11 +506,23 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 			break
 		}
 
+		// a responsive peer keeps the connection open
+		_ = ws.SetReadDeadline(time.Now().Add(wsPongWait))
+
 		if isSupportedWSType(msgType) {
+			inflight.Add(1)
+
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				defer inflight.Done()
+
+				// each message is a request of its own
+				ctx := requestid.WithID(req.Context(), requestid.Generate())
+
+				resp, handleErr := j.dispatcher.HandleWs(ctx, message, wrapConn)
 				if handleErr != nil {
-					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
+					requestid.Logger(ctx, j.logger).Error(
+						fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()),
+					)
 
 					_ = wrapConn.WriteMessage(
 						msgType,
@@ -230,14 +536,31 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// isClosing returns true once the server is shutting down
+func (j *JSONRPC) isClosing() bool {
+	select {
+	case <-j.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
 func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
 	w.Header().Set(
 		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+requestIDHeader,
 	)
 
+	// the log lines of the request are correlated by its ID
+	requestID := getRequestID(req)
+	w.Header().Set(requestIDHeader, requestID)
+
+	ctx := requestid.WithID(req.Context(), requestID)
+	logger := requestid.Logger(ctx, j.logger)
+
 	if (*req).Method == "OPTIONS" {
 		return
 	}
@@ -256,7 +579,13 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	data, err := ioutil.ReadAll(req.Body)
+	body := io.Reader(req.Body)
+	if j.config.MaxRequestBytes > 0 {
+		// read one more byte to tell whether the body is over the limit
+		body = io.LimitReader(req.Body, j.config.MaxRequestBytes+1)
+	}
+
+	data, err := ioutil.ReadAll(body)
 
 	if err != nil {
 		//nolint
@@ -265,10 +594,23 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if j.config.MaxRequestBytes > 0 && int64(len(data)) > j.config.MaxRequestBytes {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+		resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError(
+			fmt.Sprintf("request body too large, the limit is %d bytes", j.config.MaxRequestBytes),
+		)).Bytes()
+
+		//nolint
+		w.Write(resp)
+
+		return
+	}
+
 	// log request
-	j.logger.Debug("handle", "request", string(data))
+	logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.Handle(ctx, data)
 
 	if err != nil {
 		//nolint
@@ -278,5 +620,24 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 		w.Write(resp)
 	}
 
-	j.logger.Debug("handle", "response", string(resp))
+	logger.Debug("handle", "response", string(resp))
+}
+
+// getRequestID returns the ID of the request given by the caller,
+// or a new one if it is missing or malformed
+func getRequestID(req *http.Request) string {
+	id := req.Header.Get(requestIDHeader)
+	if id == "" || len(id) > maxRequestIDLength {
+		return requestid.Generate()
+	}
+
+	// the ID is written as is to the logs
+	for _, c := range id {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' && c != '_' && c != '.' {
+			return requestid.Generate()
+		}
+	}
+
+	return id
 }
@@ -1,11 +1,28 @@
 package jsonrpc
 
 import (
-	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/requestid"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHTTPServer(t *testing.T) {
@@ -26,3 +43,332 @@ func TestHTTPServer(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWSServer(t *testing.T) {
+	newAddr := func() *net.TCPAddr {
+		port, err := tests.GetFreePort()
+		if err != nil {
+			t.Fatalf("Unable to fetch free port, %v", err)
+		}
+
+		return &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port}
+	}
+
+	config := &Config{
+		Store:            newMockStore(),
+		Addr:             newAddr(),
+		WSAddr:           newAddr(),
+		WSMaxConnections: 1,
+		ShutdownTimeout:  time.Second,
+	}
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), config)
+	assert.NoError(t, err)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+config.WSAddr.String(), nil)
+	if err != nil {
+		t.Fatalf("Unable to dial the WS listener, %v", err)
+	}
+
+	defer conn.Close()
+
+	assert.NoError(t, conn.WriteMessage(
+		websocket.TextMessage,
+		[]byte(`{"id":1,"jsonrpc":"2.0","method":"eth_blockNumber","params":[]}`),
+	))
+
+	_, resp, err := conn.ReadMessage()
+	assert.NoError(t, err)
+
+	var blockNumber string
+
+	assert.NoError(t, expectJSONResult(resp, &blockNumber))
+	assert.Equal(t, "0x0", blockNumber)
+
+	// the limit covers the connections of both the WS listener and the HTTP server
+	for _, url := range []string{
+		"ws://" + config.WSAddr.String(),
+		"ws://" + config.Addr.String() + "/ws",
+	} {
+		_, httpResp, err := websocket.DefaultDialer.Dial(url, nil)
+		assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+
+		if httpResp != nil {
+			assert.Equal(t, http.StatusServiceUnavailable, httpResp.StatusCode)
+			httpResp.Body.Close()
+		}
+	}
+
+	// the open connection is closed on shutdown
+	assert.NoError(t, srv.Close())
+
+	_, _, err = conn.ReadMessage()
+	assert.Error(t, err)
+}
+
+func TestHTTPServer_UnknownNamespace(t *testing.T) {
+	_, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:      newMockStore(),
+		Namespaces: []string{NamespaceEth, "personal"},
+	})
+
+	assert.ErrorContains(t, err, "unknown JSON-RPC namespace personal")
+}
+
+func TestHTTPServer_MaxRequestBytes(t *testing.T) {
+	config := &Config{
+		MaxRequestBytes: 128,
+	}
+
+	srv := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     config,
+		dispatcher: newDispatcher(hclog.NewNullLogger(), newMockStore(), 0, nil),
+	}
+
+	handle := func(body []byte) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.handle(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+		return rec
+	}
+
+	t.Run("should handle a request within the limit", func(t *testing.T) {
+		rec := handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"result"`)
+	})
+
+	t.Run("should reject a request over the limit", func(t *testing.T) {
+		params := `"` + strings.Repeat("0", 128) + `"`
+		rec := handle([]byte(`{"id":1,"jsonrpc":"2.0","method":"web3_sha3","params":[` + params + `]}`))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "request body too large")
+	})
+}
+
+// mockRequestIDStore records the request ID of the txs added to the pool
+type mockRequestIDStore struct {
+	*mockStore
+
+	requestIDs []string
+}
+
+func (m *mockRequestIDStore) AddTx(ctx context.Context, tx *types.Transaction) error {
+	id, _ := requestid.FromContext(ctx)
+	m.requestIDs = append(m.requestIDs, id)
+
+	return errors.New("pool rejected the tx")
+}
+
+func TestHTTPServer_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Output:     &buf,
+		Level:      hclog.Debug,
+		JSONFormat: true,
+	})
+
+	store := &mockRequestIDStore{mockStore: newMockStore()}
+	srv := &JSONRPC{
+		logger:     logger,
+		config:     &Config{},
+		dispatcher: newDispatcher(logger, store, 0, nil),
+	}
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	body := `{"id":1,"jsonrpc":"2.0","method":"eth_sendRawTransaction","params":["` +
+		hex.EncodeToHex(txn.MarshalRLP()) + `"]}`
+
+	handle := func(id string) *httptest.ResponseRecorder {
+		buf.Reset()
+
+		store.requestIDs = nil
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if id != "" {
+			req.Header.Set(requestIDHeader, id)
+		}
+
+		rec := httptest.NewRecorder()
+		srv.handle(rec, req)
+
+		return rec
+	}
+
+	// messages returns the messages of the log lines tagged with the request ID
+	messages := func(id string) []string {
+		msgs := []string{}
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var entry map[string]interface{}
+
+			assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+			if entry[requestid.LogKey] == id {
+				msgs = append(msgs, entry["@message"].(string))
+			}
+		}
+
+		return msgs
+	}
+
+	t.Run("should propagate the request ID of the caller", func(t *testing.T) {
+		rec := handle("trace-1.a_b")
+
+		assert.Equal(t, "trace-1.a_b", rec.Header().Get(requestIDHeader))
+		assert.Equal(t, []string{"trace-1.a_b"}, store.requestIDs)
+
+		// both the access log and the error log carry the ID
+		msgs := messages("trace-1.a_b")
+		assert.Contains(t, msgs, "handle")
+		assert.Contains(t, msgs, "failed to dispatch")
+	})
+
+	t.Run("should generate a request ID", func(t *testing.T) {
+		for _, id := range []string{"", "bad id", strings.Repeat("a", maxRequestIDLength+1)} {
+			rec := handle(id)
+
+			generated := rec.Header().Get(requestIDHeader)
+			assert.NotEmpty(t, generated)
+			assert.NotEqual(t, id, generated)
+			assert.Equal(t, []string{generated}, store.requestIDs)
+			assert.Contains(t, messages(generated), "failed to dispatch")
+		}
+	})
+}
+
+// mockSlowCallStore runs the calls until they are released or cancelled
+type mockSlowCallStore struct {
+	*mockStore
+
+	startedCh   chan struct{}
+	releaseCh   chan struct{}
+	cancelledCh chan error
+}
+
+func newMockSlowCallStore() *mockSlowCallStore {
+	return &mockSlowCallStore{
+		mockStore:   newMockStore(),
+		startedCh:   make(chan struct{}, 1),
+		releaseCh:   make(chan struct{}),
+		cancelledCh: make(chan error, 1),
+	}
+}
+
+func (m *mockSlowCallStore) ApplyTxn(
+	ctx context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+	override types.StateOverride,
+) (*runtime.ExecutionResult, error) {
+	m.startedCh <- struct{}{}
+
+	select {
+	case <-m.releaseCh:
+		return &runtime.ExecutionResult{ReturnValue: []byte{0x1}}, nil
+	case <-ctx.Done():
+		m.cancelledCh <- ctx.Err()
+
+		return nil, ctx.Err()
+	}
+}
+
+func TestHTTPServer_GracefulShutdown(t *testing.T) {
+	client := &http.Client{
+		Transport: &http.Transport{DisableKeepAlives: true},
+	}
+
+	newServer := func(t *testing.T, shutdownTimeout time.Duration) (*JSONRPC, *mockSlowCallStore) {
+		t.Helper()
+
+		port, err := tests.GetFreePort()
+		assert.NoError(t, err)
+
+		store := newMockSlowCallStore()
+
+		srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+			Store:           store,
+			Addr:            &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+			ShutdownTimeout: shutdownTimeout,
+		})
+		assert.NoError(t, err)
+
+		return srv, store
+	}
+
+	post := func(srv *JSONRPC, body string) ([]byte, error) {
+		resp, err := client.Post("http://"+srv.config.Addr.String(), "application/json", strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	call := func(srv *JSONRPC) <-chan []byte {
+		respCh := make(chan []byte, 1)
+
+		go func() {
+			resp, _ := post(srv, `{"id":1,"jsonrpc":"2.0","method":"eth_call","params":[{"to":"`+
+				addr1.String()+`","nonce":"0x0"},"latest"]}`)
+			respCh <- resp
+		}()
+
+		return respCh
+	}
+
+	t.Run("should complete the in-flight requests", func(t *testing.T) {
+		srv, store := newServer(t, 5*time.Second)
+
+		respCh := call(srv)
+		<-store.startedCh
+
+		closeErrCh := make(chan error, 1)
+
+		go func() {
+			closeErrCh <- srv.Close()
+		}()
+
+		// the new requests are refused while draining
+		assert.Eventually(t, func() bool {
+			_, err := post(srv, `{"id":1,"jsonrpc":"2.0","method":"web3_clientVersion","params":[]}`)
+
+			return err != nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		close(store.releaseCh)
+
+		var result string
+
+		assert.NoError(t, expectJSONResult(<-respCh, &result))
+		assert.Equal(t, "0x01", result)
+		assert.NoError(t, <-closeErrCh)
+	})
+
+	t.Run("should cancel the requests past the deadline", func(t *testing.T) {
+		srv, store := newServer(t, 100*time.Millisecond)
+
+		respCh := call(srv)
+		<-store.startedCh
+
+		assert.ErrorIs(t, srv.Close(), context.DeadlineExceeded)
+
+		select {
+		case err := <-store.cancelledCh:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the request was not cancelled")
+		}
+
+		<-respCh
+	})
+}
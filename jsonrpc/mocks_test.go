@@ -1,7 +1,6 @@
 package jsonrpc
 
 import (
-	"errors"
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -90,7 +89,7 @@ func (m *mockStore) GetAccount(root types.Hash, addr types.Address) (*state.Acco
 		return acc, nil
 	}
 
-	return nil, errors.New("given root and slot not found in storage")
+	return nil, ErrStateNotFound
 }
 
 func (m *mockStore) SetAccount(addr types.Address, account *state.Account) {
@@ -128,3 +127,10 @@ func (m *mockStore) GetTxs(inclQueued bool) (
 func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
+
+func (m *mockStore) GetAccountTxs(addr types.Address) (
+	[]*types.Transaction,
+	[]*types.Transaction,
+) {
+	return nil, nil
+}
@@ -213,3 +213,63 @@ func (q *LogQuery) Match(log *types.Log) bool {
 
 	return true
 }
+
+// logBloomQuery holds the bloom bits of each address and topic of a log
+// query, so that they are computed once for a whole range of blocks
+type logBloomQuery struct {
+	addresses []types.Bloom
+	topics    [][]types.Bloom
+}
+
+func newLogBloomQuery(q *LogQuery) *logBloomQuery {
+	toBloom := func(data []byte) (bloom types.Bloom) {
+		bloom.Add(data)
+
+		return
+	}
+
+	b := &logBloomQuery{
+		addresses: make([]types.Bloom, len(q.Addresses)),
+		topics:    make([][]types.Bloom, len(q.Topics)),
+	}
+
+	for i, addr := range q.Addresses {
+		b.addresses[i] = toBloom(addr.Bytes())
+	}
+
+	for i, sub := range q.Topics {
+		b.topics[i] = make([]types.Bloom, len(sub))
+		for j, topic := range sub {
+			b.topics[i][j] = toBloom(topic.Bytes())
+		}
+	}
+
+	return b
+}
+
+// match returns whether the bloom filter may contain logs matching the query.
+// Blocks that can't match are skipped without loading their receipts
+func (b *logBloomQuery) match(bloom *types.Bloom) bool {
+	matchAny := func(set []types.Bloom) bool {
+		for i := range set {
+			if bloom.Includes(&set[i]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// check addresses
+	if len(b.addresses) > 0 && !matchAny(b.addresses) {
+		return false
+	}
+	// check topics
+	for _, sub := range b.topics {
+		if len(sub) > 0 && !matchAny(sub) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,111 @@
+package jsonrpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// number of tracked senders above which the least recently seen bucket is dropped
+const maxRateLimitedSenders = 10000
+
+// senderRateLimiter is a token bucket rate limiter keyed on the transaction sender
+type senderRateLimiter struct {
+	sync.Mutex
+
+	rate  uint64 // tokens refilled per second
+	burst uint64 // capacity of a bucket
+
+	// types.Address -> *tokenBucket, a dropped bucket starts over full
+	buckets *simplelru.LRU
+
+	// time source, replaced in tests
+	now func() time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newSenderRateLimiter returns a limiter allowing rate transactions per second
+// per sender, with bursts of up to burst transactions. A 0 burst equals the rate
+func newSenderRateLimiter(rate, burst uint64) *senderRateLimiter {
+	if burst == 0 {
+		burst = rate
+	}
+
+	// simplelru.NewLRU only fails for non-positive sizes
+	buckets, _ := simplelru.NewLRU(maxRateLimitedSenders, nil)
+
+	return &senderRateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: buckets,
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from the sender's bucket,
+// returning false if the bucket is empty [thread-safe]
+func (l *senderRateLimiter) allow(sender types.Address) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	bucket := l.bucket(sender)
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+// refund gives back a token taken by allow,
+// for the transactions that were not accepted after all [thread-safe]
+func (l *senderRateLimiter) refund(sender types.Address) {
+	l.Lock()
+	defer l.Unlock()
+
+	bucket := l.bucket(sender)
+
+	bucket.tokens++
+	if bucket.tokens > float64(l.burst) {
+		bucket.tokens = float64(l.burst)
+	}
+}
+
+// bucket returns the refilled bucket of the sender, creating a full one if it isn't tracked
+func (l *senderRateLimiter) bucket(sender types.Address) *tokenBucket {
+	now := l.now()
+
+	if value, ok := l.buckets.Get(sender); ok {
+		bucket, _ := value.(*tokenBucket)
+		l.refill(bucket, now)
+
+		return bucket
+	}
+
+	bucket := &tokenBucket{
+		tokens:  float64(l.burst),
+		updated: now,
+	}
+	l.buckets.Add(sender, bucket)
+
+	return bucket
+}
+
+// refill adds the tokens accumulated since the last update to the bucket
+func (l *senderRateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.updated).Seconds()
+
+	bucket.tokens += elapsed * float64(l.rate)
+	if bucket.tokens > float64(l.burst) {
+		bucket.tokens = float64(l.burst)
+	}
+
+	bucket.updated = now
+}
@@ -0,0 +1,109 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSenderRateLimiter(t *testing.T) {
+	now := time.Now()
+
+	limiter := newSenderRateLimiter(2, 4)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	sender := types.StringToAddress("1")
+
+	// a new sender can use the whole burst
+	for i := 0; i < 4; i++ {
+		assert.True(t, limiter.allow(sender))
+	}
+
+	assert.False(t, limiter.allow(sender))
+
+	// half a second refills a single token
+	now = now.Add(500 * time.Millisecond)
+
+	assert.True(t, limiter.allow(sender))
+	assert.False(t, limiter.allow(sender))
+
+	// the refill is capped by the burst
+	now = now.Add(time.Hour)
+
+	for i := 0; i < 4; i++ {
+		assert.True(t, limiter.allow(sender))
+	}
+
+	assert.False(t, limiter.allow(sender))
+}
+
+func TestSenderRateLimiter_DefaultBurst(t *testing.T) {
+	limiter := newSenderRateLimiter(3, 0)
+
+	assert.Equal(t, uint64(3), limiter.burst)
+}
+
+func TestSenderRateLimiter_Refund(t *testing.T) {
+	now := time.Now()
+
+	limiter := newSenderRateLimiter(1, 2)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	sender := types.StringToAddress("1")
+
+	assert.True(t, limiter.allow(sender))
+	assert.True(t, limiter.allow(sender))
+	assert.False(t, limiter.allow(sender))
+
+	// a refunded token can be taken again
+	limiter.refund(sender)
+
+	assert.True(t, limiter.allow(sender))
+	assert.False(t, limiter.allow(sender))
+
+	// the refund is capped by the burst
+	limiter.refund(sender)
+	limiter.refund(sender)
+	limiter.refund(sender)
+
+	assert.True(t, limiter.allow(sender))
+	assert.True(t, limiter.allow(sender))
+	assert.False(t, limiter.allow(sender))
+}
+
+func TestSenderRateLimiter_Eviction(t *testing.T) {
+	now := time.Now()
+
+	limiter := newSenderRateLimiter(1, 1)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	senderAt := func(i int) types.Address {
+		return types.BytesToAddress([]byte{byte(i >> 8), byte(i)})
+	}
+
+	for i := 0; i < maxRateLimitedSenders; i++ {
+		assert.True(t, limiter.allow(senderAt(i)))
+	}
+
+	// the first sender is seen again, so it's no longer the least recent one
+	assert.False(t, limiter.allow(senderAt(0)))
+
+	// the number of tracked senders is capped
+	assert.True(t, limiter.allow(types.StringToAddress("0xabcdef")))
+	assert.Equal(t, maxRateLimitedSenders, limiter.buckets.Len())
+
+	// the least recently seen sender starts over with a full bucket
+	assert.True(t, limiter.allow(senderAt(1)))
+
+	// the more recently seen senders are still limited
+	assert.False(t, limiter.allow(senderAt(0)))
+	assert.False(t, limiter.allow(senderAt(maxRateLimitedSenders-1)))
+}
@@ -14,6 +14,9 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetAccountTxs gets the pending and queued transactions of a single account
+	GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction)
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -69,44 +72,52 @@ func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
 }
 
 // Create response for txpool_content request.
+// The optional address limits the response to the transactions of that account.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
-func (t *TxPool) Content() (interface{}, error) {
-	pendingTxs, queuedTxs := t.store.GetTxs(true)
-
-	// collect pending
-	pendingRPCTxs := make(map[types.Address]map[uint64]*txpoolTransaction)
-	for addr, txs := range pendingTxs {
-		pendingRPCTxs[addr] = make(map[uint64]*txpoolTransaction, len(txs))
+func (t *TxPool) Content(addr *types.Address) (interface{}, error) {
+	var pendingTxs, queuedTxs map[types.Address][]*types.Transaction
 
-		for _, tx := range txs {
-			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
+	if addr != nil {
+		pendingTxs = make(map[types.Address][]*types.Transaction)
+		queuedTxs = make(map[types.Address][]*types.Transaction)
 
-			pendingRPCTxs[addr][nonce] = rpcTx
+		pending, queued := t.store.GetAccountTxs(*addr)
+		if len(pending) != 0 {
+			pendingTxs[*addr] = pending
 		}
-	}
-
-	// collect enqueued
-	queuedRPCTxs := make(map[types.Address]map[uint64]*txpoolTransaction)
-	for addr, txs := range queuedTxs {
-		queuedRPCTxs[addr] = make(map[uint64]*txpoolTransaction, len(txs))
 
-		for _, tx := range txs {
-			nonce := tx.Nonce
-			rpcTx := toTxPoolTransaction(tx)
-
-			queuedRPCTxs[addr][nonce] = rpcTx
+		if len(queued) != 0 {
+			queuedTxs[*addr] = queued
 		}
+	} else {
+		pendingTxs, queuedTxs = t.store.GetTxs(true)
 	}
 
 	resp := ContentResponse{
-		Pending: pendingRPCTxs,
-		Queued:  queuedRPCTxs,
+		Pending: toContentTxs(pendingTxs),
+		Queued:  toContentTxs(queuedTxs),
 	}
 
 	return resp, nil
 }
 
+// toContentTxs groups the transactions by sender and nonce
+func toContentTxs(
+	txsByAddr map[types.Address][]*types.Transaction,
+) map[types.Address]map[uint64]*txpoolTransaction {
+	rpcTxs := make(map[types.Address]map[uint64]*txpoolTransaction, len(txsByAddr))
+
+	for addr, txs := range txsByAddr {
+		rpcTxs[addr] = make(map[uint64]*txpoolTransaction, len(txs))
+
+		for _, tx := range txs {
+			rpcTxs[addr][tx.Nonce] = toTxPoolTransaction(tx)
+		}
+	}
+
+	return rpcTxs
+}
+
 // Create response for txpool_inspect request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
 func (t *TxPool) Inspect() (interface{}, error) {
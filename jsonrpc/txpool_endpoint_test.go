@@ -14,7 +14,7 @@ func TestContentEndpoint(t *testing.T) {
 		mockStore := newMockTxPoolStore()
 		txPoolEndpoint := &TxPool{mockStore}
 
-		result, _ := txPoolEndpoint.Content()
+		result, _ := txPoolEndpoint.Content(nil)
 		// nolint:forcetypeassert
 		response := result.(ContentResponse)
 
@@ -31,7 +31,7 @@ func TestContentEndpoint(t *testing.T) {
 		mockStore.pending[address1] = []*types.Transaction{testTx}
 		txPoolEndpoint := &TxPool{mockStore}
 
-		result, _ := txPoolEndpoint.Content()
+		result, _ := txPoolEndpoint.Content(nil)
 		// nolint:forcetypeassert
 		response := result.(ContentResponse)
 
@@ -59,7 +59,7 @@ func TestContentEndpoint(t *testing.T) {
 		mockStore.queued[address1] = []*types.Transaction{testTx}
 		txPoolEndpoint := &TxPool{mockStore}
 
-		result, _ := txPoolEndpoint.Content()
+		result, _ := txPoolEndpoint.Content(nil)
 		// nolint:forcetypeassert
 		response := result.(ContentResponse)
 
@@ -94,7 +94,7 @@ func TestContentEndpoint(t *testing.T) {
 		mockStore.queued[address2] = []*types.Transaction{testTx5}
 		txPoolEndpoint := &TxPool{mockStore}
 
-		result, _ := txPoolEndpoint.Content()
+		result, _ := txPoolEndpoint.Content(nil)
 		// nolint:forcetypeassert
 		response := result.(ContentResponse)
 
@@ -104,6 +104,36 @@ func TestContentEndpoint(t *testing.T) {
 		assert.Equal(t, 1, len(response.Pending[address2]))
 		assert.Equal(t, 2, len(response.Queued))
 	})
+
+	t.Run("returns only the transactions of the given address", func(t *testing.T) {
+		mockStore := newMockTxPoolStore()
+		address1 := types.Address{0x1}
+		testTx1 := newTestTransaction(2, address1)
+		testTx2 := newTestTransaction(11, address1)
+		address2 := types.Address{0x2}
+		testTx3 := newTestTransaction(7, address2)
+		mockStore.pending[address1] = []*types.Transaction{testTx1}
+		mockStore.queued[address1] = []*types.Transaction{testTx2}
+		mockStore.pending[address2] = []*types.Transaction{testTx3}
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, _ := txPoolEndpoint.Content(&address2)
+		// nolint:forcetypeassert
+		response := result.(ContentResponse)
+
+		assert.False(t, mockStore.includeQueued)
+		assert.Equal(t, 1, len(response.Pending))
+		assert.Equal(t, testTx3.Hash, response.Pending[address2][testTx3.Nonce].Hash)
+		assert.Equal(t, 0, len(response.Queued))
+
+		// unknown accounts have no entries
+		result, _ = txPoolEndpoint.Content(&types.Address{0x3})
+		// nolint:forcetypeassert
+		response = result.(ContentResponse)
+
+		assert.Equal(t, 0, len(response.Pending))
+		assert.Equal(t, 0, len(response.Queued))
+	})
 }
 
 func TestInspectEndpoint(t *testing.T) {
@@ -229,6 +259,10 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction) {
+	return s.pending[addr], s.queued[addr]
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -29,6 +30,15 @@ type transaction struct {
 	BlockHash   *types.Hash    `json:"blockHash"`
 	BlockNumber *argUint64     `json:"blockNumber"`
 	TxIndex     *argUint64     `json:"transactionIndex"`
+
+	// typed transaction fields, only present for EIP-2718 transactions
+	Type       *argUint64       `json:"type,omitempty"`
+	ChainID    *argBig          `json:"chainId,omitempty"`
+	AccessList types.AccessList `json:"accessList,omitempty"`
+
+	// EIP-1559 fields, only present for dynamic fee transactions
+	MaxFeePerGas         *argBig `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *argBig `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -54,7 +64,7 @@ func toTransaction(
 ) *transaction {
 	res := &transaction{
 		Nonce:    argUint64(t.Nonce),
-		GasPrice: argBig(*t.GasPrice),
+		GasPrice: argBig(*t.GetGasPrice(0)),
 		Gas:      argUint64(t.Gas),
 		To:       t.To,
 		Value:    argBig(*t.Value),
@@ -78,9 +88,38 @@ func toTransaction(
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
 
+	if t.Type != types.LegacyTx {
+		res.Type = argUintPtr(uint64(t.Type))
+		res.AccessList = t.AccessList
+
+		if t.ChainID != nil {
+			res.ChainID = argBigPtr(t.ChainID)
+		}
+	}
+
+	if t.Type == types.DynamicFeeTx {
+		res.MaxFeePerGas = argBigPtr(t.MaxFeePerGas)
+		res.MaxPriorityFeePerGas = argBigPtr(t.MaxPriorityFeePerGas)
+	}
+
 	return res
 }
 
+// sendRawTxResult is the result of a single transaction
+// submitted through eth_sendRawTransactions
+type sendRawTxResult struct {
+	Hash  *types.Hash `json:"hash,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// feeHistory is the result of eth_feeHistory
+type feeHistory struct {
+	OldestBlock   argUint64  `json:"oldestBlock"`
+	BaseFeePerGas []argBig   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	Reward        [][]argBig `json:"reward,omitempty"`
+}
+
 type block struct {
 	ParentHash      types.Hash          `json:"parentHash"`
 	Sha3Uncles      types.Hash          `json:"sha3Uncles"`
@@ -100,6 +139,7 @@ type block struct {
 	MixHash         types.Hash          `json:"mixHash"`
 	Nonce           types.Nonce         `json:"nonce"`
 	Hash            types.Hash          `json:"hash"`
+	BaseFee         *argUint64          `json:"baseFeePerGas,omitempty"`
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
 }
@@ -129,6 +169,10 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		Uncles:          []types.Hash{},
 	}
 
+	if h.BaseFee != 0 {
+		res.BaseFee = argUintPtr(h.BaseFee)
+	}
+
 	for idx, txn := range b.Transactions {
 		if fullTx {
 			res.Transactions = append(
@@ -166,6 +210,7 @@ type receipt struct {
 	BlockHash         types.Hash     `json:"blockHash"`
 	BlockNumber       argUint64      `json:"blockNumber"`
 	GasUsed           argUint64      `json:"gasUsed"`
+	EffectiveGasPrice argBig         `json:"effectiveGasPrice"`
 	ContractAddress   types.Address  `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
@@ -183,6 +228,46 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+func toReceipt(raw *types.Receipt, txn *types.Transaction, txIndex int, b *types.Block) *receipt {
+	logs := make([]*Log, len(raw.Logs))
+	for indx, elem := range raw.Logs {
+		logs[indx] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   b.Hash(),
+			BlockNumber: argUint64(b.Number()),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(indx),
+			Removed:     false,
+		}
+	}
+
+	// the receipts stored before the effective gas price was tracked lack it
+	effectiveGasPrice := raw.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		effectiveGasPrice = txn.GetGasPrice(b.Header.BaseFee)
+	}
+
+	return &receipt{
+		Root:              raw.Root,
+		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+		LogsBloom:         raw.LogsBloom,
+		Status:            argUint64(*raw.Status),
+		TxHash:            txn.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         b.Hash(),
+		BlockNumber:       argUint64(b.Number()),
+		GasUsed:           argUint64(raw.GasUsed),
+		EffectiveGasPrice: argBig(*effectiveGasPrice),
+		ContractAddress:   raw.ContractAddress,
+		FromAddr:          txn.From,
+		ToAddr:            txn.To,
+		Logs:              logs,
+	}
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
@@ -255,6 +340,15 @@ func argBytesPtr(b []byte) *argBytes {
 	return &bb
 }
 
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, 0, len(list))
+	for _, b := range list {
+		res = append(res, argBytes(b))
+	}
+
+	return res
+}
+
 func (b argBytes) MarshalText() ([]byte, error) {
 	return encodeToHex(b), nil
 }
@@ -302,6 +396,136 @@ type txnArgs struct {
 	Data     *argBytes
 	Input    *argBytes
 	Nonce    *argUint64
+
+	// AccessList is the EIP-2930 access list of the transaction
+	AccessList *types.AccessList `json:"accessList,omitempty"`
+}
+
+// stateOverride is the eth_call argument overriding
+// the accounts of the state the call is executed on
+type stateOverride map[types.Address]overrideAccount
+
+// overrideAccount is the override of an account, only the set fields are overridden
+type overrideAccount struct {
+	Nonce     *argUint64                 `json:"nonce"`
+	Code      *argBytes                  `json:"code"`
+	Balance   *argBig                    `json:"balance"`
+	State     *map[types.Hash]types.Hash `json:"state"`
+	StateDiff *map[types.Hash]types.Hash `json:"stateDiff"`
+}
+
+// toStateOverride converts the argument to the override applied by the executor
+func (s stateOverride) toStateOverride() types.StateOverride {
+	override := make(types.StateOverride, len(s))
+
+	for addr, account := range s {
+		overrideAccount := types.OverrideAccount{}
+
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			overrideAccount.Nonce = &nonce
+		}
+
+		if account.Code != nil {
+			overrideAccount.Code = *account.Code
+		}
+
+		if account.Balance != nil {
+			overrideAccount.Balance = (*big.Int)(account.Balance)
+		}
+
+		if account.State != nil {
+			overrideAccount.State = *account.State
+		}
+
+		if account.StateDiff != nil {
+			overrideAccount.StateDiff = *account.StateDiff
+		}
+
+		override[addr] = overrideAccount
+	}
+
+	return override
+}
+
+// bundleTxResult is the result of a single transaction
+// simulated through eth_simulateBundle
+type bundleTxResult struct {
+	TxHash     types.Hash `json:"transactionHash"`
+	GasUsed    argUint64  `json:"gasUsed"`
+	Status     argUint64  `json:"status"`
+	ReturnData argBytes   `json:"returnData"`
+	Logs       []*Log     `json:"logs"`
+
+	// Error is the execution error, if the transaction fails
+	Error string `json:"error,omitempty"`
+}
+
+func toBundleTxResult(
+	raw *types.Receipt,
+	result *runtime.ExecutionResult,
+	txn *types.Transaction,
+	txIndex int,
+	header *types.Header,
+) *bundleTxResult {
+	logs := make([]*Log, len(raw.Logs))
+	for indx, elem := range raw.Logs {
+		logs[indx] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(indx),
+		}
+	}
+
+	res := &bundleTxResult{
+		TxHash:     txn.Hash,
+		GasUsed:    argUint64(result.GasUsed),
+		Status:     argUint64(types.ReceiptSuccess),
+		ReturnData: argBytes(result.ReturnValue),
+		Logs:       logs,
+	}
+
+	if result.Reverted() {
+		res.Status = argUint64(types.ReceiptFailed)
+		res.Error = constructErrorFromRevert(result).Error()
+	} else if result.Failed() {
+		res.Status = argUint64(types.ReceiptFailed)
+		res.Error = result.Err.Error()
+	}
+
+	return res
+}
+
+// accessListResult is the result of eth_createAccessList
+type accessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    argUint64        `json:"gasUsed"`
+
+	// Error is the execution error, if the transaction fails
+	Error string `json:"error,omitempty"`
+}
+
+// accountProof is the result of eth_getProof
+type accountProof struct {
+	Address      types.Address  `json:"address"`
+	AccountProof []argBytes     `json:"accountProof"`
+	Balance      argBig         `json:"balance"`
+	CodeHash     types.Hash     `json:"codeHash"`
+	Nonce        argUint64      `json:"nonce"`
+	StorageHash  types.Hash     `json:"storageHash"`
+	StorageProof []storageProof `json:"storageProof"`
+}
+
+// storageProof is the proof of a storage slot in eth_getProof
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
 }
 
 type progression struct {
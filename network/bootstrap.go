@@ -0,0 +1,73 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// defaultBootstrapMinBackoff is the delay before the first re-dial of the bootnodes
+	defaultBootstrapMinBackoff = time.Second
+
+	// defaultBootstrapMaxBackoff is the maximum delay between the bootnode dial rounds
+	defaultBootstrapMaxBackoff = time.Minute
+
+	// bootnodeDialTimeout is the timeout of a single bootnode dial
+	bootnodeDialTimeout = 10 * time.Second
+)
+
+// bootstrap dials the bootnodes until the node connects to at least one peer,
+// backing off exponentially while the bootnodes are unreachable
+func (s *Server) bootstrap() {
+	backoff := s.bootstrapMinBackoff
+
+	for {
+		// the peer is added once the identity handshake is done,
+		// so a successful dial is checked on the next iteration
+		if s.numPeers() > 0 {
+			return
+		}
+
+		s.metrics.BootstrapAttempts.Add(1)
+		s.dialBootnodes()
+
+		select {
+		case <-time.After(backoff):
+		case <-s.closeCh:
+			return
+		}
+
+		if backoff *= 2; backoff > s.bootstrapMaxBackoff {
+			backoff = s.bootstrapMaxBackoff
+		}
+	}
+}
+
+// dialBootnodes connects to the bootnodes one by one, until a dial succeeds.
+// The dials bypass the dial backoff of libp2p, as the retries are already paced
+func (s *Server) dialBootnodes() {
+	for _, bootnode := range s.bootnodes.getBootnodes() {
+		if s.dialBootnode(bootnode) {
+			return
+		}
+	}
+}
+
+// dialBootnode connects to the bootnode, reporting whether the dial succeeded
+func (s *Server) dialBootnode(peerInfo *peer.AddrInfo) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), bootnodeDialTimeout)
+	defer cancel()
+
+	ctx = network.WithForceDirectDial(ctx, "bootstrap")
+
+	if err := s.host.Connect(ctx, *peerInfo); err != nil {
+		s.logger.Debug("failed to dial bootnode", "id", peerInfo.ID, "err", err)
+
+		return false
+	}
+
+	return true
+}
@@ -17,8 +17,9 @@ const (
 )
 
 const (
-	DiscProto     = "/disc/0.1"
-	IdentityProto = "/id/0.1"
+	DiscProto       = "/disc/0.1"
+	IdentityProto   = "/id/0.1"
+	TxAnnounceProto = "/txann/0.1"
 )
 
 // DNSRegex is a regex string to match against a valid dns/dns4/dns6 addr
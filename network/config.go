@@ -5,6 +5,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/multiformats/go-multiaddr"
 	"net"
+	"time"
 )
 
 // Config details the params for the base networking server
@@ -20,6 +21,11 @@ type Config struct {
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
 	Metrics          *Metrics               // the metrics reporting reference
+	ScoreThreshold   int64                  // the peer score below which the peer is disconnected
+	BanDuration      time.Duration          // the duration a disconnected misbehaving peer is banned for
+	TrustedPeers     []string               // the multiaddrs of the peers the node always keeps connected
+	GossipBandwidth  uint64                 // the cap in bytes per second of the gossip originated by the node, 0 disables it
+	DNSDiscoveryURL  string                 // the URL of the DNS ENR tree (EIP-1459) peers are discovered from
 }
 
 func DefaultConfig() *Config {
@@ -36,5 +42,8 @@ func DefaultConfig() *Config {
 		// The default ratio for outbound / inbound connections is 0.25
 		MaxInboundPeers:  32,
 		MaxOutboundPeers: 8,
+		// A peer is disconnected after a couple of invalid blocks or a few protocol violations
+		ScoreThreshold: -100,
+		BanDuration:    10 * time.Minute,
 	}
 }
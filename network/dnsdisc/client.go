@@ -0,0 +1,199 @@
+package dnsdisc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// maxTreeEntries is the maximum number of entries resolved in a single tree sync,
+	// so a malicious or broken tree can't keep the client busy forever
+	maxTreeEntries = 1000
+)
+
+var (
+	ErrNoRoot    = errors.New("no valid tree root found")
+	ErrStaleRoot = errors.New("tree root is older than the last synced one")
+)
+
+// Resolver looks up the TXT records of a domain. It is satisfied by net.Resolver
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Client discovers the nodes published as a DNS ENR tree (EIP-1459).
+// Only the node record subtree is synced, the links to other trees are not followed
+type Client struct {
+	logger   hclog.Logger
+	resolver Resolver
+
+	seqs     map[string]uint64 // the sequence numbers of the last synced roots, per tree domain
+	seqsLock sync.Mutex
+}
+
+// NewClient returns a new DNS discovery client using the passed in resolver
+func NewClient(logger hclog.Logger, resolver Resolver) *Client {
+	return &Client{
+		logger:   logger.Named("dnsdisc"),
+		resolver: resolver,
+		seqs:     make(map[string]uint64),
+	}
+}
+
+// SyncTree resolves the tree at the passed in URL and returns the nodes found in it.
+// The entries that are malformed, don't match their hash or carry an invalid signature are skipped,
+// and of several records of the same node only the one with the highest sequence number is kept
+func (c *Client) SyncTree(ctx context.Context, url string) ([]*peer.AddrInfo, error) {
+	treeURL, err := ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := c.resolveRoot(ctx, treeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.updateSeq(treeURL.Domain, root.seq); err != nil {
+		return nil, err
+	}
+
+	var (
+		records = make(map[peer.ID]*nodeRecord)
+		order   = make([]peer.ID, 0)
+		queue   = []string{root.enrRoot}
+		visited = make(map[string]struct{})
+	)
+
+	for len(queue) > 0 && len(visited) < maxTreeEntries {
+		hash := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[hash]; ok {
+			continue
+		}
+
+		visited[hash] = struct{}{}
+
+		txt, err := c.resolveEntry(ctx, treeURL.Domain, hash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			c.logger.Debug("skipping tree entry", "hash", hash, "err", err)
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(txt, branchPrefix):
+			children, err := parseBranch(txt)
+			if err != nil {
+				c.logger.Debug("skipping branch entry", "hash", hash, "err", err)
+
+				continue
+			}
+
+			queue = append(queue, children...)
+		case strings.HasPrefix(txt, enrPrefix):
+			record, err := parseRecord(txt)
+			if err != nil {
+				c.logger.Debug("skipping node record", "hash", hash, "err", err)
+
+				continue
+			}
+
+			id := record.peerInfo.ID
+
+			if known, ok := records[id]; ok {
+				if known.seq < record.seq {
+					records[id] = record
+				}
+
+				continue
+			}
+
+			records[id] = record
+			order = append(order, id)
+		default:
+			// links to other trees don't belong to the node record subtree
+			c.logger.Debug("skipping unexpected tree entry", "hash", hash)
+		}
+	}
+
+	peers := make([]*peer.AddrInfo, 0, len(order))
+	for _, id := range order {
+		peers = append(peers, records[id].peerInfo)
+	}
+
+	return peers, nil
+}
+
+// resolveRoot looks up the root of the tree and checks it is signed by the tree key
+func (c *Client) resolveRoot(ctx context.Context, treeURL *TreeURL) (*rootEntry, error) {
+	txts, err := c.resolver.LookupTXT(ctx, treeURL.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up the tree root, %w", err)
+	}
+
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, rootPrefix) {
+			continue
+		}
+
+		root, err := parseRoot(txt)
+		if err != nil {
+			c.logger.Debug("skipping tree root", "domain", treeURL.Domain, "err", err)
+
+			continue
+		}
+
+		if !root.verify(treeURL.PubKey) {
+			c.logger.Debug("skipping tree root", "domain", treeURL.Domain, "err", ErrInvalidSignature)
+
+			continue
+		}
+
+		return root, nil
+	}
+
+	return nil, ErrNoRoot
+}
+
+// resolveEntry looks up the tree entry with the passed in hash, and checks its content matches the hash
+func (c *Client) resolveEntry(ctx context.Context, domain, hash string) (string, error) {
+	txts, err := c.resolver.LookupTXT(ctx, hash+"."+domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, txt := range txts {
+		if entryHash(txt) == hash {
+			return txt, nil
+		}
+	}
+
+	return "", ErrHashMismatch
+}
+
+// updateSeq records the sequence number of the synced root,
+// rejecting the roots older than the last synced one
+func (c *Client) updateSeq(domain string, seq uint64) error {
+	c.seqsLock.Lock()
+	defer c.seqsLock.Unlock()
+
+	if last, ok := c.seqs[domain]; ok && seq < last {
+		return ErrStaleRoot
+	}
+
+	c.seqs[domain] = seq
+
+	return nil
+}
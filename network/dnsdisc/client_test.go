@@ -0,0 +1,169 @@
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/hashicorp/go-hclog"
+	libp2pCrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDomain = "nodes.example.org"
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+
+	return key
+}
+
+func peerID(t *testing.T, key *ecdsa.PrivateKey) peer.ID {
+	t.Helper()
+
+	id, err := peer.IDFromPublicKey((*libp2pCrypto.Secp256k1PublicKey)((*btcec.PublicKey)(&key.PublicKey)))
+	assert.NoError(t, err)
+
+	return id
+}
+
+func encodeRecord(t *testing.T, key *ecdsa.PrivateKey, seq uint64, port uint64) string {
+	t.Helper()
+
+	record, err := EncodeRecord(key, seq, net.ParseIP("127.0.0.1"), port)
+	assert.NoError(t, err)
+
+	return record
+}
+
+func TestParseURL(t *testing.T) {
+	key := generateKey(t)
+	validKey := b32format.EncodeToString((*btcec.PublicKey)(&key.PublicKey).SerializeCompressed())
+
+	testTable := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{"valid URL", "enrtree://" + validKey + "@" + testDomain, true},
+		{"missing scheme", validKey + "@" + testDomain, false},
+		{"missing domain", "enrtree://" + validKey + "@", false},
+		{"missing separator", "enrtree://" + validKey, false},
+		{"invalid key encoding", "enrtree://not-base32@" + testDomain, false},
+		{"invalid key", "enrtree://" + b32format.EncodeToString([]byte{1, 2, 3}) + "@" + testDomain, false},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			treeURL, err := ParseURL(testCase.url)
+			if !testCase.valid {
+				assert.ErrorIs(t, err, ErrInvalidURL)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testDomain, treeURL.Domain)
+			assert.Equal(t, testCase.url, treeURL.String())
+		})
+	}
+}
+
+func TestSyncTree(t *testing.T) {
+	treeKey := generateKey(t)
+	nodeKeys := []*ecdsa.PrivateKey{generateKey(t), generateKey(t), generateKey(t)}
+
+	// a record whose signature doesn't match its content
+	tampered := encodeRecord(t, generateKey(t), 1, 30303)
+	tampered = tampered[:len(tampered)-4] + "AAAA"
+
+	// an entry replaced without updating the tree
+	replaced := encodeRecord(t, generateKey(t), 1, 30304)
+
+	entries := []string{
+		encodeRecord(t, nodeKeys[0], 1, 10001),
+		encodeRecord(t, nodeKeys[1], 1, 10002),
+		// an outdated record of the node is ignored
+		encodeRecord(t, nodeKeys[2], 2, 10003),
+		encodeRecord(t, nodeKeys[2], 1, 20003),
+		// malformed entries are skipped
+		tampered,
+		"enr:not-a-record",
+		"some garbage",
+		replaced,
+	}
+
+	resolver := MockResolver{}
+
+	url, err := resolver.AddTree(treeKey, testDomain, 1, entries)
+	assert.NoError(t, err)
+
+	// the entry no longer matches its hash
+	resolver[entryHash(replaced)+"."+testDomain] = []string{encodeRecord(t, generateKey(t), 1, 30305)}
+
+	client := NewClient(hclog.NewNullLogger(), resolver)
+
+	peers, err := client.SyncTree(context.Background(), url)
+	assert.NoError(t, err)
+
+	if !assert.Len(t, peers, 3) {
+		return
+	}
+
+	for i, nodeKey := range nodeKeys {
+		assert.Equal(t, peerID(t, nodeKey), peers[i].ID)
+		assert.Equal(t, fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", 10001+i), peers[i].Addrs[0].String())
+	}
+}
+
+func TestSyncTree_Root(t *testing.T) {
+	treeKey := generateKey(t)
+	entries := []string{encodeRecord(t, generateKey(t), 1, 10001)}
+
+	t.Run("root signed by another key is rejected", func(t *testing.T) {
+		resolver := MockResolver{}
+
+		_, err := resolver.AddTree(generateKey(t), testDomain, 1, entries)
+		assert.NoError(t, err)
+
+		url := (&TreeURL{Domain: testDomain, PubKey: (*btcec.PublicKey)(&treeKey.PublicKey)}).String()
+
+		_, err = NewClient(hclog.NewNullLogger(), resolver).SyncTree(context.Background(), url)
+		assert.ErrorIs(t, err, ErrNoRoot)
+	})
+
+	t.Run("older root is rejected", func(t *testing.T) {
+		resolver := MockResolver{}
+		client := NewClient(hclog.NewNullLogger(), resolver)
+
+		url, err := resolver.AddTree(treeKey, testDomain, 2, entries)
+		assert.NoError(t, err)
+
+		peers, err := client.SyncTree(context.Background(), url)
+		assert.NoError(t, err)
+		assert.Len(t, peers, 1)
+
+		_, err = resolver.AddTree(treeKey, testDomain, 1, entries)
+		assert.NoError(t, err)
+
+		_, err = client.SyncTree(context.Background(), url)
+		assert.ErrorIs(t, err, ErrStaleRoot)
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		url := (&TreeURL{Domain: testDomain, PubKey: (*btcec.PublicKey)(&treeKey.PublicKey)}).String()
+
+		_, err := NewClient(hclog.NewNullLogger(), MockResolver{}).SyncTree(context.Background(), url)
+		assert.Error(t, err)
+	})
+}
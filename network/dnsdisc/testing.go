@@ -0,0 +1,101 @@
+package dnsdisc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/umbracle/fastrlp"
+)
+
+// MockResolver is an in-memory Resolver mapping the domain names to their TXT records
+type MockResolver map[string][]string
+
+// LookupTXT returns the TXT records of the domain
+func (m MockResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	txts, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf("no TXT records found for %s", name)
+	}
+
+	return txts, nil
+}
+
+// AddTree publishes the passed in entries as a tree signed by the key,
+// with all the entries under a single branch. It returns the URL of the tree
+func (m MockResolver) AddTree(key *ecdsa.PrivateKey, domain string, seq uint64, entries []string) (string, error) {
+	hashes := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		hash := entryHash(entry)
+
+		m[hash+"."+domain] = []string{entry}
+		hashes = append(hashes, hash)
+	}
+
+	branch := branchPrefix + strings.Join(hashes, ",")
+	m[entryHash(branch)+"."+domain] = []string{branch}
+
+	// no linked trees
+	links := branchPrefix
+	m[entryHash(links)+"."+domain] = []string{links}
+
+	root := &rootEntry{
+		enrRoot:  entryHash(branch),
+		linkRoot: entryHash(links),
+		seq:      seq,
+	}
+
+	sig, err := crypto.Sign(key, crypto.Keccak256([]byte(root.signedContent())))
+	if err != nil {
+		return "", err
+	}
+
+	root.sig = sig
+	m[domain] = []string{root.String()}
+
+	treeURL := &TreeURL{
+		Domain: domain,
+		PubKey: (*btcec.PublicKey)(&key.PublicKey),
+	}
+
+	return treeURL.String(), nil
+}
+
+// EncodeRecord returns the enr: entry of the node reachable at the IPv4 address and TCP port,
+// signed by the node key
+func EncodeRecord(key *ecdsa.PrivateKey, seq uint64, ip net.IP, port uint64) (string, error) {
+	arena := &fastrlp.Arena{}
+
+	// the keys are sorted
+	content := arena.NewArray()
+	content.Set(arena.NewUint(seq))
+	content.Set(arena.NewString("id"))
+	content.Set(arena.NewString("v4"))
+	content.Set(arena.NewString("ip"))
+	content.Set(arena.NewCopyBytes(ip.To4()))
+	content.Set(arena.NewString("secp256k1"))
+	content.Set(arena.NewCopyBytes((*btcec.PublicKey)(&key.PublicKey).SerializeCompressed()))
+	content.Set(arena.NewString("tcp"))
+	content.Set(arena.NewUint(port))
+
+	sig, err := crypto.Sign(key, crypto.Keccak256(content.MarshalTo(nil)))
+	if err != nil {
+		return "", err
+	}
+
+	elems, _ := content.GetElems()
+
+	record := arena.NewArray()
+	record.Set(arena.NewCopyBytes(sig[:64]))
+
+	for _, elem := range elems {
+		record.Set(elem)
+	}
+
+	return enrPrefix + b64format.EncodeToString(record.MarshalTo(nil)), nil
+}
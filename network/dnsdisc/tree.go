@@ -0,0 +1,327 @@
+package dnsdisc
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/btcsuite/btcd/btcec"
+	libp2pCrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	urlScheme    = "enrtree://"
+	rootPrefix   = "enrtree-root:v1"
+	branchPrefix = "enrtree-branch:"
+	enrPrefix    = "enr:"
+
+	// maxRecordSize is the maximum size of an encoded node record (EIP-778)
+	maxRecordSize = 300
+
+	// hashLength is the length of the base32 encoded entry hashes
+	hashLength = 26
+)
+
+var (
+	ErrInvalidURL       = errors.New("invalid ENR tree URL")
+	ErrInvalidEntry     = errors.New("invalid tree entry")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrHashMismatch     = errors.New("entry hash mismatch")
+	ErrInvalidRecord    = errors.New("invalid node record")
+)
+
+var (
+	b32format = base32.StdEncoding.WithPadding(base32.NoPadding)
+	b64format = base64.RawURLEncoding
+)
+
+// TreeURL is a parsed enrtree://<public key>@<domain> URL
+type TreeURL struct {
+	Domain string           // the domain the root of the tree is published at
+	PubKey *btcec.PublicKey // the key the tree root is signed with
+}
+
+// ParseURL parses an ENR tree URL
+func ParseURL(url string) (*TreeURL, error) {
+	if !strings.HasPrefix(url, urlScheme) {
+		return nil, fmt.Errorf("%w: missing %s scheme", ErrInvalidURL, urlScheme)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(url, urlScheme), "@", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("%w: missing public key or domain", ErrInvalidURL)
+	}
+
+	keyBytes, err := b32format.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid public key encoding, %v", ErrInvalidURL, err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid public key, %v", ErrInvalidURL, err)
+	}
+
+	return &TreeURL{
+		Domain: parts[1],
+		PubKey: pubKey,
+	}, nil
+}
+
+// String returns the URL form of the tree location
+func (u *TreeURL) String() string {
+	return urlScheme + b32format.EncodeToString(u.PubKey.SerializeCompressed()) + "@" + u.Domain
+}
+
+// rootEntry is the signed root of the tree
+type rootEntry struct {
+	enrRoot  string // the hash of the node record subtree root
+	linkRoot string // the hash of the link subtree root
+	seq      uint64 // the tree version, increased on every update
+	sig      []byte // the signature over the other fields
+}
+
+// signedContent returns the text the root signature is made over
+func (r *rootEntry) signedContent() string {
+	return fmt.Sprintf("%s e=%s l=%s seq=%d", rootPrefix, r.enrRoot, r.linkRoot, r.seq)
+}
+
+// String returns the TXT record form of the root
+func (r *rootEntry) String() string {
+	return r.signedContent() + " sig=" + b64format.EncodeToString(r.sig)
+}
+
+// verify checks the root is signed by the key of the tree
+func (r *rootEntry) verify(pubKey *btcec.PublicKey) bool {
+	return verifySignature(pubKey, crypto.Keccak256([]byte(r.signedContent())), r.sig)
+}
+
+// parseRoot parses the root entry TXT record
+func parseRoot(txt string) (*rootEntry, error) {
+	var (
+		root rootEntry
+		sig  string
+	)
+
+	if _, err := fmt.Sscanf(
+		txt,
+		rootPrefix+" e=%s l=%s seq=%d sig=%s",
+		&root.enrRoot,
+		&root.linkRoot,
+		&root.seq,
+		&sig,
+	); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+
+	if !isHash(root.enrRoot) || !isHash(root.linkRoot) {
+		return nil, fmt.Errorf("%w: invalid subtree root hash", ErrInvalidEntry)
+	}
+
+	sigBytes, err := b64format.DecodeString(sig)
+	if err != nil || len(sigBytes) != 65 {
+		return nil, fmt.Errorf("%w: invalid root signature", ErrInvalidEntry)
+	}
+
+	root.sig = sigBytes
+
+	return &root, nil
+}
+
+// parseBranch parses the child hashes of a branch entry
+func parseBranch(txt string) ([]string, error) {
+	content := strings.TrimPrefix(txt, branchPrefix)
+	if content == "" {
+		return nil, nil
+	}
+
+	children := strings.Split(content, ",")
+	for _, child := range children {
+		if !isHash(child) {
+			return nil, fmt.Errorf("%w: invalid child hash %q", ErrInvalidEntry, child)
+		}
+	}
+
+	return children, nil
+}
+
+// nodeRecord is the subset of an EIP-778 node record needed to dial the node
+type nodeRecord struct {
+	seq      uint64
+	peerInfo *peer.AddrInfo
+}
+
+// parseRecord decodes and verifies the node record of an enr: entry,
+// and converts it to the libp2p address of the node
+func parseRecord(txt string) (*nodeRecord, error) {
+	raw, err := b64format.DecodeString(strings.TrimPrefix(txt, enrPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	if len(raw) > maxRecordSize {
+		return nil, fmt.Errorf("%w: record too large", ErrInvalidRecord)
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	// [signature, seq, k, v, ...]
+	if len(elems) < 2 || len(elems)%2 != 0 {
+		return nil, fmt.Errorf("%w: invalid number of fields", ErrInvalidRecord)
+	}
+
+	sig, err := elems[0].Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	seq, err := elems[1].GetUint64()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	pairs := make(map[string]*fastrlp.Value)
+
+	for i := 2; i < len(elems); i += 2 {
+		key, err := elems[i].GetString()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+		}
+
+		pairs[key] = elems[i+1]
+	}
+
+	if pairs["id"] == nil {
+		return nil, fmt.Errorf("%w: missing identity scheme", ErrInvalidRecord)
+	}
+
+	if id, _ := pairs["id"].GetString(); id != "v4" {
+		return nil, fmt.Errorf("%w: unsupported identity scheme %q", ErrInvalidRecord, id)
+	}
+
+	if pairs["secp256k1"] == nil {
+		return nil, fmt.Errorf("%w: missing public key", ErrInvalidRecord)
+	}
+
+	keyBytes, err := pairs["secp256k1"].Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid public key, %v", ErrInvalidRecord, err)
+	}
+
+	// the signature is made over the record without the signature field
+	arena := &fastrlp.Arena{}
+	content := arena.NewArray()
+
+	for _, elem := range elems[1:] {
+		content.Set(elem)
+	}
+
+	if !verifySignature(pubKey, crypto.Keccak256(content.MarshalTo(nil)), sig) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, ErrInvalidSignature)
+	}
+
+	addr, err := recordAddr(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := peer.IDFromPublicKey((*libp2pCrypto.Secp256k1PublicKey)(pubKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	return &nodeRecord{
+		seq: seq,
+		peerInfo: &peer.AddrInfo{
+			ID:    id,
+			Addrs: []multiaddr.Multiaddr{addr},
+		},
+	}, nil
+}
+
+// recordAddr builds the TCP multiaddr of the node from the record fields
+func recordAddr(pairs map[string]*fastrlp.Value) (multiaddr.Multiaddr, error) {
+	var (
+		ipProto = "ip4"
+		ipValue = pairs["ip"]
+	)
+
+	if ipValue == nil {
+		ipProto, ipValue = "ip6", pairs["ip6"]
+	}
+
+	if ipValue == nil || pairs["tcp"] == nil {
+		return nil, fmt.Errorf("%w: missing IP address or TCP port", ErrInvalidRecord)
+	}
+
+	ipBytes, err := ipValue.Bytes()
+	if err != nil || (len(ipBytes) != net.IPv4len && len(ipBytes) != net.IPv6len) {
+		return nil, fmt.Errorf("%w: invalid IP address", ErrInvalidRecord)
+	}
+
+	port, err := pairs["tcp"].GetUint64()
+	if err != nil || port == 0 || port > 0xffff {
+		return nil, fmt.Errorf("%w: invalid TCP port", ErrInvalidRecord)
+	}
+
+	addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", ipProto, net.IP(ipBytes), port))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	return addr, nil
+}
+
+// verifySignature checks the [R || S] or [R || S || V] signature of the hash
+func verifySignature(pubKey *btcec.PublicKey, hash, sig []byte) bool {
+	if len(sig) != 64 && len(sig) != 65 {
+		return false
+	}
+
+	signature := &btcec.Signature{
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+	}
+
+	return signature.Verify(hash, pubKey)
+}
+
+// entryHash returns the subdomain label of the entry,
+// the base32 encoding of the first 16 bytes of its keccak256 hash
+func entryHash(txt string) string {
+	return b32format.EncodeToString(crypto.Keccak256([]byte(txt))[:16])
+}
+
+// isHash checks if the value is an entry hash
+func isHash(value string) bool {
+	if len(value) != hashLength {
+		return false
+	}
+
+	decoded, err := b32format.DecodeString(value)
+
+	return err == nil && len(decoded) == 16
+}
@@ -21,7 +21,11 @@ type Topic struct {
 
 	topic   *pubsub.Topic
 	typ     reflect.Type
-	closeCh chan struct{}
+	closeCh <-chan struct{} // closed along with the networking server
+
+	throttle  *gossipThrottle     // paces the published messages, nil if unlimited
+	priority  bool                // flag indicating if the messages skip the throttle queue
+	publisher *throttledPublisher // publishes the throttled messages, nil if not throttled
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -39,6 +43,19 @@ func (t *Topic) Publish(obj proto.Message) error {
 		return err
 	}
 
+	// the regular gossip waits for the throttle off the publisher's path
+	if t.publisher != nil {
+		return t.publisher.enqueue(data)
+	}
+
+	if err := t.throttle.wait(context.Background(), len(data), t.priority); err != nil {
+		return err
+	}
+
+	return t.publish(data)
+}
+
+func (t *Topic) publish(data []byte) error {
 	return t.topic.Publish(context.Background(), data)
 }
 
@@ -61,9 +78,15 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{})
 		cancelFn()
 	}()
 
+	defer sub.Cancel()
+
 	for {
 		msg, err := sub.Next(ctx)
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
 			t.logger.Error("failed to get topic", "err", err)
 
 			continue
@@ -82,16 +105,35 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{})
 	}
 }
 
+// NewTopic joins the gossip topic, its messages are paced by the bandwidth throttle
 func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
+	return s.newTopic(protoID, obj, false)
+}
+
+// NewPriorityTopic joins the gossip topic for the essential (consensus) messages,
+// which are sent ahead of the regular gossip when the bandwidth is throttled
+func (s *Server) NewPriorityTopic(protoID string, obj proto.Message) (*Topic, error) {
+	return s.newTopic(protoID, obj, true)
+}
+
+func (s *Server) newTopic(protoID string, obj proto.Message, priority bool) (*Topic, error) {
 	topic, err := s.ps.Join(protoID)
 	if err != nil {
 		return nil, err
 	}
 
 	tt := &Topic{
-		logger: s.logger.Named(protoID),
-		topic:  topic,
-		typ:    reflect.TypeOf(obj).Elem(),
+		logger:  s.logger.Named(protoID),
+		topic:   topic,
+		typ:     reflect.TypeOf(obj).Elem(),
+		closeCh: s.closeCh,
+
+		throttle: s.gossipThrottle,
+		priority: priority,
+	}
+
+	if s.gossipThrottle != nil && !priority {
+		tt.publisher = newThrottledPublisher(tt.logger, s.gossipThrottle, tt.publish, tt.closeCh)
 	}
 
 	return tt, nil
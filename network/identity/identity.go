@@ -18,6 +18,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerBanned       = errors.New("peer is banned")
 )
 
 // networkingServer defines the base communication interface between
@@ -51,6 +52,12 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsBanned checks if the peer is banned for misbehaving [Thread safe]
+	IsBanned(peerID peer.ID) bool
+
+	// IsTrustedPeer checks if the peer is a trusted peer, exempt from the connection limits
+	IsTrustedPeer(peerID peer.ID) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -92,7 +99,14 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if i.baseServer.IsBanned(peerID) {
+				i.disconnectFromPeer(peerID, ErrPeerBanned.Error())
+
+				return
+			}
+
+			if !i.baseServer.IsTrustedPeer(peerID) &&
+				!i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
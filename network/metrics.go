@@ -23,6 +23,9 @@ type Metrics struct {
 
 	// Number of pending inbound connections
 	PendingInboundConnectionsCount metrics.Gauge
+
+	// Number of bootnode dial rounds made to join the network
+	BootstrapAttempts metrics.Counter
 }
 
 // GetPrometheusMetrics return the network metrics instance
@@ -68,6 +71,13 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "pending_inbound_connections_count",
 			Help:      "Number of pending inbound connections",
 		}, labels).With(labelsWithValues...),
+
+		BootstrapAttempts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "network",
+			Name:      "bootstrap_attempts",
+			Help:      "Number of bootnode dial rounds made to join the network",
+		}, labels).With(labelsWithValues...),
 	}
 }
 
@@ -79,5 +89,6 @@ func NilMetrics() *Metrics {
 		InboundConnectionsCount:         discard.NewGauge(),
 		PendingOutboundConnectionsCount: discard.NewGauge(),
 		PendingInboundConnectionsCount:  discard.NewGauge(),
+		BootstrapAttempts:               discard.NewCounter(),
 	}
 }
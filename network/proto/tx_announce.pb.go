@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: tx_announce.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NewPooledTxHashes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *NewPooledTxHashes) Reset() {
+	*x = NewPooledTxHashes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tx_announce_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewPooledTxHashes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewPooledTxHashes) ProtoMessage() {}
+
+func (x *NewPooledTxHashes) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_announce_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewPooledTxHashes.ProtoReflect.Descriptor instead.
+func (*NewPooledTxHashes) Descriptor() ([]byte, []int) {
+	return file_tx_announce_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NewPooledTxHashes) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type GetPooledTxs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *GetPooledTxs) Reset() {
+	*x = GetPooledTxs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tx_announce_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPooledTxs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPooledTxs) ProtoMessage() {}
+
+func (x *GetPooledTxs) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_announce_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPooledTxs.ProtoReflect.Descriptor instead.
+func (*GetPooledTxs) Descriptor() ([]byte, []int) {
+	return file_tx_announce_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPooledTxs) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type PooledTxs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP encoded transactions
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (x *PooledTxs) Reset() {
+	*x = PooledTxs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tx_announce_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PooledTxs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PooledTxs) ProtoMessage() {}
+
+func (x *PooledTxs) ProtoReflect() protoreflect.Message {
+	mi := &file_tx_announce_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PooledTxs.ProtoReflect.Descriptor instead.
+func (*PooledTxs) Descriptor() ([]byte, []int) {
+	return file_tx_announce_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PooledTxs) GetTxs() [][]byte {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+var File_tx_announce_proto protoreflect.FileDescriptor
+
+var file_tx_announce_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x74, 0x78, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x50, 0x6f, 0x6f, 0x6c, 0x65,
+	0x64, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x22, 0x26, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x65, 0x64, 0x54, 0x78,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x1d, 0x0a, 0x09, 0x50, 0x6f, 0x6f,
+	0x6c, 0x65, 0x64, 0x54, 0x78, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x03, 0x74, 0x78, 0x73, 0x32, 0x71, 0x0a, 0x0a, 0x54, 0x78, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77, 0x50, 0x6f, 0x6f, 0x6c, 0x65,
+	0x64, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x28, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x10, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x65, 0x64, 0x54, 0x78, 0x73, 0x1a, 0x0d, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x65, 0x64, 0x54, 0x78, 0x73, 0x42, 0x10, 0x5a, 0x0e, 0x2f,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tx_announce_proto_rawDescOnce sync.Once
+	file_tx_announce_proto_rawDescData = file_tx_announce_proto_rawDesc
+)
+
+func file_tx_announce_proto_rawDescGZIP() []byte {
+	file_tx_announce_proto_rawDescOnce.Do(func() {
+		file_tx_announce_proto_rawDescData = protoimpl.X.CompressGZIP(file_tx_announce_proto_rawDescData)
+	})
+	return file_tx_announce_proto_rawDescData
+}
+
+var file_tx_announce_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_tx_announce_proto_goTypes = []interface{}{
+	(*NewPooledTxHashes)(nil), // 0: v1.NewPooledTxHashes
+	(*GetPooledTxs)(nil),      // 1: v1.GetPooledTxs
+	(*PooledTxs)(nil),         // 2: v1.PooledTxs
+	(*emptypb.Empty)(nil),     // 3: google.protobuf.Empty
+}
+var file_tx_announce_proto_depIdxs = []int32{
+	0, // 0: v1.TxAnnounce.Announce:input_type -> v1.NewPooledTxHashes
+	1, // 1: v1.TxAnnounce.Fetch:input_type -> v1.GetPooledTxs
+	3, // 2: v1.TxAnnounce.Announce:output_type -> google.protobuf.Empty
+	2, // 3: v1.TxAnnounce.Fetch:output_type -> v1.PooledTxs
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_tx_announce_proto_init() }
+func file_tx_announce_proto_init() {
+	if File_tx_announce_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tx_announce_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewPooledTxHashes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tx_announce_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPooledTxs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tx_announce_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PooledTxs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tx_announce_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tx_announce_proto_goTypes,
+		DependencyIndexes: file_tx_announce_proto_depIdxs,
+		MessageInfos:      file_tx_announce_proto_msgTypes,
+	}.Build()
+	File_tx_announce_proto = out.File
+	file_tx_announce_proto_rawDesc = nil
+	file_tx_announce_proto_goTypes = nil
+	file_tx_announce_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TxAnnounceClient is the client API for TxAnnounce service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TxAnnounceClient interface {
+	Announce(ctx context.Context, in *NewPooledTxHashes, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Fetch(ctx context.Context, in *GetPooledTxs, opts ...grpc.CallOption) (*PooledTxs, error)
+}
+
+type txAnnounceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTxAnnounceClient(cc grpc.ClientConnInterface) TxAnnounceClient {
+	return &txAnnounceClient{cc}
+}
+
+func (c *txAnnounceClient) Announce(ctx context.Context, in *NewPooledTxHashes, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.TxAnnounce/Announce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txAnnounceClient) Fetch(ctx context.Context, in *GetPooledTxs, opts ...grpc.CallOption) (*PooledTxs, error) {
+	out := new(PooledTxs)
+	err := c.cc.Invoke(ctx, "/v1.TxAnnounce/Fetch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxAnnounceServer is the server API for TxAnnounce service.
+// All implementations must embed UnimplementedTxAnnounceServer
+// for forward compatibility
+type TxAnnounceServer interface {
+	Announce(context.Context, *NewPooledTxHashes) (*emptypb.Empty, error)
+	Fetch(context.Context, *GetPooledTxs) (*PooledTxs, error)
+	mustEmbedUnimplementedTxAnnounceServer()
+}
+
+// UnimplementedTxAnnounceServer must be embedded to have forward compatible implementations.
+type UnimplementedTxAnnounceServer struct {
+}
+
+func (UnimplementedTxAnnounceServer) Announce(context.Context, *NewPooledTxHashes) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Announce not implemented")
+}
+func (UnimplementedTxAnnounceServer) Fetch(context.Context, *GetPooledTxs) (*PooledTxs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (UnimplementedTxAnnounceServer) mustEmbedUnimplementedTxAnnounceServer() {}
+
+// UnsafeTxAnnounceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TxAnnounceServer will
+// result in compilation errors.
+type UnsafeTxAnnounceServer interface {
+	mustEmbedUnimplementedTxAnnounceServer()
+}
+
+func RegisterTxAnnounceServer(s grpc.ServiceRegistrar, srv TxAnnounceServer) {
+	s.RegisterService(&TxAnnounce_ServiceDesc, srv)
+}
+
+func _TxAnnounce_Announce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewPooledTxHashes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxAnnounceServer).Announce(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxAnnounce/Announce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxAnnounceServer).Announce(ctx, req.(*NewPooledTxHashes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxAnnounce_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPooledTxs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxAnnounceServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxAnnounce/Fetch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxAnnounceServer).Fetch(ctx, req.(*GetPooledTxs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TxAnnounce_ServiceDesc is the grpc.ServiceDesc for TxAnnounce service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TxAnnounce_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.TxAnnounce",
+	HandlerType: (*TxAnnounceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Announce",
+			Handler:    _TxAnnounce_Announce_Handler,
+		},
+		{
+			MethodName: "Fetch",
+			Handler:    _TxAnnounce_Fetch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tx_announce.proto",
+}
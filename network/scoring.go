@@ -0,0 +1,170 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Misbehavior is a kind of misbehavior a peer can be penalized for
+type Misbehavior int
+
+const (
+	// MisbehaviorInvalidTx is reported when a peer sends an invalid transaction
+	MisbehaviorInvalidTx Misbehavior = iota
+
+	// MisbehaviorInvalidBlock is reported when a peer sends a block that fails verification
+	MisbehaviorInvalidBlock
+
+	// MisbehaviorProtocolViolation is reported when a peer sends a malformed or unsolicited message
+	MisbehaviorProtocolViolation
+)
+
+// misbehaviorPenalties are the score penalties for each kind of misbehavior
+var misbehaviorPenalties = map[Misbehavior]int64{
+	MisbehaviorInvalidTx:         10,
+	MisbehaviorInvalidBlock:      50,
+	MisbehaviorProtocolViolation: 20,
+}
+
+// String returns the name of the misbehavior
+func (m Misbehavior) String() string {
+	switch m {
+	case MisbehaviorInvalidTx:
+		return "invalid transaction"
+	case MisbehaviorInvalidBlock:
+		return "invalid block"
+	case MisbehaviorProtocolViolation:
+		return "protocol violation"
+	default:
+		return "unknown misbehavior"
+	}
+}
+
+// Penalty returns the score penalty of the misbehavior
+func (m Misbehavior) Penalty() int64 {
+	return misbehaviorPenalties[m]
+}
+
+const (
+	// scoreRecoveryInterval is the interval at which a negative score recovers
+	scoreRecoveryInterval = time.Minute
+
+	// scoreRecoveryPoints are the points a negative score recovers every interval,
+	// so that occasional penalties of a long lived peer don't add up to a ban
+	scoreRecoveryPoints int64 = 10
+)
+
+// peerScore is the score of a peer along with the time it last recovered from
+type peerScore struct {
+	value   int64
+	updated time.Time
+}
+
+// peerScores keeps track of the peer scores and of the banned peers
+type peerScores struct {
+	sync.Mutex
+
+	// scores are the negative scores of the peers. A peer starts with a score of 0,
+	// which is decreased by the penalty of each misbehavior it is reported for
+	// and recovers over time
+	scores map[peer.ID]*peerScore
+
+	// bans are the expiry times of the peer bans
+	bans map[peer.ID]time.Time
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[peer.ID]*peerScore),
+		bans:   make(map[peer.ID]time.Time),
+	}
+}
+
+// penalize decreases the score of the peer at the given time by the penalty,
+// and returns the new score [Thread safe]
+func (ps *peerScores) penalize(peerID peer.ID, penalty int64, now time.Time) int64 {
+	ps.Lock()
+	defer ps.Unlock()
+
+	score := ps.recover(peerID, now)
+	if score == nil {
+		score = &peerScore{updated: now}
+		ps.scores[peerID] = score
+	}
+
+	score.value -= penalty
+
+	return score.value
+}
+
+// score returns the score of the peer at the given time [Thread safe]
+func (ps *peerScores) score(peerID peer.ID, now time.Time) int64 {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if score := ps.recover(peerID, now); score != nil {
+		return score.value
+	}
+
+	return 0
+}
+
+// recover adds the points the score of the peer recovered since it was last updated,
+// removing it once it is back to 0. It returns nil if the peer has no negative score
+func (ps *peerScores) recover(peerID peer.ID, now time.Time) *peerScore {
+	score, ok := ps.scores[peerID]
+	if !ok {
+		return nil
+	}
+
+	if intervals := now.Sub(score.updated) / scoreRecoveryInterval; intervals > 0 {
+		score.value += int64(intervals) * scoreRecoveryPoints
+		score.updated = score.updated.Add(intervals * scoreRecoveryInterval)
+	}
+
+	if score.value >= 0 {
+		delete(ps.scores, peerID)
+
+		return nil
+	}
+
+	return score
+}
+
+// reset clears the score of the peer [Thread safe]
+func (ps *peerScores) reset(peerID peer.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	delete(ps.scores, peerID)
+}
+
+// ban bans the peer until the given time [Thread safe]
+func (ps *peerScores) ban(peerID peer.ID, until time.Time) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	ps.bans[peerID] = until
+}
+
+// isBanned checks if the peer is banned at the given time,
+// removing the ban once it has expired [Thread safe]
+func (ps *peerScores) isBanned(peerID peer.ID, now time.Time) bool {
+	ps.Lock()
+	defer ps.Unlock()
+
+	until, ok := ps.bans[peerID]
+	if !ok {
+		return false
+	}
+
+	if now.After(until) {
+		delete(ps.bans, peerID)
+
+		return false
+	}
+
+	return true
+}
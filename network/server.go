@@ -7,9 +7,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/dial"
 	"github.com/0xPolygon/polygon-edge/network/discovery"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
 	"github.com/libp2p/go-libp2p"
 	noise "github.com/libp2p/go-libp2p-noise"
 	rawGrpc "google.golang.org/grpc"
+	"net"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -86,6 +88,17 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	bootstrapMinBackoff time.Duration // the delay before the first re-dial of the bootnodes
+	bootstrapMaxBackoff time.Duration // the maximum delay between the bootnode dial rounds
+
+	scores *peerScores // scores of the peers and the banned peers
+
+	trustedPeers *trustedPeersWrapper // reference of all trusted peers for the node
+
+	gossipThrottle *gossipThrottle // paces the gossip published by the node
+
+	dnsDiscovery *dnsdisc.Client // client for the DNS ENR tree peer discovery
 }
 
 // NewServer returns a new instance of the networking server
@@ -149,10 +162,18 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			bootnodesMap:      make(map[peer.ID]*peer.AddrInfo),
 			bootnodeConnCount: 0,
 		},
+		bootstrapMinBackoff: defaultBootstrapMinBackoff,
+		bootstrapMaxBackoff: defaultBootstrapMaxBackoff,
 		connectionCounts: NewBlankConnectionInfo(
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		scores: newPeerScores(),
+		trustedPeers: &trustedPeersWrapper{
+			trustedPeersMap: make(map[peer.ID]*peer.AddrInfo),
+		},
+		gossipThrottle: newGossipThrottle(config.GossipBandwidth),
+		dnsDiscovery:   dnsdisc.NewClient(logger, net.DefaultResolver),
 	}
 
 	// start gossip protocol
@@ -258,6 +279,29 @@ func (s *Server) Start() error {
 		if setupErr := s.setupDiscovery(); setupErr != nil {
 			return fmt.Errorf("unable to setup discovery, %w", setupErr)
 		}
+
+		// Keep dialing the bootnodes until the node joins the network
+		if s.bootnodes.hasBootnodes() {
+			go s.bootstrap()
+		}
+
+		// Discover the peers published in the DNS tree, if any
+		if s.config.DNSDiscoveryURL != "" {
+			if _, parseErr := dnsdisc.ParseURL(s.config.DNSDiscoveryURL); parseErr != nil {
+				return fmt.Errorf("unable to parse DNS discovery URL, %w", parseErr)
+			}
+
+			go s.runDNSDiscovery()
+		}
+	}
+
+	// Parse the trusted peers and keep them connected
+	if setupErr := s.setupTrustedPeers(); setupErr != nil {
+		return fmt.Errorf("unable to parse trusted peers, %w", setupErr)
+	}
+
+	for _, trustedPeer := range s.trustedPeers.trustedPeersMap {
+		go s.keepTrustedPeerConnected(trustedPeer)
 	}
 
 	go s.runDial()
@@ -435,6 +479,27 @@ func (s *Server) Peers() []*PeerConnInfo {
 	return peers
 }
 
+// GetPeerDirections returns the directions of the active connections with the peer [Thread safe]
+func (s *Server) GetPeerDirections(peerID peer.ID) []network.Direction {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	connectionInfo, ok := s.peers[peerID]
+	if !ok {
+		return nil
+	}
+
+	directions := make([]network.Direction, 0, 2)
+
+	for _, direction := range []network.Direction{network.DirInbound, network.DirOutbound} {
+		if connectionInfo.connDirections[direction] {
+			directions = append(directions, direction)
+		}
+	}
+
+	return directions
+}
+
 // hasPeer checks if the peer is present in the peers list [Thread safe]
 func (s *Server) hasPeer(peerID peer.ID) bool {
 	s.peersLock.Lock()
@@ -469,6 +534,9 @@ func (s *Server) removePeer(peerID peer.ID) {
 		return
 	}
 
+	// Clear the score of the peer, a new connection starts over
+	s.scores.reset(peerID)
+
 	// Emit the event alerting listeners
 	s.emitEvent(peerID, peerEvent.PeerDisconnected)
 }
@@ -533,6 +601,36 @@ func (s *Server) DisconnectFromPeer(peer peer.ID, reason string) {
 	}
 }
 
+// ReportMisbehavior decreases the score of the peer by the penalty of the misbehavior,
+// which it recovers from over time. Once the score drops below the threshold, the peer is disconnected and banned
+// for the configured duration, unless it is a trusted peer [Thread safe]
+func (s *Server) ReportMisbehavior(peerID peer.ID, misbehavior Misbehavior) {
+	score := s.scores.penalize(peerID, misbehavior.Penalty(), time.Now())
+
+	s.logger.Debug("Peer misbehaved", "id", peerID, "misbehavior", misbehavior, "score", score)
+
+	if score >= s.config.ScoreThreshold || s.IsTrustedPeer(peerID) {
+		return
+	}
+
+	if s.config.BanDuration > 0 {
+		s.scores.ban(peerID, time.Now().Add(s.config.BanDuration))
+	}
+
+	s.scores.reset(peerID)
+	s.DisconnectFromPeer(peerID, fmt.Sprintf("score too low after %s", misbehavior))
+}
+
+// GetPeerScore returns the score of the peer [Thread safe]
+func (s *Server) GetPeerScore(peerID peer.ID) int64 {
+	return s.scores.score(peerID, time.Now())
+}
+
+// IsBanned checks if the peer is banned for misbehaving [Thread safe]
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.scores.isBanned(peerID, time.Now())
+}
+
 var (
 	// Anything below 35s is prone to false timeouts, as seen from empirical test data
 	DefaultJoinTimeout   = 40 * time.Second
@@ -658,7 +756,12 @@ type Subscription struct {
 func (s *Subscription) run() {
 	// convert interface{} to *PeerEvent channels
 	for {
-		evnt := <-s.sub.Out()
+		evnt, ok := <-s.sub.Out()
+		if !ok {
+			// the subscription is closed
+			return
+		}
+
 		if obj, ok := evnt.(peerEvent.PeerEvent); ok {
 			s.ch <- &obj
 		}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+)
+
+const (
+	// dnsDiscoveryInterval is the interval at which the DNS discovery tree is synced
+	dnsDiscoveryInterval = 10 * time.Minute
+
+	// dnsDiscoveryTimeout is the timeout of a single DNS discovery tree sync
+	dnsDiscoveryTimeout = time.Minute
+)
+
+// runDNSDiscovery periodically syncs the DNS discovery tree
+// and dials the discovered peers
+func (s *Server) runDNSDiscovery() {
+	for {
+		s.dialDNSPeers()
+
+		select {
+		case <-time.After(dnsDiscoveryInterval):
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// dialDNSPeers syncs the DNS discovery tree and queues the dials
+// of the discovered peers, up to the free outbound connection slots
+func (s *Server) dialDNSPeers() {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsDiscoveryTimeout)
+	defer cancel()
+
+	peers, err := s.dnsDiscovery.SyncTree(ctx, s.config.DNSDiscoveryURL)
+	if err != nil {
+		s.logger.Error("failed to sync the DNS discovery tree", "url", s.config.DNSDiscoveryURL, "err", err)
+
+		return
+	}
+
+	freeSlots := s.connectionCounts.maxOutboundConnCount() -
+		s.connectionCounts.GetOutboundConnCount() -
+		s.connectionCounts.GetPendingOutboundConnCount()
+
+	for _, peerInfo := range peers {
+		if freeSlots <= 0 {
+			return
+		}
+
+		if peerInfo.ID == s.host.ID() || s.isConnected(peerInfo.ID) {
+			continue
+		}
+
+		s.addToDialQueue(peerInfo, common.PriorityRandomDial)
+		freeSlots--
+	}
+}
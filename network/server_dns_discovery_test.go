@@ -0,0 +1,97 @@
+package network
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network/dnsdisc"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSDiscovery(t *testing.T) {
+	// the nodes published in the tree
+	nodes, createErr := createServers(2, map[int]*CreateServerParams{
+		0: {ConfigCallback: func(c *Config) { c.NoDiscover = true }},
+		1: {ConfigCallback: func(c *Config) { c.NoDiscover = true }},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, nodes)
+	})
+
+	entries := []string{
+		// malformed entries are ignored
+		"enr:not-a-record",
+	}
+
+	nodeIDs := make([]peer.ID, len(nodes))
+
+	for i, node := range nodes {
+		nodeIDs[i] = node.host.ID()
+
+		rawKey, err := node.host.Peerstore().PrivKey(node.host.ID()).Raw()
+		assert.NoError(t, err)
+
+		key, err := crypto.ParsePrivateKey(rawKey)
+		assert.NoError(t, err)
+
+		record, err := dnsdisc.EncodeRecord(key, 1, net.ParseIP("127.0.0.1"), uint64(node.config.Addr.Port))
+		assert.NoError(t, err)
+
+		entries = append(entries, record)
+	}
+
+	treeKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+
+	resolver := dnsdisc.MockResolver{}
+
+	url, err := resolver.AddTree(treeKey, "nodes.example.org", 1, entries)
+	assert.NoError(t, err)
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = false
+			c.DNSDiscoveryURL = url
+		},
+		ServerCallback: func(server *Server) {
+			server.dnsDiscovery = dnsdisc.NewClient(hclog.NewNullLogger(), resolver)
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create networking server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		assert.NoError(t, server.Close())
+	})
+
+	// the discovered nodes are dialed
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancelWait()
+
+	connected, err := WaitUntilPeerConnectsTo(waitCtx, server, nodeIDs...)
+	if err != nil {
+		t.Fatalf("Unable to wait for the discovered peers, %v", err)
+	}
+
+	assert.True(t, connected)
+}
+
+func TestDNSDiscovery_InvalidURL(t *testing.T) {
+	_, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = false
+			c.DNSDiscoveryURL = "https://nodes.example.org"
+		},
+	})
+
+	assert.ErrorIs(t, createErr, dnsdisc.ErrInvalidURL)
+}
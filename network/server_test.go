@@ -12,6 +12,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -133,6 +134,51 @@ func TestConnLimit_Outbound(t *testing.T) {
 	}
 }
 
+func TestConnLimit_InboundFullAllowsOutbound(t *testing.T) {
+	// a node with all the inbound slots taken should refuse
+	// new inbound peers, but still be able to dial out
+	defaultConfig := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxInboundPeers = 1
+			c.MaxOutboundPeers = 1
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(4, map[int]*CreateServerParams{
+		0: defaultConfig,
+		1: defaultConfig,
+		2: defaultConfig,
+		3: defaultConfig,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// Server 1 takes the only inbound slot of Server 0
+	if joinErr := JoinAndWait(servers[1], servers[0], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	// Server 2 can't connect to Server 0, as its inbound slots are full
+	smallTimeout := time.Second * 5
+	if joinErr := JoinAndWait(servers[2], servers[0], smallTimeout, smallTimeout); joinErr == nil {
+		t.Fatal("Peer join should've failed", joinErr)
+	}
+
+	// Server 0 can still connect to Server 3 using its outbound slot
+	if joinErr := JoinAndWait(servers[0], servers[3], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	assert.Equal(t, int64(1), servers[0].connectionCounts.GetInboundConnCount())
+	assert.Equal(t, int64(1), servers[0].connectionCounts.GetOutboundConnCount())
+}
+
 func TestPeerEvent_EmitAndSubscribe(t *testing.T) {
 	server, createErr := CreateServer(&CreateServerParams{ConfigCallback: func(c *Config) {
 		c.NoDiscover = true
@@ -954,6 +1000,11 @@ func TestPeerAdditionDeletion(t *testing.T) {
 			assert.Equal(t, randomPeers[indx].peerID, connInfo.Info.ID)
 			assert.True(t, connInfo.connDirections[network.DirOutbound])
 			assert.True(t, connInfo.connDirections[network.DirInbound])
+			assert.Equal(
+				t,
+				[]network.Direction{network.DirInbound, network.DirOutbound},
+				server.GetPeerDirections(connInfo.Info.ID),
+			)
 		}
 
 		outbound, inbound := extractExpectedDirectionCounts(randomPeers)
@@ -1022,3 +1073,263 @@ func generateRandomPeers(t *testing.T, count int) ([]*randomPeer, error) {
 
 	return randomPeers, nil
 }
+
+func TestMisbehavingPeerDisconnection(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	misbehavingPeer := servers[1].AddrInfo().ID
+
+	// Server 1 keeps sending malformed messages to Server 0,
+	// which keeps the connection until the score drops below the threshold
+	penalty := MisbehaviorProtocolViolation.Penalty()
+	reports := int(-servers[0].config.ScoreThreshold/penalty) + 1
+
+	for i := 1; i < reports; i++ {
+		servers[0].ReportMisbehavior(misbehavingPeer, MisbehaviorProtocolViolation)
+
+		assert.Equal(t, -int64(i)*penalty, servers[0].GetPeerScore(misbehavingPeer))
+		assert.True(t, servers[0].hasPeer(misbehavingPeer))
+	}
+
+	servers[0].ReportMisbehavior(misbehavingPeer, MisbehaviorProtocolViolation)
+
+	disconnectCtx, disconnectFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer disconnectFn()
+
+	if _, disconnectErr := WaitUntilPeerDisconnectsFrom(
+		disconnectCtx,
+		servers[0],
+		misbehavingPeer,
+	); disconnectErr != nil {
+		t.Fatalf("Unable to disconnect from peer, %v", disconnectErr)
+	}
+
+	assert.True(t, servers[0].IsBanned(misbehavingPeer))
+	assert.Equal(t, int64(0), servers[0].GetPeerScore(misbehavingPeer))
+
+	// Server 1 can't connect again while it is banned
+	servers[1].joinPeer(servers[0].AddrInfo())
+
+	connectCtx, connectFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer connectFn()
+
+	if _, connectErr := WaitUntilPeerConnectsTo(connectCtx, servers[0], misbehavingPeer); connectErr == nil {
+		t.Fatal("Banned peer should not be connected")
+	}
+}
+
+func TestPeerScores_BanExpiry(t *testing.T) {
+	scores := newPeerScores()
+	peerID := peer.ID("A")
+	now := time.Now()
+
+	scores.ban(peerID, now.Add(time.Minute))
+
+	assert.True(t, scores.isBanned(peerID, now))
+	assert.False(t, scores.isBanned(peerID, now.Add(2*time.Minute)))
+
+	// the expired ban is removed
+	assert.False(t, scores.isBanned(peerID, now))
+}
+
+func TestPeerScores_Recovery(t *testing.T) {
+	scores := newPeerScores()
+	peerID := peer.ID("A")
+	now := time.Now()
+
+	assert.Equal(t, int64(-50), scores.penalize(peerID, 50, now))
+
+	// the score recovers every full interval
+	assert.Equal(t, int64(-50), scores.score(peerID, now.Add(scoreRecoveryInterval/2)))
+	assert.Equal(t, -50+scoreRecoveryPoints, scores.score(peerID, now.Add(scoreRecoveryInterval)))
+
+	// the penalties add up to the recovered score, without restarting the interval
+	assert.Equal(t, -60+scoreRecoveryPoints, scores.penalize(peerID, 10, now.Add(scoreRecoveryInterval*3/2)))
+	assert.Equal(t, -60+2*scoreRecoveryPoints, scores.score(peerID, now.Add(2*scoreRecoveryInterval)))
+
+	// it recovers up to 0, and the peer starts over
+	assert.Equal(t, int64(0), scores.score(peerID, now.Add(time.Hour)))
+	assert.Empty(t, scores.scores)
+	assert.Equal(t, int64(-10), scores.penalize(peerID, 10, now.Add(time.Hour)))
+}
+
+func TestTrustedPeerReconnection(t *testing.T) {
+	trustedServer, createErr := CreateServer(nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create networking server, %v", createErr)
+	}
+
+	trustedID := trustedServer.AddrInfo().ID
+	events := make(chan peerEvent.PeerEventType, 16)
+
+	// the node has no outbound slots, it only connects to its trusted peer
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.MaxOutboundPeers = 0
+			c.NoDiscover = true
+			c.TrustedPeers = []string{common.AddrInfoToString(trustedServer.AddrInfo())}
+		},
+		ServerCallback: func(server *Server) {
+			// subscribe before the server starts, so the startup dial isn't missed
+			if err := server.SubscribeFn(func(event *peerEvent.PeerEvent) {
+				if event.PeerID != trustedID {
+					return
+				}
+
+				select {
+				case events <- event.Type:
+				default:
+				}
+			}); err != nil {
+				t.Fatalf("Unable to subscribe to network events, %v", err)
+			}
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create networking server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, []*Server{server, trustedServer})
+	})
+
+	waitForEvent := func(eventType peerEvent.PeerEventType) {
+		t.Helper()
+
+		timeout := time.After(DefaultJoinTimeout)
+
+		for {
+			select {
+			case received := <-events:
+				if received == eventType {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("Peer event %d not received", eventType)
+			}
+		}
+	}
+
+	// the trusted peer is dialed on startup
+	waitForEvent(peerEvent.PeerConnected)
+
+	// the trusted peer is never disconnected for misbehaving
+	for i := 0; i < 10; i++ {
+		server.ReportMisbehavior(trustedID, MisbehaviorInvalidBlock)
+	}
+
+	assert.True(t, server.hasPeer(trustedID))
+	assert.False(t, server.IsBanned(trustedID))
+
+	// the trusted peer drops the connection, and it is re-dialed
+	trustedServer.DisconnectFromPeer(server.AddrInfo().ID, "bye")
+
+	waitForEvent(peerEvent.PeerDisconnected)
+	waitForEvent(peerEvent.PeerConnected)
+
+	assert.True(t, server.hasPeer(trustedID))
+}
+
+// attemptsCounter is a metrics counter signaling every increment
+type attemptsCounter struct {
+	attempts chan struct{}
+}
+
+func (c *attemptsCounter) With(...string) metrics.Counter {
+	return c
+}
+
+func (c *attemptsCounter) Add(float64) {
+	select {
+	case c.attempts <- struct{}{}:
+	default:
+	}
+}
+
+func TestBootstrapRetry(t *testing.T) {
+	// the bootnode is not running yet, only its identity and address are known
+	key, directoryName := GenerateTestLibp2pKey(t)
+	bootnodeID, err := peer.IDFromPrivateKey(key)
+	assert.NoError(t, err)
+
+	port, portErr := tests.GetFreePort()
+	if portErr != nil {
+		t.Fatalf("Unable to fetch free port, %v", portErr)
+	}
+
+	bootstrapAttempts := &attemptsCounter{
+		attempts: make(chan struct{}),
+	}
+
+	server, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = false
+		},
+		ServerCallback: func(server *Server) {
+			server.config.Chain.Bootnodes = []string{
+				fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", port, bootnodeID),
+			}
+
+			server.bootstrapMinBackoff = 10 * time.Millisecond
+			server.bootstrapMaxBackoff = 50 * time.Millisecond
+			server.metrics.BootstrapAttempts = bootstrapAttempts
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create networking server, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		assert.NoError(t, server.Close())
+	})
+
+	// the first dials fail, and the bootnodes are dialed again
+	timeout := time.After(DefaultJoinTimeout)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-bootstrapAttempts.attempts:
+		case <-timeout:
+			t.Fatalf("Bootstrap attempt %d not made", i)
+		}
+	}
+
+	assert.Equal(t, int64(0), server.numPeers())
+
+	// the bootnode comes up
+	bootnode, createErr := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.DataDir = directoryName
+			c.Addr.Port = port
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create bootnode, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		assert.NoError(t, bootnode.Close())
+	})
+
+	// and the node eventually connects to it
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancelWait()
+
+	connected, err := WaitUntilPeerConnectsTo(waitCtx, server, bootnodeID)
+	if err != nil {
+		t.Fatalf("Unable to wait for the bootnode connection, %v", err)
+	}
+
+	assert.True(t, connected)
+}
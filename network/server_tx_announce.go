@@ -0,0 +1,114 @@
+package network
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	rawGrpc "google.golang.org/grpc"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	errTxAnnouncePeerDisconnected = errors.New("peer disconnected before the tx announce client was initialized")
+)
+
+// RegisterTxAnnounceService registers the transaction announcement protocol
+// handled by the given service
+func (s *Server) RegisterTxAnnounceService(service proto.TxAnnounceServer) {
+	if s.gossipThrottle != nil {
+		service = &throttledTxAnnounceServer{
+			TxAnnounceServer: service,
+			throttle:         s.gossipThrottle,
+		}
+	}
+
+	grpcStream := grpc.NewGrpcStream()
+	proto.RegisterTxAnnounceServer(grpcStream.GrpcServer(), service)
+	grpcStream.Serve()
+
+	s.RegisterProtocol(common.TxAnnounceProto, grpcStream)
+}
+
+// NewTxAnnounceClient returns a new or existing transaction announcement client connection.
+// It fails if the peer doesn't support the protocol
+func (s *Server) NewTxAnnounceClient(peerID peer.ID) (proto.TxAnnounceClient, error) {
+	if !s.isConnected(peerID) {
+		return nil, errTxAnnouncePeerDisconnected
+	}
+
+	// Check if there is an active stream connection already
+	if protoStream := s.getProtoStream(common.TxAnnounceProto, peerID); protoStream != nil {
+		return s.throttleTxAnnounceClient(proto.NewTxAnnounceClient(protoStream)), nil
+	}
+
+	// Create a new stream connection and return it
+	protoStream, err := s.newProtoConnection(common.TxAnnounceProto, peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Announcement streams are reused for every announcement to the peer
+	s.saveProtocolStream(common.TxAnnounceProto, protoStream, peerID)
+
+	return s.throttleTxAnnounceClient(proto.NewTxAnnounceClient(protoStream)), nil
+}
+
+// throttleTxAnnounceClient paces the announcements sent by the client, if throttled
+func (s *Server) throttleTxAnnounceClient(client proto.TxAnnounceClient) proto.TxAnnounceClient {
+	if s.gossipThrottle == nil {
+		return client
+	}
+
+	return &throttledTxAnnounceClient{
+		TxAnnounceClient: client,
+		throttle:         s.gossipThrottle,
+	}
+}
+
+// throttledTxAnnounceClient draws the announced hashes from the gossip bandwidth
+type throttledTxAnnounceClient struct {
+	proto.TxAnnounceClient
+
+	throttle *gossipThrottle
+}
+
+func (c *throttledTxAnnounceClient) Announce(
+	ctx context.Context,
+	in *proto.NewPooledTxHashes,
+	opts ...rawGrpc.CallOption,
+) (*emptypb.Empty, error) {
+	if err := c.throttle.wait(ctx, protoV2.Size(in), false); err != nil {
+		return nil, err
+	}
+
+	return c.TxAnnounceClient.Announce(ctx, in, opts...)
+}
+
+// throttledTxAnnounceServer draws the transactions served to the peers
+// from the gossip bandwidth
+type throttledTxAnnounceServer struct {
+	proto.TxAnnounceServer
+
+	throttle *gossipThrottle
+}
+
+func (s *throttledTxAnnounceServer) Fetch(
+	ctx context.Context,
+	req *proto.GetPooledTxs,
+) (*proto.PooledTxs, error) {
+	resp, err := s.TxAnnounceServer.Fetch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.throttle.wait(ctx, protoV2.Size(resp), false); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
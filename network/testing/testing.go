@@ -29,6 +29,8 @@ type MockNetworkingServer struct {
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isBannedFn               isBannedDelegate
+	isTrustedPeerFn          isTrustedPeerDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -72,6 +74,8 @@ type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isBannedDelegate func(peer.ID) bool
+type isTrustedPeerDelegate func(peer.ID) bool
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -161,6 +165,30 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) IsBanned(peerID peer.ID) bool {
+	if m.isBannedFn != nil {
+		return m.isBannedFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsBanned(fn isBannedDelegate) {
+	m.isBannedFn = fn
+}
+
+func (m *MockNetworkingServer) IsTrustedPeer(peerID peer.ID) bool {
+	if m.isTrustedPeerFn != nil {
+		return m.isTrustedPeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsTrustedPeer(fn isTrustedPeerDelegate) {
+	m.isTrustedPeerFn = fn
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
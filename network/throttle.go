@@ -0,0 +1,153 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/time/rate"
+)
+
+// maximum number of messages of a topic waiting for the throttle
+const throttledQueueSize = 1024
+
+var (
+	errThrottledQueueFull       = errors.New("throttled gossip queue is full")
+	errThrottledPublisherClosed = errors.New("throttled gossip publisher is closed")
+)
+
+// gossipThrottle paces the outbound gossip originated by the node to the configured
+// bandwidth, using a token bucket sized to one second of traffic. It covers the messages
+// published through Topic.Publish and the transaction announcements and fetch replies.
+// The messages gossipsub relays on behalf of other peers and its control traffic
+// are not throttled.
+// Priority messages are never delayed, but their size is still drawn from the bucket,
+// so the regular gossip makes way for them
+type gossipThrottle struct {
+	limiter *rate.Limiter
+
+	// time source and timer, replaced in tests
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// newGossipThrottle returns a throttle capped at bytesPerSec, or nil if the cap is disabled (0)
+func newGossipThrottle(bytesPerSec uint64) *gossipThrottle {
+	if bytesPerSec == 0 {
+		return nil
+	}
+
+	return &gossipThrottle{
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSec), int(bytesPerSec)),
+		now:     time.Now,
+		after:   time.After,
+	}
+}
+
+// wait blocks until a message of the given size can be sent within the bandwidth cap
+func (g *gossipThrottle) wait(ctx context.Context, size int, priority bool) error {
+	if g == nil {
+		return nil
+	}
+
+	// a message larger than the bucket is charged the whole bucket,
+	// otherwise it could never be sent
+	if burst := g.limiter.Burst(); size > burst {
+		size = burst
+	}
+
+	now := g.now()
+
+	// the tokens are taken upfront, the bucket goes into debt if needed
+	reservation := g.limiter.ReserveN(now, size)
+
+	delay := reservation.DelayFrom(now)
+	if priority || delay == 0 {
+		return nil
+	}
+
+	select {
+	case <-g.after(delay):
+		return nil
+	case <-ctx.Done():
+		// the message is not sent, give back its tokens
+		reservation.CancelAt(g.now())
+
+		return ctx.Err()
+	}
+}
+
+// throttledPublisher publishes the messages of a topic in the background,
+// paced by the throttle, so the publishers are never blocked by the bandwidth cap.
+// The messages are published in the order they are queued, until closeCh is closed
+type throttledPublisher struct {
+	logger   hclog.Logger
+	throttle *gossipThrottle
+	publish  func(data []byte) error
+	queue    chan []byte
+	closeCh  <-chan struct{}
+}
+
+func newThrottledPublisher(
+	logger hclog.Logger,
+	throttle *gossipThrottle,
+	publish func(data []byte) error,
+	closeCh <-chan struct{},
+) *throttledPublisher {
+	p := &throttledPublisher{
+		logger:   logger,
+		throttle: throttle,
+		publish:  publish,
+		queue:    make(chan []byte, throttledQueueSize),
+		closeCh:  closeCh,
+	}
+
+	go p.run()
+
+	return p
+}
+
+// enqueue queues the message for publishing, it fails if the queue is full
+// or the publisher is closed
+func (p *throttledPublisher) enqueue(data []byte) error {
+	select {
+	case <-p.closeCh:
+		return errThrottledPublisherClosed
+	default:
+	}
+
+	select {
+	case p.queue <- data:
+		return nil
+	default:
+		return errThrottledQueueFull
+	}
+}
+
+// run publishes the queued messages as the bandwidth allows.
+// It returns once closeCh is closed, dropping the messages still queued
+func (p *throttledPublisher) run() {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	go func() {
+		<-p.closeCh
+		cancelFn()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-p.queue:
+			// the wait only fails once closed
+			if err := p.throttle.wait(ctx, len(data), false); err != nil {
+				return
+			}
+
+			if err := p.publish(data); err != nil {
+				p.logger.Error("failed to publish throttled gossip", "err", err)
+			}
+		}
+	}
+}
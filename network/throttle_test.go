@@ -0,0 +1,291 @@
+package network
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is a fake time source for the throttle,
+// its timers fire at once, moving the time forward
+type testClock struct {
+	sync.Mutex
+
+	current time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.current
+}
+
+func (c *testClock) after(d time.Duration) <-chan time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	c.current = c.current.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.current
+
+	return ch
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.current = c.current.Add(d)
+}
+
+// newTestThrottle returns a throttle running on a fake clock
+func newTestThrottle(bytesPerSec uint64) (*gossipThrottle, *testClock) {
+	clock := &testClock{current: time.Now()}
+
+	throttle := newGossipThrottle(bytesPerSec)
+	throttle.now = clock.now
+	throttle.after = clock.after
+
+	return throttle, clock
+}
+
+// blockTimers makes the throttle wait forever for the tokens
+func blockTimers(throttle *gossipThrottle) {
+	throttle.after = func(time.Duration) <-chan time.Time {
+		return nil
+	}
+}
+
+func TestGossipThrottle_Disabled(t *testing.T) {
+	throttle := newGossipThrottle(0)
+	assert.Nil(t, throttle)
+
+	// a nil throttle never blocks
+	assert.NoError(t, throttle.wait(context.Background(), 1<<20, false))
+}
+
+func TestGossipThrottle_ThroughputCap(t *testing.T) {
+	var (
+		bandwidth   = uint64(20000)
+		messageSize = 1000
+		numMessages = 50
+	)
+
+	throttle, clock := newTestThrottle(bandwidth)
+
+	start := clock.now()
+
+	for i := 0; i < numMessages; i++ {
+		assert.NoError(t, throttle.wait(context.Background(), messageSize, false))
+	}
+
+	elapsed := clock.now().Sub(start)
+
+	// the bucket holds one second of traffic upfront,
+	// the rest is paced at the configured rate
+	sent := float64(messageSize * numMessages)
+	allowed := float64(bandwidth) * (elapsed.Seconds() + 1)
+
+	assert.LessOrEqual(t, sent, allowed)
+	assert.GreaterOrEqual(t, elapsed, 1400*time.Millisecond)
+}
+
+func TestGossipThrottle_PriorityMessages(t *testing.T) {
+	var (
+		bandwidth   = uint64(10000)
+		messageSize = 1000
+	)
+
+	throttle, clock := newTestThrottle(bandwidth)
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), int(bandwidth), false))
+
+	start := clock.now()
+
+	// the priority messages go through without waiting for the tokens
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, throttle.wait(context.Background(), messageSize, true))
+	}
+
+	assert.Equal(t, start, clock.now())
+
+	// while a regular message has to wait for the tokens
+	// taken by the priority messages to be refilled
+	assert.NoError(t, throttle.wait(context.Background(), messageSize, false))
+	assert.GreaterOrEqual(t, clock.now().Sub(start), 500*time.Millisecond)
+}
+
+func TestGossipThrottle_OversizedMessage(t *testing.T) {
+	throttle, _ := newTestThrottle(100)
+	blockTimers(throttle)
+
+	// a message larger than the bucket is still sent
+	assert.NoError(t, throttle.wait(context.Background(), 1000, false))
+}
+
+func TestGossipThrottle_Canceled(t *testing.T) {
+	bandwidth := uint64(1000)
+
+	throttle, clock := newTestThrottle(bandwidth)
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), int(bandwidth), false))
+
+	// a canceled message doesn't keep its tokens
+	blockTimers(throttle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, throttle.wait(ctx, 500, false), context.Canceled)
+
+	// so the refilled tokens are left for the next message
+	throttle.after = clock.after
+	clock.advance(500 * time.Millisecond)
+
+	start := clock.now()
+
+	assert.NoError(t, throttle.wait(context.Background(), 500, false))
+	assert.Equal(t, start, clock.now())
+}
+
+func TestThrottledPublisher(t *testing.T) {
+	var (
+		bandwidth   = uint64(1000)
+		messageSize = 100
+	)
+
+	throttle, clock := newTestThrottle(bandwidth)
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), int(bandwidth), false))
+
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+
+	publishedCh := make(chan []byte, 3)
+	publisher := newThrottledPublisher(hclog.NewNullLogger(), throttle, func(data []byte) error {
+		publishedCh <- data
+
+		return nil
+	}, closeCh)
+
+	start := clock.now()
+
+	// the publishers don't wait for the bandwidth
+	for i := 0; i < 3; i++ {
+		message := make([]byte, messageSize)
+		message[0] = byte(i)
+
+		assert.NoError(t, publisher.enqueue(message))
+	}
+
+	// the messages are published in order, as the bandwidth allows
+	for i := 0; i < 3; i++ {
+		message := <-publishedCh
+		assert.Equal(t, byte(i), message[0])
+	}
+
+	assert.GreaterOrEqual(t, clock.now().Sub(start), 300*time.Millisecond)
+}
+
+func TestThrottledPublisher_QueueFull(t *testing.T) {
+	throttle, _ := newTestThrottle(1)
+	blockTimers(throttle)
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), 1, false))
+
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+
+	publisher := newThrottledPublisher(hclog.NewNullLogger(), throttle, func([]byte) error {
+		return nil
+	}, closeCh)
+
+	var err error
+
+	for i := 0; i < throttledQueueSize+2 && err == nil; i++ {
+		err = publisher.enqueue([]byte{0})
+	}
+
+	assert.ErrorIs(t, err, errThrottledQueueFull)
+}
+
+func TestThrottledPublisher_Close(t *testing.T) {
+	throttle, _ := newTestThrottle(1)
+	blockTimers(throttle)
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), 1, false))
+
+	closeCh := make(chan struct{})
+
+	publisher := &throttledPublisher{
+		logger:   hclog.NewNullLogger(),
+		throttle: throttle,
+		publish: func([]byte) error {
+			t.Error("message published after close")
+
+			return nil
+		},
+		queue:   make(chan []byte, throttledQueueSize),
+		closeCh: closeCh,
+	}
+
+	doneCh := make(chan struct{})
+
+	go func() {
+		publisher.run()
+		close(doneCh)
+	}()
+
+	// the message waits for the bandwidth until the publisher is closed
+	assert.NoError(t, publisher.enqueue([]byte{0}))
+
+	close(closeCh)
+	<-doneCh
+
+	assert.ErrorIs(t, publisher.enqueue([]byte{0}), errThrottledPublisherClosed)
+}
+
+// pooledTxsServer serves the given transactions to every fetch request
+type pooledTxsServer struct {
+	proto.UnimplementedTxAnnounceServer
+
+	txs [][]byte
+}
+
+func (s *pooledTxsServer) Fetch(context.Context, *proto.GetPooledTxs) (*proto.PooledTxs, error) {
+	return &proto.PooledTxs{Txs: s.txs}, nil
+}
+
+func TestThrottledTxAnnounceServer(t *testing.T) {
+	bandwidth := uint64(10000)
+
+	throttle, clock := newTestThrottle(bandwidth)
+	server := &throttledTxAnnounceServer{
+		TxAnnounceServer: &pooledTxsServer{txs: [][]byte{make([]byte, 5000)}},
+		throttle:         throttle,
+	}
+
+	// the regular gossip drains the bucket
+	assert.NoError(t, throttle.wait(context.Background(), int(bandwidth), false))
+
+	start := clock.now()
+
+	// the served transactions wait for the bandwidth like the gossip
+	resp, err := server.Fetch(context.Background(), &proto.GetPooledTxs{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Txs, 1)
+
+	assert.GreaterOrEqual(t, clock.now().Sub(start), 400*time.Millisecond)
+}
@@ -0,0 +1,122 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+)
+
+const (
+	// trustedPeerMinBackoff is the delay before the first re-dial of a dropped trusted peer,
+	// and the interval in which the connections to the trusted peers are checked
+	trustedPeerMinBackoff = time.Second
+
+	// trustedPeerMaxBackoff is the maximum delay between the re-dials of a trusted peer
+	trustedPeerMaxBackoff = time.Minute
+
+	// trustedPeerDialTimeout is the timeout of a single trusted peer dial
+	trustedPeerDialTimeout = 10 * time.Second
+)
+
+// trustedPeersWrapper holds the peers the node always keeps a connection to
+type trustedPeersWrapper struct {
+	// trustedPeersMap is a map used for quick trusted peer lookup.
+	// It is initialized once on startup and not modified afterwards
+	trustedPeersMap map[peer.ID]*peer.AddrInfo
+}
+
+// isTrusted checks if the node ID belongs to a trusted peer
+func (tw *trustedPeersWrapper) isTrusted(nodeID peer.ID) bool {
+	_, ok := tw.trustedPeersMap[nodeID]
+
+	return ok
+}
+
+// setupTrustedPeers parses the trusted peers from the configuration
+func (s *Server) setupTrustedPeers() error {
+	trustedPeersMap := make(map[peer.ID]*peer.AddrInfo)
+
+	for _, rawAddr := range s.config.TrustedPeers {
+		trustedPeer, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("failed to parse trusted peer %s: %w", rawAddr, err)
+		}
+
+		if trustedPeer.ID == s.host.ID() {
+			s.logger.Info("Omitting trusted peer with same ID as host", "id", trustedPeer.ID)
+
+			continue
+		}
+
+		trustedPeersMap[trustedPeer.ID] = trustedPeer
+	}
+
+	s.trustedPeers = &trustedPeersWrapper{
+		trustedPeersMap: trustedPeersMap,
+	}
+
+	return nil
+}
+
+// IsTrustedPeer checks if the peer is a trusted peer. Trusted peers are
+// not subject to the connection limits nor disconnected for misbehaving
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.trustedPeers.isTrusted(peerID)
+}
+
+// keepTrustedPeerConnected dials the trusted peer whenever it is not connected,
+// backing off exponentially while the dials keep failing
+func (s *Server) keepTrustedPeerConnected(peerInfo *peer.AddrInfo) {
+	s.host.Peerstore().AddAddrs(peerInfo.ID, peerInfo.Addrs, peerstore.PermanentAddrTTL)
+
+	backoff := trustedPeerMinBackoff
+	delay := time.Duration(0)
+
+	for {
+		select {
+		case <-time.After(delay):
+		case <-s.closeCh:
+			return
+		}
+
+		if s.hasPeer(peerInfo.ID) {
+			backoff = trustedPeerMinBackoff
+			delay = trustedPeerMinBackoff
+
+			continue
+		}
+
+		// the peer is added once the identity handshake is done,
+		// so a successful dial is checked on the next iteration as well
+		if s.dialTrustedPeer(peerInfo) {
+			delay = trustedPeerMinBackoff
+
+			continue
+		}
+
+		delay = backoff
+
+		if backoff *= 2; backoff > trustedPeerMaxBackoff {
+			backoff = trustedPeerMaxBackoff
+		}
+	}
+}
+
+// dialTrustedPeer connects to the trusted peer, bypassing the dial queue
+// so the dial doesn't wait for a free outbound slot
+func (s *Server) dialTrustedPeer(peerInfo *peer.AddrInfo) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), trustedPeerDialTimeout)
+	defer cancel()
+
+	if err := s.host.Connect(ctx, *peerInfo); err != nil {
+		s.logger.Debug("failed to dial trusted peer", "id", peerInfo.ID, "err", err)
+
+		return false
+	}
+
+	return true
+}
@@ -547,6 +547,14 @@ func (s *Syncer) findCommonAncestor(clt proto.V1Client, status *Status) (*types.
 		} else {
 			expectedHeader, ok := s.blockchain.GetHeaderByNumber(m)
 			if !ok {
+				if m < h.Number {
+					// the local chain starts from a state snapshot above m,
+					// so the common ancestor can only be higher
+					min = m + 1
+
+					continue
+				}
+
 				return nil, nil, fmt.Errorf("cannot find the header %d in local chain", m)
 			}
 			if expectedHeader.Hash == found.Hash {
@@ -603,6 +611,7 @@ func (s *Syncer) WatchSyncWithPeer(p *SyncPeer, handler func(b *types.Block) boo
 
 		if err := s.blockchain.WriteBlock(b); err != nil {
 			s.logger.Error("failed to write block", "err", err)
+			s.reportInvalidBlock(p.peer, err)
 
 			break
 		}
@@ -613,6 +622,14 @@ func (s *Syncer) WatchSyncWithPeer(p *SyncPeer, handler func(b *types.Block) boo
 	}
 }
 
+// reportInvalidBlock penalizes the peer if the block
+// it sent was rejected for failing the verification
+func (s *Syncer) reportInvalidBlock(peerID peer.ID, err error) {
+	if errors.Is(err, blockchain.ErrInvalidBlock) {
+		s.server.ReportMisbehavior(peerID, network.MisbehaviorInvalidBlock)
+	}
+}
+
 func (s *Syncer) logSyncPeerPopBlockError(err error, peer *SyncPeer) {
 	if errors.Is(err, ErrPopTimeout) {
 		msg := "failed to pop block within %ds from peer: id=%s, please check if all the validators are running"
@@ -677,6 +694,8 @@ func (s *Syncer) BulkSyncWithPeer(p *SyncPeer, newBlockHandler func(block *types
 			for _, slot := range sk.slots {
 				for _, block := range slot.blocks {
 					if err := s.blockchain.WriteBlock(block); err != nil {
+						s.reportInvalidBlock(p.peer, err)
+
 						return fmt.Errorf("failed to write bulk sync blocks: %w", err)
 					}
 
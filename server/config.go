@@ -2,10 +2,12 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -17,25 +19,45 @@ const DefaultJSONRPCPort int = 8545
 type Config struct {
 	Chain *chain.Chain
 
-	JSONRPC    *JSONRPC
-	GRPCAddr   *net.TCPAddr
-	LibP2PAddr *net.TCPAddr
+	JSONRPC        *JSONRPC
+	GRPCAddr       *net.TCPAddr
+	GRPCReflection bool
+	LibP2PAddr     *net.TCPAddr
 
-	PriceLimit uint64
-	MaxSlots   uint64
-	BlockTime  uint64
+	PriceLimit          uint64
+	MaxSlots            uint64
+	MaxAccountSlots     uint64
+	PriceBump           uint64
+	TxLifetime          uint64
+	TxGossipBatch       uint64
+	TxJournal           bool
+	TxJournalRemote     bool
+	TxRejournal         uint64
+	TxGasMargin         uint64
+	TxRejectUnprotected bool // reject txs without replay protection
+	BlockTime           uint64
+	RoundTimeout        uint64
+	RoundBackoff        uint64
+	RetainBlocks        uint64
+	RetainReceipts      uint64
+	MaxReorgDepth       uint64
+	ParallelWorkers     uint64
 
 	Telemetry *Telemetry
 	Network   *network.Config
 
-	DataDir     string
-	RestoreFile *string
+	DataDir      string
+	RestoreFile  *string
+	SnapshotFile string
 
 	Seal bool
 
 	SecretsManager *secrets.SecretsManagerConfig
 
 	LogLevel hclog.Level
+
+	// JSONLogFormat formats the logs as JSON
+	JSONLogFormat bool
 }
 
 // Telemetry holds the config details for metric services
@@ -46,5 +68,16 @@ type Telemetry struct {
 // JSONRPC holds the config details for the JSON-RPC server
 type JSONRPC struct {
 	JSONRPCAddr              *net.TCPAddr
+	WSAddr                   *net.TCPAddr
+	WSMaxConnections         uint64
 	AccessControlAllowOrigin []string
+	TxRateLimit              uint64
+	TxRateBurst              uint64
+	GasCap                   uint64
+	MaxRequestBytes          int64
+	ExecutionTimeout         time.Duration
+	ShutdownTimeout          time.Duration
+	Namespaces               []string
+	HealthMaxBlockAge        time.Duration
+	GasPriceOracle           *jsonrpc.GasPriceOracleConfig
 }
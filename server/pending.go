@@ -0,0 +1,198 @@
+package server
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// pendingBlockAssembler assembles the pending block by applying the executable
+// transactions of the pool on top of the latest state, so that the JSON-RPC
+// queries can target the pending block.
+// The assembled block is cached until the head or the executable transactions of the pool change.
+// Its state is committed in memory only, and dropped once the next pending block is assembled
+type pendingBlockAssembler struct {
+	logger     hclog.Logger
+	blockchain *blockchain.Blockchain
+	state      *itrie.State
+	executor   *state.Executor
+	txpool     *txpool.TxPool
+
+	lock sync.Mutex
+
+	// header is the header of the last assembled pending block
+	header *types.Header
+
+	// fingerprint identifies the head and the pool transactions
+	// the last pending block was assembled from
+	fingerprint types.Hash
+}
+
+func newPendingBlockAssembler(
+	logger hclog.Logger,
+	blockchain *blockchain.Blockchain,
+	state *itrie.State,
+	executor *state.Executor,
+	txpool *txpool.TxPool,
+) *pendingBlockAssembler {
+	return &pendingBlockAssembler{
+		logger:     logger.Named("pending"),
+		blockchain: blockchain,
+		state:      state,
+		executor:   executor,
+		txpool:     txpool,
+	}
+}
+
+// PendingHeader returns the header of the pending block, whose state root
+// is the state after applying the executable transactions of the pool [Thread safe]
+func (p *pendingBlockAssembler) PendingHeader() (*types.Header, error) {
+	parent := p.blockchain.Header()
+	promoted, _ := p.txpool.GetTxs(false)
+
+	txs := sortPendingTxs(promoted)
+	fingerprint := pendingFingerprint(parent, txs)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.header != nil && p.fingerprint == fingerprint {
+		return p.header, nil
+	}
+
+	header, err := p.assemble(parent, txs)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.header != nil && p.header.StateRoot != header.StateRoot {
+		p.state.ReleaseTransient(p.header.StateRoot)
+	}
+
+	p.header = header
+	p.fingerprint = fingerprint
+
+	return header, nil
+}
+
+// assemble applies the transactions on top of the parent state,
+// and returns the header of the resulting block
+func (p *pendingBlockAssembler) assemble(parent *types.Header, txs []*types.Transaction) (*types.Header, error) {
+	// the proposer of the pending block is not known yet,
+	// the creator of the latest block receives the fees instead
+	coinbase, err := p.blockchain.GetConsensus().GetBlockCreator(parent)
+	if err != nil {
+		coinbase = types.ZeroAddress
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Miner:      coinbase,
+		Difficulty: parent.Difficulty,
+		Sha3Uncles: types.EmptyUncleHash,
+		Timestamp:  uint64(time.Now().Unix()),
+		BaseFee:    p.blockchain.CalcBaseFee(parent),
+	}
+
+	if header.Timestamp < parent.Timestamp {
+		header.Timestamp = parent.Timestamp
+	}
+
+	if header.GasLimit, err = p.blockchain.CalculateGasLimit(header.Number); err != nil {
+		return nil, err
+	}
+
+	transition, err := p.executor.BeginTxn(parent.StateRoot, header, coinbase)
+	if err != nil {
+		return nil, err
+	}
+
+	// the pending state is not written to the storage
+	snap, err := p.state.NewTransientSnapshotAt(parent.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.SetTxn(state.NewTxn(p.state, snap))
+
+	included := 0
+
+	for i := 0; i < len(txs); i++ {
+		tx := txs[i]
+
+		if tx.ExceedsBlockGasLimit(header.GasLimit) {
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			// the following transactions of the account can't be applied without this one
+			for i+1 < len(txs) && txs[i+1].From == tx.From {
+				i++
+			}
+
+			continue
+		}
+
+		included++
+	}
+
+	_, root := transition.Commit()
+
+	header.StateRoot = root
+	header.GasUsed = transition.TotalGas()
+	header.ComputeHash()
+
+	p.logger.Debug("assembled pending block", "number", header.Number, "txns", included)
+
+	return header, nil
+}
+
+// sortPendingTxs flattens the executable transactions of the pool,
+// ordering them by sender address and then by nonce
+func sortPendingTxs(promoted map[types.Address][]*types.Transaction) []*types.Transaction {
+	addrs := make([]types.Address, 0, len(promoted))
+	for addr := range promoted {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	txs := []*types.Transaction{}
+
+	for _, addr := range addrs {
+		accountTxs := promoted[addr]
+
+		sort.Slice(accountTxs, func(i, j int) bool {
+			return accountTxs[i].Nonce < accountTxs[j].Nonce
+		})
+
+		txs = append(txs, accountTxs...)
+	}
+
+	return txs
+}
+
+// pendingFingerprint hashes the parent header and the transactions the pending block is assembled from
+func pendingFingerprint(parent *types.Header, txs []*types.Transaction) types.Hash {
+	buf := make([]byte, 0, types.HashLength*(len(txs)+1))
+	buf = append(buf, parent.Hash.Bytes()...)
+
+	for _, tx := range txs {
+		buf = append(buf, tx.Hash.Bytes()...)
+	}
+
+	return types.BytesToHash(keccak.Keccak256(nil, buf))
+}
@@ -33,6 +33,10 @@ type SystemClient interface {
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// ExportSnapshot returns the state snapshot at a block
+	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (System_ExportSnapshotClient, error)
+	// Version returns the version of the client and of the API it serves
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
 }
 
 type systemClient struct {
@@ -152,6 +156,47 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (System_ExportSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[2], "/v1.System/ExportSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemExportSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_ExportSnapshotClient interface {
+	Recv() (*ExportSnapshotEvent, error)
+	grpc.ClientStream
+}
+
+type systemExportSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemExportSnapshotClient) Recv() (*ExportSnapshotEvent, error) {
+	m := new(ExportSnapshotEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *systemClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -170,6 +215,10 @@ type SystemServer interface {
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// ExportSnapshot returns the state snapshot at a block
+	ExportSnapshot(*ExportSnapshotRequest, System_ExportSnapshotServer) error
+	// Version returns the version of the client and of the API it serves
+	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -198,6 +247,12 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) ExportSnapshot(*ExportSnapshotRequest, System_ExportSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSnapshot not implemented")
+}
+func (UnimplementedSystemServer) Version(context.Context, *emptypb.Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -343,6 +398,45 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_ExportSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).ExportSnapshot(m, &systemExportSnapshotServer{stream})
+}
+
+type System_ExportSnapshotServer interface {
+	Send(*ExportSnapshotEvent) error
+	grpc.ServerStream
+}
+
+type systemExportSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemExportSnapshotServer) Send(m *ExportSnapshotEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _System_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -370,6 +464,10 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _System_Version_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -382,6 +480,11 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _System_Export_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportSnapshot",
+			Handler:       _System_ExportSnapshot_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "system.proto",
 }
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// statePruner removes the state of the blocks that fall out of the retention window.
+// The pruning runs once every retain blocks, so the state of up to 2 * retain blocks is kept
+type statePruner struct {
+	logger     hclog.Logger
+	state      *itrie.State
+	blockchain *blockchain.Blockchain
+
+	// retain is the number of latest blocks whose state is kept
+	retain uint64
+
+	// lastPruned is the head at the last pruning
+	lastPruned uint64
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	state *itrie.State,
+	blockchain *blockchain.Blockchain,
+	retain uint64,
+) *statePruner {
+	return &statePruner{
+		logger:     logger.Named("pruner"),
+		state:      state,
+		blockchain: blockchain,
+		retain:     retain,
+		closeCh:    make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// start prunes the state as the head of the chain advances
+func (p *statePruner) start() {
+	followHead(p.blockchain, p.closeCh, p.doneCh, p.maybePrune)
+}
+
+// close stops the pruning and waits for the running one to finish
+func (p *statePruner) close() {
+	close(p.closeCh)
+	<-p.doneCh
+}
+
+func (p *statePruner) maybePrune(head uint64) {
+	if head < p.lastPruned+p.retain {
+		return
+	}
+
+	from := uint64(0)
+	if head >= p.retain {
+		from = head - p.retain + 1
+	}
+
+	roots := make([]types.Hash, 0, p.retain)
+
+	for num := from; num <= head; num++ {
+		header, ok := p.blockchain.GetHeaderByNumber(num)
+		if !ok {
+			p.logger.Error("failed to find the header to retain", "number", num)
+
+			return
+		}
+
+		roots = append(roots, header.StateRoot)
+	}
+
+	deleted, err := p.state.Prune(roots)
+	if err != nil {
+		p.logger.Error("failed to prune the state", "err", err)
+
+		return
+	}
+
+	p.lastPruned = head
+
+	p.logger.Debug("pruned the state", "head", head, "retain", p.retain, "deleted", deleted)
+}
+
+// receiptPruner removes the receipts and the logs of the blocks that fall out of the retention window.
+// The retention window is independent of the state one
+type receiptPruner struct {
+	logger     hclog.Logger
+	blockchain *blockchain.Blockchain
+
+	// retain is the number of latest blocks whose receipts are kept
+	retain uint64
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newReceiptPruner(
+	logger hclog.Logger,
+	blockchain *blockchain.Blockchain,
+	retain uint64,
+) *receiptPruner {
+	return &receiptPruner{
+		logger:     logger.Named("receipt-pruner"),
+		blockchain: blockchain,
+		retain:     retain,
+		closeCh:    make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// start prunes the receipts as the head of the chain advances
+func (p *receiptPruner) start() {
+	followHead(p.blockchain, p.closeCh, p.doneCh, p.prune)
+}
+
+// close stops the pruning and waits for the running one to finish
+func (p *receiptPruner) close() {
+	close(p.closeCh)
+	<-p.doneCh
+}
+
+func (p *receiptPruner) prune(head uint64) {
+	if head < p.retain {
+		return
+	}
+
+	deleted, err := p.blockchain.PruneReceipts(head - p.retain + 1)
+	if err != nil {
+		p.logger.Error("failed to prune the receipts", "err", err)
+
+		return
+	}
+
+	if deleted > 0 {
+		p.logger.Debug("pruned the receipts", "head", head, "retain", p.retain, "deleted", deleted)
+	}
+}
+
+// followHead calls onHead with the number of the head every time the chain advances,
+// until closeCh is closed. doneCh is closed once the last call returns
+func followHead(
+	b *blockchain.Blockchain,
+	closeCh chan struct{},
+	doneCh chan struct{},
+	onHead func(head uint64),
+) {
+	sub := b.SubscribeEvents()
+	eventCh := sub.GetEventCh()
+
+	go func() {
+		defer close(doneCh)
+		defer sub.Close()
+
+		for {
+			select {
+			case evnt := <-eventCh:
+				if evnt.Type == blockchain.EventFork || len(evnt.NewChain) == 0 {
+					continue
+				}
+
+				onHead(b.Header().Number)
+			case <-closeCh:
+				return
+			}
+		}
+	}()
+}
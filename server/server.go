@@ -27,18 +27,21 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 	"math/big"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 // Minimal is the central manager of the blockchain client
 type Server struct {
 	logger       hclog.Logger
 	config       *Config
-	state        state.State
+	state        *itrie.State
 	stateStorage itrie.Storage
 
 	consensus consensus.Consensus
@@ -71,19 +74,27 @@ type Server struct {
 
 	// restore
 	restoreProgression *progress.ProgressionWrapper
+
+	// historical state pruning
+	pruner *statePruner
+
+	// receipt and log pruning
+	receiptPruner *receiptPruner
 }
 
 var dirPaths = []string{
 	"blockchain",
 	"keystore",
 	"trie",
+	"txpool",
 }
 
 // NewServer creates a new Minimal server, using the passed in configuration
 func NewServer(config *Config) (*Server, error) {
 	logger := hclog.New(&hclog.LoggerOptions{
-		Name:  "polygon",
-		Level: config.LogLevel,
+		Name:       "polygon",
+		Level:      config.LogLevel,
+		JSONFormat: config.JSONLogFormat,
 	})
 
 	m := &Server{
@@ -137,11 +148,16 @@ func NewServer(config *Config) (*Server, error) {
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
+	if config.RetainBlocks > 0 {
+		st.EnablePruning()
+	}
+
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
 	m.executor.SetRuntime(precompiled.NewPrecompiled())
 	m.executor.SetRuntime(evm.NewEVM())
+	m.executor.SetParallelWorkers(int(config.ParallelWorkers))
 
 	// compute the genesis root state
 	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
@@ -153,6 +169,8 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	m.blockchain.SetMaxReorgDepth(config.MaxReorgDepth)
+
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
 	{
@@ -161,26 +179,52 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 		// start transaction pool
+		txpoolConfig := &txpool.Config{
+			Sealing:           m.config.Seal,
+			MaxSlots:          m.config.MaxSlots,
+			MaxAccountSlots:   m.config.MaxAccountSlots,
+			PriceBump:         m.config.PriceBump,
+			Lifetime:          time.Duration(m.config.TxLifetime) * time.Second,
+			GossipBatchWindow: time.Duration(m.config.TxGossipBatch) * time.Millisecond,
+			PriceLimit:        m.config.PriceLimit,
+			GasLimitMargin:    m.config.TxGasMargin,
+			RejectUnprotected: m.config.TxRejectUnprotected,
+		}
+
+		if m.config.TxJournal {
+			txpoolConfig.Journal = filepath.Join(m.config.DataDir, "txpool", "transactions.rlp")
+			txpoolConfig.JournalRemotes = m.config.TxJournalRemote
+			txpoolConfig.Rejournal = time.Duration(m.config.TxRejournal) * time.Second
+		}
+
 		m.txpool, err = txpool.NewTxPool(
 			logger,
-			m.chain.Params.Forks.At(0),
+			m.chain.Params.Forks,
 			hub,
 			m.grpcServer,
 			m.network,
 			m.serverMetrics.txpool,
-			&txpool.Config{
-				Sealing:    m.config.Seal,
-				MaxSlots:   m.config.MaxSlots,
-				PriceLimit: m.config.PriceLimit,
-			},
+			txpoolConfig,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		// use the eip155 signer
-		signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
-		m.txpool.SetSigner(signer)
+		// share the recovered senders between the txpool and the executor
+		senderCache, err := crypto.NewSenderCache(crypto.DefaultSenderCacheSize)
+		if err != nil {
+			return nil, err
+		}
+
+		m.executor.SenderCache = senderCache
+
+		// validate transactions against the signing rules of the next block
+		signer := crypto.NewForkSigner(m.config.Chain.Params, func() uint64 {
+			return m.blockchain.Header().Number + 1
+		})
+		signer.SetAllowUnprotected(!m.config.TxRejectUnprotected)
+
+		m.txpool.SetSigner(crypto.NewCachedSigner(signer, senderCache))
 	}
 
 	{
@@ -198,6 +242,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// import the state snapshot before the consensus layer reads the head
+	if err := m.importSnapshot(); err != nil {
+		return nil, err
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -229,9 +278,36 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.txpool.Start()
 
+	if config.RetainBlocks > 0 {
+		m.pruner = newStatePruner(logger, st, m.blockchain, config.RetainBlocks)
+		m.pruner.start()
+	}
+
+	if config.RetainReceipts > 0 {
+		m.receiptPruner = newReceiptPruner(logger, m.blockchain, config.RetainReceipts)
+		m.receiptPruner.start()
+	}
+
 	return m, nil
 }
 
+func (s *Server) importSnapshot() error {
+	if s.config.SnapshotFile == "" {
+		return nil
+	}
+
+	header, err := archive.ImportSnapshot(s.blockchain, s.state, s.config.SnapshotFile)
+	if err != nil {
+		return fmt.Errorf("failed to import the state snapshot: %w", err)
+	}
+
+	if header != nil {
+		s.logger.Info("imported the state snapshot", "number", header.Number, "hash", header.Hash)
+	}
+
+	return nil
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
@@ -275,8 +351,12 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 		return nil, fmt.Errorf("unable to get snapshot for root, %w", err)
 	}
 
-	result, ok := snap.Get(keccak.Keccak256(nil, addr.Bytes()))
-	if !ok {
+	result, err := snap.TryGet(keccak.Keccak256(nil, addr.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
 		return big.NewInt(0), nil
 	}
 
@@ -366,6 +446,9 @@ func (s *Server) setupConsensus() error {
 			Metrics:        s.serverMetrics.consensus,
 			SecretsManager: s.secretsManager,
 			BlockTime:      s.config.BlockTime,
+
+			BaseRoundTimeout:    s.config.RoundTimeout,
+			RoundTimeoutBackoff: s.config.RoundBackoff,
 		},
 	)
 
@@ -379,8 +462,9 @@ func (s *Server) setupConsensus() error {
 }
 
 type jsonRPCHub struct {
-	state              state.State
+	state              *itrie.State
 	restoreProgression *progress.ProgressionWrapper
+	pending            *pendingBlockAssembler
 
 	*blockchain.Blockchain
 	*txpool.TxPool
@@ -395,6 +479,32 @@ func (j *jsonRPCHub) GetPeers() int {
 	return len(j.Server.Peers())
 }
 
+func (j *jsonRPCHub) GetPeersInfo() []*jsonrpc.PeerInfo {
+	peers := j.Server.Peers()
+	infos := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		info := &jsonrpc.PeerInfo{
+			ID:         p.Info.ID.String(),
+			Addrs:      []string{},
+			Directions: []string{},
+			Score:      j.Server.GetPeerScore(p.Info.ID),
+		}
+
+		for _, addr := range j.Server.GetPeerInfo(p.Info.ID).Addrs {
+			info.Addrs = append(info.Addrs, addr.String())
+		}
+
+		for _, direction := range j.Server.GetPeerDirections(p.Info.ID) {
+			info.Directions = append(info.Directions, strings.ToLower(direction.String()))
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
 func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 	// the values in the trie are the hashed objects of the keys
 	key := keccak.Keccak256(nil, slot)
@@ -404,9 +514,12 @@ func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	result, ok := snap.Get(key)
+	result, err := snap.TryGet(key)
+	if err != nil {
+		return nil, err
+	}
 
-	if !ok {
+	if result == nil {
 		return nil, jsonrpc.ErrStateNotFound
 	}
 
@@ -458,11 +571,47 @@ func (j *jsonRPCHub) GetCode(hash types.Hash) ([]byte, error) {
 	return res, nil
 }
 
+// GetProof returns the Merkle proof of the key in the trie with the given root.
+// The trie keys are the hashed keys, the same as in getState
+func (j *jsonRPCHub) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return j.state.Prove(root, keccak.Keccak256(nil, key))
+}
+
+// FinalizedHeader returns the header of the latest finalized block.
+// The blocks sealed by the supported consensuses are final, so it is the latest header
+func (j *jsonRPCHub) FinalizedHeader() *types.Header {
+	return j.Header()
+}
+
+// SafeHeader returns the header of the latest block which is safe from reorganizations,
+// which is the finalized one
+func (j *jsonRPCHub) SafeHeader() *types.Header {
+	return j.FinalizedHeader()
+}
+
+// PendingHeader returns the header of the pending block,
+// assembled from the executable transactions of the pool
+func (j *jsonRPCHub) PendingHeader() (*types.Header, error) {
+	return j.pending.PendingHeader()
+}
+
+// getBlockCreator returns the creator of the block. The pending block isn't sealed,
+// so its creator is the miner it was assembled with
+func (j *jsonRPCHub) getBlockCreator(header *types.Header) (types.Address, error) {
+	if header.Number > j.Header().Number {
+		return header.Miner, nil
+	}
+
+	return j.GetConsensus().GetBlockCreator(header)
+}
+
 func (j *jsonRPCHub) ApplyTxn(
+	ctx context.Context,
 	header *types.Header,
 	txn *types.Transaction,
+	override types.StateOverride,
 ) (result *runtime.ExecutionResult, err error) {
-	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	blockCreator, err := j.getBlockCreator(header)
 	if err != nil {
 		return nil, err
 	}
@@ -473,9 +622,208 @@ func (j *jsonRPCHub) ApplyTxn(
 		return
 	}
 
-	result, err = transition.Apply(txn)
+	// the simulated calls need not pay the base fee
+	transition.SetNoBaseFee()
+
+	if override != nil {
+		if err = transition.ApplyStateOverride(override); err != nil {
+			return
+		}
+	}
+
+	return applyWithContext(ctx, transition, txn)
+}
+
+// applyWithContext applies the transaction, stopping the execution
+// once the request is cancelled or timed out
+func applyWithContext(
+	ctx context.Context,
+	transition *state.Transition,
+	txn *types.Transaction,
+) (*runtime.ExecutionResult, error) {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			transition.Cancel()
+		case <-done:
+		}
+	}()
 
-	return
+	result, err := transition.Apply(txn)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	return result, err
+}
+
+// SimulateBundle writes the transactions in order over the state of the given block,
+// so that each one executes over the changes of the previous ones.
+// Nothing is committed, and the execution stops once the context is done
+func (j *jsonRPCHub) SimulateBundle(
+	ctx context.Context,
+	header *types.Header,
+	txns []*types.Transaction,
+) ([]*types.Receipt, []*runtime.ExecutionResult, error) {
+	blockCreator, err := j.getBlockCreator(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			transition.Cancel()
+		case <-done:
+		}
+	}()
+
+	results := make([]*runtime.ExecutionResult, len(txns))
+
+	for i, txn := range txns {
+		result, err := transition.Execute(txn)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		results[i] = result
+	}
+
+	return transition.Receipts(), results, nil
+}
+
+// CreateAccessList applies the transaction with the access list it touches, until applying
+// it with the access list doesn't touch anything else. The execution stops once the context is done
+func (j *jsonRPCHub) CreateAccessList(
+	ctx context.Context,
+	header *types.Header,
+	txn *types.Transaction,
+) (types.AccessList, *runtime.ExecutionResult, error) {
+	blockCreator, err := j.getBlockCreator(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the sender, the recipient and the precompiles are always warm
+	to := crypto.CreateAddress(txn.From, txn.Nonce)
+	if txn.To != nil {
+		to = *txn.To
+	}
+
+	forks := j.GetForksInTime(header.Number)
+	excluded := append(precompiled.NewPrecompiled().Addresses(&forks), txn.From, to)
+
+	list := txn.AccessList
+
+	for {
+		tracer := state.NewAccessListTracer(list, excluded...)
+
+		transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		transition.SetAccessListTracer(tracer)
+		transition.SetNoBaseFee()
+
+		msg := txn.Copy()
+		msg.AccessList = list
+
+		result, err := applyWithContext(ctx, transition, msg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the access list only grows, so the loop stops
+		// once the touched accesses are all in the list
+		if tracer.Equal(list) {
+			return list, result, nil
+		}
+
+		list = tracer.AccessList()
+	}
+}
+
+// TraceTxn re-executes the transactions of the block up to the one with the given hash,
+// and applies it with the tracer set. The execution stops once the context is done
+func (j *jsonRPCHub) TraceTxn(
+	ctx context.Context,
+	block *types.Block,
+	txHash types.Hash,
+	tracer runtime.Tracer,
+) (*runtime.ExecutionResult, error) {
+	parentHeader, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, fmt.Errorf("parent header of block %d not found", block.Number())
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.BeginTxn(parentHeader.StateRoot, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			transition.Cancel()
+		case <-done:
+		}
+	}()
+
+	for _, tx := range block.Transactions {
+		if tx.Hash == txHash {
+			transition.SetTracer(tracer)
+
+			result, err := transition.Apply(tx.Copy())
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			return result, err
+		}
+
+		// same as the block processing
+		if tx.ExceedsBlockGasLimit(block.Header.GasLimit) {
+			if err := transition.WriteFailedReceipt(tx); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("transaction %s not found in block %d", txHash, block.Number())
 }
 
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
@@ -499,6 +847,7 @@ func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
+		pending:            newPendingBlockAssembler(s.logger, s.blockchain, s.state, s.executor, s.txpool),
 		Blockchain:         s.blockchain,
 		TxPool:             s.txpool,
 		Executor:           s.executor,
@@ -509,8 +858,19 @@ func (s *Server) setupJSONRPC() error {
 	conf := &jsonrpc.Config{
 		Store:                    hub,
 		Addr:                     s.config.JSONRPC.JSONRPCAddr,
+		WSAddr:                   s.config.JSONRPC.WSAddr,
+		WSMaxConnections:         s.config.JSONRPC.WSMaxConnections,
 		ChainID:                  uint64(s.config.Chain.Params.ChainID),
 		AccessControlAllowOrigin: s.config.JSONRPC.AccessControlAllowOrigin,
+		TxRateLimit:              s.config.JSONRPC.TxRateLimit,
+		TxRateBurst:              s.config.JSONRPC.TxRateBurst,
+		GasCap:                   s.config.JSONRPC.GasCap,
+		MaxRequestBytes:          s.config.JSONRPC.MaxRequestBytes,
+		ExecutionTimeout:         s.config.JSONRPC.ExecutionTimeout,
+		ShutdownTimeout:          s.config.JSONRPC.ShutdownTimeout,
+		Namespaces:               s.config.JSONRPC.Namespaces,
+		HealthMaxBlockAge:        s.config.JSONRPC.HealthMaxBlockAge,
+		GasPriceOracle:           s.config.JSONRPC.GasPriceOracle,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -527,6 +887,10 @@ func (s *Server) setupJSONRPC() error {
 func (s *Server) setupGRPC() error {
 	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
 
+	if s.config.GRPCReflection {
+		reflection.Register(s.grpcServer)
+	}
+
 	lis, err := net.Listen("tcp", s.config.GRPCAddr.String())
 	if err != nil {
 		return err
@@ -555,6 +919,18 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	// Drain the JSON-RPC requests before closing the layers they read from
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Close(); err != nil {
+			s.logger.Error("failed to close JSON-RPC server", "err", err.Error())
+		}
+	}
+
+	// Stop the receipt pruning before closing the blockchain storage
+	if s.receiptPruner != nil {
+		s.receiptPruner.close()
+	}
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -570,6 +946,11 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Stop the state pruning before closing its storage
+	if s.pruner != nil {
+		s.pruner.close()
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/libp2p/go-libp2p-core/peer"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
@@ -41,6 +43,16 @@ func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto
 	return status, nil
 }
 
+// Version returns the build version and commit of the client,
+// along with the version of the operator API it serves
+func (s *systemService) Version(context.Context, *empty.Empty) (*proto.VersionResponse, error) {
+	return &proto.VersionResponse{
+		Version:         versioning.Version,
+		Commit:          versioning.Commit,
+		ProtocolVersion: versioning.ProtocolVersion,
+	}, nil
+}
+
 // Subscribe implements the blockchain event subscription service
 func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_SubscribeServer) error {
 	sub := s.server.blockchain.SubscribeEvents()
@@ -221,6 +233,31 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// ExportSnapshot streams the state snapshot at the requested block
+func (s *systemService) ExportSnapshot(
+	req *proto.ExportSnapshotRequest,
+	stream proto.System_ExportSnapshotServer,
+) error {
+	header := s.server.blockchain.Header()
+
+	if req.Number != 0 {
+		var ok bool
+
+		if header, ok = s.server.blockchain.GetHeaderByNumber(req.Number); !ok {
+			return fmt.Errorf("block %d not found", req.Number)
+		}
+	}
+
+	// leave room for the other fields of the event
+	writer := newSnapshotStreamWriter(stream, header, defaultMaxGRPCPayloadSize-1024)
+
+	if err := archive.ExportSnapshot(writer, s.server.blockchain, s.server.state, header); err != nil {
+		return err
+	}
+
+	return writer.flush()
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 4 * 1024 * 1024 // 4MB
 )
@@ -301,3 +338,67 @@ func (w *blockStreamWriter) reset() {
 	w.pendingFrom = nil
 	w.pendingTo = nil
 }
+
+// snapshotStreamWriter sends the written snapshot data in chunks of the max payload size
+type snapshotStreamWriter struct {
+	buf        bytes.Buffer
+	stream     proto.System_ExportSnapshotServer
+	header     *types.Header
+	maxPayload uint64
+}
+
+func newSnapshotStreamWriter(
+	stream proto.System_ExportSnapshotServer,
+	header *types.Header,
+	maxPayload uint64,
+) *snapshotStreamWriter {
+	return &snapshotStreamWriter{
+		buf:        *bytes.NewBuffer(make([]byte, 0, maxPayload)),
+		stream:     stream,
+		header:     header,
+		maxPayload: maxPayload,
+	}
+}
+
+func (w *snapshotStreamWriter) Write(data []byte) (int, error) {
+	written := 0
+
+	// the snapshot is a plain byte stream, so the data can be split at any point
+	for len(data) > 0 {
+		n := int(w.maxPayload) - w.buf.Len()
+		if n > len(data) {
+			n = len(data)
+		}
+
+		w.buf.Write(data[:n])
+		data = data[n:]
+		written += n
+
+		if uint64(w.buf.Len()) >= w.maxPayload {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+func (w *snapshotStreamWriter) flush() error {
+	// nothing happens in case of empty buffer
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	if err := w.stream.Send(&proto.ExportSnapshotEvent{
+		Number: w.header.Number,
+		Hash:   w.header.Hash.String(),
+		Data:   w.buf.Bytes(),
+	}); err != nil {
+		return err
+	}
+
+	w.buf.Reset()
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/versioning"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const reflectionServiceName = "grpc.reflection.v1alpha.ServerReflection"
+
+func TestSystemService_Version(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	proto.RegisterSystemServer(grpcServer, &systemService{})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	resp, err := proto.NewSystemClient(conn).Version(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, resp.Version)
+	assert.NotEmpty(t, resp.Commit)
+	assert.NotEmpty(t, resp.ProtocolVersion)
+
+	assert.Equal(t, versioning.Version, resp.Version)
+	assert.Equal(t, versioning.Commit, resp.Commit)
+	assert.Equal(t, versioning.ProtocolVersion, resp.ProtocolVersion)
+}
+
+func TestServer_SetupGRPC_Reflection(t *testing.T) {
+	testCases := []struct {
+		name       string
+		reflection bool
+	}{
+		{"reflection enabled", true},
+		{"reflection disabled", false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			s := &Server{
+				logger:     hclog.NewNullLogger(),
+				grpcServer: grpc.NewServer(),
+				config: &Config{
+					GRPCAddr:       &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 0},
+					GRPCReflection: testCase.reflection,
+				},
+			}
+
+			assert.NoError(t, s.setupGRPC())
+			t.Cleanup(s.grpcServer.Stop)
+
+			services := s.grpcServer.GetServiceInfo()
+
+			assert.Contains(t, services, "v1.System")
+
+			_, ok := services[reflectionServiceName]
+			assert.Equal(t, testCase.reflection, ok)
+		})
+	}
+}
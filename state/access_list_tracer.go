@@ -0,0 +1,114 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AccessListTracer records the addresses and storage slots
+// touched by the transactions applied in a transition (EIP-2930)
+type AccessListTracer struct {
+	// addresses which are warm regardless of the access list
+	// (sender, recipient, precompiles). Their storage slots are still recorded
+	excluded map[types.Address]struct{}
+
+	slots map[types.Address]map[types.Hash]struct{}
+}
+
+// NewAccessListTracer creates a tracer seeded with the given access list,
+// which doesn't record the accesses of the excluded addresses
+func NewAccessListTracer(list types.AccessList, excluded ...types.Address) *AccessListTracer {
+	a := &AccessListTracer{
+		excluded: make(map[types.Address]struct{}, len(excluded)),
+		slots:    make(map[types.Address]map[types.Hash]struct{}),
+	}
+
+	for _, addr := range excluded {
+		a.excluded[addr] = struct{}{}
+	}
+
+	for _, tuple := range list {
+		a.addAddress(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			a.addSlot(tuple.Address, key)
+		}
+	}
+
+	return a
+}
+
+// addAddress records an account access
+func (a *AccessListTracer) addAddress(addr types.Address) {
+	if _, ok := a.excluded[addr]; ok {
+		return
+	}
+
+	if _, ok := a.slots[addr]; !ok {
+		a.slots[addr] = make(map[types.Hash]struct{})
+	}
+}
+
+// addSlot records a storage slot access
+func (a *AccessListTracer) addSlot(addr types.Address, key types.Hash) {
+	keys, ok := a.slots[addr]
+	if !ok {
+		keys = make(map[types.Hash]struct{})
+		a.slots[addr] = keys
+	}
+
+	keys[key] = struct{}{}
+}
+
+// AccessList returns the recorded accesses, sorted by address and storage key
+func (a *AccessListTracer) AccessList() types.AccessList {
+	list := make(types.AccessList, 0, len(a.slots))
+
+	for addr, keys := range a.slots {
+		tuple := types.AccessTuple{
+			Address:     addr,
+			StorageKeys: make([]types.Hash, 0, len(keys)),
+		}
+
+		for key := range keys {
+			tuple.StorageKeys = append(tuple.StorageKeys, key)
+		}
+
+		sort.Slice(tuple.StorageKeys, func(i, j int) bool {
+			return bytes.Compare(tuple.StorageKeys[i].Bytes(), tuple.StorageKeys[j].Bytes()) < 0
+		})
+
+		list = append(list, tuple)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].Address.Bytes(), list[j].Address.Bytes()) < 0
+	})
+
+	return list
+}
+
+// Equal checks if the recorded accesses match the access list
+func (a *AccessListTracer) Equal(list types.AccessList) bool {
+	other := NewAccessListTracer(list)
+	if len(other.slots) != len(a.slots) {
+		return false
+	}
+
+	for addr, keys := range a.slots {
+		otherKeys, ok := other.slots[addr]
+		if !ok || len(otherKeys) != len(keys) {
+			return false
+		}
+
+		for key := range keys {
+			if _, ok := otherKeys[key]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -19,6 +20,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address in the access list (EIP-2930)
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key in the access list (EIP-2930)
 )
 
 var emptyCodeHashTwo = types.BytesToHash(crypto.Keccak256(nil))
@@ -36,7 +40,15 @@ type Executor struct {
 	state    State
 	GetHash  GetHashByNumberHelper
 
+	// SenderCache (optional) holds the senders already recovered,
+	// e.g. during txpool admission
+	SenderCache *crypto.SenderCache
+
 	PostHook func(txn *Transition)
+
+	// parallelWorkers is the number of workers executing the
+	// transactions of a block optimistically in parallel
+	parallelWorkers int
 }
 
 // NewExecutor creates a new executor
@@ -76,6 +88,23 @@ func (e *Executor) WriteGenesis(alloc map[types.Address]*chain.GenesisAccount) t
 	return types.BytesToHash(root)
 }
 
+// newSigner returns the signer for the given block height,
+// backed by the sender cache if one is set
+func (e *Executor) newSigner(blockNumber uint64) crypto.TxSigner {
+	signer := crypto.SignerForFork(e.config, blockNumber)
+	if e.SenderCache != nil {
+		return crypto.NewCachedSigner(signer, e.SenderCache)
+	}
+
+	return signer
+}
+
+// SetParallelWorkers sets the number of workers executing the transactions of a block
+// optimistically in parallel. With less than two workers they are executed serially
+func (e *Executor) SetParallelWorkers(workers int) {
+	e.parallelWorkers = workers
+}
+
 // SetRuntime adds a runtime to the runtime set
 func (e *Executor) SetRuntime(r runtime.Runtime) {
 	e.runtimes = append(e.runtimes, r)
@@ -100,6 +129,14 @@ func (e *Executor) ProcessBlock(
 
 	txn.block = block
 
+	if e.parallelWorkers > 1 && e.PostHook == nil && txn.config.Byzantium && len(block.Transactions) > 1 {
+		if err := e.processParallel(txn, parentRoot, block, blockCreator); err != nil {
+			return nil, err
+		}
+
+		return txn, nil
+	}
+
 	for _, t := range block.Transactions {
 		if t.ExceedsBlockGasLimit(block.Header.GasLimit) {
 			if err := txn.WriteFailedReceipt(t); err != nil {
@@ -153,6 +190,7 @@ func (e *Executor) BeginTxn(
 		Difficulty: types.BytesToHash(new(big.Int).SetUint64(header.Difficulty).Bytes()),
 		GasLimit:   int64(header.GasLimit),
 		ChainID:    int64(e.config.ChainID),
+		BaseFee:    header.BaseFee,
 	}
 
 	txn := &Transition{
@@ -191,6 +229,98 @@ type Transition struct {
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
+
+	// records the accessed addresses and storage slots, if set
+	accessListTracer *AccessListTracer
+
+	// receives the executed opcodes, if set
+	tracer runtime.Tracer
+
+	// set once the execution is cancelled, accessed atomically
+	cancelled uint32
+
+	// holds back the coinbase fees instead of paying them, if set
+	coinbaseFee *big.Int
+
+	// lets zero priced messages execute below the base fee, if set
+	noBaseFee bool
+}
+
+// SetNoBaseFee lets the messages with a zero gas price skip the base fee check,
+// as the calls simulated over the RPC do not pay for their gas
+func (t *Transition) SetNoBaseFee() {
+	t.noBaseFee = true
+}
+
+// SetAccessListTracer sets the tracer recording the accesses of the applied transactions
+func (t *Transition) SetAccessListTracer(tracer *AccessListTracer) {
+	t.accessListTracer = tracer
+}
+
+// SetTracer sets the tracer receiving the opcodes executed by the applied transactions
+func (t *Transition) SetTracer(tracer runtime.Tracer) {
+	t.tracer = tracer
+}
+
+// captureEnter reports the entered frame to the tracer, if set
+func (t *Transition) captureEnter(c *runtime.Contract) {
+	if t.tracer != nil {
+		t.tracer.CaptureEnter(c)
+	}
+}
+
+// captureExit reports the result of the frame to the tracer, if set
+func (t *Transition) captureExit(result *runtime.ExecutionResult) {
+	if t.tracer != nil {
+		t.tracer.CaptureExit(result)
+	}
+}
+
+// traceAddress records the account access if the access list is traced
+func (t *Transition) traceAddress(addr types.Address) {
+	if t.accessListTracer != nil {
+		t.accessListTracer.addAddress(addr)
+	}
+}
+
+// traceSlot records the storage slot access if the access list is traced
+func (t *Transition) traceSlot(addr types.Address, key types.Hash) {
+	if t.accessListTracer != nil {
+		t.accessListTracer.addSlot(addr, key)
+	}
+}
+
+// ApplyStateOverride overrides the accounts of the state the transactions are applied on.
+// The overrides live in the transition's pending state, so they are only
+// persisted if the transition is committed
+func (t *Transition) ApplyStateOverride(override types.StateOverride) error {
+	if err := override.Validate(); err != nil {
+		return err
+	}
+
+	for addr, account := range override {
+		if account.Nonce != nil {
+			t.state.SetNonce(addr, *account.Nonce)
+		}
+
+		if account.Code != nil {
+			t.state.SetCode(addr, account.Code)
+		}
+
+		if account.Balance != nil {
+			t.state.SetBalance(addr, account.Balance)
+		}
+
+		if account.State != nil {
+			t.state.SetFullStorage(addr, account.State)
+		}
+
+		for key, value := range account.StateDiff {
+			t.state.SetState(addr, key, value)
+		}
+	}
+
+	return nil
 }
 
 func (t *Transition) TotalGas() uint64 {
@@ -204,7 +334,7 @@ func (t *Transition) Receipts() []*types.Receipt {
 var emptyFrom = types.Address{}
 
 func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
-	signer := crypto.NewSigner(t.config, uint64(t.r.config.ChainID))
+	signer := t.r.newSigner(uint64(t.ctx.Number))
 
 	if txn.From == emptyFrom {
 		// Decrypt the from address
@@ -235,14 +365,22 @@ func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
 
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
-	signer := crypto.NewSigner(t.config, uint64(t.r.config.ChainID))
+	_, err := t.Execute(txn)
+
+	return err
+}
+
+// Execute writes another transaction to the executor like Write,
+// returning the result of its execution
+func (t *Transition) Execute(txn *types.Transaction) (*runtime.ExecutionResult, error) {
+	signer := t.r.newSigner(uint64(t.ctx.Number))
 
 	var err error
 	if txn.From == emptyFrom {
 		// Decrypt the from address
 		txn.From, err = signer.Sender(txn)
 		if err != nil {
-			return NewTransitionApplicationError(err, false)
+			return nil, NewTransitionApplicationError(err, false)
 		}
 	}
 
@@ -253,9 +391,16 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	if e != nil {
 		t.logger.Error("failed to apply tx", "err", e)
 
-		return e
+		return nil, e
 	}
 
+	t.writeReceipt(txn, msg, result)
+
+	return result, nil
+}
+
+// writeReceipt records the receipt of the applied transaction
+func (t *Transition) writeReceipt(txn, msg *types.Transaction, result *runtime.ExecutionResult) {
 	t.totalGas += result.GasUsed
 
 	logs := t.state.Logs()
@@ -266,6 +411,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		CumulativeGasUsed: t.totalGas,
 		TxHash:            txn.Hash,
 		GasUsed:           result.GasUsed,
+		EffectiveGasPrice: t.gasPrice(msg),
 	}
 
 	if t.config.Byzantium {
@@ -293,8 +439,6 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	receipt.Logs = logs
 	receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
 	t.receipts = append(t.receipts, receipt)
-
-	return nil
 }
 
 // Commit commits the final result
@@ -352,9 +496,34 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 	return &t.ctx
 }
 
+// gasPrice returns the price per unit of gas the message pays
+// given the base fee of the block
+func (t *Transition) gasPrice(msg *types.Transaction) *big.Int {
+	return msg.GetGasPrice(t.ctx.BaseFee)
+}
+
+// baseFee returns the base fee the message burns per unit of gas
+func (t *Transition) baseFee(msg *types.Transaction) *big.Int {
+	if !t.config.London || (t.noBaseFee && t.gasPrice(msg).Sign() == 0) {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).SetUint64(t.ctx.BaseFee)
+}
+
+func (t *Transition) feeCapCheck(msg *types.Transaction) error {
+	// the effective price only falls below the base fee
+	// if the fee cap of the message does
+	if t.gasPrice(msg).Cmp(t.baseFee(msg)) < 0 {
+		return ErrFeeCapTooLow
+	}
+
+	return nil
+}
+
 func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	// deduct the upfront max gas cost
-	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
+	upfrontGasCost := t.gasPrice(msg)
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
 	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
@@ -368,6 +537,15 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// CheckTxType checks if the type of the transaction is enabled by the fork rules
+func CheckTxType(msg *types.Transaction, forks chain.ForksInTime) error {
+	if !forks.TxTypeEnabled(msg.Type) {
+		return types.ErrTxTypeNotSupported
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -388,6 +566,7 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrFeeCapTooLow          = fmt.Errorf("max fee per gas less than block base fee")
 )
 
 type TransitionApplicationError struct {
@@ -420,48 +599,60 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// First check this message satisfies all consensus rules before
 	// applying the message. The rules include these clauses
 	//
-	// 1. the nonce of the message caller is correct
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
-	// 3. the amount of gas required is available in the block
-	// 4. there is no overflow when calculating intrinsic gas
-	// 5. the purchased gas is enough to cover intrinsic usage
-	// 6. caller has enough balance to cover asset transfer for **topmost** call
+	// 1. the type of the message is enabled by the fork rules
+	// 2. the nonce of the message caller is correct
+	// 3. the fee cap of the message covers the base fee of the block
+	// 4. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 5. the amount of gas required is available in the block
+	// 6. there is no overflow when calculating intrinsic gas
+	// 7. the purchased gas is enough to cover intrinsic usage
+	// 8. caller has enough balance to cover asset transfer for **topmost** call
 	txn := t.state
 
-	// 1. the nonce of the message caller is correct
+	// 1. the type of the message is enabled by the fork rules
+	if err := CheckTxType(msg, t.config); err != nil {
+		return nil, NewTransitionApplicationError(err, false)
+	}
+
+	// 2. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 3. the fee cap of the message covers the base fee of the block
+	if err := t.feeCapCheck(msg); err != nil {
+		return nil, NewTransitionApplicationError(err, true)
+	}
+
+	// 4. caller has enough balance to cover transaction fee(gaslimit * gasprice)
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
-	// 3. the amount of gas required is available in the block
+	// 5. the amount of gas required is available in the block
 	if err := t.subGasPool(msg.Gas); err != nil {
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
-	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	// 6. there is no overflow when calculating intrinsic gas
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Berlin)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
 
-	// 5. the purchased gas is enough to cover intrinsic usage
+	// 7. the purchased gas is enough to cover intrinsic usage
 	gasLeft := msg.Gas - intrinsicGasCost
 	// Because we are working with unsigned integers for gas, the `>` operator is used instead of the more intuitive `<`
 	if gasLeft > msg.Gas {
 		return nil, NewTransitionApplicationError(ErrNotEnoughIntrinsicGas, false)
 	}
 
-	// 6. caller has enough balance to cover asset transfer for **topmost** call
+	// 8. caller has enough balance to cover asset transfer for **topmost** call
 	if balance := txn.GetBalance(msg.From); balance.Cmp(msg.Value) < 0 {
 		return nil, NewTransitionApplicationError(ErrNotEnoughFunds, true)
 	}
 
-	gasPrice := new(big.Int).Set(msg.GasPrice)
+	gasPrice := t.gasPrice(msg)
 	value := new(big.Int).Set(msg.Value)
 
 	// Set the specific transaction fields in the context
@@ -483,9 +674,10 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 	txn.AddBalance(msg.From, remaining)
 
-	// pay the coinbase
-	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
-	txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	// pay the coinbase the tip, the base fee is burned
+	tip := new(big.Int).Sub(gasPrice, t.baseFee(msg))
+	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), tip)
+	t.payCoinbase(coinbaseFee)
 
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
@@ -493,6 +685,18 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// payCoinbase pays the fee to the coinbase, or holds it back
+// if the transition defers the coinbase fees
+func (t *Transition) payCoinbase(fee *big.Int) {
+	if t.coinbaseFee != nil {
+		t.coinbaseFee.Add(t.coinbaseFee, fee)
+
+		return
+	}
+
+	t.state.AddBalance(t.ctx.Coinbase, fee)
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -501,8 +705,13 @@ func (t *Transition) Create2(
 ) *runtime.ExecutionResult {
 	address := crypto.CreateAddress(caller, t.state.GetNonce(caller))
 	contract := runtime.NewContractCreation(1, caller, caller, address, value, gas, code)
+	contract.Type = runtime.Create
+
+	t.captureEnter(contract)
+	result := t.applyCreate(contract, t)
+	t.captureExit(result)
 
-	return t.applyCreate(contract, t)
+	return result
 }
 
 func (t *Transition) Call2(
@@ -514,7 +723,11 @@ func (t *Transition) Call2(
 ) *runtime.ExecutionResult {
 	c := runtime.NewContractCall(1, caller, caller, to, value, gas, t.state.GetCode(to), input)
 
-	return t.applyCall(c, runtime.Call, t)
+	t.captureEnter(c)
+	result := t.applyCall(c, runtime.Call, t)
+	t.captureExit(result)
+
+	return result
 }
 
 func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
@@ -682,6 +895,8 @@ func (t *Transition) SetStorage(
 	value types.Hash,
 	config *chain.ForksInTime,
 ) runtime.StorageStatus {
+	t.traceSlot(addr, key)
+
 	return t.state.SetStorage(addr, key, value, config)
 }
 
@@ -698,22 +913,32 @@ func (t *Transition) EmitLog(addr types.Address, topics []types.Hash, data []byt
 }
 
 func (t *Transition) GetCodeSize(addr types.Address) int {
+	t.traceAddress(addr)
+
 	return t.state.GetCodeSize(addr)
 }
 
 func (t *Transition) GetCodeHash(addr types.Address) (res types.Hash) {
+	t.traceAddress(addr)
+
 	return t.state.GetCodeHash(addr)
 }
 
 func (t *Transition) GetCode(addr types.Address) []byte {
+	t.traceAddress(addr)
+
 	return t.state.GetCode(addr)
 }
 
 func (t *Transition) GetBalance(addr types.Address) *big.Int {
+	t.traceAddress(addr)
+
 	return t.state.GetBalance(addr)
 }
 
 func (t *Transition) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	t.traceSlot(addr, key)
+
 	return t.state.GetState(addr, key)
 }
 
@@ -729,7 +954,23 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 	return t.state.GetNonce(addr)
 }
 
+func (t *Transition) GetTracer() runtime.Tracer {
+	return t.tracer
+}
+
+// Cancel stops the running execution. It is safe to call from another goroutine
+func (t *Transition) Cancel() {
+	atomic.StoreUint32(&t.cancelled, 1)
+}
+
+// Cancelled returns true once the execution is cancelled
+func (t *Transition) Cancelled() bool {
+	return atomic.LoadUint32(&t.cancelled) == 1
+}
+
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
+	t.traceAddress(beneficiary)
+
 	if !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
@@ -739,11 +980,21 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
-		return t.applyCreate(c, h)
+	t.captureEnter(c)
+
+	var result *runtime.ExecutionResult
+
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
+		result = t.applyCreate(c, h)
+	} else {
+		t.traceAddress(c.CodeAddress)
+
+		result = t.applyCall(c, c.Type, h)
 	}
 
-	return t.applyCall(c, c.Type, h)
+	t.captureExit(result)
+
+	return result
 }
 
 // SetAccountDirectly sets an account to the given address
@@ -765,7 +1016,7 @@ func (t *Transition) SetAccountDirectly(addr types.Address, account *chain.Genes
 	return nil
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isBerlin bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -805,5 +1056,11 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		cost += zeros * 4
 	}
 
+	// the access list is only paid from the Berlin fork (EIP-2930) onwards
+	if len(msg.AccessList) > 0 && isBerlin {
+		cost += uint64(len(msg.AccessList)) * TxAccessListAddressGas
+		cost += uint64(msg.AccessList.StorageKeys()) * TxAccessListStorageKeyGas
+	}
+
 	return cost, nil
 }
@@ -0,0 +1,191 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ForEachAccount calls fn with the hashed address and the account
+// of every leaf in the account trie at the given root
+func (s *State) ForEachAccount(root types.Hash, fn func(key types.Hash, account *state.Account) error) error {
+	return s.forEachLeaf(root, func(key, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("failed to decode account %s: %w", hex.EncodeToHex(key), err)
+		}
+
+		return fn(types.BytesToHash(key), &account)
+	})
+}
+
+// ForEachStorage calls fn with the hashed slot and the value
+// of every leaf in the storage trie at the given root
+func (s *State) ForEachStorage(root types.Hash, fn func(key types.Hash, value []byte) error) error {
+	return s.forEachLeaf(root, func(key, value []byte) error {
+		p := parserPool.Get()
+		defer parserPool.Put(p)
+
+		v, err := p.Parse(value)
+		if err != nil {
+			return err
+		}
+
+		// copy the value, the parsed one is only valid until the parser is released
+		val, err := v.GetBytes(nil)
+		if err != nil {
+			return fmt.Errorf("failed to decode slot %s: %w", hex.EncodeToHex(key), err)
+		}
+
+		return fn(types.BytesToHash(key), val)
+	})
+}
+
+func (s *State) forEachLeaf(root types.Hash, fn func(key, value []byte) error) error {
+	snap, err := s.NewSnapshotAt(root)
+	if err != nil {
+		return err
+	}
+
+	trie, ok := snap.(*Trie)
+	if !ok {
+		return errors.New("invalid type assertion")
+	}
+
+	return walkLeaves(s.storage, trie.root, nil, fn)
+}
+
+// walkLeaves calls fn for every leaf below the node, the path holds the nibbles up to the node
+func walkLeaves(storage Storage, node Node, path []byte, fn func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			nc, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("trie node %s not found", hex.EncodeToHex(n.buf))
+			}
+
+			return walkLeaves(storage, nc, path, fn)
+		}
+
+		key, err := hexNibblesToBytes(path)
+		if err != nil {
+			return err
+		}
+
+		return fn(key, n.buf)
+
+	case *ShortNode:
+		return walkLeaves(storage, n.child, concat(path, n.key), fn)
+
+	case *FullNode:
+		for i, child := range n.children {
+			if err := walkLeaves(storage, child, concat(path, []byte{byte(i)}), fn); err != nil {
+				return err
+			}
+		}
+
+		return walkLeaves(storage, n.value, path, fn)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// hexNibblesToBytes packs the nibbles (with an optional terminator flag) into bytes
+func hexNibblesToBytes(nibbles []byte) ([]byte, error) {
+	if hasTerminator(nibbles) {
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	if len(nibbles)%2 != 0 {
+		return nil, fmt.Errorf("odd number of nibbles in key")
+	}
+
+	res := make([]byte, len(nibbles)/2)
+	for i := range res {
+		res[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return res, nil
+}
+
+// StateBuilder writes a state from the leaves of its tries,
+// as they are reported by ForEachAccount and ForEachStorage
+type StateBuilder struct {
+	state    *State
+	batch    Batch
+	accounts *Txn
+}
+
+// NewStateBuilder creates a builder of a new state on top of the storage
+func (s *State) NewStateBuilder() *StateBuilder {
+	batch := s.storage.Batch()
+
+	accounts := NewTrie().Txn()
+	accounts.storage = s.storage
+	accounts.batch = batch
+
+	return &StateBuilder{
+		state:    s,
+		batch:    batch,
+		accounts: accounts,
+	}
+}
+
+// AddAccount adds the account with the given hashed address. The storage is
+// keyed by the hashed slots, and it sets the storage root of the account
+func (b *StateBuilder) AddAccount(key types.Hash, account *state.Account, storage map[types.Hash][]byte) error {
+	ar := stateArenaPool.Get()
+	defer stateArenaPool.Put(ar)
+
+	storageTxn := NewTrie().Txn()
+	storageTxn.storage = b.state.storage
+	storageTxn.batch = b.batch
+
+	for slot, val := range storage {
+		vv := ar.NewBytes(bytes.TrimLeft(val, "\x00"))
+		storageTxn.Insert(slot.Bytes(), vv.MarshalTo(nil))
+	}
+
+	storageRoot, err := storageTxn.Hash()
+	if err != nil {
+		return err
+	}
+
+	account.Root = types.BytesToHash(storageRoot)
+
+	arena := accountArenaPool.Get()
+	defer accountArenaPool.Put(arena)
+
+	b.accounts.Insert(key.Bytes(), account.MarshalWith(arena).MarshalTo(nil))
+
+	return nil
+}
+
+// Commit writes the state to the storage and returns its root
+func (b *StateBuilder) Commit() (types.Hash, error) {
+	b.state.lock.RLock()
+	defer b.state.lock.RUnlock()
+
+	root, err := b.accounts.Hash()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	b.batch.Write()
+	b.state.addCommitted(types.BytesToHash(root))
+
+	return types.BytesToHash(root), nil
+}
@@ -0,0 +1,201 @@
+package itrie
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const parallelTestGasLimit = 30000000
+
+var parallelTestCoinbase = types.StringToAddress("0xc0")
+
+// newParallelTestExecutor returns an executor whose genesis funds the given accounts
+func newParallelTestExecutor(
+	t testing.TB,
+	workers int,
+	funded []types.Address,
+) (*state.Executor, types.Hash) {
+	t.Helper()
+
+	params := &chain.Params{
+		Forks:   chain.AllForksEnabled,
+		ChainID: 100,
+	}
+
+	executor := state.NewExecutor(params, NewState(NewMemoryStorage()), hclog.NewNullLogger())
+	executor.SetRuntime(evm.NewEVM())
+	executor.SetParallelWorkers(workers)
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			return types.Hash{}
+		}
+	}
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+	for _, addr := range funded {
+		alloc[addr] = &chain.GenesisAccount{
+			Balance: big.NewInt(1000000000000000000),
+		}
+	}
+
+	return executor, executor.WriteGenesis(alloc)
+}
+
+func newParallelTestKeys(t testing.TB, n int) ([]*ecdsa.PrivateKey, []types.Address) {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	addrs := make([]types.Address, n)
+
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys[i] = key
+		addrs[i] = crypto.PubKeyToAddress(&key.PublicKey)
+	}
+
+	return keys, addrs
+}
+
+func signParallelTestTx(t testing.TB, key *ecdsa.PrivateKey, nonce uint64, to types.Address, gas uint64) *types.Transaction {
+	t.Helper()
+
+	tx, err := crypto.NewEIP155Signer(100).SignTx(&types.Transaction{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    big.NewInt(1000),
+		Gas:      gas,
+		GasPrice: big.NewInt(10),
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tx
+}
+
+// processParallelTestBlock processes the block on a copy of its transactions,
+// so that every run recovers the senders
+func processParallelTestBlock(
+	t testing.TB,
+	executor *state.Executor,
+	root types.Hash,
+	txs []*types.Transaction,
+) (types.Hash, []*types.Receipt) {
+	t.Helper()
+
+	block := &types.Block{
+		Header: &types.Header{
+			Number:   1,
+			GasLimit: parallelTestGasLimit,
+		},
+	}
+
+	for _, tx := range txs {
+		block.Transactions = append(block.Transactions, tx.Copy())
+	}
+
+	transition, err := executor.ProcessBlock(root, block, parallelTestCoinbase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, newRoot := transition.Commit()
+
+	return newRoot, transition.Receipts()
+}
+
+func TestExecutor_ParallelMatchesSerial(t *testing.T) {
+	keys, addrs := newParallelTestKeys(t, 8)
+	funded := append([]types.Address{parallelTestCoinbase}, addrs...)
+
+	shared := types.StringToAddress("0xff")
+
+	txs := []*types.Transaction{
+		// disjoint transfers
+		signParallelTestTx(t, keys[0], 0, types.StringToAddress("0x01"), 21000),
+		signParallelTestTx(t, keys[1], 0, types.StringToAddress("0x02"), 21000),
+		// a second transaction of the same sender
+		signParallelTestTx(t, keys[0], 1, types.StringToAddress("0x03"), 21000),
+		// transfers to the same recipient
+		signParallelTestTx(t, keys[2], 0, shared, 21000),
+		signParallelTestTx(t, keys[3], 0, shared, 21000),
+		// a transfer to the sender of an earlier transaction
+		signParallelTestTx(t, keys[4], 0, addrs[1], 21000),
+		// a transfer from the coinbase
+		signParallelTestTx(t, keys[5], 0, parallelTestCoinbase, 21000),
+		// a transaction exceeding the block gas limit
+		signParallelTestTx(t, keys[6], 0, types.StringToAddress("0x04"), parallelTestGasLimit+1),
+		// a transaction with an invalid nonce is rejected
+		signParallelTestTx(t, keys[7], 1, types.StringToAddress("0x05"), 21000),
+	}
+
+	serial, serialGenesis := newParallelTestExecutor(t, 0, funded)
+	parallel, parallelGenesis := newParallelTestExecutor(t, 4, funded)
+
+	assert.Equal(t, serialGenesis, parallelGenesis)
+
+	// the transaction with the invalid nonce fails the block in both cases
+	_, err := serial.ProcessBlock(serialGenesis, &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: parallelTestGasLimit},
+		Transactions: []*types.Transaction{txs[8].Copy()},
+	}, parallelTestCoinbase)
+	assert.EqualError(t, err, state.ErrNonceIncorrect.Error())
+
+	_, err = parallel.ProcessBlock(parallelGenesis, &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: parallelTestGasLimit},
+		Transactions: []*types.Transaction{txs[0].Copy(), txs[8].Copy()},
+	}, parallelTestCoinbase)
+	assert.EqualError(t, err, state.ErrNonceIncorrect.Error())
+
+	txs = txs[:8]
+
+	serialRoot, serialReceipts := processParallelTestBlock(t, serial, serialGenesis, txs)
+	parallelRoot, parallelReceipts := processParallelTestBlock(t, parallel, parallelGenesis, txs)
+
+	assert.Equal(t, serialRoot, parallelRoot)
+	assert.Equal(t, serialReceipts, parallelReceipts)
+}
+
+// BenchmarkExecutor_Transfers processes a block of transfers between disjoint account pairs
+func BenchmarkExecutor_Transfers(b *testing.B) {
+	for _, bench := range []struct {
+		name    string
+		workers int
+	}{
+		{"serial", 0},
+		{"parallel-4", 4},
+		{"parallel-8", 8},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			keys, senders := newParallelTestKeys(b, 500)
+			_, recipients := newParallelTestKeys(b, 500)
+
+			executor, root := newParallelTestExecutor(b, bench.workers, senders)
+
+			txs := make([]*types.Transaction, len(keys))
+			for i, key := range keys {
+				txs[i] = signParallelTestTx(b, key, 0, recipients[i], 21000)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				processParallelTestBlock(b, executor, root, txs)
+			}
+		})
+	}
+}
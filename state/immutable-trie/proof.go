@@ -0,0 +1,150 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// ErrInvalidProof is returned when a proof doesn't chain up to the root
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// Prove returns the Merkle proof of the key in the trie with the given root:
+// the RLP encoded nodes on the path from the root towards the key.
+// The key is the trie key, the hash of the address or of the storage slot.
+// The proof of a missing key ends with the node proving its absence
+func (s *State) Prove(root types.Hash, key []byte) ([][]byte, error) {
+	// resolves the root the same way the lookups do
+	if _, err := s.NewSnapshotAt(root); err != nil {
+		return nil, err
+	}
+
+	proof := [][]byte{}
+
+	_, err := walkProofPath(root, key, func(hash []byte) ([]byte, bool) {
+		node, ok := s.storage.Get(hash)
+		if ok {
+			proof = append(proof, append([]byte{}, node...))
+		}
+
+		return node, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the proof of the key against the root of the trie.
+// It returns the value of the key, or nil if the proof shows it is missing
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[string][]byte, len(proof))
+	for _, node := range proof {
+		nodes[hex.EncodeToHex(keccak.Keccak256(nil, node))] = node
+	}
+
+	return walkProofPath(root, key, func(hash []byte) ([]byte, bool) {
+		node, ok := nodes[hex.EncodeToHex(hash)]
+
+		return node, ok
+	})
+}
+
+// walkProofPath follows the path of the key from the root, resolving the
+// hashed nodes with getNode, and returns the value of the key (if any)
+func walkProofPath(root types.Hash, key []byte, getNode func(hash []byte) ([]byte, bool)) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	path := bytesToHexNibbles(key)
+	hash := root.Bytes()
+
+	for {
+		data, ok := getNode(hash)
+		if !ok {
+			return nil, fmt.Errorf("%w: trie node %s not found", ErrInvalidProof, hex.EncodeToHex(hash))
+		}
+
+		node, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// follow the path through the nodes embedded in this one
+	embedded:
+		for {
+			var next *fastrlp.Value
+
+			switch node.Elems() {
+			case 2:
+				// short node, either a leaf or an extension
+				nodeKey := decodeCompact(node.Get(0).Raw())
+
+				if hasTerminator(nodeKey) {
+					if !bytes.Equal(nodeKey, path) {
+						// the path ends at a different leaf
+						return nil, nil
+					}
+
+					return append([]byte{}, node.Get(1).Raw()...), nil
+				}
+
+				if len(path) < len(nodeKey) || !bytes.Equal(path[:len(nodeKey)], nodeKey) {
+					// the path diverges from the extension
+					return nil, nil
+				}
+
+				path = path[len(nodeKey):]
+				next = node.Get(1)
+
+			case 17:
+				// full node, the terminator selects its value
+				next = node.Get(int(path[0]))
+
+				if path[0] == 16 {
+					if len(next.Raw()) == 0 {
+						return nil, nil
+					}
+
+					return append([]byte{}, next.Raw()...), nil
+				}
+
+				path = path[1:]
+
+			default:
+				return nil, fmt.Errorf("%w: node has incorrect number of leafs", ErrInvalidProof)
+			}
+
+			if next.Type() == fastrlp.TypeArray {
+				// embedded node
+				node = next
+
+				continue
+			}
+
+			switch len(next.Raw()) {
+			case 0:
+				// empty branch
+				return nil, nil
+			case 32:
+				hash = append([]byte{}, next.Raw()...)
+			default:
+				return nil, fmt.Errorf("%w: invalid node reference", ErrInvalidProof)
+			}
+
+			break embedded
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package itrie
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildProofState(t *testing.T, accounts int) (*State, types.Hash) {
+	t.Helper()
+
+	s := NewState(NewMemoryStorage())
+	txn := state.NewTxn(s, s.NewSnapshot())
+
+	for i := 0; i < accounts; i++ {
+		addr := types.BytesToAddress([]byte{byte(i + 1)})
+
+		txn.CreateAccount(addr)
+		txn.SetBalance(addr, big.NewInt(int64(i+1)))
+		txn.SetNonce(addr, uint64(i))
+		txn.SetState(addr, types.StringToHash("1"), types.StringToHash("2"))
+	}
+
+	_, root := txn.Commit(false)
+
+	return s, types.BytesToHash(root)
+}
+
+func TestProof_Account(t *testing.T) {
+	s, root := buildProofState(t, 20)
+
+	snap, err := s.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		addr := types.BytesToAddress([]byte{byte(i + 1)})
+		key := keccak.Keccak256(nil, addr.Bytes())
+
+		proof, err := s.Prove(root, key)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, proof)
+
+		value, err := VerifyProof(root, key, proof)
+		assert.NoError(t, err)
+
+		expected, ok := snap.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, expected, value)
+
+		var account state.Account
+		assert.NoError(t, account.UnmarshalRlp(value))
+		assert.Equal(t, uint64(i), account.Nonce)
+		assert.Equal(t, big.NewInt(int64(i+1)), account.Balance)
+
+		// the storage proof chains up to the storage root of the account
+		slot := keccak.Keccak256(nil, types.StringToHash("1").Bytes())
+
+		storageProof, err := s.Prove(account.Root, slot)
+		assert.NoError(t, err)
+
+		storageValue, err := VerifyProof(account.Root, slot, storageProof)
+		assert.NoError(t, err)
+		assert.NotNil(t, storageValue)
+	}
+}
+
+func TestProof_MissingKey(t *testing.T) {
+	s, root := buildProofState(t, 20)
+
+	key := keccak.Keccak256(nil, types.BytesToAddress([]byte{0xff, 0xff}).Bytes())
+
+	proof, err := s.Prove(root, key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, proof)
+
+	value, err := VerifyProof(root, key, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	s := NewState(NewMemoryStorage())
+	key := keccak.Keccak256(nil, types.StringToAddress("1").Bytes())
+
+	proof, err := s.Prove(types.EmptyRootHash, key)
+	assert.NoError(t, err)
+	assert.Empty(t, proof)
+
+	value, err := VerifyProof(types.EmptyRootHash, key, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestProof_Invalid(t *testing.T) {
+	s, root := buildProofState(t, 20)
+
+	key := keccak.Keccak256(nil, types.BytesToAddress([]byte{1}).Bytes())
+
+	proof, err := s.Prove(root, key)
+	assert.NoError(t, err)
+
+	// the proof doesn't chain up to another root
+	_, err = VerifyProof(types.StringToHash("1"), key, proof)
+	assert.True(t, errors.Is(err, ErrInvalidProof))
+
+	// a tampered node breaks the chain of hashes
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+
+	last := append([]byte{}, proof[len(proof)-1]...)
+	last[len(last)-1] ^= 0xff
+	tampered[len(tampered)-1] = last
+
+	_, err = VerifyProof(root, key, tampered)
+	assert.True(t, errors.Is(err, ErrInvalidProof))
+
+	// the proof of an unknown root is not built
+	_, err = s.Prove(types.StringToHash("1"), key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package itrie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// pruneMarkPrefix is the prefix of the keys marking the reachable nodes during a pruning
+	pruneMarkPrefix = []byte("prune")
+)
+
+// pruneChunkSize is the number of keys written or deleted at once by the pruning
+const pruneChunkSize = 1024
+
+// Prune removes from the storage every trie node that is not reachable from the given
+// state roots, or from the roots committed since the last pruning (their blocks might
+// not be written yet). The contract code is always kept. It returns the number of deleted nodes.
+//
+// The reachable nodes are marked in the storage, so the memory in use doesn't grow with the state.
+// The commits run alongside and mark the nodes they write, they are only held off
+// while a chunk of unmarked nodes is deleted
+func (s *State) Prune(roots []types.Hash) (int, error) {
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+
+	// the marks of an interrupted pruning are told apart by the epoch
+	epoch := make([]byte, 8)
+	binary.BigEndian.PutUint64(epoch, uint64(time.Now().UnixNano()))
+
+	// from now on the commits mark the nodes they write
+	s.lock.Lock()
+	s.pruneEpoch = epoch
+	s.lock.Unlock()
+
+	defer s.clearMarks()
+
+	s.committedLock.Lock()
+	for root := range s.committed {
+		roots = append(roots, root)
+	}
+	s.committed = map[types.Hash]struct{}{}
+	s.committedLock.Unlock()
+
+	m := newMarker(s.storage, epoch)
+
+	for _, root := range roots {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		if err := m.markHash(root.Bytes(), true); err != nil {
+			return 0, err
+		}
+	}
+
+	m.flush()
+
+	// sweep the nodes, which are the only keys of hash size
+	deleted := 0
+	candidates := make([][]byte, 0, pruneChunkSize)
+
+	sweep := func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		batch := s.storage.Batch()
+
+		for _, k := range candidates {
+			// a commit might have written the node in the meantime
+			if !m.isMarked(k) {
+				batch.Delete(k)
+
+				deleted++
+			}
+		}
+
+		batch.Write()
+
+		candidates = candidates[:0]
+	}
+
+	s.storage.ForEachKey(func(k []byte) {
+		if len(k) != types.HashLength || m.isMarked(k) {
+			return
+		}
+
+		candidates = append(candidates, append([]byte{}, k...))
+
+		if len(candidates) == pruneChunkSize {
+			sweep()
+		}
+	})
+
+	sweep()
+
+	// the cached tries might reference the deleted nodes
+	s.cache.Purge()
+
+	return deleted, nil
+}
+
+// clearMarks stops the commits from marking the nodes and removes the marks
+// from the storage, including the ones left by an interrupted pruning
+func (s *State) clearMarks() {
+	s.lock.Lock()
+	s.pruneEpoch = nil
+	s.lock.Unlock()
+
+	batch := s.storage.Batch()
+	count := 0
+
+	s.storage.ForEachKey(func(k []byte) {
+		if len(k) != len(pruneMarkPrefix)+types.HashLength || !bytes.HasPrefix(k, pruneMarkPrefix) {
+			return
+		}
+
+		batch.Delete(k)
+
+		if count++; count == pruneChunkSize {
+			batch.Write()
+
+			batch = s.storage.Batch()
+			count = 0
+		}
+	})
+
+	batch.Write()
+}
+
+// pruneMarkKey returns the key marking the node with the given hash
+func pruneMarkKey(hash []byte) []byte {
+	return append(append(make([]byte, 0, len(pruneMarkPrefix)+len(hash)), pruneMarkPrefix...), hash...)
+}
+
+// markingBatch marks the nodes written while a pruning is in progress
+type markingBatch struct {
+	Batch
+	epoch []byte
+}
+
+func (b *markingBatch) Put(k, v []byte) {
+	b.Batch.Put(k, v)
+
+	if len(k) == types.HashLength {
+		b.Batch.Put(pruneMarkKey(k), b.epoch)
+	}
+}
+
+// marker walks the tries to mark the reachable nodes
+type marker struct {
+	storage Storage
+	epoch   []byte
+
+	// batch holds the marks not written yet, which are also kept in pending
+	batch   Batch
+	pending map[string]struct{}
+}
+
+func newMarker(storage Storage, epoch []byte) *marker {
+	return &marker{
+		storage: storage,
+		epoch:   epoch,
+		batch:   storage.Batch(),
+		pending: map[string]struct{}{},
+	}
+}
+
+// isMarked checks if the node with the given hash is marked in the current epoch
+func (m *marker) isMarked(hash []byte) bool {
+	if _, ok := m.pending[string(hash)]; ok {
+		return true
+	}
+
+	epoch, ok := m.storage.Get(pruneMarkKey(hash))
+
+	return ok && bytes.Equal(epoch, m.epoch)
+}
+
+// mark marks the node with the given hash, the marks are written in chunks
+func (m *marker) mark(hash []byte) {
+	m.batch.Put(pruneMarkKey(hash), m.epoch)
+	m.pending[string(hash)] = struct{}{}
+
+	if len(m.pending) == pruneChunkSize {
+		m.flush()
+	}
+}
+
+// flush writes the pending marks
+func (m *marker) flush() {
+	m.batch.Write()
+
+	m.batch = m.storage.Batch()
+	m.pending = map[string]struct{}{}
+}
+
+// markHash marks the stored node with the given hash and everything below it.
+// In the account trie the leaves are accounts, whose storage tries are marked as well
+func (m *marker) markHash(hash []byte, accounts bool) error {
+	if m.isMarked(hash) {
+		return nil
+	}
+
+	n, ok, err := GetNode(hash, m.storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// the state was already pruned
+		return nil
+	}
+
+	m.mark(hash)
+
+	return m.markNode(n, accounts)
+}
+
+func (m *marker) markNode(n Node, accounts bool) error {
+	switch n := n.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return m.markHash(n.buf, accounts)
+		}
+
+		if !accounts {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("failed to decode account: %w", err)
+		}
+
+		if account.Root == types.EmptyRootHash {
+			return nil
+		}
+
+		return m.markHash(account.Root.Bytes(), false)
+
+	case *ShortNode:
+		return m.markNode(n.child, accounts)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := m.markNode(child, accounts); err != nil {
+				return err
+			}
+		}
+
+		return m.markNode(n.value, accounts)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
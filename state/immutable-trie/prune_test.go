@@ -0,0 +1,127 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_Prune(t *testing.T) {
+	addr := types.StringToAddress("1")
+	slot := types.StringToHash("2").Bytes()
+
+	st := NewState(NewMemoryStorage())
+	st.EnablePruning()
+
+	// commits a new state on top of the given one, with a different
+	// balance and storage value for the same account
+	commit := func(snap state.Snapshot, i int64) (state.Snapshot, types.Hash) {
+		trie, ok := snap.(*Trie)
+		assert.True(t, ok)
+
+		nSnap, root := trie.Commit([]*state.Object{
+			{
+				Address: addr,
+				Balance: big.NewInt(i),
+				Root:    types.EmptyRootHash,
+				Storage: []*state.StorageObject{
+					{Key: slot, Val: big.NewInt(i).Bytes()},
+				},
+			},
+		})
+
+		return nSnap, types.BytesToHash(root)
+	}
+
+	snap, root1 := commit(st.NewSnapshot(), 1)
+	snap, root2 := commit(snap, 2)
+	_, root3 := commit(snap, 3)
+
+	// the roots committed since the last pruning are always retained
+	deleted, err := st.Prune(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = st.Prune([]types.Hash{root3})
+	assert.NoError(t, err)
+	assert.NotZero(t, deleted)
+
+	for _, root := range []types.Hash{root1, root2} {
+		_, err := st.NewSnapshotAt(root)
+		assert.True(t, errors.Is(err, ErrStatePruned))
+	}
+
+	// the retained state is complete, including the account storage
+	snap, err = st.NewSnapshotAt(root3)
+	assert.NoError(t, err)
+
+	data, ok := snap.Get(hashit(addr.Bytes()))
+	assert.True(t, ok)
+
+	var account state.Account
+	assert.NoError(t, account.UnmarshalRlp(data))
+	assert.Equal(t, big.NewInt(3), account.Balance)
+
+	storageSnap, err := st.NewSnapshotAt(account.Root)
+	assert.NoError(t, err)
+
+	_, ok = storageSnap.Get(hashit(slot))
+	assert.True(t, ok)
+}
+
+func TestState_Prune_Readers(t *testing.T) {
+	addr := types.StringToAddress("1")
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+	st.EnablePruning()
+
+	commit := func(snap state.Snapshot, i int64) (state.Snapshot, types.Hash) {
+		trie, ok := snap.(*Trie)
+		assert.True(t, ok)
+
+		nSnap, root := trie.Commit([]*state.Object{
+			{
+				Address: addr,
+				Balance: big.NewInt(i),
+				Root:    types.EmptyRootHash,
+			},
+			{
+				Address: types.StringToAddress("2"),
+				Balance: big.NewInt(i),
+				Root:    types.EmptyRootHash,
+			},
+		})
+
+		return nSnap, types.BytesToHash(root)
+	}
+
+	snap, root1 := commit(st.NewSnapshot(), 1)
+	_, root2 := commit(snap, 2)
+
+	_, err := st.Prune(nil)
+	assert.NoError(t, err)
+
+	// a reader holding the old state while it is pruned
+	oldSnap, err := st.NewSnapshotAt(root1)
+	assert.NoError(t, err)
+
+	_, err = st.Prune([]types.Hash{root2})
+	assert.NoError(t, err)
+
+	_, err = oldSnap.TryGet(hashit(addr.Bytes()))
+	assert.ErrorIs(t, err, ErrStatePruned)
+
+	_, ok := oldSnap.Get(hashit(addr.Bytes()))
+	assert.False(t, ok)
+
+	// the marks are removed once the pruning is over
+	storage.ForEachKey(func(k []byte) {
+		assert.False(t, bytes.HasPrefix(k, pruneMarkPrefix))
+	})
+}
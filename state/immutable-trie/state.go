@@ -3,6 +3,7 @@ package itrie
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
 
@@ -10,17 +11,58 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var (
+	// ErrStatePruned is returned when the requested state root was removed by the pruning
+	ErrStatePruned = errors.New("state unavailable, node is pruned")
+)
+
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// lock prevents the pruning from deleting nodes in the middle of a commit
+	lock sync.RWMutex
+
+	// pruning marks if the historical state is being pruned
+	pruning bool
+
+	// pruneLock serializes the prunings
+	pruneLock sync.Mutex
+
+	// pruneEpoch identifies the marks of the running pruning (nil if none),
+	// it is guarded by lock
+	pruneEpoch []byte
+
+	// committed are the state roots written since the last pruning
+	committed     map[types.Hash]struct{}
+	committedLock sync.Mutex
+
+	// transient are the states committed in memory, by their root
+	transient     map[types.Hash]*transientLayer
+	transientLock sync.RWMutex
+}
+
+// transientLayer holds the tries and the contract code
+// committed in memory instead of the storage
+type transientLayer struct {
+	tries map[types.Hash]*Trie
+	code  map[types.Hash][]byte
+}
+
+func newTransientLayer() *transientLayer {
+	return &transientLayer{
+		tries: map[types.Hash]*Trie{},
+		code:  map[types.Hash][]byte{},
+	}
 }
 
 func NewState(storage Storage) *State {
 	cache, _ := lru.New(128)
 
 	s := &State{
-		storage: storage,
-		cache:   cache,
+		storage:   storage,
+		cache:     cache,
+		transient: map[types.Hash]*transientLayer{},
 	}
 
 	return s
@@ -39,7 +81,20 @@ func (s *State) SetCode(hash types.Hash, code []byte) {
 }
 
 func (s *State) GetCode(hash types.Hash) ([]byte, bool) {
-	return s.storage.GetCode(hash)
+	if code, ok := s.storage.GetCode(hash); ok {
+		return code, true
+	}
+
+	s.transientLock.RLock()
+	defer s.transientLock.RUnlock()
+
+	for _, layer := range s.transient {
+		if code, ok := layer.code[hash]; ok {
+			return code, true
+		}
+	}
+
+	return nil, false
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
@@ -48,6 +103,10 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return s.NewSnapshot(), nil
 	}
 
+	if t, ok := s.getTransient(root); ok {
+		return t, nil
+	}
+
 	tt, ok := s.cache.Get(root)
 	if ok {
 		t, ok := tt.(*Trie)
@@ -72,6 +131,10 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 	}
 
 	if !ok {
+		if s.pruning {
+			return nil, fmt.Errorf("%w: state root %s", ErrStatePruned, root)
+		}
+
 		return nil, fmt.Errorf("state not found at hash %s", root)
 	}
 
@@ -87,3 +150,77 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// NewTransientSnapshotAt returns the snapshot at the given root, whose commits are kept
+// in memory instead of being written to the storage. The committed state can be read
+// like the stored one, until it is released with ReleaseTransient
+func (s *State) NewTransientSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	snap, err := s.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := snap.(*Trie)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	return &Trie{
+		state:     s,
+		root:      t.root,
+		epoch:     t.epoch,
+		storage:   t.storage,
+		transient: newTransientLayer(),
+	}, nil
+}
+
+// ReleaseTransient drops the state committed in memory with the given root
+func (s *State) ReleaseTransient(root types.Hash) {
+	s.transientLock.Lock()
+	defer s.transientLock.Unlock()
+
+	delete(s.transient, root)
+}
+
+// addTransient makes the state committed in memory with the given root readable
+func (s *State) addTransient(root types.Hash, layer *transientLayer) {
+	s.transientLock.Lock()
+	defer s.transientLock.Unlock()
+
+	s.transient[root] = layer
+}
+
+// getTransient returns the trie committed in memory with the given root (if any),
+// which is either a state root or the root of an account storage
+func (s *State) getTransient(root types.Hash) (*Trie, bool) {
+	s.transientLock.RLock()
+	defer s.transientLock.RUnlock()
+
+	for _, layer := range s.transient {
+		if t, ok := layer.tries[root]; ok {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+// EnablePruning makes the state keep track of the committed roots, so that
+// the historical state can be removed with Prune.
+// It has to be called before the state is used
+func (s *State) EnablePruning() {
+	s.pruning = true
+	s.committed = map[types.Hash]struct{}{}
+}
+
+// addCommitted records a state root written to the storage,
+// so that the next pruning retains it
+func (s *State) addCommitted(root types.Hash) {
+	if !s.pruning {
+		return
+	}
+
+	s.committedLock.Lock()
+	s.committed[root] = struct{}{}
+	s.committedLock.Unlock()
+}
@@ -1,9 +1,12 @@
 package itrie
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestState(t *testing.T) {
@@ -17,3 +20,72 @@ func buildPreState(pre state.PreStates) (state.State, state.Snapshot) {
 
 	return st, snap
 }
+
+func TestState_Transient(t *testing.T) {
+	addr := types.StringToAddress("1")
+	slot := types.StringToHash("2").Bytes()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+
+	countKeys := func() (count int) {
+		storage.ForEachKey(func(k []byte) {
+			count++
+		})
+
+		return
+	}
+
+	snap, err := st.NewTransientSnapshotAt(types.EmptyRootHash)
+	assert.NoError(t, err)
+
+	trie, ok := snap.(*Trie)
+	assert.True(t, ok)
+
+	code := []byte{0x1}
+
+	_, root := trie.Commit([]*state.Object{
+		{
+			Address:   addr,
+			Balance:   big.NewInt(1),
+			Root:      types.EmptyRootHash,
+			CodeHash:  types.BytesToHash(hashit(code)),
+			Code:      code,
+			DirtyCode: true,
+			Storage: []*state.StorageObject{
+				{Key: slot, Val: big.NewInt(1).Bytes()},
+			},
+		},
+	})
+
+	// nothing is written to the storage
+	assert.Zero(t, countKeys())
+
+	// while the committed state is readable
+	snap, err = st.NewSnapshotAt(types.BytesToHash(root))
+	assert.NoError(t, err)
+
+	data, ok := snap.Get(hashit(addr.Bytes()))
+	assert.True(t, ok)
+
+	var account state.Account
+	assert.NoError(t, account.UnmarshalRlp(data))
+
+	storageSnap, err := st.NewSnapshotAt(account.Root)
+	assert.NoError(t, err)
+
+	_, ok = storageSnap.Get(hashit(slot))
+	assert.True(t, ok)
+
+	_, ok = st.GetCode(types.BytesToHash(hashit(code)))
+	assert.True(t, ok)
+
+	// until it is released
+	st.ReleaseTransient(types.BytesToHash(root))
+
+	_, err = st.NewSnapshotAt(types.BytesToHash(root))
+	assert.Error(t, err)
+
+	_, ok = st.GetCode(types.BytesToHash(hashit(code)))
+	assert.False(t, ok)
+}
@@ -19,6 +19,7 @@ var (
 
 type Batch interface {
 	Put(k, v []byte)
+	Delete(k []byte)
 	Write()
 }
 
@@ -29,6 +30,9 @@ type Storage interface {
 	Batch() Batch
 	SetCode(hash types.Hash, code []byte)
 	GetCode(hash types.Hash) ([]byte, bool)
+	// ForEachKey calls fn with every key in the storage.
+	// The key must not be retained after fn returns
+	ForEachKey(fn func(k []byte))
 
 	Close() error
 }
@@ -48,6 +52,10 @@ func (b *KVBatch) Put(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
+func (b *KVBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
 func (b *KVBatch) Write() {
 	_ = b.db.Write(b.batch, nil)
 }
@@ -81,6 +89,15 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+func (kv *KVStorage) ForEachKey(fn func(k []byte)) {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		fn(iter.Key())
+	}
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -137,6 +154,13 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db}
 }
 
+func (m *memStorage) ForEachKey(fn func(k []byte)) {
+	for k := range m.db {
+		key, _ := hex.DecodeHex(k)
+		fn(key)
+	}
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -147,9 +171,20 @@ func (m *memBatch) Put(p, v []byte) {
 	(*m.db)[hex.EncodeToHex(p)] = buf
 }
 
+func (m *memBatch) Delete(p []byte) {
+	delete(*m.db, hex.EncodeToHex(p))
+}
+
 func (m *memBatch) Write() {
 }
 
+// discardBatch is a batch dropping the writes
+type discardBatch struct{}
+
+func (discardBatch) Put(k, v []byte) {}
+func (discardBatch) Delete(k []byte) {}
+func (discardBatch) Write()          {}
+
 // GetNode retrieves a node from storage
 func GetNode(root []byte, storage Storage) (Node, bool, error) {
 	data, ok := storage.Get(root)
@@ -2,14 +2,21 @@ package itrie
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
 	"golang.org/x/crypto/sha3"
 )
 
+var (
+	// ErrMissingNode is returned when a node referenced by the trie is not in the storage
+	ErrMissingNode = errors.New("trie node not found")
+)
+
 // Node represents a node reference
 type Node interface {
 	Hash() ([]byte, bool)
@@ -94,17 +101,32 @@ type Trie struct {
 	root    Node
 	epoch   uint32
 	storage Storage
+
+	// transient (if set) receives the commits instead of the storage
+	transient *transientLayer
 }
 
 func NewTrie() *Trie {
 	return &Trie{}
 }
 
+// Get returns the value stored at the key. A node missing
+// from the storage reads as an absent key, see TryGet
 func (t *Trie) Get(k []byte) ([]byte, bool) {
-	txn := t.Txn()
-	res := txn.Lookup(k)
+	res, err := t.TryGet(k)
 
-	return res, res != nil
+	return res, err == nil && res != nil
+}
+
+// TryGet returns the value stored at the key (nil if there is none),
+// or an error if the nodes on its path are missing from the storage
+func (t *Trie) TryGet(k []byte) ([]byte, error) {
+	res, err := t.Txn().Lookup(k)
+	if err != nil && t.state != nil && t.state.pruning {
+		return nil, fmt.Errorf("%w: %v", ErrStatePruned, err)
+	}
+
+	return res, err
 }
 
 func hashit(k []byte) []byte {
@@ -119,8 +141,19 @@ var accountArenaPool fastrlp.ArenaPool
 var stateArenaPool fastrlp.ArenaPool // TODO, Remove once we do update in fastrlp
 
 func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
-	// Create an insertion batch for all the entries
+	// Hold off the pruning until the new state root is recorded
+	t.state.lock.RLock()
+	defer t.state.lock.RUnlock()
+
+	// Create an insertion batch for all the entries,
+	// marking the written nodes if a pruning is in progress
 	batch := t.storage.Batch()
+	if t.transient != nil {
+		// the nodes are kept in memory by the committed tries
+		batch = discardBatch{}
+	} else if epoch := t.state.pruneEpoch; epoch != nil {
+		batch = &markingBatch{Batch: batch, epoch: epoch}
+	}
 
 	tt := t.Txn()
 	tt.batch = batch
@@ -170,13 +203,22 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 				accountStateTrie := localTxn.Commit()
 
 				// Add this to the cache
-				t.state.AddState(types.BytesToHash(accountStateRoot), accountStateTrie)
+				if t.transient != nil {
+					accountStateTrie.state = t.state
+					t.transient.tries[types.BytesToHash(accountStateRoot)] = accountStateTrie
+				} else {
+					t.state.AddState(types.BytesToHash(accountStateRoot), accountStateTrie)
+				}
 
 				account.Root = types.BytesToHash(accountStateRoot)
 			}
 
 			if obj.DirtyCode {
-				t.state.SetCode(obj.CodeHash, obj.Code)
+				if t.transient != nil {
+					t.transient.code[obj.CodeHash] = obj.Code
+				} else {
+					t.state.SetCode(obj.CodeHash, obj.Code)
+				}
 			}
 
 			vv := account.MarshalWith(arena)
@@ -193,10 +235,19 @@ func (t *Trie) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	nTrie.state = t.state
 	nTrie.storage = t.storage
 
+	if t.transient != nil {
+		nTrie.transient = t.transient
+		t.transient.tries[types.BytesToHash(root)] = nTrie
+		t.state.addTransient(types.BytesToHash(root), t.transient)
+
+		return nTrie, root
+	}
+
 	// Write all the entries to db
 	batch.Write()
 
 	t.state.AddState(types.BytesToHash(root), nTrie)
+	t.state.addCommitted(types.BytesToHash(root))
 
 	return nTrie, root
 }
@@ -258,65 +309,67 @@ func (t *Txn) Commit() *Trie {
 	return &Trie{epoch: t.epoch, root: t.root, storage: t.storage}
 }
 
-func (t *Txn) Lookup(key []byte) []byte {
-	_, res := t.lookup(t.root, bytesToHexNibbles(key))
+// Lookup returns the value stored at the key (nil if there is none),
+// or ErrMissingNode if a node on its path is not in the storage
+func (t *Txn) Lookup(key []byte) ([]byte, error) {
+	_, res, err := t.lookup(t.root, bytesToHexNibbles(key))
 
-	return res
+	return res, err
 }
 
-func (t *Txn) lookup(node interface{}, key []byte) (Node, []byte) {
+func (t *Txn) lookup(node interface{}, key []byte) (Node, []byte, error) {
 	switch n := node.(type) {
 	case nil:
-		return nil, nil
+		return nil, nil, nil
 
 	case *ValueNode:
 		if n.hash {
 			nc, ok, err := GetNode(n.buf, t.storage)
 			if err != nil {
-				panic(err)
+				return nil, nil, err
 			}
 
 			if !ok {
-				return nil, nil
+				return nil, nil, fmt.Errorf("%w: %s", ErrMissingNode, hex.EncodeToHex(n.buf))
 			}
 
-			_, res := t.lookup(nc, key)
+			_, res, err := t.lookup(nc, key)
 
-			return nc, res
+			return nc, res, err
 		}
 
 		if len(key) == 0 {
-			return nil, n.buf
+			return nil, n.buf, nil
 		} else {
-			return nil, nil
+			return nil, nil, nil
 		}
 
 	case *ShortNode:
 		plen := len(n.key)
 		if plen > len(key) || !bytes.Equal(key[:plen], n.key) {
-			return nil, nil
+			return nil, nil, nil
 		}
 
-		child, res := t.lookup(n.child, key[plen:])
+		child, res, err := t.lookup(n.child, key[plen:])
 
 		if child != nil {
 			n.child = child
 		}
 
-		return nil, res
+		return nil, res, err
 
 	case *FullNode:
 		if len(key) == 0 {
 			return t.lookup(n.value, key)
 		}
 
-		child, res := t.lookup(n.getEdge(key[0]), key[1:])
+		child, res, err := t.lookup(n.getEdge(key[0]), key[1:])
 
 		if child != nil {
 			n.children[key[0]] = child
 		}
 
-		return nil, res
+		return nil, res, err
 
 	default:
 		panic(fmt.Sprintf("unknown node type %v", n))
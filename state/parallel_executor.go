@@ -0,0 +1,138 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// speculativeResult is the outcome of a transaction executed
+// on the parent state, regardless of the transactions before it
+type speculativeResult struct {
+	transition *Transition
+	msg        *types.Transaction
+	result     *runtime.ExecutionResult
+	err        error
+}
+
+// processParallel writes the transactions of the block to the transition. The transactions
+// are first executed optimistically in parallel, each one on the parent state. Then, in
+// the block order, the result of a transaction is merged into the transition if none of the
+// accounts it accessed were accessed by the transactions before it. Otherwise, the
+// transaction is executed again serially, so the resulting state is the same as if every
+// transaction was executed serially
+func (e *Executor) processParallel(
+	txn *Transition,
+	parentRoot types.Hash,
+	block *types.Block,
+	blockCreator types.Address,
+) error {
+	results := e.executeSpeculatively(parentRoot, block, blockCreator)
+
+	txn.state.trackAccesses()
+
+	for i, t := range block.Transactions {
+		if t.ExceedsBlockGasLimit(block.Header.GasLimit) {
+			if err := txn.WriteFailedReceipt(t); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		res := results[i]
+		if res.err != nil || txn.gasPool < res.msg.Gas || txn.state.accessedAny(res.transition.state.accessed) {
+			// the speculative execution is not valid, execute the transaction on the current state
+			if err := txn.Write(t); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		txn.state.merge(res.transition.state)
+
+		for _, log := range res.transition.state.Logs() {
+			txn.state.AddLog(log)
+		}
+
+		txn.payCoinbase(res.transition.coinbaseFee)
+		txn.gasPool -= uint64(res.transition.ctx.GasLimit) - res.transition.gasPool
+
+		txn.writeReceipt(t, res.msg, res.result)
+	}
+
+	return nil
+}
+
+// executeSpeculatively executes every transaction of the block on
+// the parent state, spread over the parallel workers
+func (e *Executor) executeSpeculatively(
+	parentRoot types.Hash,
+	block *types.Block,
+	blockCreator types.Address,
+) []*speculativeResult {
+	results := make([]*speculativeResult, len(block.Transactions))
+	indexes := make(chan int, len(block.Transactions))
+
+	for i, t := range block.Transactions {
+		if !t.ExceedsBlockGasLimit(block.Header.GasLimit) {
+			indexes <- i
+		}
+	}
+
+	close(indexes)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < e.parallelWorkers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				results[index] = e.executeSpeculative(parentRoot, block.Header, blockCreator, block.Transactions[index])
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// executeSpeculative executes the transaction on the parent state, recording the accounts
+// it accesses. The coinbase fee is held back, so paying it does not count as an access
+func (e *Executor) executeSpeculative(
+	parentRoot types.Hash,
+	header *types.Header,
+	blockCreator types.Address,
+	txn *types.Transaction,
+) *speculativeResult {
+	transition, err := e.BeginTxn(parentRoot, header, blockCreator)
+	if err != nil {
+		return &speculativeResult{err: err}
+	}
+
+	transition.state.trackAccesses()
+	transition.coinbaseFee = new(big.Int)
+
+	if txn.From == emptyFrom {
+		if txn.From, err = e.newSigner(header.Number).Sender(txn); err != nil {
+			return &speculativeResult{err: err}
+		}
+	}
+
+	msg := txn.Copy()
+	result, err := transition.Apply(msg)
+
+	return &speculativeResult{
+		transition: transition,
+		msg:        msg,
+		result:     result,
+		err:        err,
+	}
+}
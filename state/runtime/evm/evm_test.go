@@ -79,6 +79,14 @@ func (m *mockHost) Callx(*runtime.Contract, runtime.Host) *runtime.ExecutionResu
 	panic("Not implemented in tests")
 }
 
+func (m *mockHost) GetTracer() runtime.Tracer {
+	return nil
+}
+
+func (m *mockHost) Cancelled() bool {
+	return false
+}
+
 func (m *mockHost) Empty(addr types.Address) bool {
 	panic("Not implemented in tests")
 }
@@ -611,6 +611,16 @@ func opChainID(c *state) {
 	c.push1().SetUint64(uint64(c.host.GetTxContext().ChainID))
 }
 
+func opBaseFee(c *state) {
+	if !c.config.London {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetUint64(c.host.GetTxContext().BaseFee)
+}
+
 func opOrigin(c *state) {
 	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
 }
@@ -957,6 +967,11 @@ func opJumpi(c *state) {
 }
 
 func opJumpDest(c *state) {
+	// the loops go through a jump destination, so a
+	// cancelled execution is stopped here at the latest
+	if c.host.Cancelled() {
+		c.exit(runtime.ErrExecutionCancelled)
+	}
 }
 
 func opPush(n int) instruction {
@@ -1077,7 +1092,11 @@ func opCreate(op OpCode) instruction {
 			return
 		}
 
-		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		} else {
+			contract.Type = runtime.Create
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
@@ -57,6 +57,7 @@ type state struct {
 	host   runtime.Host
 	msg    *runtime.Contract // change with msg
 	config *chain.ForksInTime
+	tracer runtime.Tracer
 
 	// memory
 	memory      []byte
@@ -88,6 +89,7 @@ func (c *state) reset() {
 	c.lastGasCost = 0
 	c.stop = false
 	c.err = nil
+	c.tracer = nil
 
 	// reset bitmap
 	c.bitmap.reset()
@@ -210,10 +212,19 @@ func (c *state) resetReturnData() {
 
 // Run executes the virtual machine
 func (c *state) Run() ([]byte, error) {
-	var vmerr error
+	var (
+		vmerr error
+		step  *runtime.Step
+	)
 
 	codeSize := len(c.code)
 	for !c.stop {
+		// the previous opcode is done
+		if step != nil {
+			c.captureStateEnd(step)
+			step = nil
+		}
+
 		if c.ip >= codeSize {
 			c.halt()
 
@@ -222,6 +233,10 @@ func (c *state) Run() ([]byte, error) {
 
 		op := OpCode(c.code[c.ip])
 
+		if c.tracer != nil {
+			step = c.captureState(op)
+		}
+
 		inst := dispatchTable[op]
 		if inst.inst == nil {
 			c.exit(errOpCodeNotFound)
@@ -253,6 +268,10 @@ func (c *state) Run() ([]byte, error) {
 		c.ip++
 	}
 
+	if step != nil {
+		c.captureStateEnd(step)
+	}
+
 	if err := c.err; err != nil {
 		vmerr = err
 	}
@@ -260,6 +279,35 @@ func (c *state) Run() ([]byte, error) {
 	return c.ret, vmerr
 }
 
+// captureState reports the opcode to the tracer before it is executed
+func (c *state) captureState(op OpCode) *runtime.Step {
+	step := &runtime.Step{
+		PC:      uint64(c.ip),
+		Op:      byte(op),
+		OpName:  op.String(),
+		Gas:     c.gas,
+		Depth:   c.msg.Depth,
+		Address: c.msg.Address,
+		Stack:   c.stack[:c.sp],
+		Memory:  c.memory,
+	}
+
+	c.tracer.CaptureState(c.host, step)
+
+	return step
+}
+
+// captureStateEnd reports the gas cost and the error of the executed opcode to the tracer
+func (c *state) captureStateEnd(step *runtime.Step) {
+	if c.gas < step.Gas {
+		step.GasCost = step.Gas - c.gas
+	}
+
+	step.Err = c.err
+
+	c.tracer.CaptureStateEnd(c.host, step)
+}
+
 func (c *state) inStaticCall() bool {
 	return c.msg.Static
 }
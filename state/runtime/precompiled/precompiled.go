@@ -88,6 +88,19 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	return true
 }
 
+// Addresses returns the addresses of the precompiled contracts enabled by the forks
+func (p *Precompiled) Addresses(config *chain.ForksInTime) []types.Address {
+	addrs := make([]types.Address, 0, len(p.contracts))
+
+	for addr := range p.contracts {
+		if p.CanRun(&runtime.Contract{CodeAddress: addr}, nil, config) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
 // Name implements the runtime interface
 func (p *Precompiled) Name() string {
 	return "precompiled"
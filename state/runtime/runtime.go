@@ -18,6 +18,7 @@ type TxContext struct {
 	GasLimit   int64
 	ChainID    int64
 	Difficulty types.Hash
+	BaseFee    uint64
 }
 
 // StorageStatus is the status of the storage access
@@ -69,6 +70,8 @@ type Host interface {
 	Callx(*Contract, Host) *ExecutionResult
 	Empty(addr types.Address) bool
 	GetNonce(addr types.Address) uint64
+	GetTracer() Tracer
+	Cancelled() bool
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -107,6 +110,7 @@ var (
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
 	ErrCodeStoreOutOfGas        = errors.New("contract creation code storage out of gas")
+	ErrExecutionCancelled       = errors.New("execution cancelled")
 )
 
 type CallType int
@@ -120,6 +124,25 @@ const (
 	Create2
 )
 
+func (c CallType) String() string {
+	switch c {
+	case Call:
+		return "CALL"
+	case CallCode:
+		return "CALLCODE"
+	case DelegateCall:
+		return "DELEGATECALL"
+	case StaticCall:
+		return "STATICCALL"
+	case Create:
+		return "CREATE"
+	case Create2:
+		return "CREATE2"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // Runtime can process contracts
 type Runtime interface {
 	Run(c *Contract, host Host, config *chain.ForksInTime) *ExecutionResult
@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Step is an opcode executed by the runtime
+type Step struct {
+	PC      uint64
+	Op      byte
+	OpName  string
+	Gas     uint64 // gas available before the opcode
+	GasCost uint64 // gas consumed by the opcode, set once it is executed
+	Depth   int
+	Address types.Address // address of the executing contract
+
+	// Stack (bottom first) and Memory before the opcode. They are views
+	// of the runtime state, only valid during the CaptureState call
+	Stack  []*big.Int
+	Memory []byte
+
+	// Err is the error the opcode failed with, set once it is executed
+	Err error
+}
+
+// Tracer receives the call frames and the opcodes executed by the runtime
+type Tracer interface {
+	// CaptureEnter is called when a frame is entered, for the message of the
+	// transaction and for every call or creation of a contract it makes
+	CaptureEnter(c *Contract)
+
+	// CaptureExit is called with the result of the last entered frame
+	CaptureExit(result *ExecutionResult)
+
+	// CaptureState is called before the opcode is executed
+	CaptureState(host Host, step *Step)
+
+	// CaptureStateEnd is called with the same step once the opcode is executed.
+	// For the calls, it comes after the steps of the callee
+	CaptureStateEnd(host Host, step *Step)
+}
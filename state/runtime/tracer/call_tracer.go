@@ -0,0 +1,116 @@
+package tracer
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errNoCallFrame = errors.New("no call frame recorded")
+
+// CallFrame is a call or a creation of a contract made by the traced transaction,
+// with the frames it made in turn
+type CallFrame struct {
+	Type    string        `json:"type"`
+	From    types.Address `json:"from"`
+	To      types.Address `json:"to"`
+	Value   string        `json:"value,omitempty"`
+	Gas     string        `json:"gas"`
+	GasUsed string        `json:"gasUsed"`
+	Input   string        `json:"input"`
+	Output  string        `json:"output,omitempty"`
+	Error   string        `json:"error,omitempty"`
+	Calls   []*CallFrame  `json:"calls,omitempty"`
+
+	gas uint64
+}
+
+// CallTracer records the tree of the call frames of a transaction
+type CallTracer struct {
+	root *CallFrame
+
+	// frames being executed, the last one is the innermost
+	stack []*CallFrame
+}
+
+// NewCallTracer creates a call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureEnter implements the runtime.Tracer interface
+func (c *CallTracer) CaptureEnter(contract *runtime.Contract) {
+	frame := &CallFrame{
+		Type: contract.Type.String(),
+		From: contract.Caller,
+		To:   contract.Address,
+		Gas:  hex.EncodeUint64(contract.Gas),
+		gas:  contract.Gas,
+	}
+
+	switch contract.Type {
+	case runtime.Create, runtime.Create2:
+		frame.Input = hex.EncodeToHex(contract.Code)
+	default:
+		frame.Input = hex.EncodeToHex(contract.Input)
+	}
+
+	// the delegated and the static calls don't transfer value
+	if contract.Type != runtime.DelegateCall && contract.Type != runtime.StaticCall && contract.Value != nil {
+		frame.Value = hex.EncodeBig(contract.Value)
+	}
+
+	if len(c.stack) == 0 {
+		c.root = frame
+	} else {
+		parent := c.stack[len(c.stack)-1]
+		parent.Calls = append(parent.Calls, frame)
+	}
+
+	c.stack = append(c.stack, frame)
+}
+
+// CaptureExit implements the runtime.Tracer interface
+func (c *CallTracer) CaptureExit(result *runtime.ExecutionResult) {
+	if len(c.stack) == 0 {
+		return
+	}
+
+	frame := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+
+	gasUsed := uint64(0)
+	if result.GasLeft < frame.gas {
+		gasUsed = frame.gas - result.GasLeft
+	}
+
+	frame.GasUsed = hex.EncodeUint64(gasUsed)
+
+	if len(result.ReturnValue) != 0 {
+		frame.Output = hex.EncodeToHex(result.ReturnValue)
+	}
+
+	if result.Err != nil {
+		frame.Error = result.Err.Error()
+	}
+}
+
+// CaptureState implements the runtime.Tracer interface
+func (c *CallTracer) CaptureState(host runtime.Host, step *runtime.Step) {}
+
+// CaptureStateEnd implements the runtime.Tracer interface
+func (c *CallTracer) CaptureStateEnd(host runtime.Host, step *runtime.Step) {}
+
+// GetResult returns the frame of the transaction message. Its gas used is the one
+// of the transaction, which includes the intrinsic gas and the refunds
+func (c *CallTracer) GetResult(result *runtime.ExecutionResult) (interface{}, error) {
+	if c.root == nil {
+		return nil, errNoCallFrame
+	}
+
+	c.root.GasUsed = hex.EncodeUint64(result.GasUsed)
+
+	return c.root, nil
+}
@@ -0,0 +1,218 @@
+package tracer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	opSLOAD  = 0x54
+	opSSTORE = 0x55
+)
+
+// Config bounds the output of the struct logger
+type Config struct {
+	DisableStack   bool
+	DisableMemory  bool
+	DisableStorage bool
+
+	// MaxBytes is the maximum size of the recorded logs, approximated by the size
+	// of their values. The trace fails once it is exceeded. 0 disables the limit
+	MaxBytes int
+}
+
+// ErrTraceTooLarge is returned for a trace exceeding the maximum size of the struct logs
+var ErrTraceTooLarge = errors.New("trace too large")
+
+// structLogBaseSize approximates the size of the fields of a struct log other than its values
+const structLogBaseSize = 64
+
+// StructLog is an opcode executed by the traced transaction
+type StructLog struct {
+	PC      uint64            `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     uint64            `json:"gas"`
+	GasCost uint64            `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Error   string            `json:"error,omitempty"`
+	Stack   []string          `json:"stack,omitempty"`
+	Memory  []string          `json:"memory,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// StructLogResult is the trace of a transaction made by the struct logger
+type StructLogResult struct {
+	Gas         uint64       `json:"gas"`
+	Failed      bool         `json:"failed"`
+	ReturnValue string       `json:"returnValue"`
+	StructLogs  []*StructLog `json:"structLogs"`
+}
+
+// StructLogger records every executed opcode with the stack,
+// the memory and the storage of the contract (as far as it is known)
+type StructLogger struct {
+	config Config
+
+	logs []*StructLog
+
+	// logs of the opcodes being executed, the calls are nested
+	pending []*StructLog
+
+	// storage slots accessed by each contract
+	storage map[types.Address]map[types.Hash]types.Hash
+
+	// size is the approximate size of the recorded logs, which are
+	// dropped once it exceeds the maximum
+	size     int
+	exceeded bool
+}
+
+// NewStructLogger creates a struct logger with the given config
+func NewStructLogger(config Config) *StructLogger {
+	return &StructLogger{
+		config:  config,
+		logs:    []*StructLog{},
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+// CaptureEnter implements the runtime.Tracer interface
+func (l *StructLogger) CaptureEnter(c *runtime.Contract) {}
+
+// CaptureExit implements the runtime.Tracer interface
+func (l *StructLogger) CaptureExit(result *runtime.ExecutionResult) {}
+
+// CaptureState implements the runtime.Tracer interface
+func (l *StructLogger) CaptureState(host runtime.Host, step *runtime.Step) {
+	if l.exceeded {
+		return
+	}
+
+	log := &StructLog{
+		PC:    step.PC,
+		Op:    step.OpName,
+		Gas:   step.Gas,
+		Depth: step.Depth,
+	}
+
+	if !l.config.DisableStack {
+		log.Stack = make([]string, len(step.Stack))
+		for i, item := range step.Stack {
+			log.Stack[i] = hex.EncodeBig(item)
+		}
+	}
+
+	if !l.config.DisableMemory {
+		log.Memory = make([]string, 0, len(step.Memory)/32)
+		for i := 0; i+32 <= len(step.Memory); i += 32 {
+			log.Memory = append(log.Memory, hex.EncodeToString(step.Memory[i:i+32]))
+		}
+	}
+
+	if !l.config.DisableStorage {
+		l.captureStorage(host, step, log)
+	}
+
+	if l.config.MaxBytes > 0 {
+		if l.size += log.size(); l.size > l.config.MaxBytes {
+			// the trace fails, so the remaining steps aren't recorded
+			l.exceeded = true
+			l.logs, l.pending = nil, nil
+
+			return
+		}
+	}
+
+	l.logs = append(l.logs, log)
+	l.pending = append(l.pending, log)
+}
+
+// size approximates the size of the log by the size of its values
+func (log *StructLog) size() int {
+	size := structLogBaseSize + len(log.Op)
+
+	for _, item := range log.Stack {
+		size += len(item)
+	}
+
+	for _, word := range log.Memory {
+		size += len(word)
+	}
+
+	for key, value := range log.Storage {
+		size += len(key) + len(value)
+	}
+
+	return size
+}
+
+// captureStorage records the slot accessed by a SLOAD or a SSTORE,
+// and attaches the known storage of the contract to the log
+func (l *StructLogger) captureStorage(host runtime.Host, step *runtime.Step, log *StructLog) {
+	if (step.Op != opSLOAD || len(step.Stack) < 1) && (step.Op != opSSTORE || len(step.Stack) < 2) {
+		return
+	}
+
+	storage, ok := l.storage[step.Address]
+	if !ok {
+		storage = map[types.Hash]types.Hash{}
+		l.storage[step.Address] = storage
+	}
+
+	key := bigToHash(step.Stack[len(step.Stack)-1])
+
+	if step.Op == opSLOAD {
+		storage[key] = host.GetStorage(step.Address, key)
+	} else {
+		storage[key] = bigToHash(step.Stack[len(step.Stack)-2])
+	}
+
+	log.Storage = make(map[string]string, len(storage))
+	for k, v := range storage {
+		log.Storage[hex.EncodeToString(k.Bytes())] = hex.EncodeToString(v.Bytes())
+	}
+}
+
+// CaptureStateEnd implements the runtime.Tracer interface
+func (l *StructLogger) CaptureStateEnd(host runtime.Host, step *runtime.Step) {
+	if len(l.pending) == 0 {
+		return
+	}
+
+	log := l.pending[len(l.pending)-1]
+	l.pending = l.pending[:len(l.pending)-1]
+
+	log.GasCost = step.GasCost
+
+	if step.Err != nil {
+		log.Error = step.Err.Error()
+	}
+}
+
+// StructLogs returns the recorded opcodes
+func (l *StructLogger) StructLogs() []*StructLog {
+	return l.logs
+}
+
+// GetResult returns the trace of the transaction with the given execution result
+func (l *StructLogger) GetResult(result *runtime.ExecutionResult) (interface{}, error) {
+	if l.exceeded {
+		return nil, fmt.Errorf("%w: the struct logs exceed %d bytes", ErrTraceTooLarge, l.config.MaxBytes)
+	}
+
+	return &StructLogResult{
+		Gas:         result.GasUsed,
+		Failed:      result.Failed(),
+		ReturnValue: hex.EncodeToString(result.ReturnValue),
+		StructLogs:  l.logs,
+	}, nil
+}
+
+func bigToHash(b *big.Int) types.Hash {
+	return types.BytesToHash(b.Bytes())
+}
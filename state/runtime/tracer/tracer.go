@@ -0,0 +1,36 @@
+package tracer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+// Tracer traces the execution of a transaction and reports its result
+type Tracer interface {
+	runtime.Tracer
+
+	// GetResult returns the trace of the transaction with the given execution result
+	GetResult(result *runtime.ExecutionResult) (interface{}, error)
+}
+
+const (
+	// StructLoggerName is the name of the default tracer
+	StructLoggerName = "structLogger"
+
+	// CallTracerName is the name of the call tracer
+	CallTracerName = "callTracer"
+)
+
+// New creates the tracer with the given name (the struct logger if empty).
+// The config only applies to the struct logger
+func New(name string, config Config) (Tracer, error) {
+	switch name {
+	case "", StructLoggerName:
+		return NewStructLogger(config), nil
+	case CallTracerName:
+		return NewCallTracer(), nil
+	default:
+		return nil, fmt.Errorf("tracer %s not found", name)
+	}
+}
@@ -20,6 +20,9 @@ type State interface {
 
 type Snapshot interface {
 	Get(k []byte) ([]byte, bool)
+	// TryGet is Get reporting the state that can't be read
+	// (i.e. pruned), instead of taking it for an absent key
+	TryGet(k []byte) ([]byte, error)
 	Commit(objs []*Object) (Snapshot, []byte)
 }
 
@@ -3,8 +3,13 @@ package state
 import (
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -149,3 +154,743 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost_AccessList(t *testing.T) {
+	to := types.StringToAddress("1")
+
+	tests := []struct {
+		name        string
+		accessList  types.AccessList
+		expectedGas uint64
+	}{
+		{
+			name:        "should charge the base cost without an access list",
+			accessList:  nil,
+			expectedGas: TxGas,
+		},
+		{
+			name: "should charge per address and per storage key",
+			accessList: types.AccessList{
+				{
+					Address:     addr1,
+					StorageKeys: []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+				},
+				{
+					Address: addr2,
+				},
+			},
+			expectedGas: TxGas + 2*TxAccessListAddressGas + 2*TxAccessListStorageKeyGas,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &types.Transaction{
+				Type:       types.AccessListTx,
+				To:         &to,
+				AccessList: tt.accessList,
+			}
+
+			gas, err := TransactionGasCost(msg, true, true, true)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedGas, gas)
+
+			// the access list is free before Berlin
+			gas, err = TransactionGasCost(msg, true, true, false)
+			assert.NoError(t, err)
+			assert.Equal(t, TxGas, gas)
+		})
+	}
+}
+
+func TestAccessListTracer(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		contract = types.StringToAddress("20")
+		other    = types.StringToAddress("30")
+	)
+
+	// reads the storage slots 0, 1 and 2, then the balance of the other account
+	code := []byte{
+		0x60, 0x00, 0x54, 0x50, // PUSH1 0 SLOAD POP
+		0x60, 0x01, 0x54, 0x50, // PUSH1 1 SLOAD POP
+		0x60, 0x02, 0x54, 0x50, // PUSH1 2 SLOAD POP
+		0x73, // PUSH20 other
+	}
+	code = append(code, other.Bytes()...)
+	code = append(code, 0x31, 0x50, 0x00) // BALANCE POP STOP
+
+	apply := func(accessList types.AccessList) (*AccessListTracer, *runtime.ExecutionResult) {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: 1},
+		})
+		transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+		transition.config = chain.ForksInTime{Berlin: true}
+		transition.gasPool = 1000000
+		transition.state.SetCode(contract, code)
+
+		tracer := NewAccessListTracer(accessList, from, contract)
+		transition.SetAccessListTracer(tracer)
+
+		result, err := transition.Apply(&types.Transaction{
+			Type:       types.AccessListTx,
+			From:       from,
+			To:         &contract,
+			Gas:        100000,
+			GasPrice:   big.NewInt(0),
+			Value:      big.NewInt(0),
+			AccessList: accessList,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+
+		return tracer, result
+	}
+
+	tracer, result := apply(nil)
+
+	// the slots of the recipient are recorded, but not the recipient itself
+	accessList := tracer.AccessList()
+	assert.Equal(t, types.AccessList{
+		{
+			Address: contract,
+			StorageKeys: []types.Hash{
+				types.StringToHash("0"),
+				types.StringToHash("1"),
+				types.StringToHash("2"),
+			},
+		},
+		{
+			Address:     other,
+			StorageKeys: []types.Hash{},
+		},
+	}, accessList)
+	assert.False(t, tracer.Equal(nil))
+
+	// applying the transaction with its access list touches nothing else
+	tracer, resultWithList := apply(accessList)
+	assert.True(t, tracer.Equal(accessList))
+
+	// the access list adds its intrinsic cost
+	assert.Equal(
+		t,
+		result.GasUsed+2*TxAccessListAddressGas+3*TxAccessListStorageKeyGas,
+		resultWithList.GasUsed,
+	)
+}
+
+func TestTransition_StructLogger(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		contract = types.StringToAddress("20")
+	)
+
+	code := []byte{
+		0x60, 0x2a, 0x60, 0x01, 0x55, // PUSH1 42 PUSH1 1 SSTORE
+		0x60, 0x01, 0x54, 0x50, // PUSH1 1 SLOAD POP
+		0x00, // STOP
+	}
+
+	apply := func(config tracer.Config) (*tracer.StructLogger, *runtime.ExecutionResult) {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: 1},
+		})
+		transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+		transition.gasPool = 1000000
+		transition.state.SetCode(contract, code)
+
+		logger := tracer.NewStructLogger(config)
+		transition.SetTracer(logger)
+
+		result, err := transition.Apply(&types.Transaction{
+			From:     from,
+			To:       &contract,
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+
+		return logger, result
+	}
+
+	logger, _ := apply(tracer.Config{})
+	logs := logger.StructLogs()
+
+	ops := make([]string, len(logs))
+	for i, log := range logs {
+		ops[i] = log.Op
+	}
+
+	assert.Equal(t, []string{"PUSH1", "PUSH1", "SSTORE", "PUSH1", "SLOAD", "POP", "STOP"}, ops)
+
+	slot := "0000000000000000000000000000000000000000000000000000000000000001"
+	value := "000000000000000000000000000000000000000000000000000000000000002a"
+
+	sstore := logs[2]
+	assert.Equal(t, uint64(4), sstore.PC)
+	assert.Equal(t, 1, sstore.Depth)
+	assert.Equal(t, logs[1].Gas-logs[1].GasCost, sstore.Gas)
+	assert.Equal(t, uint64(20000), sstore.GasCost)
+	assert.Equal(t, []string{"0x2a", "0x1"}, sstore.Stack)
+	assert.Equal(t, map[string]string{slot: value}, sstore.Storage)
+
+	// the load reports the stored value
+	assert.Equal(t, map[string]string{slot: value}, logs[4].Storage)
+	assert.Equal(t, []string{"0x2a"}, logs[5].Stack)
+
+	// the disabled fields are left out
+	logger, _ = apply(tracer.Config{DisableStack: true, DisableMemory: true, DisableStorage: true})
+
+	for _, log := range logger.StructLogs() {
+		assert.Nil(t, log.Stack)
+		assert.Nil(t, log.Memory)
+		assert.Nil(t, log.Storage)
+	}
+}
+
+func TestTransition_CallTracer(t *testing.T) {
+	var (
+		from      = types.StringToAddress("10")
+		contract  = types.StringToAddress("20")
+		reverting = types.StringToAddress("30")
+		looping   = types.StringToAddress("40")
+	)
+
+	// calls the address with the given gas, without value nor input
+	call := func(addr types.Address, gas byte) []byte {
+		code := []byte{
+			0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, // PUSH1 0 (x5)
+			0x73, // PUSH20 addr
+		}
+		code = append(code, addr.Bytes()...)
+
+		return append(code, 0x61, gas, 0x00, 0xf1, 0x50) // PUSH2 gas CALL POP
+	}
+
+	code := append(call(reverting, 0x40), call(looping, 0x10)...)
+	code = append(code, 0x00) // STOP
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		from: {Balance: 1},
+	})
+	transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+	transition.config = chain.AllForksEnabled.At(0)
+	transition.gasPool = 1000000
+	transition.state.SetCode(contract, code)
+	transition.state.SetCode(reverting, []byte{0x60, 0x00, 0x60, 0x00, 0xfd}) // PUSH1 0 PUSH1 0 REVERT
+	transition.state.SetCode(looping, []byte{0x5b, 0x60, 0x00, 0x56})         // JUMPDEST PUSH1 0 JUMP
+
+	callTracer := tracer.NewCallTracer()
+	transition.SetTracer(callTracer)
+
+	result, err := transition.Apply(&types.Transaction{
+		From:     from,
+		To:       &contract,
+		Gas:      100000,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+		Input:    []byte{0x1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err)
+
+	res, err := callTracer.GetResult(result)
+	assert.NoError(t, err)
+
+	root, ok := res.(*tracer.CallFrame)
+	assert.True(t, ok)
+
+	assert.Equal(t, "CALL", root.Type)
+	assert.Equal(t, from, root.From)
+	assert.Equal(t, contract, root.To)
+	assert.Equal(t, "0x01", root.Input)
+	assert.Equal(t, "0x0", root.Value)
+	assert.Equal(t, hex.EncodeUint64(result.GasUsed), root.GasUsed)
+	assert.Empty(t, root.Error)
+
+	if !assert.Len(t, root.Calls, 2) {
+		t.FailNow()
+	}
+
+	// the internal revert is reported in its frame only
+	reverted := root.Calls[0]
+	assert.Equal(t, "CALL", reverted.Type)
+	assert.Equal(t, contract, reverted.From)
+	assert.Equal(t, reverting, reverted.To)
+	assert.Equal(t, "0x4000", reverted.Gas)
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), reverted.Error)
+	assert.Empty(t, reverted.Calls)
+
+	// the frame out of gas consumes all its gas
+	outOfGas := root.Calls[1]
+	assert.Equal(t, looping, outOfGas.To)
+	assert.Equal(t, "0x1000", outOfGas.Gas)
+	assert.Equal(t, "0x1000", outOfGas.GasUsed)
+	assert.Equal(t, runtime.ErrOutOfGas.Error(), outOfGas.Error)
+}
+
+func TestTransition_BaseFee(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		contract = types.StringToAddress("20")
+		header   = &types.Header{Number: 1, BaseFee: 875000000}
+	)
+
+	// returns block.basefee
+	code := []byte{
+		0x48,             // BASEFEE
+		0x60, 0x00, 0x52, // PUSH1 0 MSTORE
+		0x60, 0x20, 0x60, 0x00, 0xf3, // PUSH1 32 PUSH1 0 RETURN
+	}
+
+	apply := func(forks chain.ForksInTime) *runtime.ExecutionResult {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: 1000000000000000000},
+		})
+		transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+		transition.config = forks
+		transition.ctx = runtime.TxContext{
+			Number:  int64(header.Number),
+			BaseFee: header.BaseFee,
+		}
+		transition.gasPool = 1000000
+		transition.state.SetCode(contract, code)
+
+		result, err := transition.Apply(&types.Transaction{
+			From:     from,
+			To:       &contract,
+			Gas:      100000,
+			GasPrice: new(big.Int).SetUint64(header.BaseFee),
+			Value:    big.NewInt(0),
+		})
+		assert.NoError(t, err)
+
+		return result
+	}
+
+	t.Run("should return the base fee of the block", func(t *testing.T) {
+		result := apply(chain.AllForksEnabled.At(0))
+
+		assert.NoError(t, result.Err)
+		assert.Equal(t, header.BaseFee, new(big.Int).SetBytes(result.ReturnValue).Uint64())
+	})
+
+	t.Run("should be an invalid opcode before London", func(t *testing.T) {
+		forks := chain.AllForksEnabled.At(0)
+		forks.London = false
+
+		result := apply(forks)
+
+		assert.Error(t, result.Err)
+		assert.Empty(t, result.ReturnValue)
+	})
+}
+
+func TestTransition_FeeCap(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		to       = types.StringToAddress("20")
+		coinbase = types.StringToAddress("30")
+		baseFee  = uint64(1000)
+		balance  = uint64(1000000000)
+	)
+
+	newTransition := func() *Transition {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: balance},
+		})
+		transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+		transition.config = chain.AllForksEnabled.At(0)
+		transition.ctx = runtime.TxContext{
+			Number:   1,
+			Coinbase: coinbase,
+			BaseFee:  baseFee,
+		}
+		transition.gasPool = 1000000
+
+		return transition
+	}
+
+	legacyTx := func(gasPrice uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     from,
+			To:       &to,
+			Gas:      21000,
+			GasPrice: new(big.Int).SetUint64(gasPrice),
+			Value:    big.NewInt(0),
+		}
+	}
+
+	dynamicTx := func(maxFee, maxTip uint64) *types.Transaction {
+		return &types.Transaction{
+			Type:                 types.DynamicFeeTx,
+			From:                 from,
+			To:                   &to,
+			Gas:                  21000,
+			MaxFeePerGas:         new(big.Int).SetUint64(maxFee),
+			MaxPriorityFeePerGas: new(big.Int).SetUint64(maxTip),
+			Value:                big.NewInt(0),
+		}
+	}
+
+	t.Run("should reject fee caps below the base fee", func(t *testing.T) {
+		for _, msg := range []*types.Transaction{
+			legacyTx(baseFee - 1),
+			dynamicTx(baseFee-1, baseFee-1),
+		} {
+			transition := newTransition()
+
+			_, err := transition.Apply(msg)
+			assert.EqualError(t, err, ErrFeeCapTooLow.Error())
+
+			// nothing is charged
+			assert.Equal(t, new(big.Int).SetUint64(balance), transition.GetBalance(from))
+		}
+	})
+
+	t.Run("should burn the base fee and pay the tip to the coinbase", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			msg   *types.Transaction
+			price uint64
+		}{
+			{"legacy", legacyTx(1500), 1500},
+			{"dynamic under the cap", dynamicTx(2000, 300), 1300},
+			{"dynamic at the cap", dynamicTx(1200, 300), 1200},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				transition := newTransition()
+
+				result, err := transition.Apply(tt.msg)
+				assert.NoError(t, err)
+
+				gasUsed := new(big.Int).SetUint64(result.GasUsed)
+
+				paid := new(big.Int).Mul(gasUsed, new(big.Int).SetUint64(tt.price))
+				assert.Equal(t, paid, new(big.Int).Sub(new(big.Int).SetUint64(balance), transition.GetBalance(from)))
+
+				tip := new(big.Int).Mul(gasUsed, new(big.Int).SetUint64(tt.price-baseFee))
+				assert.Equal(t, tip, transition.GetBalance(coinbase))
+			})
+		}
+	})
+
+	t.Run("should let zero priced calls skip the base fee if set", func(t *testing.T) {
+		transition := newTransition()
+		transition.SetNoBaseFee()
+
+		_, err := transition.Apply(legacyTx(0))
+		assert.NoError(t, err)
+
+		// zero priced messages are rejected without it
+		_, err = newTransition().Apply(legacyTx(0))
+		assert.EqualError(t, err, ErrFeeCapTooLow.Error())
+
+		// and priced messages still pay the base fee with it
+		transition = newTransition()
+		transition.SetNoBaseFee()
+
+		_, err = transition.Apply(legacyTx(baseFee - 1))
+		assert.EqualError(t, err, ErrFeeCapTooLow.Error())
+	})
+}
+
+func TestTransition_TxType(t *testing.T) {
+	var (
+		from    = types.StringToAddress("10")
+		to      = types.StringToAddress("20")
+		balance = uint64(1000000000)
+	)
+
+	newTransition := func(forks chain.ForksInTime) *Transition {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: balance},
+		})
+		transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+		transition.config = forks
+		transition.gasPool = 1000000
+
+		return transition
+	}
+
+	tests := []struct {
+		name   string
+		msg    *types.Transaction
+		before chain.ForksInTime
+		after  chain.ForksInTime
+	}{
+		{
+			"access list tx",
+			&types.Transaction{
+				Type:     types.AccessListTx,
+				From:     from,
+				To:       &to,
+				Gas:      25000,
+				GasPrice: big.NewInt(10),
+				Value:    big.NewInt(0),
+				AccessList: types.AccessList{
+					{Address: to},
+				},
+			},
+			chain.ForksInTime{Homestead: true, Istanbul: true},
+			chain.ForksInTime{Homestead: true, Istanbul: true, Berlin: true},
+		},
+		{
+			"dynamic fee tx",
+			&types.Transaction{
+				Type:                 types.DynamicFeeTx,
+				From:                 from,
+				To:                   &to,
+				Gas:                  21000,
+				MaxFeePerGas:         big.NewInt(10),
+				MaxPriorityFeePerGas: big.NewInt(10),
+				Value:                big.NewInt(0),
+			},
+			chain.ForksInTime{Homestead: true, Istanbul: true, Berlin: true},
+			chain.ForksInTime{Homestead: true, Istanbul: true, Berlin: true, London: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the tx is rejected before the fork introducing its type
+			transition := newTransition(tt.before)
+
+			_, err := transition.Apply(tt.msg)
+			assert.EqualError(t, err, types.ErrTxTypeNotSupported.Error())
+			assert.Equal(t, new(big.Int).SetUint64(balance), transition.GetBalance(from))
+
+			_, err = newTransition(tt.after).Apply(tt.msg)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransition_ForkActivation(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		contract = types.StringToAddress("20")
+	)
+
+	// Istanbul, and with it CHAINID, activates at block 5
+	executor := &Executor{
+		config: &chain.Params{
+			ChainID: 100,
+			Forks: &chain.Forks{
+				Homestead: chain.NewFork(0),
+				Byzantium: chain.NewFork(0),
+				Istanbul:  chain.NewFork(5),
+			},
+		},
+		runtimes: []runtime.Runtime{evm.NewEVM()},
+	}
+
+	// returns chainid
+	code := []byte{
+		0x46,             // CHAINID
+		0x60, 0x00, 0x52, // PUSH1 0 MSTORE
+		0x60, 0x20, 0x60, 0x00, 0xf3, // PUSH1 32 PUSH1 0 RETURN
+	}
+
+	apply := func(number uint64) *runtime.ExecutionResult {
+		transition := newTestTransition(map[types.Address]*PreState{
+			from: {Balance: 1},
+		})
+		transition.r = executor
+		transition.config = executor.GetForksInTime(number)
+		transition.ctx = runtime.TxContext{
+			Number:  int64(number),
+			ChainID: int64(executor.config.ChainID),
+		}
+		transition.gasPool = 1000000
+		transition.state.SetCode(contract, code)
+
+		result, err := transition.Apply(&types.Transaction{
+			From:     from,
+			To:       &contract,
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		})
+		assert.NoError(t, err)
+
+		return result
+	}
+
+	t.Run("should be an invalid opcode before the activation", func(t *testing.T) {
+		result := apply(4)
+
+		assert.Error(t, result.Err)
+		assert.Empty(t, result.ReturnValue)
+	})
+
+	t.Run("should return the chain id from the activation on", func(t *testing.T) {
+		for _, number := range []uint64{5, 6} {
+			result := apply(number)
+
+			assert.NoError(t, result.Err)
+			assert.Equal(t, uint64(100), new(big.Int).SetBytes(result.ReturnValue).Uint64())
+		}
+	})
+}
+
+func TestTransition_Cancel(t *testing.T) {
+	var (
+		from     = types.StringToAddress("10")
+		contract = types.StringToAddress("20")
+		gas      = uint64(1000000000000)
+	)
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		from: {Balance: 1},
+	})
+	transition.r = &Executor{runtimes: []runtime.Runtime{evm.NewEVM()}}
+	transition.gasPool = gas
+	// JUMPDEST PUSH1 0 JUMP, loops until the gas runs out
+	transition.state.SetCode(contract, []byte{0x5b, 0x60, 0x00, 0x56})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		transition.Cancel()
+	}()
+
+	result, err := transition.Apply(&types.Transaction{
+		From:     from,
+		To:       &contract,
+		Gas:      gas,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+	})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, result.Err, runtime.ErrExecutionCancelled)
+}
+
+func TestTransition_ExecuteDependentTxs(t *testing.T) {
+	var (
+		sender    = types.StringToAddress("10")
+		funded    = types.StringToAddress("20")
+		recipient = types.StringToAddress("30")
+	)
+
+	newTransition := func() *Transition {
+		transition := newTestTransition(map[types.Address]*PreState{
+			sender: {Balance: 1000},
+		})
+		transition.r = &Executor{
+			config:   &chain.Params{ChainID: 100, Forks: chain.AllForksEnabled},
+			runtimes: []runtime.Runtime{evm.NewEVM()},
+		}
+		transition.config = chain.AllForksEnabled.At(0)
+		transition.gasPool = 1000000
+
+		return transition
+	}
+
+	transfer := func(from, to types.Address, value int64) *types.Transaction {
+		return &types.Transaction{
+			From:     from,
+			To:       &to,
+			Gas:      21000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(value),
+		}
+	}
+
+	// the second transfer spends the funds of the first one
+	first := transfer(sender, funded, 600)
+	second := transfer(funded, recipient, 500)
+
+	t.Run("should execute over the changes of the previous transactions", func(t *testing.T) {
+		transition := newTransition()
+
+		for _, txn := range []*types.Transaction{first, second} {
+			result, err := transition.Execute(txn.Copy())
+			assert.NoError(t, err)
+			assert.NoError(t, result.Err)
+			assert.Equal(t, uint64(21000), result.GasUsed)
+		}
+
+		receipts := transition.Receipts()
+		assert.Len(t, receipts, 2)
+		assert.Equal(t, uint64(42000), receipts[1].CumulativeGasUsed)
+
+		assert.Equal(t, big.NewInt(400), transition.GetBalance(sender))
+		assert.Equal(t, big.NewInt(100), transition.GetBalance(funded))
+		assert.Equal(t, big.NewInt(500), transition.GetBalance(recipient))
+	})
+
+	t.Run("should fail without the previous transactions", func(t *testing.T) {
+		transition := newTransition()
+
+		result, err := transition.Execute(second.Copy())
+		assert.EqualError(t, err, ErrNotEnoughFunds.Error())
+		assert.Nil(t, result)
+		assert.Empty(t, transition.Receipts())
+	})
+}
+
+func TestTransition_ApplyStateOverride(t *testing.T) {
+	state, snap := newStateWithPreState(map[types.Address]*PreState{
+		addr1: {Nonce: 1, Balance: 10},
+	})
+
+	// the transitions are started on the same state
+	newTransition := func() *Transition {
+		txn := newTxn(state, snap)
+		txn.SetState(addr1, hash1, hash1)
+
+		return &Transition{
+			logger: hclog.NewNullLogger(),
+			state:  txn,
+		}
+	}
+
+	nonce := uint64(5)
+	code := []byte{0x60, 0x00}
+
+	transition := newTransition()
+	assert.NoError(t, transition.ApplyStateOverride(types.StateOverride{
+		addr1: {
+			Nonce:   &nonce,
+			Balance: big.NewInt(100),
+			Code:    code,
+			State:   map[types.Hash]types.Hash{hash2: hash2},
+		},
+		addr2: {
+			StateDiff: map[types.Hash]types.Hash{hash1: hash2},
+		},
+	}))
+
+	assert.Equal(t, nonce, transition.GetNonce(addr1))
+	assert.Equal(t, big.NewInt(100), transition.GetBalance(addr1))
+	assert.Equal(t, code, transition.GetCode(addr1))
+
+	// the storage is replaced by the state override
+	assert.Equal(t, types.ZeroHash, transition.GetStorage(addr1, hash1))
+	assert.Equal(t, hash2, transition.GetStorage(addr1, hash2))
+
+	// and patched by the state diff
+	assert.Equal(t, hash2, transition.GetStorage(addr2, hash1))
+
+	// the overrides don't leak into the other transitions
+	other := newTransition()
+
+	assert.Equal(t, uint64(1), other.GetNonce(addr1))
+	assert.Equal(t, big.NewInt(10), other.GetBalance(addr1))
+	assert.NotEqual(t, transition.GetCodeHash(addr1), other.GetCodeHash(addr1))
+	assert.Equal(t, hash1, other.GetStorage(addr1, hash1))
+	assert.Equal(t, types.ZeroHash, other.GetStorage(addr1, hash2))
+	assert.False(t, other.AccountExists(addr2))
+
+	// the storage can't be both replaced and patched
+	assert.ErrorIs(t, other.ApplyStateOverride(types.StateOverride{
+		addr1: {
+			State:     map[types.Hash]types.Hash{},
+			StateDiff: map[types.Hash]types.Hash{},
+		},
+	}), types.ErrStateAndStateDiff)
+}
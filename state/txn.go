@@ -31,6 +31,9 @@ type Txn struct {
 	txn       *iradix.Txn
 	codeCache *lru.Cache
 	hash      *keccak.Keccak
+
+	// accessed records the accounts read or written, if set
+	accessed map[types.Address]struct{}
 }
 
 func NewTxn(state State, snapshot Snapshot) *Txn {
@@ -92,7 +95,44 @@ func (txn *Txn) GetAccount(addr types.Address) (*Account, bool) {
 	return object.Account, true
 }
 
+// trackAccesses makes the txn record the accounts it reads or writes
+func (txn *Txn) trackAccesses() {
+	txn.accessed = map[types.Address]struct{}{}
+}
+
+// accessedAny returns true if the txn accessed any of the given accounts
+func (txn *Txn) accessedAny(addrs map[types.Address]struct{}) bool {
+	for addr := range addrs {
+		if _, ok := txn.accessed[addr]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge copies the accounts written by other into the txn, and records the accounts
+// other accessed. The txn must not have accessed any of them, so that other was
+// executed on the same view of those accounts
+func (txn *Txn) merge(other *Txn) {
+	other.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		if obj, ok := v.(*StateObject); ok {
+			txn.txn.Insert(k, obj)
+		}
+
+		return false
+	})
+
+	for addr := range other.accessed {
+		txn.accessed[addr] = struct{}{}
+	}
+}
+
 func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
+	if txn.accessed != nil {
+		txn.accessed[addr] = struct{}{}
+	}
+
 	// Try to get state from radix tree which holds transient states during block processing first
 	val, exists := txn.txn.Get(addr.Bytes())
 	if exists {
@@ -339,6 +379,21 @@ func (txn *Txn) SetState(
 	})
 }
 
+// SetFullStorage replaces the whole storage of an address
+func (txn *Txn) SetFullStorage(addr types.Address, storage map[types.Hash]types.Hash) {
+	txn.upsertAccount(addr, true, func(object *StateObject) {
+		object.Account.Root = emptyStateHash
+		object.Account.Trie = txn.state.NewSnapshot()
+		object.Txn = iradix.New().Txn()
+
+		for key, value := range storage {
+			if value != zeroHash {
+				object.Txn.Insert(key.Bytes(), value.Bytes())
+			}
+		}
+	})
+}
+
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
 	object, exists := txn.getStateObject(addr)
@@ -45,6 +45,10 @@ func (m *mockSnapshot) Get(k []byte) ([]byte, bool) {
 	return v, ok
 }
 
+func (m *mockSnapshot) TryGet(k []byte) ([]byte, error) {
+	return m.data[hex.EncodeToHex(k)], nil
+}
+
 func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
 	panic("Not implemented in tests")
 }
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -11,7 +12,6 @@ import (
 // Each account (value) is bound to one address (key).
 type accountsMap struct {
 	sync.Map
-	count uint64
 }
 
 // Intializes an account for the given address.
@@ -26,9 +26,6 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 
 		// set the nonce
 		newAccount.setNonce(nonce)
-
-		// update global count
-		atomic.AddUint64(&m.count, 1)
 	})
 
 	return newAccount
@@ -103,6 +100,7 @@ func (m *accountsMap) promoted() (total uint64) {
 }
 
 // allTxs returns all promoted and all enqueued transactions, depending on the flag.
+// The returned slices are copies, safe to use after the queues are unlocked.
 func (m *accountsMap) allTxs(includeEnqueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
 ) {
@@ -117,7 +115,7 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 		defer account.promoted.unlock()
 
 		if account.promoted.length() != 0 {
-			allPromoted[addr] = account.promoted.queue
+			allPromoted[addr] = account.promoted.snapshot()
 		}
 
 		if includeEnqueued {
@@ -125,7 +123,7 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 			defer account.enqueued.unlock()
 
 			if account.enqueued.length() != 0 {
-				allEnqueued[addr] = account.enqueued.queue
+				allEnqueued[addr] = account.enqueued.snapshot()
 			}
 		}
 
@@ -150,6 +148,9 @@ type account struct {
 	init               sync.Once
 	enqueued, promoted *accountQueue
 	nextNonce          uint64
+
+	// set while the account holds any transaction
+	holding uint32
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -162,6 +163,55 @@ func (a *account) setNonce(nonce uint64) {
 	atomic.StoreUint64(&a.nextNonce, nonce)
 }
 
+// setHolding records whether the account currently holds any transaction.
+// Returns the counted change in accounts holding transactions (-1, 0 or 1).
+func (a *account) setHolding() int {
+	a.promoted.lock(false)
+	a.enqueued.lock(false)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	var holding uint32
+	if a.promoted.length()+a.enqueued.length() > 0 {
+		holding = 1
+	}
+
+	if atomic.SwapUint32(&a.holding, holding) == holding {
+		return 0
+	}
+
+	if holding == 1 {
+		return 1
+	}
+
+	return -1
+}
+
+// pendingNonce returns the next nonce of the account, accounting for
+// the enqueued transactions that are sequential to it but are still
+// waiting to be promoted. Future nonce transactions past a gap are ignored.
+func (a *account) pendingNonce() uint64 {
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	enqueued := make(map[uint64]struct{}, a.enqueued.length())
+	for _, tx := range a.enqueued.queue {
+		enqueued[tx.Nonce] = struct{}{}
+	}
+
+	nonce := a.getNonce()
+	for {
+		if _, ok := enqueued[nonce]; !ok {
+			return nonce
+		}
+
+		nonce++
+	}
+}
+
 //	reset aligns the account with the new nonce
 //	by pruning all transactions with nonce lesser than new.
 //	After pruning, a promotion may be signaled if the first
@@ -208,20 +258,215 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	return
 }
 
+// evict removes the transactions that satisfy the given condition.
+// The promoted transactions following an evicted one are demoted
+// to the enqueued queue, and the nonce expected for this account
+// is rolled back to the first evicted promoted transaction.
+func (a *account) evict(cond func(tx *types.Transaction) bool) (
+	prunedPromoted,
+	prunedEnqueued,
+	demoted []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	prunedEnqueued = a.enqueued.pruneIf(cond)
+
+	// find the first evicted promoted tx
+	var (
+		firstNonce uint64
+		found      bool
+	)
+
+	for _, tx := range a.promoted.queue {
+		if cond(tx) && (!found || tx.Nonce < firstNonce) {
+			firstNonce = tx.Nonce
+			found = true
+		}
+	}
+
+	if !found {
+		return
+	}
+
+	// the txs following it are no longer executable
+	for _, tx := range a.promoted.pruneIf(func(tx *types.Transaction) bool {
+		return tx.Nonce >= firstNonce
+	}) {
+		if cond(tx) {
+			prunedPromoted = append(prunedPromoted, tx)
+		} else {
+			demoted = append(demoted, tx)
+			a.enqueued.push(tx)
+		}
+	}
+
+	a.setNonce(firstNonce)
+
+	return
+}
+
+// getTx returns the transaction with the given nonce
+// from either the promoted or the enqueued queue (if any).
+func (a *account) getTx(nonce uint64) *types.Transaction {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	if tx := a.promoted.get(nonce); tx != nil {
+		return tx
+	}
+
+	return a.enqueued.get(nonce)
+}
+
+// costWith returns the cumulative cost of the account's transactions
+// (promoted or enqueued) along with the given one, which takes the place
+// of the transaction with the same nonce (if any).
+func (a *account) costWith(tx *types.Transaction) *big.Int {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	cost := tx.Cost()
+	cost.Add(cost, a.promoted.totalCost())
+	cost.Add(cost, a.enqueued.totalCost())
+
+	if existing := a.promoted.get(tx.Nonce); existing != nil {
+		cost.Sub(cost, existing.Cost())
+	} else if existing := a.enqueued.get(tx.Nonce); existing != nil {
+		cost.Sub(cost, existing.Cost())
+	}
+
+	return cost
+}
+
+// nonceGap returns the lowest missing nonce that blocks the promotion
+// of the account's enqueued transactions, if there is one.
+// Only the head of the enqueued queue is inspected.
+func (a *account) nonceGap() (missing uint64, enqueued uint64, found bool) {
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	first := a.enqueued.peek()
+	if first == nil {
+		return 0, 0, false
+	}
+
+	nextNonce := a.getNonce()
+	if first.Nonce <= nextNonce {
+		// the head is (about to be) promoted
+		return 0, 0, false
+	}
+
+	return nextNonce, a.enqueued.length(), true
+}
+
+// exceedsEnqueuedLimit checks if enqueuing the transaction would exceed
+// the account's limit of enqueued transactions (0 is no limit).
+// Only the transactions behind a nonce gap are counted against the limit,
+// so the transactions following the promoted tail are always accepted.
+func (a *account) exceedsEnqueuedLimit(tx *types.Transaction, limit uint64) bool {
+	// write lock, the sequence of the enqueued txs is cached
+	a.enqueued.lock(true)
+	defer a.enqueued.unlock()
+
+	return a.enqueuedLimitReached(tx, limit)
+}
+
+func (a *account) enqueuedLimitReached(tx *types.Transaction, limit uint64) bool {
+	if limit == 0 {
+		return false
+	}
+
+	tail, gapped := a.enqueuedGap()
+
+	return tx.Nonce > tail && gapped >= limit
+}
+
+// enqueuedGap returns the nonce following the enqueued transactions
+// that extend the promoted tail without a gap, and the number of
+// enqueued transactions behind the gap
+func (a *account) enqueuedGap() (tail uint64, gapped uint64) {
+	nextNonce := a.getNonce()
+	tail = a.enqueued.sequenceEnd(nextNonce)
+
+	// the enqueued txs have unique nonces, not lower than the next one
+	if sequential := tail - nextNonce; sequential < a.enqueued.length() {
+		gapped = a.enqueued.length() - sequential
+	}
+
+	return tail, gapped
+}
+
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) error {
+// If the account already holds a transaction with the same nonce
+// (promoted or enqueued), it is replaced in place, provided the new
+// transaction is priced high enough. The replaced transaction is returned.
+func (a *account) enqueue(tx *types.Transaction, limit, priceBump uint64) (*types.Transaction, error) {
+	a.promoted.lock(true)
+	defer a.promoted.unlock()
+
 	a.enqueued.lock(true)
 	defer a.enqueued.unlock()
 
+	// replace the tx with the same nonce (if any)
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		existing := queue.get(tx.Nonce)
+		if existing == nil {
+			continue
+		}
+
+		if !isReplacementPriced(existing, tx, priceBump) {
+			return nil, ErrReplaceUnderpriced
+		}
+
+		return queue.replace(tx), nil
+	}
+
 	// reject low nonce tx
 	if tx.Nonce < a.getNonce() {
-		return ErrNonceTooLow
+		return nil, ErrNonceTooLow
+	}
+
+	// reject tx if the account holds too many future txs
+	if a.enqueuedLimitReached(tx, limit) {
+		return nil, ErrTooManyAccountTxs
 	}
 
 	// enqueue tx
 	a.enqueued.push(tx)
 
-	return nil
+	return nil, nil
+}
+
+// isReplacementPriced checks if the replacement transaction
+// pays at least priceBump percent more than the existing one.
+// For dynamic fee transactions both fee caps need to be bumped.
+func isReplacementPriced(existing, replacement *types.Transaction, priceBump uint64) bool {
+	bumped := func(oldPrice, newPrice *big.Int) bool {
+		// newPrice * 100 >= oldPrice * (100 + priceBump)
+		threshold := new(big.Int).Mul(oldPrice, new(big.Int).SetUint64(100+priceBump))
+		scaled := new(big.Int).Mul(newPrice, big.NewInt(100))
+
+		return newPrice.Cmp(oldPrice) > 0 && scaled.Cmp(threshold) >= 0
+	}
+
+	if existing.Type == types.DynamicFeeTx && replacement.Type == types.DynamicFeeTx {
+		return bumped(existing.MaxFeePerGas, replacement.MaxFeePerGas) &&
+			bumped(existing.MaxPriorityFeePerGas, replacement.MaxPriorityFeePerGas)
+	}
+
+	return bumped(existing.GetGasPrice(0), replacement.GetGasPrice(0))
 }
 
 // Promote moves eligible transactions from enqueued to promoted.
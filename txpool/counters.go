@@ -0,0 +1,51 @@
+package txpool
+
+import "sync/atomic"
+
+// Counters of the transactions held by the pool,
+// tracked so that status queries run in constant time.
+type txCounters struct {
+	pending  int64 // promoted (executable) transactions
+	queued   int64 // enqueued (future nonce) transactions
+	accounts int64 // accounts holding at least one transaction
+}
+
+// addPending adjusts the number of pending transactions by delta.
+func (c *txCounters) addPending(delta int) {
+	atomic.AddInt64(&c.pending, int64(delta))
+}
+
+// addQueued adjusts the number of queued transactions by delta.
+func (c *txCounters) addQueued(delta int) {
+	atomic.AddInt64(&c.queued, int64(delta))
+}
+
+// addAccounts adjusts the number of accounts holding transactions by delta.
+func (c *txCounters) addAccounts(delta int) {
+	atomic.AddInt64(&c.accounts, int64(delta))
+}
+
+// readPending returns the current number of pending transactions.
+func (c *txCounters) readPending() uint64 {
+	return clampCount(atomic.LoadInt64(&c.pending))
+}
+
+// readQueued returns the current number of queued transactions.
+func (c *txCounters) readQueued() uint64 {
+	return clampCount(atomic.LoadInt64(&c.queued))
+}
+
+// readAccounts returns the current number of accounts holding transactions.
+func (c *txCounters) readAccounts() uint64 {
+	return clampCount(atomic.LoadInt64(&c.accounts))
+}
+
+// clampCount guards against transient negative reads caused
+// by concurrent adjustments of the same counter.
+func clampCount(n int64) uint64 {
+	if n < 0 {
+		return 0
+	}
+
+	return uint64(n)
+}
@@ -0,0 +1,102 @@
+package txpool
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// admissionError is an error rejecting a transaction
+// along with the status code the operator returns for it
+// and the reason label of the rejected transactions metric
+type admissionError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// admissionErrors are the errors returned by the admission checks of the pool
+var admissionErrors = []admissionError{
+	// malformed transactions
+	{ErrIntrinsicGas, codes.InvalidArgument, "intrinsic_gas"},
+	{ErrNegativeValue, codes.InvalidArgument, "negative_value"},
+	{ErrNonEncryptedTx, codes.InvalidArgument, "non_encrypted"},
+	{ErrInvalidSender, codes.InvalidArgument, "invalid_sender"},
+	{ErrTipAboveFeeCap, codes.InvalidArgument, "tip_above_fee_cap"},
+
+	// transactions rejected by the local limits, which other nodes may accept
+	{ErrGasLimitTooHigh, codes.FailedPrecondition, "gas_limit_too_high"},
+	{ErrOversizedData, codes.FailedPrecondition, "oversized_data"},
+
+	// transactions invalid against the current state or pool
+	{ErrNonceTooLow, codes.FailedPrecondition, "nonce_too_low"},
+	{ErrInsufficientFunds, codes.FailedPrecondition, "insufficient_funds"},
+	{ErrInvalidAccountState, codes.FailedPrecondition, "invalid_account_state"},
+	{ErrUnderpriced, codes.FailedPrecondition, "underpriced"},
+	{ErrReplaceUnderpriced, codes.FailedPrecondition, "replace_underpriced"},
+	{crypto.ErrUnprotectedTx, codes.FailedPrecondition, "unprotected"},
+	{types.ErrTxTypeNotSupported, codes.FailedPrecondition, "tx_type_not_supported"},
+
+	{ErrAlreadyKnown, codes.AlreadyExists, "already_known"},
+
+	// transactions which fit once the pool has room
+	{ErrTxPoolOverflow, codes.ResourceExhausted, "pool_overflow"},
+	{ErrTooManyAccountTxs, codes.ResourceExhausted, "too_many_account_txs"},
+}
+
+// isMalformedTxError checks if the admission error rejects a malformed
+// transaction, which no honest peer would pass on
+func isMalformedTxError(err error) bool {
+	for _, admissionErr := range admissionErrors {
+		if errors.Is(err, admissionErr.err) {
+			return admissionErr.code == codes.InvalidArgument
+		}
+	}
+
+	return false
+}
+
+// rejectionReason returns the reason label of the admission error
+// for the rejected transactions metric
+func rejectionReason(err error) string {
+	for _, admissionErr := range admissionErrors {
+		if errors.Is(err, admissionErr.err) {
+			return admissionErr.reason
+		}
+	}
+
+	return "other"
+}
+
+// toStatusError converts an admission error to a gRPC status error.
+// The message of the error is kept as the status description
+func toStatusError(err error) error {
+	for _, admissionErr := range admissionErrors {
+		if errors.Is(err, admissionErr.err) {
+			return grpcStatus.Error(admissionErr.code, err.Error())
+		}
+	}
+
+	return err
+}
+
+// FromStatusError converts a status error returned by the operator
+// back to the admission error it carries, so the callers can check it
+// with errors.Is. Other errors are returned as they are
+func FromStatusError(err error) error {
+	st, ok := grpcStatus.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, admissionErr := range admissionErrors {
+		if st.Code() == admissionErr.code && st.Message() == admissionErr.err.Error() {
+			return admissionErr.err
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,116 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	any "google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestStatusError(t *testing.T) {
+	for _, admissionErr := range admissionErrors {
+		// the wrapped admission errors are converted as well
+		err := toStatusError(fmt.Errorf("%w", admissionErr.err))
+
+		st, ok := grpcStatus.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, admissionErr.code, st.Code())
+		assert.Equal(t, admissionErr.err.Error(), st.Message())
+
+		assert.ErrorIs(t, FromStatusError(err), admissionErr.err)
+	}
+
+	// the other errors are left as they are
+	otherErr := errors.New("other")
+	assert.Equal(t, otherErr, toStatusError(otherErr))
+	assert.Equal(t, otherErr, FromStatusError(otherErr))
+
+	unknownStatus := grpcStatus.Error(codes.FailedPrecondition, "other")
+	assert.Equal(t, unknownStatus, FromStatusError(unknownStatus))
+}
+
+func TestAddTxn_StatusCodes(t *testing.T) {
+	lowGasTx := newTx(addr1, 1, 1)
+	lowGasTx.Gas = 1
+
+	highGasTx := newTx(addr1, 1, 1)
+	highGasTx.Gas = mockHeader.GasLimit + 1
+
+	testCases := []struct {
+		name        string
+		raw         []byte
+		priceLimit  uint64
+		expectedErr error
+		code        codes.Code
+	}{
+		{
+			"malformed transaction",
+			[]byte{0xff},
+			defaultPriceLimit,
+			nil,
+			codes.InvalidArgument,
+		},
+		{
+			"intrinsic gas too low",
+			lowGasTx.MarshalRLP(),
+			defaultPriceLimit,
+			ErrIntrinsicGas,
+			codes.InvalidArgument,
+		},
+		{
+			"gas limit too high",
+			highGasTx.MarshalRLP(),
+			defaultPriceLimit,
+			ErrGasLimitTooHigh,
+			codes.FailedPrecondition,
+		},
+		{
+			"nonce too low",
+			newTx(addr1, 0, 1).MarshalRLP(),
+			defaultPriceLimit,
+			ErrNonceTooLow,
+			codes.FailedPrecondition,
+		},
+		{
+			"underpriced",
+			newTx(addr1, 1, 1).MarshalRLP(),
+			1000000,
+			ErrUnderpriced,
+			codes.FailedPrecondition,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			pool, err := newTestPool(nonceMockStore{
+				defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+				nonces:           map[types.Address]uint64{addr1: 1},
+			})
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+			pool.priceLimit = testCase.priceLimit
+
+			_, err = pool.AddTxn(context.Background(), &proto.AddTxnReq{
+				Raw:  &any.Any{Value: testCase.raw},
+				From: addr1.String(),
+			})
+
+			assert.Equal(t, testCase.code, grpcStatus.Code(err))
+
+			if testCase.expectedErr != nil {
+				// the message is the admission error
+				assert.Equal(t, testCase.expectedErr.Error(), grpcStatus.Convert(err).Message())
+				assert.ErrorIs(t, FromStatusError(err), testCase.expectedErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,10 @@ import (
 	"sync/atomic"
 )
 
+// subscriptionBufferSize is the maximum number of events buffered
+// for a single stream subscriber before it is considered too slow and dropped
+const subscriptionBufferSize = 1024
+
 type eventManager struct {
 	subscriptions     map[subscriptionID]*eventSubscription
 	subscriptionsLock sync.RWMutex
@@ -30,8 +34,21 @@ type subscribeResult struct {
 	subscriptionChannel chan *proto.TxPoolEvent
 }
 
-// subscribe registers a new listener for TxPool events
+// subscribe registers a new in-process listener for TxPool events.
+// Its events are buffered without a bound, so it's never dropped
 func (em *eventManager) subscribe(eventTypes []proto.EventType) *subscribeResult {
+	return em.addSubscription(eventTypes, 0)
+}
+
+// subscribeStream registers a new listener streaming the TxPool events to an external consumer.
+// It's dropped once it falls subscriptionBufferSize events behind, so a slow consumer
+// can't hold up the pool or make it buffer the events without a bound
+func (em *eventManager) subscribeStream(eventTypes []proto.EventType) *subscribeResult {
+	return em.addSubscription(eventTypes, subscriptionBufferSize)
+}
+
+// addSubscription registers a new listener buffering up to maxBuffered events (0 is unbounded)
+func (em *eventManager) addSubscription(eventTypes []proto.EventType, maxBuffered int) *subscribeResult {
 	em.subscriptionsLock.Lock()
 	defer em.subscriptionsLock.Unlock()
 
@@ -42,7 +59,8 @@ func (em *eventManager) subscribe(eventTypes []proto.EventType) *subscribeResult
 		doneCh:     make(chan struct{}),
 		notifyCh:   make(chan struct{}, 1),
 		eventStore: &eventQueue{
-			events: make([]*proto.TxPoolEvent, 0),
+			events:  make([]*proto.TxPoolEvent, 0),
+			maxSize: maxBuffered,
 		},
 	}
 
@@ -81,26 +99,78 @@ func (em *eventManager) Close() {
 		subscription.close()
 	}
 
+	em.subscriptions = make(map[subscriptionID]*eventSubscription)
 	atomic.StoreInt64(&em.numSubscriptions, 0)
 }
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
-func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+func (em *eventManager) signalEvent(eventType proto.EventType, txs ...*types.Transaction) {
+	em.signalEventWithReason(eventType, "", txs...)
+}
+
+// signalEventWithReason alerts listeners of a new TxPool event, along with the reason
+// that caused it. Stream subscribers that can't keep up with the events are dropped
+func (em *eventManager) signalEventWithReason(
+	eventType proto.EventType,
+	reason string,
+	txs ...*types.Transaction,
+) {
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
 		return
 	}
 
+	slowSubscriptions := em.pushEvents(eventType, reason, txs)
+
+	for _, id := range slowSubscriptions {
+		em.logger.Warn(fmt.Sprintf("Dropping subscription %d, event buffer is full", id))
+		em.cancelSubscription(id)
+	}
+}
+
+// pushEvents pushes the events to all subscriptions,
+// and returns the ones that have a full event buffer
+func (em *eventManager) pushEvents(
+	eventType proto.EventType,
+	reason string,
+	txs []*types.Transaction,
+) []subscriptionID {
 	em.subscriptionsLock.RLock()
 	defer em.subscriptionsLock.RUnlock()
 
-	for _, txHash := range txHashes {
-		for _, subscription := range em.subscriptions {
-			subscription.pushEvent(&proto.TxPoolEvent{
-				Type:   eventType,
-				TxHash: txHash.String(),
-			})
+	// the subscriptions interested in the event type
+	subscribed := make(map[subscriptionID]*eventSubscription)
+
+	for id, subscription := range em.subscriptions {
+		if subscription.eventSupported(eventType) {
+			subscribed[id] = subscription
+		}
+	}
+
+	slowSubscriptions := make([]subscriptionID, 0)
+
+	for _, tx := range txs {
+		if len(subscribed) == 0 {
+			break
+		}
+
+		// the event is built once and shared by the subscriptions
+		event := &proto.TxPoolEvent{
+			Type:   eventType,
+			TxHash: tx.Hash.String(),
+			Sender: tx.From.String(),
+			Nonce:  tx.Nonce,
+			Reason: reason,
+		}
+
+		for id, subscription := range subscribed {
+			if !subscription.pushEvent(event) {
+				slowSubscriptions = append(slowSubscriptions, id)
+				delete(subscribed, id)
+			}
 		}
 	}
+
+	return slowSubscriptions
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -98,11 +99,11 @@ func TestEventManager_SignalEvent(t *testing.T) {
 	}
 
 	mockEvents := shuffleTxPoolEvents(supportedEventTypes, totalEvents, invalidEvents)
-	mockHash := types.StringToHash(mockEvents[0].TxHash)
+	mockTx := &types.Transaction{Hash: types.StringToHash(mockEvents[0].TxHash)}
 
 	// Send the events
 	for _, mockEvent := range mockEvents {
-		em.signalEvent(mockEvent.Type, mockHash)
+		em.signalEvent(mockEvent.Type, mockTx)
 	}
 
 	// Make sure all valid events get processed
@@ -149,7 +150,7 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 	subscription := em.subscribe(supportedEventTypes)
 
 	mockEvents := shuffleTxPoolEvents(supportedEventTypes, totalEvents, 0)
-	mockHash := types.StringToHash(mockEvents[0].TxHash)
+	mockTx := &types.Transaction{Hash: types.StringToHash(mockEvents[0].TxHash)}
 	eventsProcessed := 0
 
 	var wg sync.WaitGroup
@@ -160,13 +161,16 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 		for {
 			select {
 			case event, more := <-subscription.subscriptionChannel:
-				if more {
-					assert.Equal(t, mockEvents[eventsProcessed].Type, event.Type)
+				if !more {
+					// the subscription is closed
+					return
+				}
 
-					eventsProcessed++
+				assert.Equal(t, mockEvents[eventsProcessed].Type, event.Type)
 
-					wg.Done()
-				}
+				eventsProcessed++
+
+				wg.Done()
 			case <-time.After(time.Second * 5):
 				for i := 0; i < totalEvents-eventsProcessed; i++ {
 					wg.Done()
@@ -177,7 +181,7 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 
 	// Send the events
 	for _, mockEvent := range mockEvents {
-		em.signalEvent(mockEvent.Type, mockHash)
+		em.signalEvent(mockEvent.Type, mockTx)
 	}
 
 	// Make sure all valid events get processed
@@ -185,3 +189,67 @@ func TestEventManager_SignalEventOrder(t *testing.T) {
 
 	assert.Equal(t, totalEvents, eventsProcessed)
 }
+
+func TestEventManager_SignalEventDetails(t *testing.T) {
+	em := newEventManager(hclog.NewNullLogger())
+
+	defer em.Close()
+
+	subscription := em.subscribe([]proto.EventType{proto.EventType_DROPPED})
+
+	tx := &types.Transaction{
+		Hash:  types.StringToHash("123"),
+		From:  types.StringToAddress("456"),
+		Nonce: 7,
+	}
+
+	em.signalEventWithReason(proto.EventType_DROPPED, "some reason", tx)
+
+	select {
+	case event := <-subscription.subscriptionChannel:
+		assert.Equal(t, proto.EventType_DROPPED, event.Type)
+		assert.Equal(t, tx.Hash.String(), event.TxHash)
+		assert.Equal(t, tx.From.String(), event.Sender)
+		assert.Equal(t, tx.Nonce, event.Nonce)
+		assert.Equal(t, "some reason", event.Reason)
+	case <-time.After(time.Second * 5):
+		t.Fatal("event not received")
+	}
+}
+
+func TestEventManager_DropSlowSubscription(t *testing.T) {
+	em := newEventManager(hclog.NewNullLogger())
+
+	defer em.Close()
+
+	// the subscription channels are never read from,
+	// so the event buffers fill up
+	subscription := em.subscribeStream([]proto.EventType{proto.EventType_ADDED})
+	inProcessSubscription := em.subscribe([]proto.EventType{proto.EventType_ADDED})
+
+	tx := &types.Transaction{Hash: types.StringToHash("123")}
+
+	// the extra events account for the one held by the worker thread
+	for i := 0; i < subscriptionBufferSize+2; i++ {
+		em.signalEvent(proto.EventType_ADDED, tx)
+	}
+
+	// the slow stream subscription is cancelled without blocking the sender,
+	// while the in-process one keeps buffering the events
+	assert.Equal(t, int64(1), atomic.LoadInt64(&em.numSubscriptions))
+
+	// the buffered events are discarded and the channel is closed
+	for event := range subscription.subscriptionChannel {
+		assert.Equal(t, proto.EventType_ADDED, event.Type)
+	}
+
+	// the in-process subscription gets every event
+	for i := 0; i < subscriptionBufferSize+2; i++ {
+		select {
+		case event := <-inProcessSubscription.subscriptionChannel:
+			assert.Equal(t, proto.EventType_ADDED, event.Type)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event %d not received", i)
+		}
+	}
+}
@@ -8,13 +8,24 @@ import (
 type eventQueue struct {
 	events []*proto.TxPoolEvent
 	sync.Mutex
+
+	// maxSize is the maximum number of buffered events (0 is unbounded)
+	maxSize int
 }
 
-func (es *eventQueue) push(event *proto.TxPoolEvent) {
+// push appends the event to the queue.
+// Returns false if the queue is full and the event was not added
+func (es *eventQueue) push(event *proto.TxPoolEvent) bool {
 	es.Lock()
 	defer es.Unlock()
 
+	if es.maxSize > 0 && len(es.events) >= es.maxSize {
+		return false
+	}
+
 	es.events = append(es.events, event)
+
+	return true
 }
 
 func (es *eventQueue) pop() *proto.TxPoolEvent {
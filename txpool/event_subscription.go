@@ -38,12 +38,13 @@ func (es *eventSubscription) eventSupported(eventType proto.EventType) bool {
 // close stops the event subscription
 func (es *eventSubscription) close() {
 	close(es.doneCh)
-	close(es.outputCh)
-	close(es.notifyCh)
 }
 
-// runLoop is the main loop that listens for notifications and handles the event / close signals
+// runLoop is the main loop that listens for notifications and handles the event / close signals.
+// The output channel is closed once the loop exits
 func (es *eventSubscription) runLoop() {
+	defer close(es.outputCh)
+
 	for {
 		select {
 		case <-es.doneCh: // Break if a close signal has been received
@@ -67,14 +68,21 @@ func (es *eventSubscription) runLoop() {
 }
 
 // pushEvent sends the event off for processing by the subscription. [NON-BLOCKING]
-func (es *eventSubscription) pushEvent(event *proto.TxPoolEvent) {
-	if es.eventSupported(event.Type) {
-		// Append the event to the event store, so order can be preserved
-		es.eventStore.push(event)
+// Returns false if the subscriber is not keeping up and the event buffer is full
+func (es *eventSubscription) pushEvent(event *proto.TxPoolEvent) bool {
+	if !es.eventSupported(event.Type) {
+		return true
+	}
 
-		select {
-		case es.notifyCh <- struct{}{}: // Notify the worker thread
-		default:
-		}
+	// Append the event to the event store, so order can be preserved
+	if !es.eventStore.push(event) {
+		return false
 	}
+
+	select {
+	case es.notifyCh <- struct{}{}: // Notify the worker thread
+	default:
+	}
+
+	return true
 }
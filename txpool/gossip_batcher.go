@@ -0,0 +1,90 @@
+package txpool
+
+import (
+	"sync"
+	"time"
+)
+
+// maximum size of the encoded transactions in a single gossip batch
+const maxGossipBatchSize = 512 * 1024 // 512kB
+
+// gossipBatcher coalesces the transactions announced within
+// a time window into a single gossip message. A batch is published
+// when the window expires or when it reaches the size limit,
+// whichever comes first.
+type gossipBatcher struct {
+	sync.Mutex
+
+	window  time.Duration
+	maxSize int
+	publish func(raws [][]byte)
+
+	pending     [][]byte
+	pendingSize int
+	timer       *time.Timer
+}
+
+func newGossipBatcher(
+	window time.Duration,
+	maxSize int,
+	publish func(raws [][]byte),
+) *gossipBatcher {
+	return &gossipBatcher{
+		window:  window,
+		maxSize: maxSize,
+		publish: publish,
+	}
+}
+
+// add queues the encoded transaction for the next batch. [thread-safe]
+func (b *gossipBatcher) add(raw []byte) {
+	var full [][]byte
+
+	b.Lock()
+
+	// publish the pending batch first if the tx doesn't fit
+	if len(b.pending) > 0 && b.pendingSize+len(raw) > b.maxSize {
+		full = b.take()
+	}
+
+	b.pending = append(b.pending, raw)
+	b.pendingSize += len(raw)
+
+	// the window starts with the first tx of the batch
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+
+	b.Unlock()
+
+	if full != nil {
+		b.publish(full)
+	}
+}
+
+// flush publishes the pending batch, if any. [thread-safe]
+func (b *gossipBatcher) flush() {
+	b.Lock()
+	batch := b.take()
+	b.Unlock()
+
+	if len(batch) > 0 {
+		b.publish(batch)
+	}
+}
+
+// take removes the pending batch and stops its timer.
+// Must be called with the lock held.
+func (b *gossipBatcher) take() [][]byte {
+	batch := b.pending
+
+	b.pending = nil
+	b.pendingSize = 0
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	return batch
+}
@@ -0,0 +1,163 @@
+package txpool
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	any "google.golang.org/protobuf/types/known/anypb"
+)
+
+// batchRecorder collects the batches published by a gossipBatcher
+type batchRecorder struct {
+	sync.Mutex
+	batches [][][]byte
+}
+
+func (r *batchRecorder) publish(raws [][]byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.batches = append(r.batches, raws)
+}
+
+func (r *batchRecorder) get() [][][]byte {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.batches
+}
+
+func TestGossipBatcher_Window(t *testing.T) {
+	recorder := &batchRecorder{}
+	batcher := newGossipBatcher(50*time.Millisecond, maxGossipBatchSize, recorder.publish)
+
+	for i := 0; i < 10; i++ {
+		batcher.add([]byte{byte(i)})
+	}
+
+	// nothing is published before the window expires
+	assert.Len(t, recorder.get(), 0)
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.get()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	batch := recorder.get()[0]
+	assert.Len(t, batch, 10)
+
+	for i, raw := range batch {
+		assert.Equal(t, []byte{byte(i)}, raw)
+	}
+
+	// a new window starts with the next tx
+	batcher.add([]byte{0xff})
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.get()) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGossipBatcher_MaxSize(t *testing.T) {
+	recorder := &batchRecorder{}
+	batcher := newGossipBatcher(time.Hour, 10, recorder.publish)
+
+	batcher.add(make([]byte, 4))
+	batcher.add(make([]byte, 4))
+	assert.Len(t, recorder.get(), 0)
+
+	// the third tx doesn't fit, the pending batch is published right away
+	batcher.add(make([]byte, 4))
+	assert.Len(t, recorder.get(), 1)
+	assert.Len(t, recorder.get()[0], 2)
+
+	// flushing publishes the remainder
+	batcher.flush()
+	assert.Len(t, recorder.get(), 2)
+	assert.Len(t, recorder.get()[1], 1)
+
+	// nothing left to publish
+	batcher.flush()
+	assert.Len(t, recorder.get(), 2)
+}
+
+func TestAddGossipBatch(t *testing.T) {
+	key1, sender1 := tests.GenerateKeyAndAddr(t)
+	key2, sender2 := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(uint64(100))
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(signer)
+
+	pool.sealing = true
+
+	tx1, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key1)
+	assert.NoError(t, err)
+
+	tx2, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key2)
+	assert.NoError(t, err)
+
+	batch := &proto.TxnBatch{
+		Raws: []*any.Any{
+			{Value: tx1.MarshalRLP()},
+			{Value: []byte{0xff}}, // invalid txs don't affect the rest of the batch
+			{Value: tx2.MarshalRLP()},
+		},
+	}
+
+	go pool.addGossipBatch(batch)
+
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(1), pool.accounts.get(sender1).enqueued.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(sender2).enqueued.length())
+}
+
+// benchmarkGossip reports the number of gossip messages
+// needed for announcing a burst of transactions
+func benchmarkGossip(b *testing.B, batched bool) {
+	b.Helper()
+
+	const burst = 1000
+
+	raw := newTx(addr1, 0, 1).MarshalRLP()
+	messages := 0
+
+	for i := 0; i < b.N; i++ {
+		if !batched {
+			// every tx is a message
+			for j := 0; j < burst; j++ {
+				messages++
+			}
+
+			continue
+		}
+
+		batcher := newGossipBatcher(time.Hour, maxGossipBatchSize, func([][]byte) {
+			messages++
+		})
+
+		for j := 0; j < burst; j++ {
+			batcher.add(raw)
+		}
+
+		batcher.flush()
+	}
+
+	b.ReportMetric(float64(messages)/float64(b.N), "msgs/op")
+}
+
+func BenchmarkGossip_PerTx(b *testing.B) {
+	benchmarkGossip(b, false)
+}
+
+func BenchmarkGossip_Batched(b *testing.B) {
+	benchmarkGossip(b, true)
+}
@@ -0,0 +1,144 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// size of a journal record header: the local flag and the length of the encoded tx
+const journalHeaderSize = 1 + 4
+
+var errJournalRecordTooLarge = errors.New("journal record exceeds the max tx size")
+
+// journalEntry is a journaled transaction
+type journalEntry struct {
+	tx *types.Transaction
+
+	// flag indicating if the sender is a local account
+	local bool
+}
+
+// txJournal persists the pool's transactions to a file, so they can be
+// reloaded after the node restarts (as go-ethereum's transaction journal).
+// The journal is regenerated from the pool on every flush,
+// which keeps it from growing beyond the size of the pool
+type txJournal struct {
+	path string
+}
+
+// load reads the journaled transactions. A truncated trailing record,
+// left by a crash in the middle of a write, ends the journal
+func (j *txJournal) load() ([]journalEntry, error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var (
+		reader  = bufio.NewReader(file)
+		header  = make([]byte, journalHeaderSize)
+		entries []journalEntry
+	)
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return entries, journalEnd(err)
+		}
+
+		size := binary.BigEndian.Uint32(header[1:])
+		if size > txMaxSize {
+			return entries, errJournalRecordTooLarge
+		}
+
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(reader, raw); err != nil {
+			return entries, journalEnd(err)
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return entries, fmt.Errorf("failed to decode journaled tx: %w", err)
+		}
+
+		entries = append(entries, journalEntry{
+			tx:    tx,
+			local: header[0] == 1,
+		})
+	}
+}
+
+// journalEnd filters out the errors marking the end of the journal
+func journalEnd(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+
+	return err
+}
+
+// rotate replaces the journal with the given transactions.
+// The new journal is written next to the current one and renamed over it,
+// so a crash while writing leaves the previous journal intact
+func (j *txJournal) rotate(entries []journalEntry) error {
+	tmpPath := j.path + ".new"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := writeJournal(file, entries); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, j.path)
+}
+
+// writeJournal encodes the journal entries as length prefixed records
+func writeJournal(file *os.File, entries []journalEntry) error {
+	var (
+		writer = bufio.NewWriter(file)
+		header = make([]byte, journalHeaderSize)
+	)
+
+	for _, entry := range entries {
+		raw := entry.tx.MarshalRLP()
+
+		header[0] = 0
+		if entry.local {
+			header[0] = 1
+		}
+
+		binary.BigEndian.PutUint32(header[1:], uint32(len(raw)))
+
+		if _, err := writer.Write(header); err != nil {
+			return err
+		}
+
+		if _, err := writer.Write(raw); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
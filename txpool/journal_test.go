@@ -0,0 +1,172 @@
+package txpool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// nonceMockStore returns the configured account nonces
+type nonceMockStore struct {
+	defaultMockStore
+
+	nonces map[types.Address]uint64
+}
+
+func (m nonceMockStore) GetNonce(_ types.Hash, addr types.Address) uint64 {
+	return m.nonces[addr]
+}
+
+func newJournalTestPool(t *testing.T, store store, path string, remotes bool) *TxPool {
+	t.Helper()
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		forks,
+		store,
+		nil,
+		nil,
+		nilMetrics,
+		&Config{
+			PriceLimit:     defaultPriceLimit,
+			MaxSlots:       defaultMaxSlots,
+			Journal:        path,
+			JournalRemotes: remotes,
+		},
+	)
+	assert.NoError(t, err)
+
+	pool.SetSigner(crypto.NewEIP155Signer(uint64(100)))
+
+	return pool
+}
+
+func TestTxJournal_RoundTrip(t *testing.T) {
+	localKey, localAddr := tests.GenerateKeyAndAddr(t)
+	remoteKey, remoteAddr := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(uint64(100))
+
+	signTx := func(nonce uint64, key *ecdsa.PrivateKey) *types.Transaction {
+		t.Helper()
+
+		tx, err := signer.SignTx(newTx(types.ZeroAddress, nonce, 1), key)
+		assert.NoError(t, err)
+
+		return tx
+	}
+
+	testCases := []struct {
+		name          string
+		remotes       bool
+		expectRemotes bool
+	}{
+		{"only local accounts are journaled by default", false, false},
+		{"all accounts are journaled with remotes", true, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+			pool := newJournalTestPool(t, defaultMockStore{DefaultHeader: mockHeader}, path, testCase.remotes)
+			pool.Start()
+
+			subscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_ENQUEUED})
+
+			// 2 pending and 1 queued local txs, 1 pending remote tx
+			assert.NoError(t, pool.AddLocalTx(signTx(0, localKey)))
+			assert.NoError(t, pool.AddLocalTx(signTx(1, localKey)))
+			assert.NoError(t, pool.AddLocalTx(signTx(3, localKey)))
+			assert.NoError(t, pool.addTx(gossip, signTx(0, remoteKey)))
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelFn()
+
+			assert.Len(t, waitForEvents(ctx, subscription, 4), 4)
+
+			// the journal is written on shutdown
+			pool.Close()
+
+			// the first local tx was included in the meantime
+			restarted := newJournalTestPool(t, nonceMockStore{
+				defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+				nonces:           map[types.Address]uint64{localAddr: 1},
+			}, path, testCase.remotes)
+
+			subscription = restarted.eventManager.subscribe([]proto.EventType{proto.EventType_ENQUEUED})
+
+			restarted.Start()
+			defer restarted.Close()
+
+			expectedTxs := 2
+			if testCase.expectRemotes {
+				expectedTxs++
+			}
+
+			assert.Len(t, waitForEvents(ctx, subscription, expectedTxs), expectedTxs)
+
+			// the stale tx is discarded, the sender is still local
+			promoted, enqueued := restarted.GetAccountTxs(localAddr)
+			assert.Len(t, append(promoted, enqueued...), 2)
+			assert.True(t, restarted.locals.contains(localAddr))
+
+			if testCase.expectRemotes {
+				assert.NotNil(t, restarted.accounts.get(remoteAddr))
+				assert.False(t, restarted.locals.contains(remoteAddr))
+			} else {
+				assert.Nil(t, restarted.accounts.get(remoteAddr))
+			}
+		})
+	}
+}
+
+func TestTxJournal_Rotate(t *testing.T) {
+	journal := &txJournal{path: filepath.Join(t.TempDir(), "transactions.rlp")}
+
+	// a missing journal is empty
+	entries, err := journal.load()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 0)
+
+	tx1, tx2 := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+	assert.NoError(t, journal.rotate([]journalEntry{{tx: tx1, local: true}, {tx: tx2}}))
+
+	entries, err = journal.load()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.True(t, entries[0].local)
+	assert.False(t, entries[1].local)
+	assert.Equal(t, tx2.Input, entries[1].tx.Input)
+
+	// the rotated journal only holds the latest txs
+	assert.NoError(t, journal.rotate([]journalEntry{{tx: tx2}}))
+
+	entries, err = journal.load()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, tx2.Input, entries[0].tx.Input)
+
+	// a truncated trailing record ends the journal
+	assert.NoError(t, journal.rotate([]journalEntry{{tx: tx1}, {tx: tx2}}))
+
+	info, err := os.Stat(journal.path)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(journal.path, info.Size()-1))
+
+	entries, err = journal.load()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, tx1.Input, entries[0].tx.Input)
+}
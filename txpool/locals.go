@@ -0,0 +1,54 @@
+package txpool
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Set of accounts whose transactions were submitted
+// directly to this node by its operator. Transactions
+// of local accounts are exempt from the price limit,
+// are never evicted by the lifetime sweeper, are sealed
+// ahead of the remote ones and are periodically re-broadcast.
+type localAccounts struct {
+	sync.RWMutex
+	accounts map[types.Address]struct{}
+}
+
+// add marks the given address as local. [thread-safe]
+func (l *localAccounts) add(addr types.Address) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.accounts[addr] = struct{}{}
+}
+
+// contains checks if the given address is local. [thread-safe]
+func (l *localAccounts) contains(addr types.Address) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, ok := l.accounts[addr]
+
+	return ok
+}
+
+// list returns all local addresses, sorted. [thread-safe]
+func (l *localAccounts) list() []types.Address {
+	l.RLock()
+	defer l.RUnlock()
+
+	addrs := make([]types.Address, 0, len(l.accounts))
+	for addr := range l.accounts {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	return addrs
+}
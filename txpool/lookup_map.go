@@ -2,14 +2,24 @@ package txpool
 
 import (
 	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// A transaction tracked by the pool, along with the time
+// it entered the pool (or was last promoted) and the
+// block it has to be included by (0 if there is none).
+type poolEntry struct {
+	tx        *types.Transaction
+	timestamp time.Time
+	deadline  uint64
+}
+
 // Lookup map used to find transactions present in the pool
 type lookupMap struct {
 	sync.RWMutex
-	all map[types.Hash]*types.Transaction
+	all map[types.Hash]*poolEntry
 }
 
 // add inserts the given transaction into the map. [thread-safe]
@@ -17,8 +27,9 @@ func (m *lookupMap) add(txs ...*types.Transaction) {
 	m.Lock()
 	defer m.Unlock()
 
+	now := time.Now()
 	for _, tx := range txs {
-		m.all[tx.Hash] = tx
+		m.all[tx.Hash] = &poolEntry{tx: tx, timestamp: now}
 	}
 }
 
@@ -37,10 +48,63 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 	m.RLock()
 	defer m.RUnlock()
 
-	tx, ok := m.all[hash]
+	entry, ok := m.all[hash]
 	if !ok {
 		return nil, false
 	}
 
-	return tx, true
+	return entry.tx, true
+}
+
+// touch resets the timestamp of the given transactions. [thread-safe]
+func (m *lookupMap) touch(txs ...*types.Transaction) {
+	m.Lock()
+	defer m.Unlock()
+
+	now := time.Now()
+	for _, tx := range txs {
+		if entry, ok := m.all[tx.Hash]; ok {
+			entry.timestamp = now
+		}
+	}
+}
+
+// timestamp returns the time the transaction associated
+// with the given hash entered the pool. [thread-safe]
+func (m *lookupMap) timestamp(hash types.Hash) (time.Time, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	entry, ok := m.all[hash]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return entry.timestamp, true
+}
+
+// setDeadline sets the block the transaction associated
+// with the given hash has to be included by. [thread-safe]
+func (m *lookupMap) setDeadline(hash types.Hash, deadline uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	if entry, ok := m.all[hash]; ok {
+		entry.deadline = deadline
+	}
+}
+
+// pastDeadline returns the transactions that had to be
+// included by the given block (or earlier). [thread-safe]
+func (m *lookupMap) pastDeadline(number uint64) (txs []*types.Transaction) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, entry := range m.all {
+		if entry.deadline != 0 && entry.deadline <= number {
+			txs = append(txs, entry.tx)
+		}
+	}
+
+	return
 }
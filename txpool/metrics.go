@@ -7,10 +7,36 @@ import (
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 )
 
+// reasons of the dropped transactions metric
+const (
+	dropReasonExecutionError   = "execution_error"
+	dropReasonLifetimeExceeded = "lifetime_exceeded"
+	dropReasonDeadlineExceeded = "inclusion_deadline_exceeded"
+	dropReasonRemoved          = "removed"
+)
+
 // Metrics represents the txpool metrics
 type Metrics struct {
 	// Pending transactions
 	PendingTxs metrics.Gauge
+
+	// Queued (enqueued) transactions
+	QueuedTxs metrics.Gauge
+
+	// Transactions admitted to the pool
+	AddedTxs metrics.Counter
+
+	// Transactions promoted from the queued to the pending ones
+	PromotedTxs metrics.Counter
+
+	// Transactions dropped from the pool, labeled by the reason of the drop
+	DroppedTxs metrics.Counter
+
+	// Transactions rejected at admission, labeled by the reason of the rejection
+	RejectedTxs metrics.Counter
+
+	// Time of the admission of a transaction in seconds
+	AdmissionTime metrics.Histogram
 }
 
 // GetPrometheusMetrics return the txpool metrics instance
@@ -28,12 +54,55 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "pending_transactions",
 			Help:      "Pending transactions in the pool",
 		}, labels).With(labelsWithValues...),
+		QueuedTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "queued_transactions",
+			Help:      "Queued transactions in the pool",
+		}, labels).With(labelsWithValues...),
+		AddedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "added_transactions",
+			Help:      "Transactions admitted to the pool",
+		}, labels).With(labelsWithValues...),
+		PromotedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "promoted_transactions",
+			Help:      "Transactions promoted from the queued to the pending ones",
+		}, labels).With(labelsWithValues...),
+		DroppedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "dropped_transactions",
+			Help:      "Transactions dropped from the pool, by reason",
+		}, append(labels, "reason")).With(labelsWithValues...),
+		RejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "rejected_transactions",
+			Help:      "Transactions rejected at admission, by reason",
+		}, append(labels, "reason")).With(labelsWithValues...),
+		AdmissionTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "admission_seconds",
+			Help:      "Time of the admission of a transaction in seconds",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, labels).With(labelsWithValues...),
 	}
 }
 
 // NilMetrics will return the non operational txpool metrics
 func NilMetrics() *Metrics {
 	return &Metrics{
-		PendingTxs: discard.NewGauge(),
+		PendingTxs:    discard.NewGauge(),
+		QueuedTxs:     discard.NewGauge(),
+		AddedTxs:      discard.NewCounter(),
+		PromotedTxs:   discard.NewCounter(),
+		DroppedTxs:    discard.NewCounter(),
+		RejectedTxs:   discard.NewCounter(),
+		AdmissionTime: discard.NewHistogram(),
 	}
 }
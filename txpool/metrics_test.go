@@ -0,0 +1,148 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockGauge holds the value of the gauge
+type mockGauge struct {
+	value float64
+}
+
+func (g *mockGauge) With(labelValues ...string) metrics.Gauge {
+	return g
+}
+
+func (g *mockGauge) Set(value float64) {
+	g.value = value
+}
+
+func (g *mockGauge) Add(delta float64) {
+	g.value += delta
+}
+
+// mockCounter counts by the last label value (the reason),
+// or under the empty label if there is none
+type mockCounter struct {
+	label  string
+	counts map[string]float64
+}
+
+func newMockCounter() *mockCounter {
+	return &mockCounter{counts: map[string]float64{}}
+}
+
+func (c *mockCounter) With(labelValues ...string) metrics.Counter {
+	return &mockCounter{
+		label:  labelValues[len(labelValues)-1],
+		counts: c.counts,
+	}
+}
+
+func (c *mockCounter) Add(delta float64) {
+	c.counts[c.label] += delta
+}
+
+// mockHistogram counts the observations
+type mockHistogram struct {
+	observations int
+}
+
+func (h *mockHistogram) With(labelValues ...string) metrics.Histogram {
+	return h
+}
+
+func (h *mockHistogram) Observe(value float64) {
+	h.observations++
+}
+
+func TestMetrics(t *testing.T) {
+	var (
+		pending       = &mockGauge{}
+		queued        = &mockGauge{}
+		added         = newMockCounter()
+		promoted      = newMockCounter()
+		dropped       = newMockCounter()
+		rejected      = newMockCounter()
+		admissionTime = &mockHistogram{}
+	)
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.lifetime = time.Hour
+	pool.metrics = &Metrics{
+		PendingTxs:    pending,
+		QueuedTxs:     queued,
+		AddedTxs:      added,
+		PromotedTxs:   promoted,
+		DroppedTxs:    dropped,
+		RejectedTxs:   rejected,
+		AdmissionTime: admissionTime,
+	}
+
+	// send 1 tx and promote it
+	promotedTx := newTx(addr1, 0, 1)
+	go func() {
+		err := pool.addTx(local, promotedTx)
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, float64(1), pending.value)
+	assert.Equal(t, float64(0), queued.value)
+	assert.Equal(t, map[string]float64{"": 1}, promoted.counts)
+
+	// send 2 future nonce txs
+	expiredTx := newTx(addr1, 5, 1)
+	futureTx := newTx(addr1, 6, 1)
+
+	for _, tx := range []*types.Transaction{expiredTx, futureTx} {
+		go func(tx *types.Transaction) {
+			err := pool.addTx(local, tx)
+			assert.NoError(t, err)
+		}(tx)
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	assert.Equal(t, float64(1), pending.value)
+	assert.Equal(t, float64(2), queued.value)
+
+	// reject a known and a malformed tx
+	assert.ErrorIs(t, pool.addTx(local, promotedTx), ErrAlreadyKnown)
+
+	// the known txs gossiped back are dropped without being counted as rejected
+	assert.NoError(t, pool.addTx(gossip, promotedTx))
+
+	malformedTx := newTx(addr2, 0, 1)
+	malformedTx.Gas = 1
+	assert.ErrorIs(t, pool.addTx(local, malformedTx), ErrIntrinsicGas)
+
+	assert.Equal(t, map[string]float64{"": 3}, added.counts)
+	assert.Equal(t, map[string]float64{"already_known": 1, "intrinsic_gas": 1}, rejected.counts)
+	assert.Equal(t, 6, admissionTime.observations)
+
+	// evict the expired tx
+	pool.index.all[expiredTx.Hash].timestamp = time.Now().Add(-2 * pool.lifetime)
+	pool.evictExpired()
+
+	assert.Equal(t, float64(1), queued.value)
+	assert.Equal(t, map[string]float64{dropReasonLifetimeExceeded: 1}, dropped.counts)
+
+	// drop the txs of the account
+	pool.Drop(promotedTx)
+
+	assert.Equal(t, float64(0), pending.value)
+	assert.Equal(t, float64(0), queued.value)
+	assert.Equal(t, map[string]float64{
+		dropReasonLifetimeExceeded: 1,
+		dropReasonExecutionError:   2,
+	}, dropped.counts)
+}
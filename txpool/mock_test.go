@@ -35,12 +35,32 @@ func (m defaultMockStore) GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 	return nil, false
 }
 
+func (m defaultMockStore) ReadTxLookup(types.Hash) (types.Hash, bool) {
+	return types.ZeroHash, false
+}
+
+// CalcBaseFee keeps the base fee of the parent
+func (m defaultMockStore) CalcBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee
+}
+
 func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error) {
 	balance := big.NewInt(0).SetUint64(100000000000000)
 
 	return balance, nil
 }
 
+// balanceMockStore returns the configured balance for every account
+type balanceMockStore struct {
+	defaultMockStore
+
+	balance *big.Int
+}
+
+func (m balanceMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error) {
+	return new(big.Int).Set(m.balance), nil
+}
+
 type faultyMockStore struct {
 }
 
@@ -56,6 +76,14 @@ func (fms faultyMockStore) GetBlockByHash(hash types.Hash, b bool) (*types.Block
 	return nil, false
 }
 
+func (fms faultyMockStore) ReadTxLookup(types.Hash) (types.Hash, bool) {
+	return types.ZeroHash, false
+}
+
+func (fms faultyMockStore) CalcBaseFee(*types.Header) uint64 {
+	return 0
+}
+
 func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big.Int, error) {
 	return nil, fmt.Errorf("unable to fetch account state")
 }
@@ -1,18 +1,31 @@
 package txpool
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync/atomic"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	any "google.golang.org/protobuf/types/known/anypb"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // Status implements the GRPC status endpoint. Returns the number of transactions in the pool
 func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolStatusResp, error) {
+	pending := p.counters.readPending()
+
 	resp := &proto.TxnPoolStatusResp{
-		Length: p.accounts.promoted(),
+		Length:   pending,
+		Pending:  pending,
+		Queued:   p.counters.readQueued(),
+		Accounts: p.counters.readAccounts(),
 	}
 
 	return resp, nil
@@ -20,31 +33,145 @@ func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolSt
 
 // AddTxn adds a local transaction to the pool
 func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
-	if raw.Raw == nil {
+	txn, err := decodeTxn(raw.Raw, raw.From)
+	if err != nil {
+		return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the sender of an admitted local tx is marked as local
+	origin := local
+	if raw.Local {
+		origin = operator
+	}
+
+	// the TTL is counted from the current head
+	var deadline uint64
+	if raw.TtlBlocks > 0 {
+		deadline = p.store.Header().Number + raw.TtlBlocks
+	}
+
+	// the admission errors are returned with distinct status codes,
+	// which FromStatusError converts back to the errors
+	if err := p.submitTx(ctx, origin, txn, deadline); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &proto.AddTxnResp{
+		TxHash: txn.Hash.String(),
+	}, nil
+}
+
+// ValidateTxn implements the operator endpoint. It runs the admission checks
+// of AddTxn on the transaction, without adding it to the pool
+func (p *TxPool) ValidateTxn(ctx context.Context, req *proto.ValidateTxnReq) (*proto.ValidateTxnResp, error) {
+	txn, err := decodeTxn(req.Raw, req.From)
+	if err != nil {
+		return nil, grpcStatus.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the origin is chosen as in AddTxn
+	origin := local
+	if req.Local {
+		origin = operator
+	}
+
+	resp := &proto.ValidateTxnResp{
+		TxHash: txn.Hash.String(),
+		Valid:  true,
+	}
+
+	if err := p.checkTx(origin, txn); err != nil {
+		resp.Valid = false
+		resp.Reason = err.Error()
+	}
+
+	return resp, nil
+}
+
+// RemoveTxn implements the operator endpoint. It drops a queued or pending
+// transaction from the pool, if the request is signed by its sender
+// or the sender is a local account
+func (p *TxPool) RemoveTxn(ctx context.Context, req *proto.RemoveTxnReq) (*proto.RemoveTxnResp, error) {
+	hash := types.StringToHash(req.Hash)
+
+	tx, ok := p.index.get(hash)
+	if !ok {
+		return nil, p.notInPoolError(hash)
+	}
+
+	if err := p.checkOwnership(tx, req.Signature); err != nil {
+		return nil, grpcStatus.Error(codes.PermissionDenied, err.Error())
+	}
+
+	demoted, err := p.RemoveTx(hash)
+	if errors.Is(err, ErrTxExecuting) {
+		// the block being built is done with it soon
+		return nil, grpcStatus.Error(codes.Unavailable, err.Error())
+	} else if err != nil {
+		return nil, p.notInPoolError(hash)
+	}
+
+	return &proto.RemoveTxnResp{
+		TxHash:  hash.String(),
+		Demoted: uint64(len(demoted)),
+	}, nil
+}
+
+// notInPoolError returns the status error of a transaction the pool doesn't hold,
+// telling apart the transactions that were already mined
+func (p *TxPool) notInPoolError(hash types.Hash) error {
+	if _, mined := p.store.ReadTxLookup(hash); mined {
+		return grpcStatus.Error(codes.FailedPrecondition, "transaction already mined")
+	}
+
+	return grpcStatus.Error(codes.NotFound, ErrTxNotFound.Error())
+}
+
+// checkOwnership checks the signature recovers the sender of the transaction
+// from its hash. The transactions of local accounts need no signature
+func (p *TxPool) checkOwnership(tx *types.Transaction, signature []byte) error {
+	if len(signature) == 0 {
+		if p.locals.contains(tx.From) {
+			return nil
+		}
+
+		return errors.New("signature required to remove the transaction of a non-local account")
+	}
+
+	pub, err := crypto.RecoverPubkey(signature, tx.Hash.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if crypto.PubKeyToAddress(pub) != tx.From {
+		return errors.New("signature is not from the sender of the transaction")
+	}
+
+	return nil
+}
+
+// decodeTxn decodes the RLP encoded transaction of an operator request,
+// setting its sender if given
+func decodeTxn(raw *any.Any, sender string) (*types.Transaction, error) {
+	if raw == nil {
 		return nil, fmt.Errorf("transaction's field raw is empty")
 	}
 
 	txn := new(types.Transaction)
-	if err := txn.UnmarshalRLP(raw.Raw.Value); err != nil {
+	if err := txn.UnmarshalRLP(raw.Value); err != nil {
 		return nil, err
 	}
 
-	if raw.From != "" {
+	if sender != "" {
 		from := types.Address{}
-		if err := from.UnmarshalText([]byte(raw.From)); err != nil {
+		if err := from.UnmarshalText([]byte(sender)); err != nil {
 			return nil, err
 		}
 
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
-		return nil, err
-	}
-
-	return &proto.AddTxnResp{
-		TxHash: txn.Hash.String(),
-	}, nil
+	return txn, nil
 }
 
 // Subscribe implements the operator endpoint. It subscribes to new events in the tx pool
@@ -52,7 +179,7 @@ func (p *TxPool) Subscribe(
 	request *proto.SubscribeRequest,
 	stream proto.TxnPoolOperator_SubscribeServer,
 ) error {
-	subscription := p.eventManager.subscribe(request.Types)
+	subscription := p.eventManager.subscribeStream(request.Types)
 
 	cancel := func() {
 		p.eventManager.cancelSubscription(subscription.subscriptionID)
@@ -78,3 +205,125 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// AccountStatus implements the operator endpoint. Returns the number of
+// promoted and enqueued transactions the pool holds for the given account
+func (p *TxPool) AccountStatus(
+	ctx context.Context,
+	req *proto.AccountStatusReq,
+) (*proto.AccountStatusResp, error) {
+	addr := types.Address{}
+	if err := addr.UnmarshalText([]byte(req.Address)); err != nil {
+		return nil, err
+	}
+
+	account := p.accounts.get(addr)
+	if account == nil {
+		// unknown accounts have no transactions in the pool
+		return &proto.AccountStatusResp{}, nil
+	}
+
+	account.promoted.lock(false)
+	defer account.promoted.unlock()
+
+	account.enqueued.lock(false)
+	defer account.enqueued.unlock()
+
+	return &proto.AccountStatusResp{
+		Promoted:  account.promoted.length(),
+		Enqueued:  account.enqueued.length(),
+		NextNonce: account.getNonce(),
+	}, nil
+}
+
+// SetPriceLimit implements the operator endpoint. It changes the minimum
+// gas price for accepting new transactions, without restarting the node
+func (p *TxPool) SetPriceLimit(
+	ctx context.Context,
+	req *proto.SetPriceLimitReq,
+) (*proto.SetPriceLimitResp, error) {
+	previous := atomic.SwapUint64(&p.priceLimit, req.PriceLimit)
+
+	p.logger.Info("price limit changed", "previous", previous, "new", req.PriceLimit)
+
+	return &proto.SetPriceLimitResp{
+		Previous: previous,
+	}, nil
+}
+
+// LocalAccounts implements the operator endpoint. Returns the accounts
+// whose transactions are prioritized as local
+func (p *TxPool) LocalAccounts(
+	ctx context.Context,
+	req *empty.Empty,
+) (*proto.LocalAccountsResp, error) {
+	locals := p.locals.list()
+
+	accounts := make([]string, len(locals))
+	for i, addr := range locals {
+		accounts[i] = addr.String()
+	}
+
+	return &proto.LocalAccountsResp{
+		Accounts: accounts,
+	}, nil
+}
+
+// TxStatus implements the operator endpoint. Returns whether the
+// transaction with the given hash is currently held by the pool
+func (p *TxPool) TxStatus(
+	ctx context.Context,
+	req *proto.TxStatusReq,
+) (*proto.TxStatusResp, error) {
+	tx, ok := p.index.get(types.StringToHash(req.Hash))
+	if !ok {
+		return &proto.TxStatusResp{}, nil
+	}
+
+	return &proto.TxStatusResp{
+		Known: true,
+		From:  tx.From.String(),
+		Nonce: tx.Nonce,
+	}, nil
+}
+
+// NonceGaps implements the operator endpoint. Returns, for each account,
+// the lowest missing nonce that blocks the promotion of its enqueued transactions
+func (p *TxPool) NonceGaps(
+	ctx context.Context,
+	req *empty.Empty,
+) (*proto.NonceGapsResp, error) {
+	var addrs []types.Address
+
+	gaps := make(map[types.Address]*proto.NonceGap)
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account := p.accounts.get(addr)
+
+		if missing, enqueued, ok := account.nonceGap(); ok {
+			addrs = append(addrs, addr)
+			gaps[addr] = &proto.NonceGap{
+				Address:      addr.String(),
+				MissingNonce: missing,
+				Enqueued:     enqueued,
+			}
+		}
+
+		return true
+	})
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	resp := &proto.NonceGapsResp{
+		Gaps: make([]*proto.NonceGap, len(addrs)),
+	}
+
+	for i, addr := range addrs {
+		resp.Gaps[i] = gaps[addr]
+	}
+
+	return resp, nil
+}
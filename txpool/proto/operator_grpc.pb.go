@@ -25,6 +25,20 @@ type TxnPoolOperatorClient interface {
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// AccountStatus returns the number of transactions the pool holds for an account
+	AccountStatus(ctx context.Context, in *AccountStatusReq, opts ...grpc.CallOption) (*AccountStatusResp, error)
+	// SetPriceLimit changes the minimum gas price for accepting new transactions
+	SetPriceLimit(ctx context.Context, in *SetPriceLimitReq, opts ...grpc.CallOption) (*SetPriceLimitResp, error)
+	// LocalAccounts returns the accounts whose transactions are treated as local
+	LocalAccounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LocalAccountsResp, error)
+	// TxStatus returns whether the pool holds the transaction with the given hash
+	TxStatus(ctx context.Context, in *TxStatusReq, opts ...grpc.CallOption) (*TxStatusResp, error)
+	// NonceGaps returns the accounts whose enqueued transactions are blocked by a missing nonce
+	NonceGaps(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NonceGapsResp, error)
+	// ValidateTxn runs the admission checks of AddTxn without adding the transaction
+	ValidateTxn(ctx context.Context, in *ValidateTxnReq, opts ...grpc.CallOption) (*ValidateTxnResp, error)
+	// RemoveTxn drops a queued or pending transaction from the pool
+	RemoveTxn(ctx context.Context, in *RemoveTxnReq, opts ...grpc.CallOption) (*RemoveTxnResp, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -85,6 +99,69 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) AccountStatus(ctx context.Context, in *AccountStatusReq, opts ...grpc.CallOption) (*AccountStatusResp, error) {
+	out := new(AccountStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/AccountStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) SetPriceLimit(ctx context.Context, in *SetPriceLimitReq, opts ...grpc.CallOption) (*SetPriceLimitResp, error) {
+	out := new(SetPriceLimitResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/SetPriceLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) LocalAccounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LocalAccountsResp, error) {
+	out := new(LocalAccountsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/LocalAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) TxStatus(ctx context.Context, in *TxStatusReq, opts ...grpc.CallOption) (*TxStatusResp, error) {
+	out := new(TxStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/TxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) NonceGaps(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NonceGapsResp, error) {
+	out := new(NonceGapsResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/NonceGaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) ValidateTxn(ctx context.Context, in *ValidateTxnReq, opts ...grpc.CallOption) (*ValidateTxnResp, error) {
+	out := new(ValidateTxnResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/ValidateTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) RemoveTxn(ctx context.Context, in *RemoveTxnReq, opts ...grpc.CallOption) (*RemoveTxnResp, error) {
+	out := new(RemoveTxnResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/RemoveTxn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -95,6 +172,20 @@ type TxnPoolOperatorServer interface {
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// AccountStatus returns the number of transactions the pool holds for an account
+	AccountStatus(context.Context, *AccountStatusReq) (*AccountStatusResp, error)
+	// SetPriceLimit changes the minimum gas price for accepting new transactions
+	SetPriceLimit(context.Context, *SetPriceLimitReq) (*SetPriceLimitResp, error)
+	// LocalAccounts returns the accounts whose transactions are treated as local
+	LocalAccounts(context.Context, *emptypb.Empty) (*LocalAccountsResp, error)
+	// TxStatus returns whether the pool holds the transaction with the given hash
+	TxStatus(context.Context, *TxStatusReq) (*TxStatusResp, error)
+	// NonceGaps returns the accounts whose enqueued transactions are blocked by a missing nonce
+	NonceGaps(context.Context, *emptypb.Empty) (*NonceGapsResp, error)
+	// ValidateTxn runs the admission checks of AddTxn without adding the transaction
+	ValidateTxn(context.Context, *ValidateTxnReq) (*ValidateTxnResp, error)
+	// RemoveTxn drops a queued or pending transaction from the pool
+	RemoveTxn(context.Context, *RemoveTxnReq) (*RemoveTxnResp, error)
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -111,6 +202,27 @@ func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) AccountStatus(context.Context, *AccountStatusReq) (*AccountStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountStatus not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) SetPriceLimit(context.Context, *SetPriceLimitReq) (*SetPriceLimitResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPriceLimit not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) LocalAccounts(context.Context, *emptypb.Empty) (*LocalAccountsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocalAccounts not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) TxStatus(context.Context, *TxStatusReq) (*TxStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxStatus not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) NonceGaps(context.Context, *emptypb.Empty) (*NonceGapsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NonceGaps not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) ValidateTxn(context.Context, *ValidateTxnReq) (*ValidateTxnResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTxn not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) RemoveTxn(context.Context, *RemoveTxnReq) (*RemoveTxnResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTxn not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -181,6 +293,132 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_AccountStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).AccountStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/AccountStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).AccountStatus(ctx, req.(*AccountStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_SetPriceLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPriceLimitReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).SetPriceLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/SetPriceLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).SetPriceLimit(ctx, req.(*SetPriceLimitReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_LocalAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).LocalAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/LocalAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).LocalAccounts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_TxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).TxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/TxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).TxStatus(ctx, req.(*TxStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_NonceGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).NonceGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/NonceGaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).NonceGaps(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_ValidateTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).ValidateTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/ValidateTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).ValidateTxn(ctx, req.(*ValidateTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_RemoveTxn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTxnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).RemoveTxn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/RemoveTxn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).RemoveTxn(ctx, req.(*RemoveTxnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -196,6 +434,34 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTxn",
 			Handler:    _TxnPoolOperator_AddTxn_Handler,
 		},
+		{
+			MethodName: "AccountStatus",
+			Handler:    _TxnPoolOperator_AccountStatus_Handler,
+		},
+		{
+			MethodName: "SetPriceLimit",
+			Handler:    _TxnPoolOperator_SetPriceLimit_Handler,
+		},
+		{
+			MethodName: "LocalAccounts",
+			Handler:    _TxnPoolOperator_LocalAccounts_Handler,
+		},
+		{
+			MethodName: "TxStatus",
+			Handler:    _TxnPoolOperator_TxStatus_Handler,
+		},
+		{
+			MethodName: "NonceGaps",
+			Handler:    _TxnPoolOperator_NonceGaps_Handler,
+		},
+		{
+			MethodName: "ValidateTxn",
+			Handler:    _TxnPoolOperator_ValidateTxn_Handler,
+		},
+		{
+			MethodName: "RemoveTxn",
+			Handler:    _TxnPoolOperator_RemoveTxn_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1,16 +1,15 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
-// 	protoc        v3.12.0
-// source: txpool/proto/v1.proto
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.3
+// source: v1.proto
 
 package proto
 
 import (
-	proto "github.com/golang/protobuf/proto"
-	any "github.com/golang/protobuf/ptypes/any"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -22,22 +21,18 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type Txn struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Raw *any.Any `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Raw *anypb.Any `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
 }
 
 func (x *Txn) Reset() {
 	*x = Txn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_txpool_proto_v1_proto_msgTypes[0]
+		mi := &file_v1_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -50,7 +45,7 @@ func (x *Txn) String() string {
 func (*Txn) ProtoMessage() {}
 
 func (x *Txn) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_proto_v1_proto_msgTypes[0]
+	mi := &file_v1_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -63,62 +58,114 @@ func (x *Txn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Txn.ProtoReflect.Descriptor instead.
 func (*Txn) Descriptor() ([]byte, []int) {
-	return file_txpool_proto_v1_proto_rawDescGZIP(), []int{0}
+	return file_v1_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Txn) GetRaw() *any.Any {
+func (x *Txn) GetRaw() *anypb.Any {
 	if x != nil {
 		return x.Raw
 	}
 	return nil
 }
 
-var File_txpool_proto_v1_proto protoreflect.FileDescriptor
+type TxnBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raws []*anypb.Any `protobuf:"bytes,1,rep,name=raws,proto3" json:"raws,omitempty"`
+}
+
+func (x *TxnBatch) Reset() {
+	*x = TxnBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-var file_txpool_proto_v1_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76,
-	0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x26, 0x0a,
-	0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
-	0x52, 0x03, 0x72, 0x61, 0x77, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TxnBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnBatch) ProtoMessage() {}
+
+func (x *TxnBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnBatch.ProtoReflect.Descriptor instead.
+func (*TxnBatch) Descriptor() ([]byte, []int) {
+	return file_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TxnBatch) GetRaws() []*anypb.Any {
+	if x != nil {
+		return x.Raws
+	}
+	return nil
+}
+
+var File_v1_proto protoreflect.FileDescriptor
+
+var file_v1_proto_rawDesc = []byte{
+	0x0a, 0x08, 0x76, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x19,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x03, 0x54, 0x78, 0x6e,
+	0x12, 0x26, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x41, 0x6e, 0x79, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0x34, 0x0a, 0x08, 0x54, 0x78, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x28, 0x0a, 0x04, 0x72, 0x61, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x72, 0x61, 0x77, 0x73, 0x42, 0x0f,
+	0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_txpool_proto_v1_proto_rawDescOnce sync.Once
-	file_txpool_proto_v1_proto_rawDescData = file_txpool_proto_v1_proto_rawDesc
+	file_v1_proto_rawDescOnce sync.Once
+	file_v1_proto_rawDescData = file_v1_proto_rawDesc
 )
 
-func file_txpool_proto_v1_proto_rawDescGZIP() []byte {
-	file_txpool_proto_v1_proto_rawDescOnce.Do(func() {
-		file_txpool_proto_v1_proto_rawDescData = protoimpl.X.CompressGZIP(file_txpool_proto_v1_proto_rawDescData)
+func file_v1_proto_rawDescGZIP() []byte {
+	file_v1_proto_rawDescOnce.Do(func() {
+		file_v1_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_proto_rawDescData)
 	})
-	return file_txpool_proto_v1_proto_rawDescData
+	return file_v1_proto_rawDescData
 }
 
-var file_txpool_proto_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_txpool_proto_v1_proto_goTypes = []interface{}{
-	(*Txn)(nil),     // 0: v1.Txn
-	(*any.Any)(nil), // 1: google.protobuf.Any
+var file_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_v1_proto_goTypes = []interface{}{
+	(*Txn)(nil),       // 0: v1.Txn
+	(*TxnBatch)(nil),  // 1: v1.TxnBatch
+	(*anypb.Any)(nil), // 2: google.protobuf.Any
 }
-var file_txpool_proto_v1_proto_depIdxs = []int32{
-	1, // 0: v1.Txn.raw:type_name -> google.protobuf.Any
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+var file_v1_proto_depIdxs = []int32{
+	2, // 0: v1.Txn.raw:type_name -> google.protobuf.Any
+	2, // 1: v1.TxnBatch.raws:type_name -> google.protobuf.Any
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
-func init() { file_txpool_proto_v1_proto_init() }
-func file_txpool_proto_v1_proto_init() {
-	if File_txpool_proto_v1_proto != nil {
+func init() { file_v1_proto_init() }
+func file_v1_proto_init() {
+	if File_v1_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_txpool_proto_v1_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_v1_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Txn); i {
 			case 0:
 				return &v.state
@@ -130,23 +177,35 @@ func file_txpool_proto_v1_proto_init() {
 				return nil
 			}
 		}
+		file_v1_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxnBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_txpool_proto_v1_proto_rawDesc,
+			RawDescriptor: file_v1_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
-		GoTypes:           file_txpool_proto_v1_proto_goTypes,
-		DependencyIndexes: file_txpool_proto_v1_proto_depIdxs,
-		MessageInfos:      file_txpool_proto_v1_proto_msgTypes,
+		GoTypes:           file_v1_proto_goTypes,
+		DependencyIndexes: file_v1_proto_depIdxs,
+		MessageInfos:      file_v1_proto_msgTypes,
 	}.Build()
-	File_txpool_proto_v1_proto = out.File
-	file_txpool_proto_v1_proto_rawDesc = nil
-	file_txpool_proto_v1_proto_goTypes = nil
-	file_txpool_proto_v1_proto_depIdxs = nil
+	File_v1_proto = out.File
+	file_v1_proto_rawDesc = nil
+	file_v1_proto_goTypes = nil
+	file_v1_proto_depIdxs = nil
 }
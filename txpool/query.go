@@ -7,7 +7,8 @@ import "github.com/0xPolygon/polygon-edge/types"
 
 // GetNonce returns the next nonce for the account
 //
-// -> Returns the value from the TxPool if the account is initialized in-memory
+// -> Returns the value from the TxPool if the account is initialized in-memory,
+// including the sequential transactions not yet promoted
 //
 // -> Returns the value from the world state otherwise
 func (p *TxPool) GetNonce(addr types.Address) uint64 {
@@ -19,7 +20,7 @@ func (p *TxPool) GetNonce(addr types.Address) uint64 {
 		return stateNonce
 	}
 
-	return account.getNonce()
+	return account.pendingNonce()
 }
 
 // GetCapacity returns the current number of slots
@@ -38,6 +39,12 @@ func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	return tx, true
 }
 
+// GetSender recovers the sender of the transaction using the pool's signer.
+// The senders recovered by a cached signer are reused when the transaction is added
+func (p *TxPool) GetSender(tx *types.Transaction) (types.Address, error) {
+	return p.signer.Sender(tx)
+}
+
 // GetTxs gets pending and queued transactions
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
@@ -46,3 +53,21 @@ func (p *TxPool) GetTxs(inclQueued bool) (
 
 	return
 }
+
+// GetAccountTxs returns a snapshot of the pending and queued transactions of the account
+func (p *TxPool) GetAccountTxs(addr types.Address) (
+	promoted, enqueued []*types.Transaction,
+) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, nil
+	}
+
+	account.promoted.lock(false)
+	defer account.promoted.unlock()
+
+	account.enqueued.lock(false)
+	defer account.enqueued.unlock()
+
+	return account.promoted.snapshot(), account.enqueued.snapshot()
+}
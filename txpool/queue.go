@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"container/heap"
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -14,11 +15,22 @@ type accountQueue struct {
 	sync.RWMutex
 	wLock uint32
 	queue minNonceQueue
+
+	// queued transactions by nonce
+	nonces map[uint64]*types.Transaction
+
+	// cumulative cost of the queued transactions
+	cost *big.Int
+
+	// all the nonces in [seqStart, seqEnd) are queued
+	seqStart, seqEnd uint64
 }
 
 func newAccountQueue() *accountQueue {
 	q := accountQueue{
-		queue: make(minNonceQueue, 0),
+		queue:  make(minNonceQueue, 0),
+		nonces: make(map[uint64]*types.Transaction),
+		cost:   new(big.Int),
 	}
 
 	heap.Init(&q.queue)
@@ -72,12 +84,70 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	// clear the underlying queue
 	q.queue = q.queue[:0]
 
+	q.nonces = make(map[uint64]*types.Transaction)
+	q.cost = new(big.Int)
+	q.seqStart, q.seqEnd = 0, 0
+
 	return
 }
 
+// pruneIf removes all transactions from the queue
+// that satisfy the given condition.
+func (q *accountQueue) pruneIf(cond func(tx *types.Transaction) bool) (
+	pruned []*types.Transaction,
+) {
+	kept := make(minNonceQueue, 0, len(q.queue))
+
+	for _, tx := range q.queue {
+		if cond(tx) {
+			pruned = append(pruned, tx)
+			q.untrack(tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	if len(pruned) > 0 {
+		q.queue = kept
+		heap.Init(&q.queue)
+
+		// the pruned nonces might break the sequence
+		q.seqStart, q.seqEnd = 0, 0
+	}
+
+	return
+}
+
+// get returns the transaction with the given nonce (if any).
+func (q *accountQueue) get(nonce uint64) *types.Transaction {
+	return q.nonces[nonce]
+}
+
+// replace swaps the transaction with the same nonce for the given one.
+// Since the nonce is the same, the queue order is preserved.
+func (q *accountQueue) replace(tx *types.Transaction) (replaced *types.Transaction) {
+	if _, ok := q.nonces[tx.Nonce]; !ok {
+		return nil
+	}
+
+	for i, queued := range q.queue {
+		if queued.Nonce == tx.Nonce {
+			q.queue[i] = tx
+
+			q.untrack(queued)
+			q.track(tx)
+
+			return queued
+		}
+	}
+
+	return nil
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
+	q.track(tx)
 }
 
 // peek returns the first transaction from the queue without removing it.
@@ -100,9 +170,59 @@ func (q *accountQueue) pop() *types.Transaction {
 		return nil
 	}
 
+	q.untrack(transaction)
+
+	// the lowest nonce is popped, the rest of the sequence is still queued
+	if transaction.Nonce >= q.seqStart && transaction.Nonce < q.seqEnd {
+		q.seqStart = transaction.Nonce + 1
+	}
+
 	return transaction
 }
 
+// track indexes the pushed transaction and adds up its cost.
+func (q *accountQueue) track(tx *types.Transaction) {
+	q.nonces[tx.Nonce] = tx
+	q.cost.Add(q.cost, tx.Cost())
+}
+
+// untrack removes the transaction from the index and subtracts its cost.
+func (q *accountQueue) untrack(tx *types.Transaction) {
+	delete(q.nonces, tx.Nonce)
+	q.cost.Sub(q.cost, tx.Cost())
+}
+
+// totalCost returns the cumulative cost of the queued transactions.
+func (q *accountQueue) totalCost() *big.Int {
+	return new(big.Int).Set(q.cost)
+}
+
+// sequenceEnd returns the nonce following the queued transactions
+// that are sequential from the given nonce. The sequence is cached
+// and only extended past its end, so it requires the write lock.
+func (q *accountQueue) sequenceEnd(nonce uint64) uint64 {
+	if nonce < q.seqStart || nonce > q.seqEnd {
+		q.seqStart, q.seqEnd = nonce, nonce
+	}
+
+	for {
+		if _, ok := q.nonces[q.seqEnd]; !ok {
+			return q.seqEnd
+		}
+
+		q.seqEnd++
+	}
+}
+
+// snapshot returns a copy of the queued transactions,
+// which remains valid after the queue is unlocked.
+func (q *accountQueue) snapshot() []*types.Transaction {
+	txs := make([]*types.Transaction, len(q.queue))
+	copy(txs, q.queue)
+
+	return txs
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
@@ -151,13 +271,21 @@ func (q *minNonceQueue) Pop() interface{} {
 	return x
 }
 
+// A thread-safe wrapper of a maxPriceQueue,
+// the queued transactions can be replaced while it is processed.
 type pricedQueue struct {
+	sync.Mutex
 	queue maxPriceQueue
 }
 
-func newPricedQueue() *pricedQueue {
+// newPricedQueue creates a queue sorted by effective tip,
+// preceded by the transactions of the (optional) local accounts.
+func newPricedQueue(isLocal func(types.Address) bool) *pricedQueue {
 	q := pricedQueue{
-		queue: make(maxPriceQueue, 0),
+		queue: maxPriceQueue{
+			isLocal: isLocal,
+			txs:     make([]*types.Transaction, 0),
+		},
 	}
 
 	heap.Init(&q.queue)
@@ -167,18 +295,53 @@ func newPricedQueue() *pricedQueue {
 
 // clear empties the underlying queue.
 func (q *pricedQueue) clear() {
-	q.queue = q.queue[:0]
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.txs = q.queue.txs[:0]
+}
+
+// setBaseFee sets the base fee used for computing the effective tip
+// of the queued transactions. It should only be called on an empty queue,
+// since the ordering is not re-evaluated.
+func (q *pricedQueue) setBaseFee(baseFee uint64) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.baseFee = baseFee
 }
 
 // Pushes the given transactions onto the queue.
 func (q *pricedQueue) push(tx *types.Transaction) {
+	q.Lock()
+	defer q.Unlock()
+
 	heap.Push(&q.queue, tx)
 }
 
+// replace swaps the queued transaction for its replacement.
+// Nothing is done if the transaction is not queued.
+func (q *pricedQueue) replace(queued, tx *types.Transaction) {
+	q.Lock()
+	defer q.Unlock()
+
+	for i, existing := range q.queue.txs {
+		if existing.Hash == queued.Hash {
+			q.queue.txs[i] = tx
+			heap.Fix(&q.queue, i)
+
+			return
+		}
+	}
+}
+
 // Pop removes the first transaction from the queue
 // or nil if the queue is empty.
 func (q *pricedQueue) pop() *types.Transaction {
-	if q.length() == 0 {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.queue.Len() == 0 {
 		return nil
 	}
 
@@ -192,11 +355,19 @@ func (q *pricedQueue) pop() *types.Transaction {
 
 // length returns the number of transactions in the queue.
 func (q *pricedQueue) length() uint64 {
+	q.Lock()
+	defer q.Unlock()
+
 	return uint64(q.queue.Len())
 }
 
-// transactions sorted by gas price (descending)
-type maxPriceQueue []*types.Transaction
+// transactions sorted by effective tip (descending),
+// the ones of local accounts first
+type maxPriceQueue struct {
+	baseFee uint64
+	isLocal func(types.Address) bool
+	txs     []*types.Transaction
+}
 
 /* Queue methods required by the heap interface */
 
@@ -205,19 +376,25 @@ func (q *maxPriceQueue) Peek() *types.Transaction {
 		return nil
 	}
 
-	return (*q)[0]
+	return q.txs[0]
 }
 
 func (q *maxPriceQueue) Len() int {
-	return len(*q)
+	return len(q.txs)
 }
 
 func (q *maxPriceQueue) Swap(i, j int) {
-	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
 }
 
 func (q *maxPriceQueue) Less(i, j int) bool {
-	return (*q)[i].GasPrice.Uint64() > (*q)[j].GasPrice.Uint64()
+	if q.isLocal != nil {
+		if iLocal, jLocal := q.isLocal(q.txs[i].From), q.isLocal(q.txs[j].From); iLocal != jLocal {
+			return iLocal
+		}
+	}
+
+	return q.txs[i].EffectiveTip(q.baseFee).Cmp(q.txs[j].EffectiveTip(q.baseFee)) > 0
 }
 
 func (q *maxPriceQueue) Push(x interface{}) {
@@ -226,14 +403,14 @@ func (q *maxPriceQueue) Push(x interface{}) {
 		return
 	}
 
-	*q = append(*q, transaction)
+	q.txs = append(q.txs, transaction)
 }
 
 func (q *maxPriceQueue) Pop() interface{} {
-	old := q
-	n := len(*old)
-	x := (*old)[n-1]
-	*q = (*old)[0 : n-1]
+	old := q.txs
+	n := len(old)
+	x := old[n-1]
+	q.txs = old[0 : n-1]
 
 	return x
 }
@@ -0,0 +1,508 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	libp2pGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
+	networkProto "github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// minimum number of connected peers for announcing the transaction hashes.
+	// Smaller peer sets receive the full transactions, since the saved bandwidth
+	// doesn't make up for the additional fetch round trip
+	minAnnouncePeers = 4
+
+	// maximum number of hashes in a single announcement or fetch request
+	maxAnnounceHashes = 4096
+
+	// soft limit of the encoded transactions returned by a single fetch request
+	maxPooledTxsSize = 2 * 1024 * 1024 // 2MB
+
+	// timeout of a single announcement or fetch request
+	announceTimeout = 5 * time.Second
+
+	// maximum number of fetch requests in flight at once.
+	// Announcements beyond it are dropped, the hashes are fetched once announced again
+	maxConcurrentFetches = 16
+
+	// maximum number of other peers the fetch of an announced hash is retried from
+	maxFetchRetries = 4
+
+	// how long a peer without the announcement protocol
+	// is served full transactions before it is probed again
+	legacyPeerRetryInterval = time.Minute
+)
+
+var (
+	errTooManyHashes     = errors.New("too many transaction hashes")
+	errInvalidHashLength = errors.New("invalid transaction hash length")
+)
+
+// announceNetwork is the part of the networking server used by the txAnnouncer
+type announceNetwork interface {
+	// Peers returns the connected peers
+	Peers() []*network.PeerConnInfo
+
+	// NewTxAnnounceClient returns the announcement client of the peer
+	NewTxAnnounceClient(peerID peer.ID) (networkProto.TxAnnounceClient, error)
+
+	// RegisterTxAnnounceService registers the announcement protocol handler
+	RegisterTxAnnounceService(service networkProto.TxAnnounceServer)
+
+	// ReportMisbehavior penalizes the peer for the misbehavior
+	ReportMisbehavior(peerID peer.ID, misbehavior network.Misbehavior)
+}
+
+// fetchingTx is an announced hash being fetched
+type fetchingTx struct {
+	// peer the transaction is being fetched from
+	from peer.ID
+
+	// other peers which announced the transaction,
+	// the fetch is retried from if the current one doesn't deliver it
+	announcers []peer.ID
+}
+
+// txAnnouncer implements the eth/65 style transaction propagation.
+// Instead of gossiping full transactions, their hashes are announced
+// to the peers, which fetch only the transactions they don't have yet
+type txAnnouncer struct {
+	networkProto.UnimplementedTxAnnounceServer
+
+	logger  hclog.Logger
+	pool    *TxPool
+	network announceNetwork
+
+	// peers without the announcement protocol,
+	// mapped to the time they are probed again
+	legacyPeers     map[peer.ID]time.Time
+	legacyPeersLock sync.Mutex
+
+	// announced hashes being fetched, each from a single peer at a time
+	fetching     map[types.Hash]*fetchingTx
+	fetchingLock sync.Mutex
+
+	// slots of the fetch requests in flight
+	fetchSlots chan struct{}
+}
+
+func newTxAnnouncer(
+	logger hclog.Logger,
+	pool *TxPool,
+	server announceNetwork,
+) *txAnnouncer {
+	return &txAnnouncer{
+		logger:      logger.Named("announcer"),
+		pool:        pool,
+		network:     server,
+		legacyPeers: make(map[peer.ID]time.Time),
+		fetching:    make(map[types.Hash]*fetchingTx),
+		fetchSlots:  make(chan struct{}, maxConcurrentFetches),
+	}
+}
+
+// start registers the announcement protocol
+func (a *txAnnouncer) start() {
+	a.network.RegisterTxAnnounceService(a)
+}
+
+// announce sends the hashes to all the connected peers, except the given one.
+// Nothing is announced if the node has too few peers or if any of them
+// doesn't support the protocol, in which case it returns false
+// and the transactions should be broadcast in full
+func (a *txAnnouncer) announce(hashes []types.Hash, except peer.ID) bool {
+	clients := a.clients(except)
+	if clients == nil {
+		return false
+	}
+
+	msg := &networkProto.NewPooledTxHashes{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		msg.Hashes[i] = hash.Bytes()
+	}
+
+	for _, client := range clients {
+		go func(client networkProto.TxAnnounceClient) {
+			ctx, cancel := context.WithTimeout(context.Background(), announceTimeout)
+			defer cancel()
+
+			if _, err := client.Announce(ctx, msg); err != nil {
+				a.logger.Debug("failed to announce txs", "err", err)
+			}
+		}(client)
+	}
+
+	return true
+}
+
+// clients returns the announcement clients of the connected peers,
+// or nil if the hashes can't be announced to all of them
+func (a *txAnnouncer) clients(except peer.ID) []networkProto.TxAnnounceClient {
+	peers := a.network.Peers()
+	if len(peers) < minAnnouncePeers {
+		return nil
+	}
+
+	// check the known legacy peers before opening any stream
+	for _, p := range peers {
+		if p.Info.ID != except && a.isLegacyPeer(p.Info.ID) {
+			return nil
+		}
+	}
+
+	clients := make([]networkProto.TxAnnounceClient, 0, len(peers))
+
+	for _, p := range peers {
+		peerID := p.Info.ID
+		if peerID == except {
+			continue
+		}
+
+		client, err := a.network.NewTxAnnounceClient(peerID)
+		if err != nil {
+			a.logger.Debug("peer doesn't support tx announcements", "peer", peerID, "err", err)
+			a.markLegacyPeer(peerID)
+
+			return nil
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// isLegacyPeer checks if the peer is known not to support the protocol
+func (a *txAnnouncer) isLegacyPeer(peerID peer.ID) bool {
+	a.legacyPeersLock.Lock()
+	defer a.legacyPeersLock.Unlock()
+
+	retryAt, ok := a.legacyPeers[peerID]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(retryAt) {
+		delete(a.legacyPeers, peerID)
+
+		return false
+	}
+
+	return true
+}
+
+// markLegacyPeer marks the peer as not supporting the protocol
+func (a *txAnnouncer) markLegacyPeer(peerID peer.ID) {
+	a.legacyPeersLock.Lock()
+	defer a.legacyPeersLock.Unlock()
+
+	now := time.Now()
+
+	// drop the expired entries of peers which aren't around anymore
+	for id, retryAt := range a.legacyPeers {
+		if now.After(retryAt) {
+			delete(a.legacyPeers, id)
+		}
+	}
+
+	a.legacyPeers[peerID] = now.Add(legacyPeerRetryInterval)
+}
+
+// Announce handles the hashes announced by a peer,
+// fetching the transactions missing from the pool
+func (a *txAnnouncer) Announce(
+	ctx context.Context,
+	req *networkProto.NewPooledTxHashes,
+) (*empty.Empty, error) {
+	grpcCtx, ok := ctx.(*libp2pGrpc.Context)
+	if !ok {
+		return &empty.Empty{}, nil
+	}
+
+	// like the gossiped txs, the announced ones are ignored by non-sealing nodes
+	if !a.pool.sealing {
+		return &empty.Empty{}, nil
+	}
+
+	if len(req.Hashes) > maxAnnounceHashes {
+		a.network.ReportMisbehavior(grpcCtx.PeerID, network.MisbehaviorProtocolViolation)
+
+		return nil, errTooManyHashes
+	}
+
+	missing := make([][]byte, 0, len(req.Hashes))
+
+	for _, hash := range req.Hashes {
+		if len(hash) != types.HashLength {
+			a.network.ReportMisbehavior(grpcCtx.PeerID, network.MisbehaviorProtocolViolation)
+
+			return nil, errInvalidHashLength
+		}
+
+		if _, ok := a.pool.index.get(types.BytesToHash(hash)); !ok {
+			missing = append(missing, hash)
+		}
+	}
+
+	// the hashes already being fetched from another peer
+	// are only retried from this one if that fetch fails
+	if missing = a.markFetching(grpcCtx.PeerID, missing); len(missing) > 0 {
+		a.startFetch(grpcCtx.PeerID, missing)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Fetch returns the requested transactions present in the pool
+func (a *txAnnouncer) Fetch(
+	_ context.Context,
+	req *networkProto.GetPooledTxs,
+) (*networkProto.PooledTxs, error) {
+	if len(req.Hashes) > maxAnnounceHashes {
+		return nil, errTooManyHashes
+	}
+
+	var (
+		resp = &networkProto.PooledTxs{}
+		size = 0
+	)
+
+	for _, hash := range req.Hashes {
+		if len(hash) != types.HashLength {
+			return nil, errInvalidHashLength
+		}
+
+		tx, ok := a.pool.index.get(types.BytesToHash(hash))
+		if !ok {
+			continue
+		}
+
+		raw := tx.MarshalRLP()
+		resp.Txs = append(resp.Txs, raw)
+
+		// the remaining transactions can be fetched from other peers
+		if size += len(raw); size >= maxPooledTxsSize {
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// markFetching marks the announced hashes as being fetched from the peer
+// and returns the ones which weren't being fetched already.
+// The peer is recorded as a fallback for the others
+func (a *txAnnouncer) markFetching(from peer.ID, hashes [][]byte) [][]byte {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	fresh := make([][]byte, 0, len(hashes))
+
+	for _, raw := range hashes {
+		hash := types.BytesToHash(raw)
+
+		fetching, ok := a.fetching[hash]
+		if !ok {
+			a.fetching[hash] = &fetchingTx{from: from}
+			fresh = append(fresh, raw)
+
+			continue
+		}
+
+		if fetching.from != from &&
+			len(fetching.announcers) < maxFetchRetries &&
+			!containsPeer(fetching.announcers, from) {
+			fetching.announcers = append(fetching.announcers, from)
+		}
+	}
+
+	return fresh
+}
+
+// unmarkFetching drops the hashes from the ones being fetched
+func (a *txAnnouncer) unmarkFetching(hashes [][]byte) {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	for _, raw := range hashes {
+		delete(a.fetching, types.BytesToHash(raw))
+	}
+}
+
+// doneFetching unmarks the fetched hashes. The ones the peer didn't deliver
+// are handed over to the next peer which announced them, and returned grouped by it
+func (a *txAnnouncer) doneFetching(
+	hashes [][]byte,
+	delivered map[types.Hash]struct{},
+) map[peer.ID][][]byte {
+	a.fetchingLock.Lock()
+	defer a.fetchingLock.Unlock()
+
+	retries := make(map[peer.ID][][]byte)
+
+	for _, raw := range hashes {
+		hash := types.BytesToHash(raw)
+
+		fetching, ok := a.fetching[hash]
+		if !ok {
+			continue
+		}
+
+		if _, ok := delivered[hash]; ok || len(fetching.announcers) == 0 {
+			delete(a.fetching, hash)
+
+			continue
+		}
+
+		if _, ok := a.pool.index.get(hash); ok {
+			delete(a.fetching, hash)
+
+			continue
+		}
+
+		fetching.from = fetching.announcers[0]
+		fetching.announcers = fetching.announcers[1:]
+
+		retries[fetching.from] = append(retries[fetching.from], raw)
+	}
+
+	return retries
+}
+
+// startFetch fetches the hashes from the peer in the background,
+// retrying the undelivered ones from the other peers which announced them.
+// The hashes are dropped if too many fetches are in flight already
+func (a *txAnnouncer) startFetch(from peer.ID, hashes [][]byte) {
+	select {
+	case a.fetchSlots <- struct{}{}:
+	default:
+		a.logger.Debug("too many fetches in flight, dropping announced txs", "peer", from, "count", len(hashes))
+		a.unmarkFetching(hashes)
+
+		return
+	}
+
+	go func() {
+		delivered := a.fetch(from, hashes)
+
+		<-a.fetchSlots
+
+		for next, retry := range a.doneFetching(hashes, delivered) {
+			a.startFetch(next, retry)
+		}
+	}()
+}
+
+// fetch requests the missing transactions from the peer that announced them,
+// adds them to the pool and passes them on to the other peers.
+// It returns the hashes of the transactions the peer delivered
+func (a *txAnnouncer) fetch(from peer.ID, hashes [][]byte) map[types.Hash]struct{} {
+	client, err := a.network.NewTxAnnounceClient(from)
+	if err != nil {
+		a.logger.Error("failed to open tx announce client", "peer", from, "err", err)
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), announceTimeout)
+	defer cancel()
+
+	resp, err := client.Fetch(ctx, &networkProto.GetPooledTxs{Hashes: hashes})
+	if err != nil {
+		a.logger.Error("failed to fetch announced txs", "peer", from, "err", err)
+
+		return nil
+	}
+
+	requested := make(map[types.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		requested[types.BytesToHash(hash)] = struct{}{}
+	}
+
+	var (
+		delivered = make(map[types.Hash]struct{}, len(resp.Txs))
+		added     = make([]*types.Transaction, 0, len(resp.Txs))
+	)
+
+	for _, raw := range resp.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			a.logger.Error("failed to decode fetched tx", "peer", from, "err", err)
+			a.network.ReportMisbehavior(from, network.MisbehaviorInvalidTx)
+
+			continue
+		}
+
+		if _, ok := requested[tx.Hash]; !ok {
+			a.logger.Debug("dropping unrequested tx", "peer", from, "hash", tx.Hash.String())
+			a.network.ReportMisbehavior(from, network.MisbehaviorProtocolViolation)
+
+			continue
+		}
+
+		delivered[tx.Hash] = struct{}{}
+
+		if err := a.pool.addTx(gossip, tx); err != nil {
+			a.logger.Error("failed to add fetched tx", "peer", from, "err", err)
+
+			// txs invalid against the local state may still be valid on the peer
+			if isMalformedTxError(err) {
+				a.network.ReportMisbehavior(from, network.MisbehaviorInvalidTx)
+			}
+
+			continue
+		}
+
+		added = append(added, tx)
+	}
+
+	if len(added) > 0 {
+		a.relay(from, added)
+	}
+
+	return delivered
+}
+
+// containsPeer checks if the peer is in the list
+func containsPeer(peers []peer.ID, id peer.ID) bool {
+	for _, p := range peers {
+		if p == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relay passes the fetched transactions on to the other peers,
+// falling back to the full broadcast if they can't be announced
+func (a *txAnnouncer) relay(from peer.ID, txs []*types.Transaction) {
+	hashes := make([]types.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+
+	if a.announce(hashes, from) {
+		return
+	}
+
+	if a.pool.topic == nil {
+		return
+	}
+
+	for _, tx := range txs {
+		a.pool.broadcastTx(tx)
+	}
+}
@@ -0,0 +1,562 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
+	libp2pGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
+	networkProto "github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errProtocolNotSupported = errors.New("protocol not supported")
+
+// mockAnnounceNetwork is a set of peers with their announcement clients.
+// Peers without a client don't support the protocol
+type mockAnnounceNetwork struct {
+	sync.Mutex
+
+	peers       []peer.ID
+	clients     map[peer.ID]networkProto.TxAnnounceClient
+	clientCalls int
+	reports     map[peer.ID][]network.Misbehavior
+}
+
+func newMockAnnounceNetwork() *mockAnnounceNetwork {
+	return &mockAnnounceNetwork{
+		clients: make(map[peer.ID]networkProto.TxAnnounceClient),
+		reports: make(map[peer.ID][]network.Misbehavior),
+	}
+}
+
+func (m *mockAnnounceNetwork) addPeer(id peer.ID, client networkProto.TxAnnounceClient) {
+	m.peers = append(m.peers, id)
+
+	if client != nil {
+		m.clients[id] = client
+	}
+}
+
+func (m *mockAnnounceNetwork) Peers() []*network.PeerConnInfo {
+	peers := make([]*network.PeerConnInfo, len(m.peers))
+	for i, id := range m.peers {
+		peers[i] = &network.PeerConnInfo{Info: peer.AddrInfo{ID: id}}
+	}
+
+	return peers
+}
+
+func (m *mockAnnounceNetwork) NewTxAnnounceClient(id peer.ID) (networkProto.TxAnnounceClient, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.clientCalls++
+
+	client, ok := m.clients[id]
+	if !ok {
+		return nil, errProtocolNotSupported
+	}
+
+	return client, nil
+}
+
+func (m *mockAnnounceNetwork) RegisterTxAnnounceService(networkProto.TxAnnounceServer) {}
+
+func (m *mockAnnounceNetwork) ReportMisbehavior(id peer.ID, misbehavior network.Misbehavior) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.reports[id] = append(m.reports[id], misbehavior)
+}
+
+func (m *mockAnnounceNetwork) getReports(id peer.ID) []network.Misbehavior {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.reports[id]
+}
+
+// mockAnnounceClient forwards the requests to the announcer
+// of another pool, as if they were sent by the given peer
+type mockAnnounceClient struct {
+	from   peer.ID
+	server *txAnnouncer
+}
+
+func (c *mockAnnounceClient) Announce(
+	ctx context.Context,
+	in *networkProto.NewPooledTxHashes,
+	_ ...grpc.CallOption,
+) (*empty.Empty, error) {
+	return c.server.Announce(&libp2pGrpc.Context{Context: ctx, PeerID: c.from}, in)
+}
+
+func (c *mockAnnounceClient) Fetch(
+	ctx context.Context,
+	in *networkProto.GetPooledTxs,
+	_ ...grpc.CallOption,
+) (*networkProto.PooledTxs, error) {
+	return c.server.Fetch(&libp2pGrpc.Context{Context: ctx, PeerID: c.from}, in)
+}
+
+// recordingAnnounceClient records the announced hashes
+type recordingAnnounceClient struct {
+	announcedCh chan [][]byte
+}
+
+func newRecordingAnnounceClient() *recordingAnnounceClient {
+	return &recordingAnnounceClient{
+		announcedCh: make(chan [][]byte, 8),
+	}
+}
+
+func (c *recordingAnnounceClient) Announce(
+	_ context.Context,
+	in *networkProto.NewPooledTxHashes,
+	_ ...grpc.CallOption,
+) (*empty.Empty, error) {
+	c.announcedCh <- in.Hashes
+
+	return &empty.Empty{}, nil
+}
+
+func (c *recordingAnnounceClient) Fetch(
+	context.Context,
+	*networkProto.GetPooledTxs,
+	...grpc.CallOption,
+) (*networkProto.PooledTxs, error) {
+	return &networkProto.PooledTxs{}, nil
+}
+
+func (c *recordingAnnounceClient) waitAnnouncement(t *testing.T) [][]byte {
+	t.Helper()
+
+	select {
+	case hashes := <-c.announcedCh:
+		return hashes
+	case <-time.After(time.Second):
+		t.Fatal("no announcement received")
+	}
+
+	return nil
+}
+
+// newAnnouncingPool creates a sealing pool with an announcer on the given network
+func newAnnouncingPool(t *testing.T, network *mockAnnounceNetwork) *TxPool {
+	t.Helper()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(crypto.NewEIP155Signer(uint64(100)))
+	pool.sealing = true
+	pool.announcer = newTxAnnouncer(hclog.NewNullLogger(), pool, network)
+
+	return pool
+}
+
+// enqueueSignedTx adds a new enqueued (future nonce) transaction to the pool
+func enqueueSignedTx(t *testing.T, pool *TxPool) *types.Transaction {
+	t.Helper()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+
+	tx, err := crypto.NewEIP155Signer(uint64(100)).SignTx(newTx(types.ZeroAddress, 1, 1), key)
+	assert.NoError(t, err)
+
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	return tx
+}
+
+func TestTxAnnouncer_Announce(t *testing.T) {
+	network := newMockAnnounceNetwork()
+	pool := newAnnouncingPool(t, network)
+
+	hash := types.StringToHash("0x1")
+
+	// too few peers, the tx is broadcast in full
+	client := newRecordingAnnounceClient()
+	network.addPeer("A", client)
+
+	assert.False(t, pool.announcer.announce([]types.Hash{hash}, ""))
+
+	// enough peers, all of them get the hashes
+	clients := []*recordingAnnounceClient{client}
+
+	for _, id := range []peer.ID{"B", "C", "D"} {
+		client := newRecordingAnnounceClient()
+		clients = append(clients, client)
+		network.addPeer(id, client)
+	}
+
+	assert.True(t, pool.announcer.announce([]types.Hash{hash}, ""))
+
+	for _, client := range clients {
+		assert.Equal(t, [][]byte{hash.Bytes()}, client.waitAnnouncement(t))
+	}
+
+	// a peer without the protocol gets full broadcasts
+	network.addPeer("E", nil)
+
+	assert.False(t, pool.announcer.announce([]types.Hash{hash}, ""))
+
+	// the legacy peer isn't probed again until the retry interval passes
+	calls := network.clientCalls
+
+	assert.False(t, pool.announcer.announce([]types.Hash{hash}, ""))
+	assert.Equal(t, calls, network.clientCalls)
+}
+
+func TestTxAnnouncer_Batches(t *testing.T) {
+	network := newMockAnnounceNetwork()
+	pool := newAnnouncingPool(t, network)
+
+	recorder := &batchRecorder{}
+	pool.batcher = newGossipBatcher(time.Hour, maxGossipBatchSize, recorder.publish)
+	pool.hashBatcher = newGossipBatcher(time.Hour, maxAnnounceHashes*types.HashLength, pool.announceBatch)
+
+	txs := []*types.Transaction{enqueueSignedTx(t, pool), enqueueSignedTx(t, pool)}
+
+	// too few peers, the batch is broadcast in full
+	for _, tx := range txs {
+		pool.publishTx(tx)
+	}
+
+	pool.hashBatcher.flush()
+
+	assert.Equal(t, [][][]byte{{txs[0].MarshalRLP(), txs[1].MarshalRLP()}}, recorder.get())
+
+	// enough peers, the hashes published within the window are announced at once
+	var clients []*recordingAnnounceClient
+
+	for _, id := range []peer.ID{"A", "B", "C", "D"} {
+		client := newRecordingAnnounceClient()
+		clients = append(clients, client)
+		network.addPeer(id, client)
+	}
+
+	for _, tx := range txs {
+		pool.publishTx(tx)
+	}
+
+	for _, client := range clients {
+		assert.Len(t, client.announcedCh, 0)
+	}
+
+	pool.hashBatcher.flush()
+
+	for _, client := range clients {
+		assert.Equal(t, [][]byte{txs[0].Hash.Bytes(), txs[1].Hash.Bytes()}, client.waitAnnouncement(t))
+	}
+
+	assert.Len(t, recorder.get(), 1)
+}
+
+func TestTxAnnouncer_Fetch(t *testing.T) {
+	pool := newAnnouncingPool(t, newMockAnnounceNetwork())
+	tx := enqueueSignedTx(t, pool)
+
+	unknown := types.StringToHash("0x1")
+
+	t.Run("returns the known transactions", func(t *testing.T) {
+		resp, err := pool.announcer.Fetch(context.Background(), &networkProto.GetPooledTxs{
+			Hashes: [][]byte{tx.Hash.Bytes(), unknown.Bytes()},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{tx.MarshalRLP()}, resp.Txs)
+	})
+
+	t.Run("rejects malformed hashes", func(t *testing.T) {
+		_, err := pool.announcer.Fetch(context.Background(), &networkProto.GetPooledTxs{
+			Hashes: [][]byte{{0x1}},
+		})
+		assert.ErrorIs(t, err, errInvalidHashLength)
+	})
+
+	t.Run("rejects too many hashes", func(t *testing.T) {
+		_, err := pool.announcer.Fetch(context.Background(), &networkProto.GetPooledTxs{
+			Hashes: make([][]byte, maxAnnounceHashes+1),
+		})
+		assert.ErrorIs(t, err, errTooManyHashes)
+	})
+}
+
+func TestTxAnnouncer_FetchesMissingTxs(t *testing.T) {
+	// the origin pool has the tx and announces it to the receiver
+	origin := newAnnouncingPool(t, newMockAnnounceNetwork())
+	tx := enqueueSignedTx(t, origin)
+
+	receiverNetwork := newMockAnnounceNetwork()
+	receiver := newAnnouncingPool(t, receiverNetwork)
+
+	receiverNetwork.addPeer("origin", &mockAnnounceClient{from: "receiver", server: origin.announcer})
+
+	// the other peers of the receiver get the tx announced further
+	relayed := newRecordingAnnounceClient()
+	for _, id := range []peer.ID{"B", "C", "D"} {
+		receiverNetwork.addPeer(id, relayed)
+	}
+
+	announceFromOrigin := func(hash types.Hash) error {
+		_, err := receiver.announcer.Announce(
+			&libp2pGrpc.Context{Context: context.Background(), PeerID: "origin"},
+			&networkProto.NewPooledTxHashes{Hashes: [][]byte{hash.Bytes()}},
+		)
+
+		return err
+	}
+
+	assert.NoError(t, announceFromOrigin(tx.Hash))
+	receiver.handleEnqueueRequest(<-receiver.enqueueReqCh)
+
+	received, ok := receiver.index.get(tx.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, tx.Hash, received.Hash)
+
+	// the tx is announced to every peer except its origin
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, [][]byte{tx.Hash.Bytes()}, relayed.waitAnnouncement(t))
+	}
+
+	// known txs are not fetched again
+	calls := receiverNetwork.clientCalls
+
+	assert.NoError(t, announceFromOrigin(tx.Hash))
+	assert.Equal(t, calls, receiverNetwork.clientCalls)
+
+	// non-sealing nodes ignore the announced txs, as they do with the gossiped ones
+	receiver.sealing = false
+	tx = enqueueSignedTx(t, origin)
+
+	assert.NoError(t, announceFromOrigin(tx.Hash))
+	assert.Equal(t, calls, receiverNetwork.clientCalls)
+
+	_, ok = receiver.index.get(tx.Hash)
+	assert.False(t, ok)
+}
+
+// maliciousAnnounceClient answers every fetch request with the given raw transactions
+type maliciousAnnounceClient struct {
+	recordingAnnounceClient
+
+	txs [][]byte
+}
+
+func (c *maliciousAnnounceClient) Fetch(
+	context.Context,
+	*networkProto.GetPooledTxs,
+	...grpc.CallOption,
+) (*networkProto.PooledTxs, error) {
+	return &networkProto.PooledTxs{Txs: c.txs}, nil
+}
+
+func TestTxAnnouncer_ReportsMisbehavior(t *testing.T) {
+	announceFrom := func(pool *TxPool, from peer.ID, hashes [][]byte) error {
+		_, err := pool.announcer.Announce(
+			&libp2pGrpc.Context{Context: context.Background(), PeerID: from},
+			&networkProto.NewPooledTxHashes{Hashes: hashes},
+		)
+
+		return err
+	}
+
+	t.Run("malformed announcements", func(t *testing.T) {
+		announceNetwork := newMockAnnounceNetwork()
+		pool := newAnnouncingPool(t, announceNetwork)
+
+		assert.ErrorIs(t, announceFrom(pool, "A", [][]byte{{0x1}}), errInvalidHashLength)
+		assert.ErrorIs(t, announceFrom(pool, "A", make([][]byte, maxAnnounceHashes+1)), errTooManyHashes)
+
+		assert.Equal(t, []network.Misbehavior{
+			network.MisbehaviorProtocolViolation,
+			network.MisbehaviorProtocolViolation,
+		}, announceNetwork.getReports("A"))
+	})
+
+	t.Run("invalid fetched transactions", func(t *testing.T) {
+		key, _ := tests.GenerateKeyAndAddr(t)
+		signer := crypto.NewEIP155Signer(uint64(100))
+
+		// an unsigned transaction is never valid
+		invalid := newTx(types.ZeroAddress, 1, 1)
+		invalid.ComputeHash()
+
+		unrequested, err := signer.SignTx(newTx(types.ZeroAddress, 2, 1), key)
+		assert.NoError(t, err)
+
+		// a transaction above the local gas limit may be valid on the peer
+		overLimit := newTx(types.ZeroAddress, 3, 1)
+		overLimit.Gas = mockHeader.GasLimit + 1
+
+		overLimit, err = signer.SignTx(overLimit, key)
+		assert.NoError(t, err)
+
+		announceNetwork := newMockAnnounceNetwork()
+		pool := newAnnouncingPool(t, announceNetwork)
+
+		announceNetwork.addPeer("A", &maliciousAnnounceClient{
+			recordingAnnounceClient: *newRecordingAnnounceClient(),
+			txs: [][]byte{
+				overLimit.MarshalRLP(),
+				{0xff},
+				invalid.MarshalRLP(),
+				unrequested.MarshalRLP(),
+			},
+		})
+
+		assert.NoError(t, announceFrom(pool, "A", [][]byte{invalid.Hash.Bytes(), overLimit.Hash.Bytes()}))
+
+		assert.Eventually(t, func() bool {
+			return len(announceNetwork.getReports("A")) == 3
+		}, time.Second, 10*time.Millisecond)
+
+		assert.ElementsMatch(t, []network.Misbehavior{
+			network.MisbehaviorInvalidTx,
+			network.MisbehaviorInvalidTx,
+			network.MisbehaviorProtocolViolation,
+		}, announceNetwork.getReports("A"))
+	})
+}
+
+// stallingAnnounceClient records the fetch requests
+// and answers them with no transactions once released
+type stallingAnnounceClient struct {
+	recordingAnnounceClient
+
+	fetchCh   chan [][]byte
+	releaseCh chan struct{}
+}
+
+func newStallingAnnounceClient() *stallingAnnounceClient {
+	return &stallingAnnounceClient{
+		recordingAnnounceClient: *newRecordingAnnounceClient(),
+		fetchCh:                 make(chan [][]byte, 8),
+		releaseCh:               make(chan struct{}),
+	}
+}
+
+func (c *stallingAnnounceClient) Fetch(
+	_ context.Context,
+	in *networkProto.GetPooledTxs,
+	_ ...grpc.CallOption,
+) (*networkProto.PooledTxs, error) {
+	c.fetchCh <- in.Hashes
+	<-c.releaseCh
+
+	return &networkProto.PooledTxs{}, nil
+}
+
+func (c *stallingAnnounceClient) waitFetch(t *testing.T) [][]byte {
+	t.Helper()
+
+	select {
+	case hashes := <-c.fetchCh:
+		return hashes
+	case <-time.After(time.Second):
+		t.Fatal("no fetch request received")
+	}
+
+	return nil
+}
+
+func TestTxAnnouncer_FetchesFromSingleAnnouncer(t *testing.T) {
+	origin := newAnnouncingPool(t, newMockAnnounceNetwork())
+	tx := enqueueSignedTx(t, origin)
+
+	receiverNetwork := newMockAnnounceNetwork()
+	receiver := newAnnouncingPool(t, receiverNetwork)
+
+	stalling := newStallingAnnounceClient()
+	receiverNetwork.addPeer("A", stalling)
+	receiverNetwork.addPeer("origin", &mockAnnounceClient{from: "receiver", server: origin.announcer})
+
+	announceFrom := func(from peer.ID) {
+		_, err := receiver.announcer.Announce(
+			&libp2pGrpc.Context{Context: context.Background(), PeerID: from},
+			&networkProto.NewPooledTxHashes{Hashes: [][]byte{tx.Hash.Bytes()}},
+		)
+		assert.NoError(t, err)
+	}
+
+	announceFrom("A")
+	assert.Equal(t, [][]byte{tx.Hash.Bytes()}, stalling.waitFetch(t))
+
+	// the hash is being fetched from A, the other announcers are only a fallback
+	announceFrom("origin")
+	announceFrom("A")
+
+	receiverNetwork.Lock()
+	assert.Equal(t, 1, receiverNetwork.clientCalls)
+	receiverNetwork.Unlock()
+
+	// A doesn't deliver the tx, so it is fetched from the next announcer
+	close(stalling.releaseCh)
+	receiver.handleEnqueueRequest(<-receiver.enqueueReqCh)
+
+	_, ok := receiver.index.get(tx.Hash)
+	assert.True(t, ok)
+
+	assert.Eventually(t, func() bool {
+		receiver.announcer.fetchingLock.Lock()
+		defer receiver.announcer.fetchingLock.Unlock()
+
+		return len(receiver.announcer.fetching) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Len(t, stalling.fetchCh, 0)
+}
+
+func TestTxAnnouncer_LimitsConcurrentFetches(t *testing.T) {
+	announceNetwork := newMockAnnounceNetwork()
+	pool := newAnnouncingPool(t, announceNetwork)
+
+	stalling := newStallingAnnounceClient()
+	defer close(stalling.releaseCh)
+
+	announceNetwork.addPeer("A", stalling)
+
+	hash := types.StringToHash("0x1")
+
+	announce := func() {
+		_, err := pool.announcer.Announce(
+			&libp2pGrpc.Context{Context: context.Background(), PeerID: "A"},
+			&networkProto.NewPooledTxHashes{Hashes: [][]byte{hash.Bytes()}},
+		)
+		assert.NoError(t, err)
+	}
+
+	// all the fetch slots are taken
+	for i := 0; i < maxConcurrentFetches; i++ {
+		pool.announcer.fetchSlots <- struct{}{}
+	}
+
+	announce()
+
+	// the announcement is dropped without marking the hash
+	assert.Len(t, pool.announcer.fetching, 0)
+
+	announceNetwork.Lock()
+	assert.Equal(t, 0, announceNetwork.clientCalls)
+	announceNetwork.Unlock()
+
+	// once a slot frees up, the hash is fetched when announced again
+	<-pool.announcer.fetchSlots
+
+	announce()
+	assert.Equal(t, [][]byte{hash.Bytes()}, stalling.waitFetch(t))
+}
@@ -1,9 +1,13 @@
 package txpool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
@@ -11,6 +15,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/requestid"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -21,12 +27,22 @@ const (
 	txSlotSize  = 32 * 1024  // 32kB
 	txMaxSize   = 128 * 1024 //128Kb
 	topicNameV1 = "txpool/0.1"
+
+	// topic of the gossip messages carrying multiple transactions
+	topicNameBatchV1 = "txpool/batch/0.1"
+
+	// how often the pool is swept for expired transactions
+	evictionInterval = time.Minute
+
+	// how often the pending transactions of local accounts are published again
+	rebroadcastInterval = 30 * time.Second
 )
 
 // errors
 var (
 	ErrIntrinsicGas        = errors.New("intrinsic gas too low")
-	ErrBlockLimitExceeded  = errors.New("exceeds block gas limit")
+	ErrGasLimitTooHigh     = errors.New("exceeds block gas limit")
+	ErrBlockLimitExceeded  = ErrGasLimitTooHigh // Deprecated: use ErrGasLimitTooHigh
 	ErrNegativeValue       = errors.New("negative value")
 	ErrNonEncryptedTx      = errors.New("non-encrypted transaction")
 	ErrInvalidSender       = errors.New("invalid sender")
@@ -37,15 +53,21 @@ var (
 	ErrInvalidAccountState = errors.New("invalid account state")
 	ErrAlreadyKnown        = errors.New("already known")
 	ErrOversizedData       = errors.New("oversized data")
+	ErrTipAboveFeeCap      = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrTooManyAccountTxs   = errors.New("too many enqueued transactions for account")
+	ErrReplaceUnderpriced  = errors.New("replacement transaction underpriced")
+	ErrTxNotFound          = errors.New("transaction not found in the pool")
+	ErrTxExecuting         = errors.New("transaction is being executed")
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
-	reorg                  // legacy code
+	local    txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                   // gossip protocol
+	reorg                    // legacy code
+	operator                 // operator endpoint, the sender is treated as local
 )
 
 func (o txOrigin) String() (s string) {
@@ -56,6 +78,8 @@ func (o txOrigin) String() (s string) {
 		s = "gossip"
 	case reorg:
 		s = "reorg"
+	case operator:
+		s = "operator"
 	}
 
 	return
@@ -67,6 +91,8 @@ type store interface {
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	ReadTxLookup(hash types.Hash) (types.Hash, bool)
+	CalcBaseFee(parent *types.Header) uint64
 }
 
 type signer interface {
@@ -77,6 +103,42 @@ type Config struct {
 	PriceLimit uint64
 	MaxSlots   uint64
 	Sealing    bool
+
+	// MaxAccountSlots is the maximum number of enqueued (future nonce)
+	// transactions a single account can hold. 0 means no limit
+	MaxAccountSlots uint64
+
+	// PriceBump is the minimum gas price increase (in percent)
+	// required to replace a transaction with the same nonce
+	PriceBump uint64
+
+	// Lifetime is the maximum time an enqueued (future nonce)
+	// transaction can spend in the pool. 0 disables the eviction
+	Lifetime time.Duration
+
+	// GossipBatchWindow is the time window in which the transactions
+	// added to the pool are coalesced into a single gossip message.
+	// 0 gossips every transaction individually
+	GossipBatchWindow time.Duration
+
+	// Journal is the file the transactions of local accounts are persisted to,
+	// so they survive node restarts. Empty disables the journal
+	Journal string
+
+	// JournalRemotes includes the transactions of all the accounts in the journal
+	JournalRemotes bool
+
+	// Rejournal is the interval of regenerating the journal from the pool
+	Rejournal time.Duration
+
+	// GasLimitMargin is the share (in percent) of the block gas limit
+	// a single transaction can't claim. 0 admits transactions
+	// up to the block gas limit
+	GasLimitMargin uint64
+
+	// RejectUnprotected rejects the transactions without
+	// replay protection once EIP155 is active
+	RejectUnprotected bool
 }
 
 /* All requests are passed to the main loop
@@ -88,6 +150,9 @@ through their designated channels. */
 // that passed validation in addTx.
 type enqueueRequest struct {
 	tx *types.Transaction
+
+	// block the transaction has to be included by (0 if there is none)
+	deadline uint64
 }
 
 // A promoteRequest is created each time some account
@@ -119,7 +184,7 @@ type promoteRequest struct {
 type TxPool struct {
 	logger hclog.Logger
 	signer signer
-	forks  chain.ForksInTime
+	forks  *chain.Forks
 	store  store
 
 	// map of all accounts registered by the pool
@@ -128,19 +193,67 @@ type TxPool struct {
 	// all the primaries sorted by max gas price
 	executables *pricedQueue
 
+	// transactions handed out by Peek to the block being built,
+	// until they are popped, dropped or demoted
+	executing     map[types.Hash]struct{}
+	executingLock sync.Mutex
+
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic      *network.Topic
+	batchTopic *network.Topic
+
+	// coalesces the gossiped transactions (nil if disabled)
+	batcher *gossipBatcher
+
+	// announces the hashes of new transactions to the peers
+	announcer *txAnnouncer
+
+	// coalesces the announced hashes (nil if batching is disabled)
+	hashBatcher *gossipBatcher
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
-	// priceLimit is a lower threshold for gas price
+	// pending and queued transaction counters
+	counters txCounters
+
+	// maxAccountSlots is the limit of enqueued txs per account
+	maxAccountSlots uint64
+
+	// priceBump is the minimum price increase (%) for replacing a tx
+	priceBump uint64
+
+	// gasLimitMargin is the share (%) of the block gas limit
+	// kept out of reach of a single tx
+	gasLimitMargin uint64
+
+	// rejectUnprotected rejects the txs without replay protection once EIP155 is active
+	rejectUnprotected bool
+
+	// priceLimit is a lower threshold for gas price,
+	// can be changed at runtime through the operator
 	priceLimit uint64
 
+	// accounts of transactions submitted
+	// directly to this node by its operator
+	locals localAccounts
+
+	// lifetime is the max age of enqueued txs
+	lifetime time.Duration
+
+	// persists the transactions across restarts (nil if disabled)
+	journal        *txJournal
+	journalRemotes bool
+	rejournal      time.Duration
+
+	// baseFee is the base fee used for pricing the transactions
+	// and ordering the executables by effective tip
+	baseFee uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -166,24 +279,45 @@ type TxPool struct {
 // NewTxPool returns a new pool for processing incoming transactions.
 func NewTxPool(
 	logger hclog.Logger,
-	forks chain.ForksInTime,
+	forks *chain.Forks,
 	store store,
 	grpcServer *grpc.Server,
 	network *network.Server,
 	metrics *Metrics,
 	config *Config,
 ) (*TxPool, error) {
+	if config.GasLimitMargin >= 100 {
+		return nil, fmt.Errorf("invalid gas limit margin %d%%, must be below 100%%", config.GasLimitMargin)
+	}
+
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		metrics:     metrics,
-		accounts:    accountsMap{},
-		executables: newPricedQueue(),
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		sealing:     config.Sealing,
+		logger:     logger.Named("txpool"),
+		forks:      forks,
+		store:      store,
+		metrics:    metrics,
+		accounts:   accountsMap{},
+		index:      lookupMap{all: make(map[types.Hash]*poolEntry)},
+		executing:  make(map[types.Hash]struct{}),
+		locals:     localAccounts{accounts: make(map[types.Address]struct{})},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+		sealing:    config.Sealing,
+
+		maxAccountSlots: config.MaxAccountSlots,
+		priceBump:       config.PriceBump,
+		gasLimitMargin:  config.GasLimitMargin,
+		lifetime:        config.Lifetime,
+
+		rejectUnprotected: config.RejectUnprotected,
+	}
+
+	// the txs of local accounts are sealed first
+	pool.executables = newPricedQueue(pool.locals.contains)
+
+	if config.Journal != "" {
+		pool.journal = &txJournal{path: config.Journal}
+		pool.journalRemotes = config.JournalRemotes
+		pool.rejournal = config.Rejournal
 	}
 
 	// Attach the event manager
@@ -201,6 +335,37 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+
+		// batches are always accepted, regardless of the local batching config
+		batchTopic, err := network.NewTopic(topicNameBatchV1, &proto.TxnBatch{})
+		if err != nil {
+			return nil, err
+		}
+
+		if subscribeErr := batchTopic.Subscribe(pool.addGossipBatch); subscribeErr != nil {
+			return nil, fmt.Errorf("unable to subscribe to gossip batch topic, %w", subscribeErr)
+		}
+
+		pool.batchTopic = batchTopic
+
+		if config.GossipBatchWindow > 0 {
+			pool.batcher = newGossipBatcher(
+				config.GossipBatchWindow,
+				maxGossipBatchSize,
+				pool.publishBatch,
+			)
+		}
+
+		pool.announcer = newTxAnnouncer(pool.logger, pool, network)
+		pool.announcer.start()
+
+		if config.GossipBatchWindow > 0 {
+			pool.hashBatcher = newGossipBatcher(
+				config.GossipBatchWindow,
+				maxAnnounceHashes*types.HashLength,
+				pool.announceBatch,
+			)
+		}
 	}
 
 	if grpcServer != nil {
@@ -219,8 +384,12 @@ func NewTxPool(
 // On each request received, the appropriate handler
 // is invoked in a separate goroutine.
 func (p *TxPool) Start() {
-	// set default value of txpool pending transactions gauge
+	// set default values of txpool transactions gauges
 	p.metrics.PendingTxs.Set(0)
+	p.metrics.QueuedTxs.Set(0)
+
+	// price the transactions with the base fee of the next block
+	p.SetBaseFee(p.store.CalcBaseFee(p.store.Header()))
 
 	go func() {
 		for {
@@ -234,12 +403,42 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.lifetime > 0 {
+		go p.runEvictionLoop()
+	}
+
+	if p.topic != nil {
+		go p.runRebroadcastLoop()
+	}
+
+	if p.journal != nil {
+		p.loadJournal()
+
+		if p.rejournal > 0 {
+			go p.runJournalLoop()
+		}
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
+	if p.hashBatcher != nil {
+		// announce the hashes still waiting for their batch
+		p.hashBatcher.flush()
+	}
+
+	if p.batcher != nil {
+		// publish the transactions still waiting for their batch
+		p.batcher.flush()
+	}
+
+	if p.journal != nil {
+		p.writeJournal()
+	}
+
 	p.eventManager.Close()
-	p.shutdownCh <- struct{}{}
+	close(p.shutdownCh)
 }
 
 // SetSigner sets the signer the pool will use
@@ -250,9 +449,17 @@ func (p *TxPool) SetSigner(s signer) {
 
 // AddTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
-func (p *TxPool) AddTx(tx *types.Transaction) error {
-	if err := p.addTx(local, tx); err != nil {
-		p.logger.Error("failed to add tx", "err", err)
+// The errors are logged along with the ID of the request carried by the context, if any
+func (p *TxPool) AddTx(ctx context.Context, tx *types.Transaction) error {
+	return p.submitTx(ctx, local, tx, 0)
+}
+
+// submitTx adds a new transaction to the pool, which is dropped if the chain
+// advances past the deadline block without including it (0 disables it),
+// and broadcasts it to the network (if enabled).
+func (p *TxPool) submitTx(ctx context.Context, origin txOrigin, tx *types.Transaction, deadline uint64) error {
+	if err := p.addTxWithDeadline(origin, tx, deadline); err != nil {
+		requestid.Logger(ctx, p.logger).Error("failed to add tx", "err", err)
 
 		return err
 	}
@@ -260,18 +467,111 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 	// broadcast the transaction only if a topic
 	// subscription is present
 	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
+		p.publishTx(tx)
+	}
+
+	return nil
+}
+
+// publishTx announces the transaction's hash to the peers, as part of
+// the next batch (if batching is enabled), or broadcasts the full
+// transaction if it can't be announced
+func (p *TxPool) publishTx(tx *types.Transaction) {
+	if p.hashBatcher != nil {
+		p.hashBatcher.add(tx.Hash.Bytes())
+
+		return
+	}
+
+	if p.announcer != nil && p.announcer.announce([]types.Hash{tx.Hash}, "") {
+		return
+	}
+
+	p.broadcastTx(tx)
+}
+
+// announceBatch announces the hashes of the transactions published within
+// the batch window. If they can't be announced, the transactions still
+// in the pool are broadcast in full right away
+func (p *TxPool) announceBatch(raws [][]byte) {
+	hashes := make([]types.Hash, len(raws))
+	for i, raw := range raws {
+		hashes[i] = types.BytesToHash(raw)
+	}
+
+	if p.announcer.announce(hashes, "") {
+		return
+	}
+
+	for _, hash := range hashes {
+		if tx, ok := p.index.get(hash); ok {
+			p.batcher.add(tx.MarshalRLP())
 		}
+	}
+
+	// the transactions already waited for the batch window
+	p.batcher.flush()
+}
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
+// broadcastTx gossips the transaction, either on its own
+// or as part of the next batch (if batching is enabled)
+func (p *TxPool) broadcastTx(tx *types.Transaction) {
+	if p.batcher != nil {
+		p.batcher.add(tx.MarshalRLP())
+
+		return
+	}
+
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
+}
+
+// publishBatch gossips the encoded transactions as a single message
+func (p *TxPool) publishBatch(raws [][]byte) {
+	msg := &proto.TxnBatch{
+		Raws: make([]*any.Any, len(raws)),
+	}
+
+	for i, raw := range raws {
+		msg.Raws[i] = &any.Any{
+			Value: raw,
 		}
 	}
 
-	return nil
+	if err := p.batchTopic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx batch", "err", err)
+	}
+}
+
+// AddLocalTx adds a new transaction submitted by the node operator.
+// Once the transaction is admitted, its sender is marked as local
+// (see localAccounts).
+func (p *TxPool) AddLocalTx(tx *types.Transaction) error {
+	return p.submitTx(context.Background(), operator, tx, 0)
+}
+
+// SetBaseFee sets the base fee of the block being built.
+// It is used to price the incoming transactions,
+// and to order the executables by effective tip.
+func (p *TxPool) SetBaseFee(baseFee uint64) {
+	atomic.StoreUint64(&p.baseFee, baseFee)
+}
+
+// GetBaseFee returns the base fee the pool uses for ordering.
+func (p *TxPool) GetBaseFee() uint64 {
+	return atomic.LoadUint64(&p.baseFee)
+}
+
+// GetPriceLimit returns the minimum gas price for accepting new transactions
+func (p *TxPool) GetPriceLimit() uint64 {
+	return atomic.LoadUint64(&p.priceLimit)
 }
 
 // Prepare generates all the transactions
@@ -282,6 +582,11 @@ func (p *TxPool) Prepare() {
 		p.executables.clear()
 	}
 
+	p.clearExecuting()
+
+	// executables are ordered by the effective tip
+	p.executables.setBaseFee(p.GetBaseFee())
+
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
@@ -300,7 +605,32 @@ func (p *TxPool) Peek() *types.Transaction {
 	// The executables queue just provides
 	// insight into which account has the
 	// highest priced tx (head of promoted queue)
-	return p.executables.pop()
+	p.executingLock.Lock()
+	defer p.executingLock.Unlock()
+
+	tx := p.executables.pop()
+	if tx != nil {
+		p.executing[tx.Hash] = struct{}{}
+	}
+
+	return tx
+}
+
+// doneExecuting clears the transaction handed out by Peek
+func (p *TxPool) doneExecuting(tx *types.Transaction) {
+	p.executingLock.Lock()
+	defer p.executingLock.Unlock()
+
+	delete(p.executing, tx.Hash)
+}
+
+// clearExecuting clears the transactions handed out by Peek,
+// which are left over once the block is built
+func (p *TxPool) clearExecuting() {
+	p.executingLock.Lock()
+	defer p.executingLock.Unlock()
+
+	p.executing = make(map[types.Hash]struct{})
 }
 
 // Pop removes the given transaction from the
@@ -308,20 +638,31 @@ func (p *TxPool) Peek() *types.Transaction {
 // Will update executables with the next primary
 // from that account (if any).
 func (p *TxPool) Pop(tx *types.Transaction) {
+	// runs last, the tx can't be removed until it's popped
+	defer p.doneExecuting(tx)
+
 	// fetch the associated account
 	account := p.accounts.get(tx.From)
 
+	// runs once the account is unlocked
+	defer p.trackAccount(account)
+
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
-	// pop the top most promoted tx
-	account.promoted.pop()
+	// pop the top most promoted tx. If the given tx was replaced
+	// while being executed, its replacement is popped instead
+	if popped := account.promoted.pop(); popped != nil && popped.Hash != tx.Hash {
+		p.index.remove(popped)
+		tx = popped
+	}
 
 	// update state
 	p.gauge.decrease(slotsRequired(tx))
 
 	// update metrics
 	p.metrics.PendingTxs.Add(-1)
+	p.counters.addPending(-1)
 
 	// update executables
 	if tx := account.promoted.peek(); tx != nil {
@@ -332,9 +673,14 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 // Drop clears the entire account associated with the given transaction
 // and reverts its next (expected) nonce.
 func (p *TxPool) Drop(tx *types.Transaction) {
+	defer p.doneExecuting(tx)
+
 	// fetch associated account
 	account := p.accounts.get(tx.From)
 
+	// runs once the account is unlocked
+	defer p.trackAccount(account)
+
 	account.promoted.lock(true)
 	account.enqueued.lock(true)
 
@@ -365,12 +711,17 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 
 	// update metrics
 	p.metrics.PendingTxs.Add(float64(-1 * len(dropped)))
+	p.counters.addPending(-len(dropped))
 
 	// drop enqueued
 	dropped = account.enqueued.clear()
 	clearAccountQueue(dropped)
+	p.metrics.QueuedTxs.Add(float64(-1 * len(dropped)))
+	p.counters.addQueued(-len(dropped))
 
-	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+	p.metrics.DroppedTxs.With("reason", dropReasonExecutionError).Add(float64(droppedCount))
+
+	p.eventManager.signalEventWithReason(proto.EventType_DROPPED, "unrecoverable execution error", tx)
 	p.logger.Debug("dropped account txs",
 		"num", droppedCount,
 		"next_nonce", nextNonce,
@@ -379,7 +730,8 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 }
 
 func (p *TxPool) Demote(tx *types.Transaction) {
-	p.eventManager.signalEvent(proto.EventType_DEMOTED, tx.Hash)
+	p.doneExecuting(tx)
+	p.eventManager.signalEventWithReason(proto.EventType_DEMOTED, "recoverable execution error", tx)
 }
 
 // ResetWithHeaders processes the transactions from the new
@@ -397,6 +749,9 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 // processEvent collects the latest nonces for each account containted
 // in the received event. Resets all known accounts with the new nonce.
 func (p *TxPool) processEvent(event *blockchain.Event) {
+	// the block is built, the txs it left over aren't executed
+	p.clearExecuting()
+
 	oldTxs := make(map[types.Hash]*types.Transaction)
 
 	// Legacy reorg logic //
@@ -456,17 +811,21 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		}
 	}
 
-	if len(stateNonces) == 0 {
-		return
+	if len(stateNonces) != 0 {
+		// reset accounts with the new state
+		p.resetAccounts(stateNonces)
 	}
 
-	// reset accounts with the new state
-	p.resetAccounts(stateNonces)
+	// the txs left behind by the chain are dropped (empty blocks included)
+	p.evictPastDeadline(p.store.Header().Number)
+
+	// price the transactions with the base fee of the next block
+	p.SetBaseFee(p.store.CalcBaseFee(p.store.Header()))
 }
 
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
-func (p *TxPool) validateTx(tx *types.Transaction) error {
+func (p *TxPool) validateTx(origin txOrigin, tx *types.Transaction) error {
 	// Check the transaction size to overcome DOS Attacks
 	if uint64(len(tx.MarshalRLP())) > txMaxSize {
 		return ErrOversizedData
@@ -477,11 +836,45 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrNegativeValue
 	}
 
+	// Check if the type of the transaction is enabled
+	// by the fork rules of the next block
+	nextBlock := p.store.Header().Number + 1
+
+	if err := state.CheckTxType(tx, p.forks.At(nextBlock)); err != nil {
+		return err
+	}
+
+	// Checked apart from the sender recovery, since the signer
+	// may return a sender cached by a path admitting these txs
+	if p.rejectUnprotected && p.forks.IsEIP155(nextBlock) && !crypto.IsReplayProtected(tx) {
+		return crypto.ErrUnprotectedTx
+	}
+
+	// Check the fee fields of dynamic fee transactions
+	if tx.Type == types.DynamicFeeTx &&
+		tx.MaxPriorityFeePerGas.Cmp(tx.MaxFeePerGas) > 0 {
+		return ErrTipAboveFeeCap
+	}
+
+	// Reject underpriced transactions before the
+	// (expensive) signature recovery to cheaply shed load.
+	// Local txs are exempt, the from field is checked
+	// against the signer below.
+	isLocal := origin == operator || p.locals.contains(tx.From)
+	if !isLocal && tx.IsUnderpriced(p.GetBaseFee(), p.GetPriceLimit()) {
+		return ErrUnderpriced
+	}
+
 	// Check if the transaction is signed properly
 
 	// Extract the sender
 	from, signerErr := p.signer.Sender(tx)
 	if signerErr != nil {
+		// the signer might be set to reject txs without replay protection
+		if errors.Is(signerErr, crypto.ErrUnprotectedTx) {
+			return signerErr
+		}
+
 		return ErrInvalidSender
 	}
 
@@ -497,11 +890,6 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
-	// Reject underpriced transactions
-	if tx.IsUnderpriced(p.priceLimit) {
-		return ErrUnderpriced
-	}
-
 	// Grab the state root for the latest block
 	stateRoot := p.store.Header().StateRoot
 
@@ -515,56 +903,72 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidAccountState
 	}
 
-	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
-		return ErrInsufficientFunds
+	// Check if the sender has enough funds to execute the transaction,
+	// along with its other transactions in the pool
+	cost := tx.Cost()
+	if account := p.accounts.get(tx.From); account != nil {
+		cost = account.costWith(tx)
 	}
 
-	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
-	if err != nil {
-		return err
+	if accountBalance.Cmp(cost) < 0 {
+		return ErrInsufficientFunds
 	}
 
-	if tx.Gas < intrinsicGas {
+	// Make sure the transaction has more gas than the basic transaction fee
+	// (21000 or 53000 for contract creations, plus the cost
+	// of the data bytes and of the access list entries),
+	// following the fork rules of the next block.
+	// An overflowing cost is above any gas limit
+	intrinsicGas, err := state.TransactionGasCost(
+		tx,
+		p.forks.IsHomestead(nextBlock),
+		p.forks.IsIstanbul(nextBlock),
+		p.forks.IsBerlin(nextBlock),
+	)
+	if err != nil || tx.Gas < intrinsicGas {
 		return ErrIntrinsicGas
 	}
 
-	// Grab the block gas limit for the latest block
-	latestBlockGasLimit := p.store.Header().GasLimit
-
-	if tx.Gas > latestBlockGasLimit {
-		return ErrBlockLimitExceeded
+	// Reject transactions which would never fit a block right away,
+	// instead of leaving them in the pool for good
+	if tx.Gas > p.gasLimitCeiling() {
+		return ErrGasLimitTooHigh
 	}
 
 	return nil
 }
 
+// gasLimitCeiling returns the maximum gas limit of an admitted transaction,
+// the gas limit of the latest block minus the configured margin
+func (p *TxPool) gasLimitCeiling() uint64 {
+	blockGasLimit := p.store.Header().GasLimit
+
+	return blockGasLimit - blockGasLimit/100*p.gasLimitMargin
+}
+
 // addTx is the main entry point to the pool
 // for all new transactions. If the call is
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
 func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+	return p.addTxWithDeadline(origin, tx, 0)
+}
+
+// addTxWithDeadline is addTx for a transaction that
+// has to be included by the deadline block (0 if none).
+func (p *TxPool) addTxWithDeadline(origin txOrigin, tx *types.Transaction, deadline uint64) error {
 	p.logger.Debug("add tx",
 		"origin", origin.String(),
 		"hash", tx.Hash.String(),
 	)
 
-	// validate incoming tx
-	if err := p.validateTx(tx); err != nil {
-		return err
-	}
-
-	// check for overflow
-	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
-		return ErrTxPoolOverflow
-	}
-
-	tx.ComputeHash()
+	start := time.Now()
+	defer func() {
+		p.metrics.AdmissionTime.Observe(time.Since(start).Seconds())
+	}()
 
-	// check if already known
-	if _, ok := p.index.get(tx.Hash); ok {
-		if origin == gossip {
+	if err := p.checkTx(origin, tx); err != nil {
+		if origin == gossip && errors.Is(err, ErrAlreadyKnown) {
 			// silently drop known tx
 			// that is gossiped back
 			p.logger.Debug(
@@ -573,9 +977,16 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			)
 
 			return nil
-		} else {
-			return ErrAlreadyKnown
 		}
+
+		p.metrics.RejectedTxs.With("reason", rejectionReason(err)).Add(1)
+
+		return err
+	}
+
+	// the sender is treated as local once its tx is admitted
+	if origin == operator {
+		p.locals.add(tx.From)
 	}
 
 	// initialize account for this address once
@@ -584,8 +995,49 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// send request [BLOCKING]
-	p.enqueueReqCh <- enqueueRequest{tx: tx}
-	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+	p.enqueueReqCh <- enqueueRequest{tx: tx, deadline: deadline}
+	p.eventManager.signalEvent(proto.EventType_ADDED, tx)
+	p.metrics.AddedTxs.Add(1)
+
+	return nil
+}
+
+// checkTx runs all the admission checks of a new transaction
+// and returns the first one that fails. The pool is not modified
+func (p *TxPool) checkTx(origin txOrigin, tx *types.Transaction) error {
+	// validate incoming tx
+	if err := p.validateTx(origin, tx); err != nil {
+		return err
+	}
+
+	// check for overflow
+	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
+		return ErrTxPoolOverflow
+	}
+
+	// decoded txs carry their hash already
+	tx.GetHash()
+
+	// check if already known
+	if _, ok := p.index.get(tx.Hash); ok {
+		return ErrAlreadyKnown
+	}
+
+	account := p.accounts.get(tx.From)
+	if account == nil {
+		// first tx of the account
+		return nil
+	}
+
+	// check if the tx replaces an existing one (same nonce),
+	// otherwise check the account's enqueued limit
+	if existing := account.getTx(tx.Nonce); existing != nil {
+		if !isReplacementPriced(existing, tx, p.priceBump) {
+			return ErrReplaceUnderpriced
+		}
+	} else if account.exceedsEnqueuedLimit(tx, p.maxAccountSlots) {
+		return ErrTooManyAccountTxs
+	}
 
 	return nil
 }
@@ -602,19 +1054,44 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	if err := account.enqueue(tx); err != nil {
+	replaced, err := account.enqueue(tx, p.maxAccountSlots, p.priceBump)
+	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
 		return
 	}
 
+	// evict the replaced tx
+	if replaced != nil {
+		p.index.remove(replaced)
+		p.gauge.decrease(slotsRequired(replaced))
+
+		// the replaced tx might be an executable already
+		p.executables.replace(replaced, tx)
+
+		p.eventManager.signalEventWithReason(
+			proto.EventType_REPLACED,
+			"replaced by "+tx.Hash.String(),
+			replaced,
+		)
+	} else {
+		// replacements are swapped in place
+		p.metrics.QueuedTxs.Add(1)
+		p.counters.addQueued(1)
+		p.trackAccount(account)
+	}
+
 	p.logger.Debug("enqueue request", "hash", tx.Hash.String())
 
 	// update state
 	p.index.add(tx)
 	p.gauge.increase(slotsRequired(tx))
 
-	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx.Hash)
+	if req.deadline != 0 {
+		p.index.setDeadline(tx.Hash, req.deadline)
+	}
+
+	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx)
 
 	if tx.Nonce > account.getNonce() {
 		// don't signal promotion for
@@ -638,7 +1115,15 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 	// update metrics
 	p.metrics.PendingTxs.Add(float64(len(promoted)))
-	p.eventManager.signalEvent(proto.EventType_PROMOTED, toHash(promoted...)...)
+	p.metrics.QueuedTxs.Add(float64(-1 * len(promoted)))
+	p.metrics.PromotedTxs.Add(float64(len(promoted)))
+	p.counters.addQueued(-len(promoted))
+	p.counters.addPending(len(promoted))
+
+	// the age of promoted txs is counted from promotion
+	p.index.touch(promoted...)
+
+	p.eventManager.signalEvent(proto.EventType_PROMOTED, promoted...)
 }
 
 // addGossipTx handles receiving transactions
@@ -649,10 +1134,34 @@ func (p *TxPool) addGossipTx(obj interface{}) {
 	}
 
 	raw := obj.(*proto.Txn) // nolint:forcetypeassert
+
+	p.addGossipRaw(raw.Raw)
+}
+
+// addGossipBatch handles receiving batches of transactions
+// gossiped by the network. Each transaction is processed independently.
+func (p *TxPool) addGossipBatch(obj interface{}) {
+	if !p.sealing {
+		return
+	}
+
+	batch := obj.(*proto.TxnBatch) // nolint:forcetypeassert
+
+	for _, raw := range batch.Raws {
+		p.addGossipRaw(raw)
+	}
+}
+
+// addGossipRaw decodes a gossiped transaction and adds it to the pool
+func (p *TxPool) addGossipRaw(raw *any.Any) {
+	if raw == nil {
+		return
+	}
+
 	tx := new(types.Transaction)
 
 	// decode tx
-	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+	if err := tx.UnmarshalRLP(raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcasted tx", "err", err)
 
 		return
@@ -680,6 +1189,7 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 
 		account := p.accounts.get(addr)
 		prunedPromoted, prunedEnqueued := account.reset(newNonce, p.promoteReqCh)
+		p.trackAccount(account)
 
 		//	append pruned
 		allPrunedPromoted = append(allPrunedPromoted, prunedPromoted...)
@@ -695,20 +1205,231 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	//	prune pool state
 	if len(allPrunedPromoted) > 0 {
 		cleanup(allPrunedPromoted...)
-		p.eventManager.signalEvent(
+		p.eventManager.signalEventWithReason(
 			proto.EventType_PRUNED_PROMOTED,
-			toHash(allPrunedPromoted...)...,
+			"nonce too low",
+			allPrunedPromoted...,
 		)
 
 		p.metrics.PendingTxs.Add(float64(-1 * len(allPrunedPromoted)))
+		p.counters.addPending(-len(allPrunedPromoted))
 	}
 
 	if len(allPrunedEnqueued) > 0 {
 		cleanup(allPrunedEnqueued...)
-		p.eventManager.signalEvent(
+		p.eventManager.signalEventWithReason(
 			proto.EventType_PRUNED_ENQUEUED,
-			toHash(allPrunedEnqueued...)...,
+			"nonce too low",
+			allPrunedEnqueued...,
 		)
+
+		p.metrics.QueuedTxs.Add(float64(-1 * len(allPrunedEnqueued)))
+		p.counters.addQueued(-len(allPrunedEnqueued))
+	}
+}
+
+// runEvictionLoop periodically drops the enqueued
+// transactions that outlived the pool's lifetime.
+func (p *TxPool) runEvictionLoop() {
+	ticker := time.NewTicker(evictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.evictExpired()
+		}
+	}
+}
+
+// runRebroadcastLoop periodically publishes the pending
+// transactions of the local accounts, until they are sealed.
+func (p *TxPool) runRebroadcastLoop() {
+	ticker := time.NewTicker(rebroadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.rebroadcastLocals()
+		}
+	}
+}
+
+// rebroadcastLocals publishes the pending transactions of the local accounts again,
+// so they reach the peers which dropped or missed them in the meantime
+func (p *TxPool) rebroadcastLocals() {
+	for _, addr := range p.locals.list() {
+		promoted, _ := p.GetAccountTxs(addr)
+
+		for _, tx := range promoted {
+			p.publishTx(tx)
+		}
+	}
+}
+
+// evictExpired drops all enqueued transactions older than the pool's lifetime.
+// Each account is locked only for the duration of its own sweep.
+func (p *TxPool) evictExpired() {
+	isExpired := func(tx *types.Transaction) bool {
+		timestamp, ok := p.index.timestamp(tx.Hash)
+
+		return ok && time.Since(timestamp) > p.lifetime
+	}
+
+	var expired []*types.Transaction
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr := key.(types.Address) // nolint:forcetypeassert
+		account := value.(*account) // nolint:forcetypeassert
+
+		// txs of local accounts never expire
+		if p.locals.contains(addr) {
+			return true
+		}
+
+		account.enqueued.lock(true)
+		expired = append(expired, account.enqueued.pruneIf(isExpired)...)
+		account.enqueued.unlock()
+
+		p.trackAccount(account)
+
+		return true
+	})
+
+	if len(expired) == 0 {
+		return
+	}
+
+	p.index.remove(expired...)
+	p.gauge.decrease(slotsRequired(expired...))
+	p.counters.addQueued(-len(expired))
+
+	p.metrics.QueuedTxs.Add(float64(-1 * len(expired)))
+	p.metrics.DroppedTxs.With("reason", dropReasonLifetimeExceeded).Add(float64(len(expired)))
+	p.eventManager.signalEventWithReason(proto.EventType_DROPPED, "lifetime exceeded", expired...)
+	p.logger.Info("evicted expired txs", "num", len(expired), "lifetime", p.lifetime)
+}
+
+// evictPastDeadline drops the transactions that had to be included by the
+// given block. The promoted transactions of the same accounts following a dropped
+// one are demoted, waiting for the nonce gap to be filled again.
+func (p *TxPool) evictPastDeadline(number uint64) {
+	expired := p.index.pastDeadline(number)
+	if len(expired) == 0 {
+		return
+	}
+
+	dropped, _ := p.evictTxs(expired, dropReasonDeadlineExceeded, "inclusion deadline exceeded")
+	if len(dropped) == 0 {
+		return
+	}
+
+	p.logger.Info("dropped txs past their inclusion deadline", "num", len(dropped), "block", number)
+}
+
+// RemoveTx drops the transaction with the given hash from the pool.
+// The promoted transactions of the same account following it are demoted,
+// and returned. A transaction handed out for the block being built
+// can't be removed. [thread-safe]
+func (p *TxPool) RemoveTx(hash types.Hash) ([]*types.Transaction, error) {
+	tx, ok := p.index.get(hash)
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+
+	// held until the tx is evicted, so that Peek can't hand it out meanwhile
+	p.executingLock.Lock()
+	defer p.executingLock.Unlock()
+
+	if _, ok := p.executing[hash]; ok {
+		return nil, ErrTxExecuting
+	}
+
+	dropped, demoted := p.evictTxs([]*types.Transaction{tx}, dropReasonRemoved, "removed by the sender")
+	if len(dropped) == 0 {
+		// promoted into a block in the meantime
+		return nil, ErrTxNotFound
+	}
+
+	p.logger.Info("removed tx", "hash", hash, "demoted", len(demoted))
+
+	return demoted, nil
+}
+
+// evictTxs drops the given transactions from the pool, demoting the promoted
+// transactions of the same accounts that follow a dropped one.
+// Returns the dropped and demoted transactions
+func (p *TxPool) evictTxs(
+	txs []*types.Transaction,
+	dropReason,
+	eventReason string,
+) (dropped, demoted []*types.Transaction) {
+	isEvicted := make(map[types.Hash]struct{}, len(txs))
+	senders := make(map[types.Address]struct{})
+
+	for _, tx := range txs {
+		isEvicted[tx.Hash] = struct{}{}
+		senders[tx.From] = struct{}{}
+	}
+
+	var prunedPromoted, prunedEnqueued []*types.Transaction
+
+	for addr := range senders {
+		account := p.accounts.get(addr)
+		if account == nil {
+			continue
+		}
+
+		promoted, enqueued, accountDemoted := account.evict(func(tx *types.Transaction) bool {
+			_, ok := isEvicted[tx.Hash]
+
+			return ok
+		})
+		p.trackAccount(account)
+
+		prunedPromoted = append(prunedPromoted, promoted...)
+		prunedEnqueued = append(prunedEnqueued, enqueued...)
+		demoted = append(demoted, accountDemoted...)
+	}
+
+	dropped = append(prunedPromoted, prunedEnqueued...)
+	if len(dropped) == 0 {
+		return nil, nil
+	}
+
+	p.index.remove(dropped...)
+	p.gauge.decrease(slotsRequired(dropped...))
+
+	pendingDelta := -len(prunedPromoted) - len(demoted)
+	queuedDelta := len(demoted) - len(prunedEnqueued)
+
+	p.counters.addPending(pendingDelta)
+	p.counters.addQueued(queuedDelta)
+
+	p.metrics.PendingTxs.Add(float64(pendingDelta))
+	p.metrics.QueuedTxs.Add(float64(queuedDelta))
+	p.metrics.DroppedTxs.With("reason", dropReason).Add(float64(len(dropped)))
+
+	p.eventManager.signalEventWithReason(proto.EventType_DROPPED, eventReason, dropped...)
+
+	if len(demoted) > 0 {
+		p.eventManager.signalEventWithReason(proto.EventType_DEMOTED, "preceding tx dropped", demoted...)
+	}
+
+	return dropped, demoted
+}
+
+// trackAccount updates the number of accounts holding
+// transactions after the given account was modified.
+// Must be called with the account queues unlocked.
+func (p *TxPool) trackAccount(account *account) {
+	if delta := account.setHolding(); delta != 0 {
+		p.counters.addAccounts(delta)
 	}
 }
 
@@ -730,11 +1451,90 @@ func (p *TxPool) Length() uint64 {
 	return p.accounts.promoted()
 }
 
-//	toHash returns the hash(es) of given transaction(s)
-func toHash(txs ...*types.Transaction) (hashes []types.Hash) {
-	for _, tx := range txs {
-		hashes = append(hashes, tx.Hash)
+// SubscribePoolEvents subscribes to the pool events of the given types, and returns
+// the event channel along with the function cancelling the subscription.
+// The channel is closed once the subscription is cancelled
+func (p *TxPool) SubscribePoolEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	cancel := func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
 	}
 
-	return
+	return subscription.subscriptionChannel, cancel
+}
+
+// runJournalLoop periodically regenerates the journal from the pool
+func (p *TxPool) runJournalLoop() {
+	ticker := time.NewTicker(p.rejournal)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.writeJournal()
+		}
+	}
+}
+
+// loadJournal adds the journaled transactions back to the pool.
+// The transactions are validated against the current state,
+// which discards the ones included or invalidated in the meantime
+func (p *TxPool) loadJournal() {
+	entries, err := p.journal.load()
+	if err != nil {
+		p.logger.Error("failed to read tx journal", "path", p.journal.path, "err", err)
+	}
+
+	dropped := 0
+
+	for _, entry := range entries {
+		origin := gossip
+
+		if entry.local {
+			// restores the local account, once the tx is admitted
+			origin = operator
+		}
+
+		if err := p.addTx(origin, entry.tx); err != nil {
+			p.logger.Debug("dropping stale journaled tx", "hash", entry.tx.Hash.String(), "err", err)
+
+			dropped++
+		}
+	}
+
+	p.logger.Info("loaded tx journal", "txs", len(entries), "dropped", dropped)
+}
+
+// writeJournal replaces the journal with the pending and queued transactions
+// of the local accounts, or of all the accounts if remotes are journaled
+func (p *TxPool) writeJournal() {
+	var entries []journalEntry
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr := key.(types.Address) // nolint:forcetypeassert
+		isLocal := p.locals.contains(addr)
+
+		if !isLocal && !p.journalRemotes {
+			return true
+		}
+
+		promoted, enqueued := p.GetAccountTxs(addr)
+
+		for _, tx := range append(promoted, enqueued...) {
+			entries = append(entries, journalEntry{tx: tx, local: isLocal})
+		}
+
+		return true
+	})
+
+	if err := p.journal.rotate(entries); err != nil {
+		p.logger.Error("failed to write tx journal", "path", p.journal.path, "err", err)
+
+		return
+	}
+
+	p.logger.Debug("wrote tx journal", "txs", len(entries))
 }
@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"math/big"
 	"testing"
@@ -10,11 +11,16 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
@@ -27,6 +33,8 @@ var (
 	forks = &chain.Forks{
 		Homestead: chain.NewFork(0),
 		Istanbul:  chain.NewFork(0),
+		Berlin:    chain.NewFork(0),
+		London:    chain.NewFork(0),
 	}
 
 	nilMetrics = NilMetrics()
@@ -81,7 +89,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 
 	return NewTxPool(
 		hclog.NewNullLogger(),
-		forks.At(0),
+		forks,
 		storeToUse,
 		nil,
 		nil,
@@ -197,6 +205,104 @@ func TestAddTxErrors(t *testing.T) {
 		)
 	})
 
+	t.Run("ErrUnderpriced before sender recovery", func(t *testing.T) {
+		pool := setupPool()
+		pool.priceLimit = 1000000
+
+		// unsigned, would otherwise fail with ErrInvalidSender
+		tx := newTx(defaultAddr, 0, 1)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrUnderpriced,
+		)
+	})
+
+	t.Run("ErrUnderpriced after runtime change", func(t *testing.T) {
+		pool := setupPool()
+
+		resp, err := pool.SetPriceLimit(
+			context.Background(),
+			&proto.SetPriceLimitReq{PriceLimit: 1000000},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, defaultPriceLimit, resp.Previous)
+		assert.Equal(t, uint64(1000000), pool.GetPriceLimit())
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx = signTx(tx)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrUnderpriced,
+		)
+	})
+
+	t.Run("ErrTxTypeNotSupported", func(t *testing.T) {
+		pool := setupPool()
+
+		// Berlin and London are activated by the block after the next one
+		pool.forks = &chain.Forks{
+			Homestead: chain.NewFork(0),
+			Istanbul:  chain.NewFork(0),
+			Berlin:    chain.NewFork(mockHeader.Number + 2),
+			London:    chain.NewFork(mockHeader.Number + 2),
+		}
+
+		for _, txType := range []types.TxType{types.AccessListTx, types.DynamicFeeTx} {
+			tx := newTx(defaultAddr, 0, 1)
+			tx.Type = txType
+			tx.MaxFeePerGas = big.NewInt(1)
+			tx.MaxPriorityFeePerGas = big.NewInt(1)
+
+			assert.ErrorIs(t,
+				pool.addTx(local, signTx(tx)),
+				types.ErrTxTypeNotSupported,
+			)
+		}
+	})
+
+	t.Run("ErrUnderpriced effective price", func(t *testing.T) {
+		pool := setupPool()
+		pool.priceLimit = 20
+		pool.SetBaseFee(10)
+
+		// the fee cap limits the effective price to 15
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.MaxFeePerGas = big.NewInt(15)
+		tx.MaxPriorityFeePerGas = big.NewInt(10)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrUnderpriced,
+		)
+	})
+
+	t.Run("ErrUnderpriced base fee of the next block", func(t *testing.T) {
+		pool, err := newTestPool(defaultMockStore{
+			DefaultHeader: &types.Header{GasLimit: mockHeader.GasLimit, BaseFee: 10},
+		})
+		assert.NoError(t, err)
+		pool.SetSigner(poolSigner)
+		pool.priceLimit = 20
+
+		// the pool follows the base fee of the head
+		pool.ResetWithHeaders()
+		assert.Equal(t, uint64(10), pool.GetBaseFee())
+
+		// the fee cap limits the effective price to 15
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.MaxFeePerGas = big.NewInt(15)
+		tx.MaxPriorityFeePerGas = big.NewInt(10)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrUnderpriced,
+		)
+	})
+
 	t.Run("ErrInvalidAccountState", func(t *testing.T) {
 		pool := setupPool()
 		pool.store = faultyMockStore{}
@@ -444,6 +550,219 @@ func TestAddHandler(t *testing.T) {
 		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
 	})
+
+	t.Run("reject future tx when the account slot limit is reached", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.maxAccountSlots = 1
+
+		// fill the account's enqueued slots
+		go func() {
+			err := pool.addTx(local, newTx(addr1, 5, 1))
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// another future nonce tx is rejected
+		assert.ErrorIs(t,
+			pool.addTx(local, newTx(addr1, 6, 1)),
+			ErrTooManyAccountTxs,
+		)
+
+		// the expected nonce tx is still accepted
+		go func() {
+			err := pool.addTx(local, newTx(addr1, 0, 1))
+			assert.NoError(t, err)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// catch pending promotion
+		<-pool.promoteReqCh
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+	})
+
+	t.Run("only count txs behind a nonce gap against the account slot limit", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+
+		acc := pool.createAccountOnce(addr1)
+		acc.setNonce(1)
+
+		enqueue := func(nonce uint64) error {
+			_, err := acc.enqueue(newTx(addr1, nonce, 1), 1, pool.priceBump)
+
+			return err
+		}
+
+		// the txs extending the promoted tail are not yet promoted,
+		// but they have no gap
+		assert.NoError(t, enqueue(1))
+		assert.NoError(t, enqueue(2))
+		assert.NoError(t, enqueue(3))
+
+		// the first tx behind the gap fills the slots
+		assert.NoError(t, enqueue(5))
+		assert.ErrorIs(t, enqueue(6), ErrTooManyAccountTxs)
+
+		// filling the gap is always accepted
+		assert.NoError(t, enqueue(4))
+	})
+
+	t.Run("replace tx with the same nonce", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.priceBump = 10
+
+		oldTx := newTx(addr1, 5, 1)
+		oldTx.GasPrice.SetUint64(100)
+
+		go func() {
+			err := pool.addTx(local, oldTx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// price bump below the threshold
+		underpricedTx := newTx(addr1, 5, 1)
+		underpricedTx.GasPrice.SetUint64(109)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, underpricedTx),
+			ErrReplaceUnderpriced,
+		)
+
+		// price bump matching the threshold
+		replacementTx := newTx(addr1, 5, 1)
+		replacementTx.GasPrice.SetUint64(110)
+
+		go func() {
+			err := pool.addTx(local, replacementTx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// the old tx is evicted and the new one takes its slot
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, replacementTx, pool.accounts.get(addr1).enqueued.peek())
+
+		_, oldFound := pool.index.get(oldTx.Hash)
+		assert.False(t, oldFound)
+
+		_, newFound := pool.index.get(replacementTx.Hash)
+		assert.True(t, newFound)
+	})
+
+	t.Run("replace promoted tx with the same nonce", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.priceBump = 10
+
+		// setup prestate
+		acc := pool.createAccountOnce(addr1)
+		acc.setNonce(1)
+
+		oldTx := newTx(addr1, 0, 1)
+		oldTx.GasPrice.SetUint64(100)
+		acc.promoted.push(oldTx)
+		pool.gauge.increase(slotsRequired(oldTx))
+
+		replacementTx := newTx(addr1, 0, 1)
+		replacementTx.GasPrice.SetUint64(200)
+
+		go func() {
+			err := pool.addTx(local, replacementTx)
+			assert.NoError(t, err)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// catch pending promotion
+		<-pool.promoteReqCh
+
+		assert.Equal(t, uint64(0), acc.enqueued.length())
+		assert.Equal(t, uint64(1), acc.promoted.length())
+		assert.Equal(t, replacementTx, acc.promoted.peek())
+	})
+
+	// sets up a pool with a promoted tx ready for execution,
+	// and returns it along with the tx and its replacement
+	setupExecutable := func(t *testing.T) (*TxPool, *types.Transaction, *types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.priceBump = 10
+
+		acc := pool.createAccountOnce(addr1)
+		acc.setNonce(1)
+
+		oldTx := newTx(addr1, 0, 1)
+		oldTx.GasPrice.SetUint64(100)
+		acc.promoted.push(oldTx)
+		pool.index.add(oldTx)
+		pool.gauge.increase(slotsRequired(oldTx))
+
+		replacementTx := newTx(addr1, 0, 1)
+		replacementTx.GasPrice.SetUint64(200)
+
+		pool.Prepare()
+
+		return pool, oldTx, replacementTx
+	}
+
+	replace := func(t *testing.T, pool *TxPool, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			err := pool.addTx(local, tx)
+			assert.NoError(t, err)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		// catch pending promotion
+		<-pool.promoteReqCh
+	}
+
+	t.Run("replace executable tx after Prepare", func(t *testing.T) {
+		pool, _, replacementTx := setupExecutable(t)
+
+		replace(t, pool, replacementTx)
+
+		// the replacement is executed instead
+		tx := pool.Peek()
+		assert.Equal(t, replacementTx, tx)
+
+		pool.Pop(tx)
+
+		assert.Nil(t, pool.Peek())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+	})
+
+	t.Run("replace executable tx while it is executed", func(t *testing.T) {
+		pool, oldTx, replacementTx := setupExecutable(t)
+
+		tx := pool.Peek()
+		assert.Equal(t, oldTx, tx)
+
+		replace(t, pool, replacementTx)
+
+		// the executed tx takes the nonce, the replacement is evicted with it
+		pool.Pop(tx)
+
+		assert.Nil(t, pool.Peek())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		_, found := pool.index.get(replacementTx.Hash)
+		assert.False(t, found)
+	})
 }
 
 func TestPromoteHandler(t *testing.T) {
@@ -927,96 +1246,1100 @@ func TestResetAccount(t *testing.T) {
 				}()
 				go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
 
-				// save the promotion
-				req := <-pool.promoteReqCh
+				// save the promotion
+				req := <-pool.promoteReqCh
+
+				// enqueue remaining
+				for i, tx := range test.txs {
+					if i == 0 {
+						// first was handled
+						continue
+					}
+					go func(tx *types.Transaction) {
+						err := pool.addTx(local, tx)
+						assert.NoError(t, err)
+					}(tx)
+					pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+				}
+
+				pool.handlePromoteRequest(req)
+
+				if test.signal {
+					go pool.resetAccounts(map[types.Address]uint64{
+						addr1: test.newNonce,
+					})
+					pool.handlePromoteRequest(<-pool.promoteReqCh)
+				} else {
+					pool.resetAccounts(map[types.Address]uint64{
+						addr1: test.newNonce,
+					})
+				}
+
+				assert.Equal(t, test.expected.slots, pool.gauge.read())
+				assert.Equal(t, // enqueued
+					test.expected.accounts[addr1].enqueued,
+					pool.accounts.get(addr1).enqueued.length())
+				assert.Equal(t, // promoted
+					test.expected.accounts[addr1].promoted,
+					pool.accounts.get(addr1).promoted.length())
+			})
+		}
+	})
+}
+
+func TestPop(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// send 1 tx and promote it
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 0, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+
+	// pop the tx
+	pool.Prepare()
+	tx := pool.Peek()
+	pool.Pop(tx)
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+}
+
+func TestRemoveTx_Executing(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// send 1 tx of a local account and promote it
+	go func() {
+		err := pool.addTx(operator, newTx(addr1, 0, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	pool.Prepare()
+	tx := pool.Peek()
+
+	// the tx is being executed
+	_, err = pool.RemoveTx(tx.Hash)
+	assert.ErrorIs(t, err, ErrTxExecuting)
+
+	_, err = pool.RemoveTxn(context.Background(), &proto.RemoveTxnReq{Hash: tx.Hash.String()})
+	assert.Equal(t, codes.Unavailable, grpcStatus.Code(err))
+
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, uint64(1), pool.counters.readPending())
+
+	pool.Pop(tx)
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.counters.readPending())
+	assert.Equal(t, uint64(0), pool.counters.readQueued())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+
+	// popped, the tx is no longer in the pool
+	_, err = pool.RemoveTx(tx.Hash)
+	assert.ErrorIs(t, err, ErrTxNotFound)
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.counters.readPending())
+}
+
+func TestDrop(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// send 1 tx and promote it
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 0, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+
+	// pop the tx
+	pool.Prepare()
+	tx := pool.Peek()
+	pool.Drop(tx)
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+}
+
+func TestStatus(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	assertStatus := func(pending, queued, accounts uint64) {
+		t.Helper()
+
+		resp, err := pool.Status(context.Background(), nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, pending, resp.Pending)
+		assert.Equal(t, pending, resp.Length)
+		assert.Equal(t, queued, resp.Queued)
+		assert.Equal(t, accounts, resp.Accounts)
+	}
+
+	assertStatus(0, 0, 0)
+
+	// send 1 tx for each account and promote them
+	for _, addr := range []types.Address{addr1, addr2} {
+		go func(addr types.Address) {
+			err := pool.addTx(local, newTx(addr, 0, 1))
+			assert.NoError(t, err)
+		}(addr)
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	assertStatus(2, 0, 2)
+
+	// send a future nonce tx
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 5, 1))
+		assert.NoError(t, err)
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assertStatus(2, 1, 2)
+
+	// pop the tx of addr2, leaving the account empty
+	pool.Pop(pool.accounts.get(addr2).promoted.peek())
+
+	assertStatus(1, 1, 1)
+
+	// drop all txs of addr1
+	pool.Drop(pool.accounts.get(addr1).promoted.peek())
+
+	assertStatus(0, 0, 0)
+
+	// emptied accounts are counted again once they receive a tx
+	go func() {
+		err := pool.addTx(local, newTx(addr2, 1, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assertStatus(1, 0, 1)
+
+	// and no longer counted once pruned by a new block
+	pool.resetAccounts(map[types.Address]uint64{addr2: 2})
+
+	assertStatus(0, 0, 0)
+}
+
+func TestEvictExpired(t *testing.T) {
+	lifetime := time.Hour
+
+	// ages the given tx past the pool's lifetime
+	expire := func(pool *TxPool, tx *types.Transaction) {
+		pool.index.all[tx.Hash].timestamp = time.Now().Add(-2 * lifetime)
+	}
+
+	t.Run("drop expired enqueued txs", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.lifetime = lifetime
+
+		// send 1 tx and promote it
+		promotedTx := newTx(addr1, 0, 1)
+		go func() {
+			err := pool.addTx(local, promotedTx)
+			assert.NoError(t, err)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		// send 2 future nonce txs
+		expiredTx := newTx(addr1, 5, 1)
+		freshTx := newTx(addr1, 6, 1)
+
+		for _, tx := range []*types.Transaction{expiredTx, freshTx} {
+			go func(tx *types.Transaction) {
+				err := pool.addTx(local, tx)
+				assert.NoError(t, err)
+			}(tx)
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		}
+
+		assert.Equal(t, uint64(3), pool.gauge.read())
+
+		// only enqueued txs are subject to eviction
+		expire(pool, promotedTx)
+		expire(pool, expiredTx)
+
+		pool.evictExpired()
+
+		assert.Equal(t, uint64(2), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, freshTx, pool.accounts.get(addr1).enqueued.peek())
+
+		_, found := pool.index.get(expiredTx.Hash)
+		assert.False(t, found)
+
+		assert.Equal(t, uint64(1), pool.counters.readPending())
+		assert.Equal(t, uint64(1), pool.counters.readQueued())
+	})
+
+	t.Run("promotion resets the timestamp", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.lifetime = lifetime
+
+		// send a future nonce tx and age it
+		futureTx := newTx(addr1, 1, 1)
+		go func() {
+			err := pool.addTx(local, futureTx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		expire(pool, futureTx)
+
+		// send the missing tx, promoting both
+		go func() {
+			err := pool.addTx(local, newTx(addr1, 0, 1))
+			assert.NoError(t, err)
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		timestamp, ok := pool.index.timestamp(futureTx.Hash)
+		assert.True(t, ok)
+		assert.True(t, time.Since(timestamp) < lifetime)
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+	})
+
+	t.Run("local accounts are exempt", func(t *testing.T) {
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.lifetime = lifetime
+		pool.locals.add(addr1)
+
+		// send a future nonce tx and age it
+		futureTx := newTx(addr1, 1, 1)
+		go func() {
+			err := pool.addTx(local, futureTx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		expire(pool, futureTx)
+
+		pool.evictExpired()
+
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	})
+}
+
+func TestLocalAccounts(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+	pool.priceLimit = 1000000
+
+	// remote txs are subject to the price limit
+	assert.ErrorIs(t,
+		pool.addTx(local, newTx(addr1, 0, 1)),
+		ErrUnderpriced,
+	)
+
+	addLocalTxn := func(tx *types.Transaction) error {
+		_, err := pool.AddTxn(context.Background(), &proto.AddTxnReq{
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+			From:  addr1.String(),
+			Local: true,
+		})
+
+		return err
+	}
+
+	// the sender of a rejected tx isn't marked as local
+	invalidTx := newTx(addr1, 1, 1)
+	invalidTx.Gas = 1
+
+	assert.ErrorIs(t, FromStatusError(addLocalTxn(invalidTx)), ErrIntrinsicGas)
+	assert.False(t, pool.locals.contains(addr1))
+
+	// send an underpriced (future nonce) tx through the operator as local
+	tx := newTx(addr1, 1, 1)
+	go func() {
+		assert.NoError(t, addLocalTxn(tx))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+	// subsequent txs of the account are local as well
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 2, 1))
+		assert.NoError(t, err)
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+
+	resp, err := pool.LocalAccounts(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{addr1.String()}, resp.Accounts)
+}
+
+func TestRebroadcastLocals(t *testing.T) {
+	network := newMockAnnounceNetwork()
+
+	var clients []*recordingAnnounceClient
+
+	for _, id := range []peer.ID{"A", "B", "C", "D"} {
+		client := newRecordingAnnounceClient()
+		clients = append(clients, client)
+		network.addPeer(id, client)
+	}
+
+	pool := newAnnouncingPool(t, network)
+	signer := crypto.NewEIP155Signer(100)
+
+	// adds a pending tx of a new account
+	addPending := func(origin txOrigin) *types.Transaction {
+		t.Helper()
+
+		key, _ := tests.GenerateKeyAndAddr(t)
+
+		tx, err := signer.SignTx(newTx(types.ZeroAddress, 0, 1), key)
+		assert.NoError(t, err)
+
+		go func() {
+			assert.NoError(t, pool.addTx(origin, tx))
+		}()
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		return tx
+	}
+
+	localTx := addPending(operator)
+	addPending(local)
+
+	pool.rebroadcastLocals()
+
+	// only the tx of the local account is published again
+	for _, client := range clients {
+		assert.Equal(t, [][]byte{localTx.Hash.Bytes()}, client.waitAnnouncement(t))
+		assert.Len(t, client.announcedCh, 0)
+	}
+}
+
+// emptyBlocksMockStore is a chain sealing empty blocks
+type emptyBlocksMockStore struct {
+	defaultMockStore
+}
+
+func (m emptyBlocksMockStore) GetBlockByHash(types.Hash, bool) (*types.Block, bool) {
+	return &types.Block{Header: m.DefaultHeader}, true
+}
+
+func TestInclusionDeadline(t *testing.T) {
+	head := &types.Header{
+		Number:   10,
+		GasLimit: mockHeader.GasLimit,
+	}
+
+	pool, err := newTestPool(emptyBlocksMockStore{
+		defaultMockStore: NewDefaultMockStore(head),
+	})
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	dropped := newMockCounter()
+	pool.metrics.DroppedTxs = dropped
+
+	eventCh, cancel := pool.SubscribePoolEvents(proto.EventType_DROPPED)
+	defer cancel()
+
+	addTxn := func(tx *types.Transaction, ttl uint64) {
+		tx.ComputeHash()
+
+		go func() {
+			_, err := pool.AddTxn(context.Background(), &proto.AddTxnReq{
+				Raw: &any.Any{
+					Value: tx.MarshalRLP(),
+				},
+				From:      tx.From.String(),
+				TtlBlocks: ttl,
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	// the chain stalls, sealing empty blocks only
+	sealEmptyBlock := func() {
+		head.Number++
+		pool.ResetWithHeaders(head)
+	}
+
+	expectDropped := func(txs ...*types.Transaction) {
+		t.Helper()
+
+		for _, tx := range txs {
+			select {
+			case event := <-eventCh:
+				assert.Equal(t, tx.Hash.String(), event.TxHash)
+				assert.Equal(t, "inclusion deadline exceeded", event.Reason)
+			case <-time.After(5 * time.Second):
+				t.Fatal("drop event not received")
+			}
+		}
+	}
+
+	// 2 promoted txs, the first one with a deadline
+	first, second := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+
+	addTxn(first, 2)
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	addTxn(second, 0)
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	// and a future nonce tx with a shorter deadline
+	future := newTx(addr2, 5, 1)
+
+	addTxn(future, 1)
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+
+	// block 11 was the last one to include the future tx
+	sealEmptyBlock()
+	expectDropped(future)
+
+	assert.Equal(t, uint64(0), pool.accounts.get(addr2).enqueued.length())
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+
+	// block 12 was the last one to include the first tx,
+	// the second one waits for the nonce gap to be filled
+	sealEmptyBlock()
+	expectDropped(first)
+
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, map[string]float64{dropReasonDeadlineExceeded: 2}, dropped.counts)
+
+	_, ok := pool.index.get(first.Hash)
+	assert.False(t, ok)
+
+	// the second tx is kept without a deadline
+	sealEmptyBlock()
+
+	_, ok = pool.index.get(second.Hash)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+}
+
+func TestNonceGaps(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	enqueue := func(tx *types.Transaction) {
+		go func() {
+			err := pool.addTx(local, tx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	// no accounts, no gaps
+	resp, err := pool.NonceGaps(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Gaps, 0)
+
+	// addr1 skips nonce 0
+	enqueue(newTx(addr1, 2, 1))
+	enqueue(newTx(addr1, 1, 1))
+
+	// addr2 is waiting for nonce 3
+	pool.accounts.initOnce(addr2, 3)
+	enqueue(newTx(addr2, 5, 1))
+
+	// addr3 has no gap: its next nonce is enqueued and pending promotion.
+	// The nonce isn't used by the other txs, which could have the same hash
+	pool.accounts.initOnce(addr3, 4)
+	go func() {
+		err := pool.addTx(local, newTx(addr3, 4, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	<-pool.promoteReqCh
+
+	resp, err = pool.NonceGaps(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*proto.NonceGap{
+		{
+			Address:      addr1.String(),
+			MissingNonce: 0,
+			Enqueued:     2,
+		},
+		{
+			Address:      addr2.String(),
+			MissingNonce: 3,
+			Enqueued:     1,
+		},
+	}, resp.Gaps)
+}
+
+func TestTxStatus(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 1, 1)
+
+	// unknown tx
+	resp, err := pool.TxStatus(context.Background(), &proto.TxStatusReq{
+		Hash: tx.Hash.String(),
+	})
+	assert.NoError(t, err)
+	assert.False(t, resp.Known)
+
+	go func() {
+		err := pool.addTx(local, tx)
+		assert.NoError(t, err)
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	resp, err = pool.TxStatus(context.Background(), &proto.TxStatusReq{
+		Hash: tx.Hash.String(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.Known)
+	assert.Equal(t, addr1.String(), resp.From)
+	assert.Equal(t, uint64(1), resp.Nonce)
+}
+
+// minedMockStore is a chain holding the given transactions
+type minedMockStore struct {
+	defaultMockStore
+
+	mined map[types.Hash]struct{}
+}
+
+func (m minedMockStore) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
+	_, ok := m.mined[hash]
+
+	return types.ZeroHash, ok
+}
+
+func TestRemoveTxn(t *testing.T) {
+	minedTx := newTx(addr3, 0, 1).ComputeHash()
+
+	pool, err := newTestPool(minedMockStore{
+		defaultMockStore: NewDefaultMockStore(mockHeader),
+		mined:            map[types.Hash]struct{}{minedTx.Hash: {}},
+	})
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	senderKey, sender := tests.GenerateKeyAndAddr(t)
+	otherKey, _ := tests.GenerateKeyAndAddr(t)
+
+	// enqueues a future nonce tx
+	enqueue := func(tx *types.Transaction, isLocal bool) {
+		t.Helper()
+
+		tx.ComputeHash()
+
+		origin := local
+		if isLocal {
+			origin = operator
+		}
+
+		go func() {
+			err := pool.addTx(origin, tx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	remove := func(tx *types.Transaction, signature []byte) (*proto.RemoveTxnResp, codes.Code) {
+		t.Helper()
+
+		resp, err := pool.RemoveTxn(context.Background(), &proto.RemoveTxnReq{
+			Hash:      tx.Hash.String(),
+			Signature: signature,
+		})
+
+		return resp, grpcStatus.Code(err)
+	}
+
+	sign := func(tx *types.Transaction, key *ecdsa.PrivateKey) []byte {
+		t.Helper()
+
+		signature, err := crypto.Sign(key, tx.Hash.Bytes())
+		assert.NoError(t, err)
+
+		return signature
+	}
+
+	t.Run("queued tx of a local account", func(t *testing.T) {
+		tx := newTx(addr1, 1, 1)
+		enqueue(tx, true)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+		resp, code := remove(tx, nil)
+		assert.Equal(t, codes.OK, code)
+		assert.Equal(t, tx.Hash.String(), resp.TxHash)
+
+		_, ok := pool.index.get(tx.Hash)
+		assert.False(t, ok)
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		// it's gone
+		_, code = remove(tx, nil)
+		assert.Equal(t, codes.NotFound, code)
+	})
+
+	t.Run("queued tx signed by the sender", func(t *testing.T) {
+		tx := newTx(sender, 1, 1)
+		enqueue(tx, false)
+
+		// a non-local account has to sign the removal
+		_, code := remove(tx, nil)
+		assert.Equal(t, codes.PermissionDenied, code)
+
+		_, code = remove(tx, sign(tx, otherKey))
+		assert.Equal(t, codes.PermissionDenied, code)
+
+		_, ok := pool.index.get(tx.Hash)
+		assert.True(t, ok)
+
+		_, code = remove(tx, sign(tx, senderKey))
+		assert.Equal(t, codes.OK, code)
+
+		_, ok = pool.index.get(tx.Hash)
+		assert.False(t, ok)
+		assert.Equal(t, uint64(0), pool.accounts.get(sender).enqueued.length())
+	})
+
+	t.Run("mined tx", func(t *testing.T) {
+		_, code := remove(minedTx, nil)
+		assert.Equal(t, codes.FailedPrecondition, code)
+	})
+}
+
+func TestValidateTxn(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	validateAs := func(tx *types.Transaction, isLocal bool) *proto.ValidateTxnResp {
+		resp, err := pool.ValidateTxn(context.Background(), &proto.ValidateTxnReq{
+			Raw:   &any.Any{Value: tx.MarshalRLP()},
+			From:  tx.From.String(),
+			Local: isLocal,
+		})
+		assert.NoError(t, err)
+
+		return resp
+	}
+
+	validate := func(tx *types.Transaction) *proto.ValidateTxnResp {
+		return validateAs(tx, false)
+	}
+
+	t.Run("valid tx is not added", func(t *testing.T) {
+		tx := newTx(addr1, 0, 1)
+
+		resp := validate(tx)
+		assert.True(t, resp.Valid)
+		assert.Empty(t, resp.Reason)
+		assert.Equal(t, tx.ComputeHash().Hash.String(), resp.TxHash)
+
+		assert.Nil(t, pool.accounts.get(addr1))
+		assert.Equal(t, uint64(0), pool.gauge.read())
+	})
+
+	t.Run("returns the failed check", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			modify func(tx *types.Transaction)
+			err    error
+		}{
+			{
+				"insufficient funds",
+				func(tx *types.Transaction) {
+					tx.Value = new(big.Int).SetUint64(1e18)
+				},
+				ErrInsufficientFunds,
+			},
+			{
+				"gas above the block limit",
+				func(tx *types.Transaction) {
+					tx.Gas = mockHeader.GasLimit + 1
+				},
+				ErrGasLimitTooHigh,
+			},
+			{
+				"intrinsic gas too low",
+				func(tx *types.Transaction) {
+					tx.Gas = 1
+				},
+				ErrIntrinsicGas,
+			},
+		}
+
+		for _, testCase := range testCases {
+			tx := newTx(addr1, 0, 1)
+			testCase.modify(tx)
+
+			resp := validate(tx)
+			assert.False(t, resp.Valid, testCase.name)
+			assert.Equal(t, testCase.err.Error(), resp.Reason, testCase.name)
+		}
+	})
+
+	t.Run("known tx", func(t *testing.T) {
+		tx := newTx(addr2, 1, 1)
+
+		go func() {
+			err := pool.addTx(local, tx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		resp := validate(tx)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, ErrAlreadyKnown.Error(), resp.Reason)
+	})
+
+	t.Run("local sender is exempt from the price limit", func(t *testing.T) {
+		pool.priceLimit = 1000000
+		defer func() {
+			pool.priceLimit = 0
+		}()
+
+		tx := newTx(addr1, 0, 1)
+
+		resp := validate(tx)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, ErrUnderpriced.Error(), resp.Reason)
+
+		resp = validateAs(tx, true)
+		assert.True(t, resp.Valid)
+		assert.Empty(t, resp.Reason)
+
+		// the sender isn't marked as local without adding the tx
+		assert.False(t, pool.locals.contains(addr1))
+	})
+
+	t.Run("missing raw tx", func(t *testing.T) {
+		_, err := pool.ValidateTxn(context.Background(), &proto.ValidateTxnReq{})
+		assert.Equal(t, codes.InvalidArgument, grpcStatus.Code(err))
+	})
+}
+
+func TestIntrinsicGas(t *testing.T) {
+	to := types.StringToAddress("1")
+
+	testCases := []struct {
+		name         string
+		to           *types.Address
+		input        []byte
+		accessList   types.AccessList
+		intrinsicGas uint64
+	}{
+		{
+			"bare transfer",
+			&to,
+			nil,
+			nil,
+			state.TxGas,
+		},
+		{
+			"zero and non-zero data bytes",
+			&to,
+			[]byte{0x0, 0x0, 0x1, 0x2, 0x3},
+			nil,
+			state.TxGas + 2*4 + 3*16,
+		},
+		{
+			"contract creation",
+			nil,
+			[]byte{0x1},
+			nil,
+			state.TxGasContractCreation + 16,
+		},
+		{
+			"access list entries",
+			&to,
+			nil,
+			types.AccessList{
+				{
+					Address:     addr2,
+					StorageKeys: []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+				},
+			},
+			state.TxGas + state.TxAccessListAddressGas + 2*state.TxAccessListStorageKeyGas,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+
+			newIntrinsicTx := func(gas uint64) *types.Transaction {
+				tx := &types.Transaction{
+					From:       addr1,
+					To:         testCase.to,
+					Value:      big.NewInt(1),
+					GasPrice:   big.NewInt(0).SetUint64(defaultPriceLimit),
+					Gas:        gas,
+					Input:      testCase.input,
+					AccessList: testCase.accessList,
+				}
 
-				// enqueue remaining
-				for i, tx := range test.txs {
-					if i == 0 {
-						// first was handled
-						continue
-					}
-					go func(tx *types.Transaction) {
-						err := pool.addTx(local, tx)
-						assert.NoError(t, err)
-					}(tx)
-					pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+				if testCase.accessList != nil {
+					tx.Type = types.AccessListTx
 				}
 
-				pool.handlePromoteRequest(req)
+				return tx
+			}
 
-				if test.signal {
-					go pool.resetAccounts(map[types.Address]uint64{
-						addr1: test.newNonce,
-					})
-					pool.handlePromoteRequest(<-pool.promoteReqCh)
-				} else {
-					pool.resetAccounts(map[types.Address]uint64{
-						addr1: test.newNonce,
-					})
-				}
+			// exactly the intrinsic gas is enough
+			assert.NoError(t, pool.validateTx(local, newIntrinsicTx(testCase.intrinsicGas)))
 
-				assert.Equal(t, test.expected.slots, pool.gauge.read())
-				assert.Equal(t, // enqueued
-					test.expected.accounts[addr1].enqueued,
-					pool.accounts.get(addr1).enqueued.length())
-				assert.Equal(t, // promoted
-					test.expected.accounts[addr1].promoted,
-					pool.accounts.get(addr1).promoted.length())
+			// a single unit of gas less is rejected at admission
+			_, err = pool.AddTxn(context.Background(), &proto.AddTxnReq{
+				Raw:  &any.Any{Value: newIntrinsicTx(testCase.intrinsicGas - 1).MarshalRLP()},
+				From: addr1.String(),
 			})
-		}
-	})
+			assert.ErrorIs(t, FromStatusError(err), ErrIntrinsicGas)
+
+			assert.Nil(t, pool.accounts.get(addr1))
+			assert.Equal(t, uint64(0), pool.gauge.read())
+		})
+	}
 }
 
-func TestPop(t *testing.T) {
-	pool, err := newTestPool()
+func TestInsufficientFunds_PendingCost(t *testing.T) {
+	newTransfer := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     addr1,
+			To:       &addr2,
+			Nonce:    nonce,
+			Value:    big.NewInt(1000),
+			GasPrice: big.NewInt(10),
+			Gas:      state.TxGas,
+		}
+	}
+
+	// exactly enough for a single transfer
+	pool, err := newTestPool(balanceMockStore{
+		defaultMockStore: defaultMockStore{DefaultHeader: mockHeader},
+		balance:          newTransfer(0).Cost(),
+	})
 	assert.NoError(t, err)
 	pool.SetSigner(&mockSigner{})
 
-	// send 1 tx and promote it
 	go func() {
-		err := pool.addTx(local, newTx(addr1, 0, 1))
-		assert.NoError(t, err)
+		assert.NoError(t, pool.addTx(local, newTransfer(0)))
 	}()
 	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
 	pool.handlePromoteRequest(<-pool.promoteReqCh)
 
-	assert.Equal(t, uint64(1), pool.gauge.read())
 	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
 
-	// pop the tx
-	pool.Prepare()
-	tx := pool.Peek()
-	pool.Pop(tx)
+	// the second transfer can't be paid for after the first one
+	assert.ErrorIs(t, pool.addTx(local, newTransfer(1)), ErrInsufficientFunds)
 
-	assert.Equal(t, uint64(0), pool.gauge.read())
-	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	// neither can a future one, queued behind the gap
+	assert.ErrorIs(t, pool.addTx(local, newTransfer(2)), ErrInsufficientFunds)
+
+	// while a transaction with the same nonce only needs the balance for itself
+	replacement := newTransfer(0)
+	replacement.Value = big.NewInt(0)
+
+	assert.NoError(t, pool.validateTx(local, replacement))
 }
-func TestDrop(t *testing.T) {
-	pool, err := newTestPool()
-	assert.NoError(t, err)
-	pool.SetSigner(&mockSigner{})
 
-	// send 1 tx and promote it
-	go func() {
-		err := pool.addTx(local, newTx(addr1, 0, 1))
+func TestGasLimitMargin(t *testing.T) {
+	t.Run("transactions above the ceiling are rejected at admission", func(t *testing.T) {
+		pool, err := newTestPool()
 		assert.NoError(t, err)
-	}()
-	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
-	pool.handlePromoteRequest(<-pool.promoteReqCh)
+		pool.SetSigner(&mockSigner{})
 
-	assert.Equal(t, uint64(1), pool.gauge.read())
-	assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
-	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+		// 10% of the block gas limit is out of reach
+		pool.gasLimitMargin = 10
+		ceiling := mockHeader.GasLimit - mockHeader.GasLimit/100*10
 
-	// pop the tx
-	pool.Prepare()
-	tx := pool.Peek()
-	pool.Drop(tx)
+		tx := newTx(addr1, 0, 1)
+		tx.Gas = ceiling + 1
 
-	assert.Equal(t, uint64(0), pool.gauge.read())
-	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+		assert.ErrorIs(t, pool.addTx(local, tx), ErrGasLimitTooHigh)
+
+		// nothing was enqueued
+		assert.Nil(t, pool.accounts.get(addr1))
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		// future nonce, so the tx is not promoted
+		tx = newTx(addr1, 1, 1)
+		tx.Gas = ceiling
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	})
+
+	t.Run("margin must be below 100%", func(t *testing.T) {
+		_, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks,
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			nilMetrics,
+			&Config{
+				PriceLimit:     defaultPriceLimit,
+				MaxSlots:       defaultMaxSlots,
+				GasLimitMargin: 100,
+			},
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestUnprotectedTxs(t *testing.T) {
+	key, sender := tests.GenerateKeyAndAddr(t)
+
+	// legacy signature, V is 27 or 28
+	unprotectedTx, err := (&crypto.HomesteadSigner{}).SignTx(newTx(types.ZeroAddress, 1, 1), key)
+	assert.NoError(t, err)
+	assert.False(t, crypto.IsReplayProtected(unprotectedTx))
+
+	protectedTx, err := crypto.NewEIP155Signer(100).SignTx(newTx(types.ZeroAddress, 2, 1), key)
+	assert.NoError(t, err)
+	assert.True(t, crypto.IsReplayProtected(protectedTx))
+
+	testCases := []struct {
+		name             string
+		allowUnprotected bool
+		tx               *types.Transaction
+		expectedErr      error
+	}{
+		{"unprotected tx is admitted", true, unprotectedTx, nil},
+		{"protected tx is admitted", true, protectedTx, nil},
+		{"unprotected tx is rejected", false, unprotectedTx, crypto.ErrUnprotectedTx},
+		{"protected tx is still admitted", false, protectedTx, nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			signer := crypto.NewEIP155Signer(100)
+			signer.SetAllowUnprotected(testCase.allowUnprotected)
+
+			pool, err := newTestPool()
+			assert.NoError(t, err)
+			pool.SetSigner(signer)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, pool.addTx(local, testCase.tx.Copy()), testCase.expectedErr)
+				assert.Nil(t, pool.accounts.get(sender))
+
+				return
+			}
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, testCase.tx.Copy()))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			assert.Equal(t, uint64(1), pool.accounts.get(sender).enqueued.length())
+		})
+	}
+}
+
+func TestUnprotectedTxs_CachedSender(t *testing.T) {
+	key, sender := tests.GenerateKeyAndAddr(t)
+
+	unprotectedTx, err := (&crypto.HomesteadSigner{}).SignTx(newTx(types.ZeroAddress, 1, 1), key)
+	assert.NoError(t, err)
+
+	senderCache, err := crypto.NewSenderCache(crypto.DefaultSenderCacheSize)
+	assert.NoError(t, err)
+
+	// the sender is recovered and cached by a path admitting unprotected txs,
+	// as the block execution does
+	cachedSender, err := crypto.NewCachedSigner(crypto.NewEIP155Signer(100), senderCache).Sender(unprotectedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, sender, cachedSender)
+
+	signer := crypto.NewEIP155Signer(100)
+	signer.SetAllowUnprotected(false)
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		&chain.Forks{
+			Homestead: chain.NewFork(0),
+			EIP155:    chain.NewFork(0),
+		},
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		nilMetrics,
+		&Config{
+			PriceLimit:        defaultPriceLimit,
+			MaxSlots:          defaultMaxSlots,
+			RejectUnprotected: true,
+		},
+	)
+	assert.NoError(t, err)
+	pool.SetSigner(crypto.NewCachedSigner(signer, senderCache))
+
+	pool.Start()
+	defer pool.Close()
+
+	// the cached sender doesn't let the tx through
+	assert.ErrorIs(t, pool.addTx(local, unprotectedTx.Copy()), crypto.ErrUnprotectedTx)
+	assert.Nil(t, pool.accounts.get(sender))
 }
 
 func TestDemote(t *testing.T) {
@@ -1043,7 +2366,14 @@ func waitForEvents(
 		select {
 		case <-ctx.Done():
 			completed = true
-		case event := <-subscription.subscriptionChannel:
+		case event, ok := <-subscription.subscriptionChannel:
+			if !ok {
+				// the subscription is closed
+				completed = true
+
+				continue
+			}
+
 			receivedEvents = append(receivedEvents, event)
 
 			if len(receivedEvents) == count {
@@ -1055,6 +2385,39 @@ func waitForEvents(
 	return receivedEvents
 }
 
+func TestSubscribePoolEvents(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	pool.Start()
+	defer pool.Close()
+
+	eventCh, cancel := pool.SubscribePoolEvents(proto.EventType_PROMOTED)
+
+	tx := newTx(addr1, 0, 1)
+	assert.NoError(t, pool.addTx(local, tx))
+
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, proto.EventType_PROMOTED, event.Type)
+		assert.Equal(t, tx.Hash.String(), event.TxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("promotion event not received")
+	}
+
+	// the channel is closed once the subscription is cancelled
+	cancel()
+
+	select {
+	case _, ok := <-eventCh:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event channel not closed")
+	}
+}
+
 func TestAddTxns(t *testing.T) {
 	slotSize := uint64(1)
 
@@ -1556,6 +2919,100 @@ func TestExecutablesOrder(t *testing.T) {
 	}
 }
 
+func TestExecutablesOrder_EffectiveGasPrice(t *testing.T) {
+	newDynamicFeeTx := func(addr types.Address, feeCap, tipCap uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.MaxFeePerGas = new(big.Int).SetUint64(feeCap)
+		tx.MaxPriorityFeePerGas = new(big.Int).SetUint64(tipCap)
+
+		return tx
+	}
+
+	newLegacyTx := func(addr types.Address, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+
+		return tx
+	}
+
+	testCases := []struct {
+		name          string
+		baseFee       uint64
+		txs           []*types.Transaction
+		expectedOrder []types.Address
+	}{
+		{
+			name:    "no base fee orders by tip",
+			baseFee: 0,
+			txs: []*types.Transaction{
+				newDynamicFeeTx(addr1, 100, 1),
+				newDynamicFeeTx(addr2, 100, 3),
+				newLegacyTx(addr3, 2),
+			},
+			expectedOrder: []types.Address{addr2, addr3, addr1},
+		},
+		{
+			name:    "fee cap limits the effective price",
+			baseFee: 10,
+			txs: []*types.Transaction{
+				newDynamicFeeTx(addr1, 11, 5),
+				newDynamicFeeTx(addr2, 20, 4),
+				newLegacyTx(addr3, 12),
+			},
+			expectedOrder: []types.Address{addr2, addr3, addr1},
+		},
+		{
+			name:    "fee caps below the base fee come last",
+			baseFee: 10,
+			txs: []*types.Transaction{
+				newDynamicFeeTx(addr1, 9, 5),
+				newLegacyTx(addr2, 10),
+				newDynamicFeeTx(addr3, 12, 1),
+			},
+			expectedOrder: []types.Address{addr3, addr2, addr1},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			q := newPricedQueue(nil)
+			q.setBaseFee(test.baseFee)
+
+			for _, tx := range test.txs {
+				q.push(tx)
+			}
+
+			for _, addr := range test.expectedOrder {
+				assert.Equal(t, addr, q.pop().From)
+			}
+
+			assert.Nil(t, q.pop())
+		})
+	}
+}
+
+func TestExecutablesOrder_Locals(t *testing.T) {
+	newPricedTx := func(addr types.Address, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+
+		return tx
+	}
+
+	locals := localAccounts{accounts: map[types.Address]struct{}{addr3: {}}}
+
+	q := newPricedQueue(locals.contains)
+	q.push(newPricedTx(addr1, 10))
+	q.push(newPricedTx(addr2, 20))
+	q.push(newPricedTx(addr3, 1))
+
+	// the tx of the local account is first regardless of its tip
+	for _, addr := range []types.Address{addr3, addr2, addr1} {
+		assert.Equal(t, addr, q.pop().From)
+	}
+}
+
 type status int
 
 // Status of a transaction resulted
@@ -1749,6 +3206,40 @@ func TestRecovery(t *testing.T) {
 	}
 }
 
+func TestGetAccountTxs(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// unknown account
+	promoted, enqueued := pool.GetAccountTxs(addr1)
+	assert.Empty(t, promoted)
+	assert.Empty(t, enqueued)
+
+	for _, nonce := range []uint64{2, 3} {
+		tx := newTx(addr1, nonce, 1)
+
+		go func() {
+			err := pool.addTx(local, tx)
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	}
+
+	promoted, enqueued = pool.GetAccountTxs(addr1)
+	assert.Empty(t, promoted)
+	assert.Len(t, enqueued, 2)
+
+	// the snapshot is not affected by later changes of the pool
+	queue := pool.accounts.get(addr1).enqueued
+	queue.clear()
+	queue.push(newTx(addr1, 9, 1))
+	queue.push(newTx(addr1, 10, 1))
+
+	assert.Len(t, enqueued, 2)
+	assert.ElementsMatch(t, []uint64{2, 3}, []uint64{enqueued[0].Nonce, enqueued[1].Nonce})
+}
+
 func TestGetTxs(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -1948,3 +3439,52 @@ func TestGetTxs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNonce(t *testing.T) {
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	stateNonce := func() uint64 {
+		return pool.store.GetNonce(pool.store.Header().StateRoot, addr1)
+	}
+
+	// unknown accounts fall back to the state nonce
+	assert.Equal(t, uint64(0), pool.GetNonce(addr1))
+
+	// send 2 txs and promote them
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		go func(nonce uint64) {
+			err := pool.addTx(local, newTx(addr1, nonce, 1))
+			assert.NoError(t, err)
+		}(nonce)
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	assert.Equal(t, uint64(2), pool.GetNonce(addr1))
+
+	// send the 3rd tx without promoting it
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 2, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	// wait for the promotion signal, which is left unhandled
+	<-pool.promoteReqCh
+
+	assert.Equal(t, uint64(3), pool.GetNonce(addr1))
+
+	// a future nonce tx past a gap doesn't advance the nonce
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 5, 1))
+		assert.NoError(t, err)
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	assert.Equal(t, uint64(3), pool.GetNonce(addr1))
+
+	// nothing was written to the state
+	assert.Equal(t, uint64(0), stateNonce())
+}
@@ -26,17 +26,11 @@ func CalculateReceiptsRoot(receipts []*types.Receipt) types.Hash {
 
 // CalculateTransactionsRoot calculates the root of a list of transactions
 func CalculateTransactionsRoot(transactions []*types.Transaction) types.Hash {
-	ar := arenaPool.Get()
-
-	res := calculateRootWithRlp(len(transactions), func(i int) *fastrlp.Value {
-		ar.Reset()
-
-		return transactions[i].MarshalRLPWith(ar)
+	// the canonical encoding is used, so typed transactions
+	// are inserted as their raw envelope
+	return CalculateRoot(len(transactions), func(i int) []byte {
+		return transactions[i].MarshalRLPTo(nil)
 	})
-
-	arenaPool.Put(ar)
-
-	return res
 }
 
 // CalculateUncleRoot calculates the root of a list of uncles
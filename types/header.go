@@ -27,6 +27,7 @@ type Header struct {
 	ExtraData    []byte
 	MixHash      Hash
 	Nonce        Nonce
+	BaseFee      uint64 // set from the London (EIP-1559) fork onwards
 	Hash         Hash
 }
 
@@ -3,6 +3,7 @@ package types
 import (
 	"database/sql/driver"
 	"errors"
+	"math/big"
 
 	goHex "encoding/hex"
 
@@ -28,9 +29,10 @@ type Receipt struct {
 	Status            *ReceiptStatus
 
 	// context fields
-	GasUsed         uint64
-	ContractAddress Address
-	TxHash          Hash
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int // the gas price paid per unit, nil for receipts stored before it was tracked
+	ContractAddress   Address
+	TxHash            Hash
 }
 
 func (r *Receipt) SetStatus(s ReceiptStatus) {
@@ -120,6 +122,7 @@ func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
 func (b *Bloom) IsLogInBloom(log *Log) bool {
 	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
 
 	// Check if the log address is present
 	addressPresent := b.isByteArrPresent(hasher, log.Address.Bytes())
@@ -136,11 +139,33 @@ func (b *Bloom) IsLogInBloom(log *Log) bool {
 		}
 	}
 
-	keccak.DefaultKeccakPool.Put(hasher)
+	return true
+}
+
+// Add sets the bits of the byte array (address or topic) in the bloom filter
+func (b *Bloom) Add(data []byte) {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	b.setEncode(hasher, data)
+}
+
+// Includes checks if all the bits set in other are set in the bloom filter
+func (b *Bloom) Includes(other *Bloom) bool {
+	for i := range other {
+		if b[i]&other[i] != other[i] {
+			return false
+		}
+	}
 
 	return true
 }
 
+// IsEmpty checks if no bits are set in the bloom filter
+func (b *Bloom) IsEmpty() bool {
+	return *b == Bloom{}
+}
+
 // isByteArrPresent checks if the byte array is possibly present in the Bloom filter
 func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 	hasher.Reset()
@@ -158,7 +183,7 @@ func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 
 		referenceByte := b[byteLocation]
 
-		isSet := int(referenceByte & (1 << (bitLocation - 1)))
+		isSet := int(referenceByte & (1 << bitLocation))
 
 		if isSet == 0 {
 			return false
@@ -2,10 +2,13 @@ package types
 
 import (
 	"math/big"
+	"math/rand"
 	"reflect"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
 )
 
 type codec interface {
@@ -75,3 +78,349 @@ func TestRLPUnmarshal_Header_ComputeHash(t *testing.T) {
 	assert.NoError(t, h2.UnmarshalRLP(data))
 	assert.Equal(t, h.Hash, h2.Hash)
 }
+
+func TestRLPMarshall_And_Unmarshall_HeaderBaseFee(t *testing.T) {
+	legacy := &Header{Number: 1, GasLimit: 100}
+	legacy.ComputeHash()
+
+	london := &Header{Number: 1, GasLimit: 100, BaseFee: 1000000000}
+	london.ComputeHash()
+
+	// the base fee is part of the hash
+	assert.NotEqual(t, legacy.Hash, london.Hash)
+
+	// headers without a base fee keep the old encoding
+	p := &fastrlp.Parser{}
+	v, err := p.Parse(legacy.MarshalRLP())
+	assert.NoError(t, err)
+
+	legacyElems, err := v.GetElems()
+	assert.NoError(t, err)
+	assert.Len(t, legacyElems, 15)
+
+	for _, h := range []*Header{legacy, london} {
+		h2 := new(Header)
+		assert.NoError(t, h2.UnmarshalRLP(h.MarshalRLP()))
+		assert.Equal(t, h.BaseFee, h2.BaseFee)
+		assert.Equal(t, h.Hash, h2.Hash)
+	}
+}
+
+func TestRLPMarshall_And_Unmarshall_DynamicFeeTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:                 DynamicFeeTx,
+		ChainID:              big.NewInt(100),
+		Nonce:                1,
+		GasPrice:             big.NewInt(0),
+		MaxFeePerGas:         big.NewInt(20),
+		MaxPriorityFeePerGas: big.NewInt(2),
+		Gas:                  11,
+		To:                   &addrTo,
+		Value:                big.NewInt(1),
+		Input:                []byte{1, 2},
+		V:                    big.NewInt(1),
+		S:                    big.NewInt(26),
+		R:                    big.NewInt(27),
+	}
+
+	marshaledRlp := txn.MarshalRLP()
+	assert.Equal(t, byte(DynamicFeeTx), marshaledRlp[0])
+
+	unmarshalledTxn := new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalRLP(marshaledRlp))
+
+	// the hash computed while decoding matches the one of the envelope
+	txn.ComputeHash()
+	assert.Equal(t, txn.Hash, unmarshalledTxn.Hash)
+	assert.Equal(t, txn, unmarshalledTxn)
+}
+
+func TestRLPMarshall_And_Unmarshall_AccessListTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	accessList := AccessList{
+		{
+			Address:     StringToAddress("22"),
+			StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+		},
+		{
+			Address: StringToAddress("33"),
+		},
+	}
+
+	for _, txType := range []TxType{AccessListTx, DynamicFeeTx} {
+		txn := &Transaction{
+			Type:       txType,
+			ChainID:    big.NewInt(100),
+			Nonce:      1,
+			GasPrice:   big.NewInt(0),
+			Gas:        11,
+			To:         &addrTo,
+			Value:      big.NewInt(1),
+			Input:      []byte{1, 2},
+			AccessList: accessList,
+			V:          big.NewInt(1),
+			S:          big.NewInt(26),
+			R:          big.NewInt(27),
+		}
+
+		if txType == AccessListTx {
+			txn.GasPrice = big.NewInt(10)
+		} else {
+			txn.MaxFeePerGas = big.NewInt(20)
+			txn.MaxPriorityFeePerGas = big.NewInt(2)
+		}
+
+		marshaledRlp := txn.MarshalRLP()
+		assert.Equal(t, byte(txType), marshaledRlp[0])
+
+		unmarshalledTxn := new(Transaction)
+		assert.NoError(t, unmarshalledTxn.UnmarshalRLP(marshaledRlp))
+
+		txn.ComputeHash()
+		assert.Equal(t, txn.Hash, unmarshalledTxn.Hash)
+		assert.Equal(t, txn, unmarshalledTxn)
+	}
+}
+
+func TestRLPMarshall_And_Unmarshall_LegacyTransactionWireFormat(t *testing.T) {
+	// signed transaction from the EIP-155 specification
+	raw := hex.MustDecodeHex("0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0" +
+		"b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d89" +
+		"97f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+	txn := new(Transaction)
+	assert.NoError(t, txn.UnmarshalRLP(raw))
+
+	assert.Equal(t, LegacyTx, txn.Type)
+	assert.Nil(t, txn.AccessList)
+	assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", txn.Hash.String())
+
+	// legacy transactions are byte-identical on the wire
+	assert.Equal(t, raw, txn.MarshalRLP())
+}
+
+func TestRLPMarshall_And_Unmarshall_TypedTransactionInBlock(t *testing.T) {
+	addrTo := StringToAddress("11")
+	legacyTxn := &Transaction{
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		Input:    []byte{},
+		V:        big.NewInt(25),
+		S:        big.NewInt(26),
+		R:        big.NewInt(27),
+	}
+	dynamicFeeTxn := &Transaction{
+		Type:                 DynamicFeeTx,
+		ChainID:              big.NewInt(100),
+		GasPrice:             big.NewInt(0),
+		MaxFeePerGas:         big.NewInt(20),
+		MaxPriorityFeePerGas: big.NewInt(2),
+		Gas:                  11,
+		Value:                big.NewInt(1),
+		Input:                []byte{},
+		V:                    big.NewInt(0),
+		S:                    big.NewInt(26),
+		R:                    big.NewInt(27),
+	}
+
+	block := &Block{
+		Header:       &Header{},
+		Transactions: []*Transaction{legacyTxn.ComputeHash(), dynamicFeeTxn.ComputeHash()},
+	}
+
+	unmarshalledBlock := new(Block)
+	assert.NoError(t, unmarshalledBlock.UnmarshalRLP(block.MarshalRLP()))
+
+	assert.Len(t, unmarshalledBlock.Transactions, 2)
+	assert.Equal(t, LegacyTx, unmarshalledBlock.Transactions[0].Type)
+	assert.Equal(t, legacyTxn.Hash, unmarshalledBlock.Transactions[0].Hash)
+	assert.Equal(t, DynamicFeeTx, unmarshalledBlock.Transactions[1].Type)
+	assert.Equal(t, dynamicFeeTxn.Hash, unmarshalledBlock.Transactions[1].Hash)
+}
+
+func TestRLPUnmarshal_Transaction_Malformed(t *testing.T) {
+	addrTo := StringToAddress("11")
+	legacyTx := &Transaction{
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		V:        big.NewInt(27),
+		S:        big.NewInt(26),
+		R:        big.NewInt(27),
+	}
+	dynamicFeeTx := &Transaction{
+		Type:                 DynamicFeeTx,
+		ChainID:              big.NewInt(100),
+		MaxFeePerGas:         big.NewInt(20),
+		MaxPriorityFeePerGas: big.NewInt(2),
+		Gas:                  11,
+		To:                   &addrTo,
+		Value:                big.NewInt(1),
+		V:                    big.NewInt(1),
+		S:                    big.NewInt(26),
+		R:                    big.NewInt(27),
+	}
+
+	// encodes a legacy transaction list with the given fields
+	encodeFields := func(fields func(a *fastrlp.Arena, v *fastrlp.Value)) []byte {
+		a := &fastrlp.Arena{}
+		v := a.NewArray()
+		fields(a, v)
+
+		return v.MarshalTo(nil)
+	}
+
+	legacyFields := func(a *fastrlp.Arena, v *fastrlp.Value) {
+		for i := 0; i < 9; i++ {
+			v.Set(a.NewUint(1))
+		}
+	}
+
+	highV := dynamicFeeTx.Copy()
+	highV.V = big.NewInt(27)
+
+	testCases := []struct {
+		name  string
+		input []byte
+		err   error
+	}{
+		{
+			name:  "empty input",
+			input: []byte{},
+			err:   ErrShortRLP,
+		},
+		{
+			name:  "truncated legacy transaction",
+			input: legacyTx.MarshalRLP()[:10],
+			err:   ErrShortRLP,
+		},
+		{
+			name:  "truncated typed transaction",
+			input: dynamicFeeTx.MarshalRLP()[:10],
+			err:   ErrShortRLP,
+		},
+		{
+			name:  "type byte only",
+			input: []byte{byte(DynamicFeeTx)},
+			err:   ErrShortRLP,
+		},
+		{
+			name:  "trailing bytes after legacy transaction",
+			input: append(legacyTx.MarshalRLP(), 0x01),
+			err:   ErrTrailingBytes,
+		},
+		{
+			name:  "trailing bytes after typed transaction",
+			input: append(dynamicFeeTx.MarshalRLP(), 0x01),
+			err:   ErrTrailingBytes,
+		},
+		{
+			name: "missing fields",
+			input: encodeFields(func(a *fastrlp.Arena, v *fastrlp.Value) {
+				v.Set(a.NewUint(1))
+			}),
+			err: ErrShortRLP,
+		},
+		{
+			name: "extra fields",
+			input: encodeFields(func(a *fastrlp.Arena, v *fastrlp.Value) {
+				legacyFields(a, v)
+				v.Set(a.NewUint(1))
+			}),
+			err: ErrTrailingBytes,
+		},
+		{
+			name: "oversized signature value",
+			input: encodeFields(func(a *fastrlp.Arena, v *fastrlp.Value) {
+				for i := 0; i < 8; i++ {
+					v.Set(a.NewUint(1))
+				}
+				v.Set(a.NewBytes(append([]byte{0x01}, make([]byte, 32)...)))
+			}),
+			err: ErrInvalidVRS,
+		},
+		{
+			name:  "typed transaction with legacy V",
+			input: highV.MarshalRLP(),
+			err:   ErrInvalidVRS,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, new(Transaction).UnmarshalRLP(test.input), test.err)
+		})
+	}
+
+	// sanity check of the field encoder
+	assert.NoError(t, new(Transaction).UnmarshalRLP(encodeFields(legacyFields)))
+}
+
+func TestRLPUnmarshal_Transaction_RandomInput(t *testing.T) {
+	addrTo := StringToAddress("11")
+	valid := [][]byte{
+		(&Transaction{
+			GasPrice: big.NewInt(11),
+			Gas:      11,
+			To:       &addrTo,
+			Value:    big.NewInt(1),
+			Input:    []byte{1, 2},
+			V:        big.NewInt(27),
+			S:        big.NewInt(26),
+			R:        big.NewInt(27),
+		}).MarshalRLP(),
+		(&Transaction{
+			Type:                 DynamicFeeTx,
+			ChainID:              big.NewInt(100),
+			MaxFeePerGas:         big.NewInt(20),
+			MaxPriorityFeePerGas: big.NewInt(2),
+			Gas:                  11,
+			Value:                big.NewInt(1),
+			AccessList: AccessList{
+				{Address: addrTo, StorageKeys: []Hash{StringToHash("1")}},
+			},
+			V: big.NewInt(1),
+			S: big.NewInt(26),
+			R: big.NewInt(27),
+		}).MarshalRLP(),
+	}
+
+	// decoding must never panic, regardless of the input
+	decode := func(input []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("panic while decoding %x: %v", input, r)
+			}
+		}()
+
+		_ = new(Transaction).UnmarshalRLP(input)
+	}
+
+	// every truncation and single byte mutation of a valid encoding
+	for _, input := range valid {
+		for i := 0; i < len(input); i++ {
+			decode(input[:i])
+
+			for _, b := range []byte{0x00, 0x7f, 0x80, 0xb7, 0xb8, 0xc0, 0xf7, 0xf8, 0xff} {
+				mutated := append([]byte{}, input...)
+				mutated[i] = b
+
+				decode(mutated)
+			}
+		}
+	}
+
+	// random byte slices
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10000; i++ {
+		input := make([]byte, r.Intn(128))
+		r.Read(input)
+
+		decode(input)
+	}
+}
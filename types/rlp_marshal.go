@@ -83,6 +83,12 @@ func (h *Header) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBytes(h.MixHash.Bytes()))
 	vv.Set(arena.NewCopyBytes(h.Nonce[:]))
 
+	// the base fee is only encoded for London headers,
+	// so that the hash of older headers remains the same
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	return vv
 }
 
@@ -158,12 +164,28 @@ func (t *Transaction) MarshalRLP() []byte {
 	return t.MarshalRLPTo(nil)
 }
 
+// MarshalRLPTo marshals the transaction to its canonical encoding.
+// Legacy transactions are a plain RLP list, while typed transactions
+// use the EIP-2718 envelope (type || rlp(payload))
 func (t *Transaction) MarshalRLPTo(dst []byte) []byte {
-	return MarshalRLPTo(t.MarshalRLPWith, dst)
+	switch t.Type {
+	case AccessListTx:
+		return MarshalRLPTo(t.marshalAccessListRLPWith, append(dst, byte(t.Type)))
+	case DynamicFeeTx:
+		return MarshalRLPTo(t.marshalDynamicFeeRLPWith, append(dst, byte(t.Type)))
+	default:
+		return MarshalRLPTo(t.MarshalRLPWith, dst)
+	}
 }
 
-// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena
+// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena.
+// Typed transactions are wrapped as an RLP string holding the envelope,
+// so they can be embedded in lists (block bodies, storage)
 func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if t.Type != LegacyTx {
+		return arena.NewCopyBytes(t.MarshalRLPTo(nil))
+	}
+
 	vv := arena.NewArray()
 
 	vv.Set(arena.NewUint(t.Nonce))
@@ -187,3 +209,90 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 
 	return vv
 }
+
+// marshalAccessListRLPWith marshals the payload of an EIP-2930 transaction
+func (t *Transaction) marshalAccessListRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(t.ChainID))
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.GasPrice))
+	vv.Set(arena.NewUint(t.Gas))
+
+	// Address may be empty
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+	vv.Set(t.AccessList.MarshalRLPWith(arena))
+
+	// signature values
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al AccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if len(al) == 0 {
+		return arena.NewNullArray()
+	}
+
+	vv := arena.NewArray()
+
+	for _, tuple := range al {
+		tv := arena.NewArray()
+		tv.Set(arena.NewCopyBytes(tuple.Address.Bytes()))
+
+		if len(tuple.StorageKeys) == 0 {
+			tv.Set(arena.NewNullArray())
+		} else {
+			keys := arena.NewArray()
+			for _, key := range tuple.StorageKeys {
+				keys.Set(arena.NewCopyBytes(key.Bytes()))
+			}
+
+			tv.Set(keys)
+		}
+
+		vv.Set(tv)
+	}
+
+	return vv
+}
+
+// marshalDynamicFeeRLPWith marshals the payload of an EIP-1559 transaction
+func (t *Transaction) marshalDynamicFeeRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(t.ChainID))
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.MaxPriorityFeePerGas))
+	vv.Set(arena.NewBigInt(t.MaxFeePerGas))
+	vv.Set(arena.NewUint(t.Gas))
+
+	// Address may be empty
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+
+	vv.Set(t.AccessList.MarshalRLPWith(arena))
+
+	// signature values
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
@@ -80,5 +80,10 @@ func (r *Receipt) MarshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// gas used
 	vv.Set(a.NewUint(r.GasUsed))
 
+	// effective gas price
+	if r.EffectiveGasPrice != nil {
+		vv.Set(a.NewBigInt(r.EffectiveGasPrice))
+	}
+
 	return vv
 }
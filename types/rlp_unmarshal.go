@@ -1,12 +1,25 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/umbracle/fastrlp"
 )
 
+var (
+	// ErrShortRLP is returned when the RLP input is truncated or misses fields
+	ErrShortRLP = errors.New("rlp: input too short")
+
+	// ErrTrailingBytes is returned when the RLP input holds data past the decoded value
+	ErrTrailingBytes = errors.New("rlp: trailing bytes after value")
+
+	// ErrInvalidVRS is returned when the signature values of a transaction are malformed
+	ErrInvalidVRS = errors.New("invalid signature values")
+)
+
 type RLPUnmarshaler interface {
 	UnmarshalRLP(input []byte) error
 }
@@ -34,6 +47,48 @@ func UnmarshalRlp(obj unmarshalRLPFunc, input []byte) error {
 	return nil
 }
 
+// unmarshalRlpExact works like UnmarshalRlp, but requires
+// the input to hold exactly one RLP value
+func unmarshalRlpExact(obj unmarshalRLPFunc, input []byte) (err error) {
+	if len(input) == 0 {
+		return ErrShortRLP
+	}
+
+	pr := fastrlp.DefaultParserPool.Get()
+	defer fastrlp.DefaultParserPool.Put(pr)
+
+	// the parser indexes the input by the decoded length prefixes
+	// without bounds checks, so a truncated prefix can panic
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrShortRLP, r)
+		}
+	}()
+
+	v, err := pr.Parse(input)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrShortRLP, err)
+	}
+
+	if len(pr.Raw(v)) != len(input) {
+		return ErrTrailingBytes
+	}
+
+	return obj(pr, v)
+}
+
+// checkFieldCount validates the number of fields of a decoded RLP list
+func checkFieldCount(name string, num, expected int) error {
+	switch {
+	case num < expected:
+		return fmt.Errorf("%w: not enough elements to decode %s, expected %d but found %d", ErrShortRLP, name, expected, num)
+	case num > expected:
+		return fmt.Errorf("%w: too many elements to decode %s, expected %d but found %d", ErrTrailingBytes, name, expected, num)
+	}
+
+	return nil
+}
+
 func (b *Block) UnmarshalRLP(input []byte) error {
 	return UnmarshalRlp(b.UnmarshalRLPFrom, input)
 }
@@ -97,8 +152,9 @@ func (h *Header) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
-	if num := len(elems); num != 15 {
-		return fmt.Errorf("not enough elements to decode header, expected 15 but found %d", num)
+	// headers before the London fork have no base fee
+	if num := len(elems); num != 15 && num != 16 {
+		return fmt.Errorf("not enough elements to decode header, expected 15 or 16 but found %d", num)
 	}
 
 	// parentHash
@@ -165,6 +221,13 @@ func (h *Header) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// baseFee
+	if len(elems) > 15 {
+		if h.BaseFee, err = elems[15].GetUint64(); err != nil {
+			return err
+		}
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -289,20 +352,265 @@ func (l *Log) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 }
 
 func (t *Transaction) UnmarshalRLP(input []byte) error {
-	return UnmarshalRlp(t.UnmarshalRLPFrom, input)
+	// a leading byte in the [0x00, 0x7f] range marks a typed envelope
+	if len(input) > 0 && input[0] <= 0x7f {
+		return t.unmarshalTypedRLP(input)
+	}
+
+	return unmarshalRlpExact(t.UnmarshalRLPFrom, input)
+}
+
+// unmarshalTypedRLP unmarshals an EIP-2718 transaction envelope
+func (t *Transaction) unmarshalTypedRLP(input []byte) error {
+	if len(input) == 0 {
+		return ErrShortRLP
+	}
+
+	var unmarshalPayload unmarshalRLPFunc
+
+	switch txType := TxType(input[0]); txType {
+	case AccessListTx:
+		unmarshalPayload = t.unmarshalAccessListRLPFrom
+	case DynamicFeeTx:
+		unmarshalPayload = t.unmarshalDynamicFeeRLPFrom
+	default:
+		return fmt.Errorf("unsupported transaction type %d", txType)
+	}
+
+	t.Type = TxType(input[0])
+
+	if err := unmarshalRlpExact(unmarshalPayload, input[1:]); err != nil {
+		return err
+	}
+
+	keccak.Keccak256(t.Hash[:0], input)
+
+	return nil
+}
+
+// unmarshalAccessListRLPFrom unmarshals the payload of an EIP-2930 transaction
+func (t *Transaction) unmarshalAccessListRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if err := checkFieldCount("access list transaction", len(elems), 11); err != nil {
+		return err
+	}
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err := elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+	// gasPrice
+	t.GasPrice = new(big.Int)
+	if err := elems[2].GetBigInt(t.GasPrice); err != nil {
+		return err
+	}
+	// gas
+	if t.Gas, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+	// to
+	if vv, _ := elems[4].Bytes(); len(vv) == 20 {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+	// value
+	t.Value = new(big.Int)
+	if err := elems[5].GetBigInt(t.Value); err != nil {
+		return err
+	}
+	// input
+	if t.Input, err = elems[6].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+	// access list
+	if err := t.AccessList.unmarshalRLPFrom(p, elems[7]); err != nil {
+		return err
+	}
+	// V
+	t.V = new(big.Int)
+	if err = elems[8].GetBigInt(t.V); err != nil {
+		return err
+	}
+	// R
+	t.R = new(big.Int)
+	if err = elems[9].GetBigInt(t.R); err != nil {
+		return err
+	}
+	// S
+	t.S = new(big.Int)
+	if err = elems[10].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	return t.validateVRS()
+}
+
+// unmarshalRLPFrom unmarshals an access list in RLP format
+func (al *AccessList) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	tuples, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(tuples) == 0 {
+		*al = nil
+
+		return nil
+	}
+
+	list := make(AccessList, len(tuples))
+
+	for i, tuple := range tuples {
+		elems, err := tuple.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if num := len(elems); num != 2 {
+			return fmt.Errorf("not enough elements to decode access tuple, expected 2 but found %d", num)
+		}
+
+		// address
+		if err := elems[0].GetAddr(list[i].Address[:]); err != nil {
+			return err
+		}
+
+		// storage keys
+		keys, err := elems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) != 0 {
+			list[i].StorageKeys = make([]Hash, len(keys))
+		}
+
+		for j, key := range keys {
+			if err := key.GetHash(list[i].StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	*al = list
+
+	return nil
+}
+
+// unmarshalDynamicFeeRLPFrom unmarshals the payload of an EIP-1559 transaction
+func (t *Transaction) unmarshalDynamicFeeRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if err := checkFieldCount("dynamic fee transaction", len(elems), 12); err != nil {
+		return err
+	}
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err := elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+	// maxPriorityFeePerGas
+	t.MaxPriorityFeePerGas = new(big.Int)
+	if err := elems[2].GetBigInt(t.MaxPriorityFeePerGas); err != nil {
+		return err
+	}
+	// maxFeePerGas
+	t.MaxFeePerGas = new(big.Int)
+	if err := elems[3].GetBigInt(t.MaxFeePerGas); err != nil {
+		return err
+	}
+	// gasPrice is not part of the payload
+	t.GasPrice = new(big.Int)
+	// gas
+	if t.Gas, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+	// to
+	if vv, _ := elems[5].Bytes(); len(vv) == 20 {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+	// value
+	t.Value = new(big.Int)
+	if err := elems[6].GetBigInt(t.Value); err != nil {
+		return err
+	}
+	// input
+	if t.Input, err = elems[7].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+	// access list
+	if err := t.AccessList.unmarshalRLPFrom(p, elems[8]); err != nil {
+		return err
+	}
+
+	// V
+	t.V = new(big.Int)
+	if err = elems[9].GetBigInt(t.V); err != nil {
+		return err
+	}
+	// R
+	t.R = new(big.Int)
+	if err = elems[10].GetBigInt(t.R); err != nil {
+		return err
+	}
+	// S
+	t.S = new(big.Int)
+	if err = elems[11].GetBigInt(t.S); err != nil {
+		return err
+	}
+
+	return t.validateVRS()
 }
 
 // UnmarshalRLP unmarshals a Transaction in RLP format
 func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	if v.Type() == fastrlp.TypeBytes {
+		// typed transactions are embedded in lists as byte strings
+		envelope, err := v.Bytes()
+		if err != nil {
+			return err
+		}
+
+		return t.unmarshalTypedRLP(envelope)
+	}
+
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
 	}
 
-	if num := len(elems); num != 9 {
-		return fmt.Errorf("not enough elements to decode transaction, expected 9 but found %d", num)
+	if err := checkFieldCount("transaction", len(elems), 9); err != nil {
+		return err
 	}
 
+	t.Type = LegacyTx
+
 	p.Hash(t.Hash[:0], v)
 
 	// nonce
@@ -354,5 +662,20 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
+	return t.validateVRS()
+}
+
+// validateVRS checks that the decoded signature values are well formed.
+// R and S must fit in 256 bits, and typed transactions
+// carry the plain signature parity as V
+func (t *Transaction) validateVRS() error {
+	if t.R.BitLen() > 256 || t.S.BitLen() > 256 {
+		return ErrInvalidVRS
+	}
+
+	if t.Type != LegacyTx && t.V.Cmp(big.NewInt(1)) > 0 {
+		return ErrInvalidVRS
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/umbracle/fastrlp"
 )
@@ -115,8 +116,9 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		return err
 	}
 
-	if len(elems) != 3 {
-		return fmt.Errorf("expected 3 elements")
+	// the effective gas price is missing in the receipts stored before it was tracked
+	if len(elems) != 3 && len(elems) != 4 {
+		return fmt.Errorf("expected 3 or 4 elements")
 	}
 
 	if err := r.UnmarshalRLPFrom(p, elems[0]); err != nil {
@@ -140,5 +142,13 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		return err
 	}
 
+	// effective gas price
+	if len(elems) == 4 {
+		r.EffectiveGasPrice = new(big.Int)
+		if err := elems[3].GetBigInt(r.EffectiveGasPrice); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
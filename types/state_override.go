@@ -0,0 +1,35 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrStateAndStateDiff = errors.New("both state and stateDiff are overridden")
+
+// OverrideAccount is the override of an account's fields, applied
+// over the state a call is executed on. The unset fields are kept
+type OverrideAccount struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+
+	// State replaces the whole storage of the account,
+	// while StateDiff only replaces the given slots
+	State     map[Hash]Hash
+	StateDiff map[Hash]Hash
+}
+
+// StateOverride is the set of account overrides of a call
+type StateOverride map[Address]OverrideAccount
+
+// Validate checks that the overrides are consistent
+func (s StateOverride) Validate() error {
+	for _, account := range s {
+		if account.State != nil && account.StateDiff != nil {
+			return ErrStateAndStateDiff
+		}
+	}
+
+	return nil
+}
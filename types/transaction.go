@@ -1,13 +1,66 @@
 package types
 
 import (
+	"errors"
 	"math/big"
 	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 )
 
+// TxType is the EIP-2718 type of the transaction envelope
+type TxType byte
+
+const (
+	LegacyTx     TxType = 0x0
+	AccessListTx TxType = 0x01
+	DynamicFeeTx TxType = 0x02
+)
+
+// ErrTxTypeNotSupported is returned for transactions
+// of a type the active fork rules don't enable
+var ErrTxTypeNotSupported = errors.New("transaction type not supported")
+
+// AccessTuple is an address along with the storage
+// keys the transaction plans to access (EIP-2930)
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// AccessList is the list of addresses and storage keys
+// the transaction plans to access (EIP-2930)
+type AccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al AccessList) StorageKeys() int {
+	count := 0
+	for _, tuple := range al {
+		count += len(tuple.StorageKeys)
+	}
+
+	return count
+}
+
+// Copy returns a deep copy of the access list
+func (al AccessList) Copy() AccessList {
+	if al == nil {
+		return nil
+	}
+
+	cpy := make(AccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return cpy
+}
+
 type Transaction struct {
+	Type     TxType
 	Nonce    uint64
 	GasPrice *big.Int
 	Gas      uint64
@@ -20,6 +73,16 @@ type Transaction struct {
 	Hash     Hash
 	From     Address
 
+	// chain ID, only set for typed transactions
+	ChainID *big.Int
+
+	// EIP-2930 field, only set for typed transactions
+	AccessList AccessList
+
+	// EIP-1559 fields, only set for DynamicFeeTx
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
 	// Cache
 	size atomic.Value
 }
@@ -30,6 +93,13 @@ func (t *Transaction) IsContractCreation() bool {
 
 // ComputeHash computes the hash of the transaction
 func (t *Transaction) ComputeHash() *Transaction {
+	if t.Type != LegacyTx {
+		// typed transactions are hashed over the raw envelope
+		keccak.Keccak256(t.Hash[:0], t.MarshalRLP())
+
+		return t
+	}
+
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
@@ -42,6 +112,67 @@ func (t *Transaction) ComputeHash() *Transaction {
 	return t
 }
 
+// GetHash returns the hash of the transaction, which is the hash of its wire encoding.
+// Decoded transactions carry their hash already, and the others are hashed once.
+// The fields have to be changed through the setters, or followed by InvalidateHash,
+// for the hash to be computed again
+func (t *Transaction) GetHash() Hash {
+	if t.Hash == ZeroHash {
+		t.ComputeHash()
+	}
+
+	return t.Hash
+}
+
+// InvalidateHash clears the hash and the size computed from the fields,
+// it's called after the fields are modified
+func (t *Transaction) InvalidateHash() {
+	t.Hash = ZeroHash
+	t.size = atomic.Value{}
+}
+
+// SetNonce sets the nonce, invalidating the hash
+func (t *Transaction) SetNonce(nonce uint64) {
+	t.Nonce = nonce
+	t.InvalidateHash()
+}
+
+// SetGas sets the gas limit, invalidating the hash
+func (t *Transaction) SetGas(gas uint64) {
+	t.Gas = gas
+	t.InvalidateHash()
+}
+
+// SetGasPrice sets the gas price, invalidating the hash
+func (t *Transaction) SetGasPrice(gasPrice *big.Int) {
+	t.GasPrice = gasPrice
+	t.InvalidateHash()
+}
+
+// SetValue sets the transferred value, invalidating the hash
+func (t *Transaction) SetValue(value *big.Int) {
+	t.Value = value
+	t.InvalidateHash()
+}
+
+// SetTo sets the recipient, invalidating the hash
+func (t *Transaction) SetTo(to *Address) {
+	t.To = to
+	t.InvalidateHash()
+}
+
+// SetInput sets the input data, invalidating the hash
+func (t *Transaction) SetInput(input []byte) {
+	t.Input = input
+	t.InvalidateHash()
+}
+
+// SetSignatureValues sets the signature, invalidating the hash
+func (t *Transaction) SetSignatureValues(v, r, s *big.Int) {
+	t.V, t.R, t.S = v, r, s
+	t.InvalidateHash()
+}
+
 func (t *Transaction) Copy() *Transaction {
 	tt := new(Transaction)
 	*tt = *t
@@ -56,6 +187,20 @@ func (t *Transaction) Copy() *Transaction {
 		tt.Value.Set(t.Value)
 	}
 
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
+	tt.AccessList = t.AccessList.Copy()
+
+	if t.MaxFeePerGas != nil {
+		tt.MaxFeePerGas = new(big.Int).Set(t.MaxFeePerGas)
+	}
+
+	if t.MaxPriorityFeePerGas != nil {
+		tt.MaxPriorityFeePerGas = new(big.Int).Set(t.MaxPriorityFeePerGas)
+	}
+
 	if t.R != nil {
 		tt.R = new(big.Int)
 		tt.R = big.NewInt(0).SetBits(t.R.Bits())
@@ -72,9 +217,16 @@ func (t *Transaction) Copy() *Transaction {
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// Cost returns gas * gasPrice + value.
+// For dynamic fee transactions the fee cap is used, since that is the most
+// the sender can be charged
 func (t *Transaction) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	price := t.GasPrice
+	if t.Type == DynamicFeeTx {
+		price = t.MaxFeePerGas
+	}
+
+	total := new(big.Int).Mul(price, new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
 	return total
@@ -100,6 +252,36 @@ func (t *Transaction) ExceedsBlockGasLimit(blockGasLimit uint64) bool {
 	return t.Gas > blockGasLimit
 }
 
-func (t *Transaction) IsUnderpriced(priceLimit uint64) bool {
-	return t.GasPrice.Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+// IsUnderpriced checks if the effective gas price of the
+// transaction, given the base fee, is below the price limit
+func (t *Transaction) IsUnderpriced(baseFee, priceLimit uint64) bool {
+	return t.GetGasPrice(baseFee).Cmp(new(big.Int).SetUint64(priceLimit)) < 0
+}
+
+// GetGasPrice returns the effective gas price the transaction pays per unit
+// of gas, given the base fee of the block it is included in.
+// Legacy transactions always pay their gas price, while dynamic fee
+// transactions pay min(maxFeePerGas, baseFee + maxPriorityFeePerGas)
+func (t *Transaction) GetGasPrice(baseFee uint64) *big.Int {
+	if t.Type != DynamicFeeTx {
+		return new(big.Int).Set(t.GasPrice)
+	}
+
+	price := new(big.Int).SetUint64(baseFee)
+	price.Add(price, t.MaxPriorityFeePerGas)
+
+	if price.Cmp(t.MaxFeePerGas) > 0 {
+		price.Set(t.MaxFeePerGas)
+	}
+
+	return price
+}
+
+// EffectiveTip returns the tip the transaction pays per unit of gas
+// on top of the base fee, capped by its max fee. It is negative
+// if the fee cap of the transaction is below the base fee
+func (t *Transaction) EffectiveTip(baseFee uint64) *big.Int {
+	tip := t.GetGasPrice(baseFee)
+
+	return tip.Sub(tip, new(big.Int).SetUint64(baseFee))
 }
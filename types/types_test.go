@@ -78,3 +78,96 @@ func TestTransactionCopy(t *testing.T) {
 		t.Fatal("[ERROR] Copied transaction not equal base transaction")
 	}
 }
+
+func TestTransactionGetHash(t *testing.T) {
+	addrTo := StringToAddress("11")
+	addrFrom := StringToAddress("22")
+	txn := &Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		Input:    []byte{1, 2},
+	}
+
+	// hashed before signing
+	unsigned := txn.GetHash()
+	assert.Equal(t, unsigned, txn.Hash)
+
+	// setting the signature invalidates the hash
+	txn.SetSignatureValues(big.NewInt(25), big.NewInt(26), big.NewInt(27))
+	assert.Equal(t, ZeroHash, txn.Hash)
+
+	signed := txn.GetHash()
+	assert.NotEqual(t, unsigned, signed)
+	assert.Equal(t, txn.Copy().ComputeHash().Hash, signed)
+
+	// it matches the hash of the wire encoding, set at decoding
+	decoded := new(Transaction)
+	assert.NoError(t, decoded.UnmarshalRLP(txn.MarshalRLP()))
+	assert.Equal(t, signed, decoded.Hash)
+	assert.Equal(t, signed, decoded.GetHash())
+
+	// the hash is cached
+	assert.Equal(t, signed, txn.GetHash())
+
+	// each setter invalidates the hash
+	setters := []func(){
+		func() { txn.SetNonce(2) },
+		func() { txn.SetGas(12) },
+		func() { txn.SetGasPrice(big.NewInt(12)) },
+		func() { txn.SetValue(big.NewInt(2)) },
+		func() { txn.SetTo(&addrFrom) },
+		func() { txn.SetInput([]byte{3}) },
+	}
+
+	for _, set := range setters {
+		hash := txn.GetHash()
+		txn.Size()
+
+		set()
+
+		assert.NotEqual(t, hash, txn.GetHash())
+		assert.Equal(t, txn.Copy().ComputeHash().Hash, txn.GetHash())
+		assert.Equal(t, uint64(len(txn.MarshalRLP())), txn.Size())
+	}
+
+	// a field modified in place is rehashed after invalidating the hash
+	hash := txn.GetHash()
+	txn.Value.SetUint64(100)
+	txn.Nonce++
+	assert.Equal(t, hash, txn.GetHash())
+
+	txn.InvalidateHash()
+	assert.NotEqual(t, hash, txn.GetHash())
+	assert.Equal(t, txn.Copy().ComputeHash().Hash, txn.GetHash())
+}
+
+func TestBloom(t *testing.T) {
+	log := &Log{
+		Address: StringToAddress("1"),
+		Topics:  []Hash{StringToHash("2"), StringToHash("3")},
+	}
+
+	bloom := CreateBloom([]*Receipt{{Logs: []*Log{log}}})
+
+	assert.False(t, bloom.IsEmpty())
+	assert.True(t, bloom.IsLogInBloom(log))
+
+	// a log with an unknown topic is not in the bloom
+	assert.False(t, bloom.IsLogInBloom(&Log{
+		Address: log.Address,
+		Topics:  []Hash{StringToHash("4")},
+	}))
+
+	var topicBloom Bloom
+
+	topicBloom.Add(log.Topics[1].Bytes())
+	assert.True(t, bloom.Includes(&topicBloom))
+
+	var unknownBloom Bloom
+
+	unknownBloom.Add(StringToHash("4").Bytes())
+	assert.False(t, bloom.Includes(&unknownBloom))
+}
@@ -6,4 +6,12 @@ var (
 	// Versioning should follow the SemVer guidelines
 	// https://semver.org/
 	Version = "v0.1.0"
+
+	// Commit is the git commit the binary was built from.
+	// Embedded by --ldflags on build time
+	Commit = "unknown"
+
+	// ProtocolVersion is the version of the operator gRPC API,
+	// bumped on breaking changes of the served services
+	ProtocolVersion = "v1"
 )